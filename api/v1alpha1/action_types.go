@@ -0,0 +1,196 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Actions are changes a ClusterReadiness makes to the cluster as its
+// readiness changes.
+type Actions struct {
+	// ReadyMarkers are ConfigMaps or Leases that exist only while the
+	// ClusterReadiness is ready, for init containers and tooling to key off
+	// without calling /readyz.
+	// +optional
+	// +listType=atomic
+	ReadyMarkers []ReadyMarker `json:"readyMarkers,omitempty"`
+
+	// NodeTaint taints nodes while the ClusterReadiness is not ready, so no
+	// new workloads are scheduled on them until it passes its checks.
+	// +optional
+	NodeTaint *NodeTaintAction `json:"nodeTaint,omitempty"`
+
+	// SuspendGitOps pauses GitOps rollouts while the ClusterReadiness is
+	// Unhealthy.
+	// +optional
+	SuspendGitOps *SuspendGitOpsAction `json:"suspendGitOps,omitempty"`
+
+	// TrafficWeight annotates Services and Ingresses with a value following
+	// the ClusterReadiness's state, such as an ExternalDNS weight, so global
+	// load balancers drain traffic from the cluster while it is unhealthy.
+	// +optional
+	TrafficWeight *TrafficWeightAction `json:"trafficWeight,omitempty"`
+}
+
+// ReadyMarkerKind is the kind of object a ReadyMarker maintains.
+// +kubebuilder:validation:Enum=ConfigMap;Lease
+type ReadyMarkerKind string
+
+const (
+	// ReadyMarkerConfigMap maintains a ConfigMap describing the readiness.
+	ReadyMarkerConfigMap ReadyMarkerKind = "ConfigMap"
+
+	// ReadyMarkerLease maintains a Lease renewed at each evaluation, which
+	// expires if the operator stops renewing it.
+	ReadyMarkerLease ReadyMarkerKind = "Lease"
+)
+
+// ReadyMarker is an object created while the ClusterReadiness is ready and
+// deleted once it is not.
+type ReadyMarker struct {
+	// Kind is ConfigMap or Lease.
+	Kind ReadyMarkerKind `json:"kind"`
+
+	// Name of the object.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the object. Defaults to the operator namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// NodeTaintAction taints the selected nodes while the ClusterReadiness is not
+// ready and removes the taint once it is.
+type NodeTaintAction struct {
+	// Key of the taint. Defaults to clustergate.io/not-ready.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// Value of the taint. Defaults to the name of the ClusterReadiness.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Effect of the taint: NoSchedule or PreferNoSchedule. NoExecute is not
+	// supported, as it would evict running workloads, the operator included.
+	// +optional
+	// +kubebuilder:default=NoSchedule
+	// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule
+	Effect corev1.TaintEffect `json:"effect,omitempty"`
+
+	// NodeSelector selects the nodes to taint. Empty selects every node.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+}
+
+// SuspendGitOpsAction pauses the referenced GitOps objects while the
+// ClusterReadiness is Unhealthy and resumes them once it is Healthy or
+// Degraded. While it is Initializing, objects paused stay paused.
+type SuspendGitOpsAction struct {
+	// Targets are the objects paused: Flux Kustomizations and HelmReleases
+	// are suspended, and Argo CD AppProjects get a sync window denying
+	// automated syncs.
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	Targets []GitOpsReference `json:"targets"`
+}
+
+// GitOpsKind is the kind of object a GitOpsReference names.
+// +kubebuilder:validation:Enum=Kustomization;HelmRelease;AppProject
+type GitOpsKind string
+
+const (
+	// GitOpsKustomization is a Flux Kustomization
+	// (kustomize.toolkit.fluxcd.io/v1).
+	GitOpsKustomization GitOpsKind = "Kustomization"
+
+	// GitOpsHelmRelease is a Flux HelmRelease (helm.toolkit.fluxcd.io/v2).
+	GitOpsHelmRelease GitOpsKind = "HelmRelease"
+
+	// GitOpsAppProject is an Argo CD AppProject (argoproj.io/v1alpha1).
+	GitOpsAppProject GitOpsKind = "AppProject"
+)
+
+// GitOpsReference names a Flux or Argo CD object.
+type GitOpsReference struct {
+	// Kind is Kustomization, HelmRelease or AppProject.
+	Kind GitOpsKind `json:"kind"`
+
+	// Name of the object.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the object. Defaults to flux-system for Flux objects and
+	// argocd for AppProjects.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// TrafficWeightAction sets an annotation of the targets to a value per
+// state of the ClusterReadiness.
+type TrafficWeightAction struct {
+	// Annotation set on the targets. Defaults to
+	// external-dns.alpha.kubernetes.io/aws-weight.
+	// +optional
+	Annotation string `json:"annotation,omitempty"`
+
+	// Healthy is the annotation's value while the ClusterReadiness is
+	// Healthy, and once the targets are no longer managed. Defaults to "100".
+	// +optional
+	Healthy string `json:"healthy,omitempty"`
+
+	// Degraded is the annotation's value while the ClusterReadiness is
+	// Degraded. Defaults to the healthy value.
+	// +optional
+	Degraded string `json:"degraded,omitempty"`
+
+	// Unhealthy is the annotation's value while the ClusterReadiness is
+	// Unhealthy or Initializing. Defaults to "0".
+	// +optional
+	Unhealthy string `json:"unhealthy,omitempty"`
+
+	// Targets are the annotated Services and Ingresses.
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	Targets []TrafficTarget `json:"targets"`
+}
+
+// TrafficTargetKind is the kind of object a TrafficTarget names.
+// +kubebuilder:validation:Enum=Service;Ingress
+type TrafficTargetKind string
+
+const (
+	// TrafficTargetService is a Service.
+	TrafficTargetService TrafficTargetKind = "Service"
+
+	// TrafficTargetIngress is an Ingress (networking.k8s.io/v1).
+	TrafficTargetIngress TrafficTargetKind = "Ingress"
+)
+
+// TrafficTarget names a Service or Ingress.
+type TrafficTarget struct {
+	// Kind is Service or Ingress.
+	Kind TrafficTargetKind `json:"kind"`
+
+	// Name of the object.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the object.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+}
+
+// TrafficWeightStatus is the annotation a traffic weight action manages.
+type TrafficWeightStatus struct {
+	// Annotation is the managed annotation.
+	Annotation string `json:"annotation"`
+
+	// ResetValue is the value the annotation is reset to once a target is
+	// no longer managed.
+	ResetValue string `json:"resetValue"`
+
+	// Targets are the objects whose annotation is managed.
+	// +listType=atomic
+	Targets []TrafficTarget `json:"targets"`
+}
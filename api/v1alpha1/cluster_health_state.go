@@ -1,7 +1,7 @@
 package v1alpha1
 
 // ClusterHealthState represents the overall health of the cluster.
-// +kubebuilder:validation:Enum=Healthy;Degraded;Unhealthy
+// +kubebuilder:validation:Enum=Healthy;Degraded;Unhealthy;Initializing
 type ClusterHealthState string
 
 const (
@@ -13,4 +13,9 @@ const (
 
 	// ClusterUnhealthy indicates one or more critical checks are failing.
 	ClusterUnhealthy ClusterHealthState = "Unhealthy"
+
+	// ClusterInitializing indicates checks are failing within the initial
+	// delay after creation or operator start. It is not ready, but is not
+	// reported as Unhealthy.
+	ClusterInitializing ClusterHealthState = "Initializing"
 )
@@ -1,8 +1,10 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // ClusterReadinessSpec defines the desired state of ClusterReadiness.
@@ -19,16 +21,194 @@ type ClusterReadinessSpec struct {
 	// Inline checks override profile checks with the same name/ref.
 	// +optional
 	Checks []CheckSpec `json:"checks,omitempty"`
+
+	// CheckNamespaces selects the namespaces whose NamespaceGateChecks are
+	// added to this readiness evaluation, as "namespace:<namespace>/<name>".
+	// An empty selector selects every namespace; unset selects none.
+	// +optional
+	CheckNamespaces *metav1.LabelSelector `json:"checkNamespaces,omitempty"`
+
+	// DegradedThreshold is how many warning checks must fail before the state
+	// becomes Degraded: a count (e.g. 3) or a percentage of warning checks
+	// (e.g. "20%", rounded up). Defaults to 1, so any failing warning check
+	// degrades the cluster.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+%?$`
+	DegradedThreshold *intstr.IntOrString `json:"degradedThreshold,omitempty"`
+
+	// JitterPercent delays each run of a check by up to this percentage of its
+	// interval, so checks sharing an interval spread across it instead of
+	// becoming due in the same reconcile. Defaults to 0 (no jitter).
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	JitterPercent int32 `json:"jitterPercent,omitempty"`
+
+	// HistoryLimit is how many status transitions each check keeps in its
+	// history; the oldest are dropped first. Defaults to 10; 0 disables the
+	// history.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=50
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+
+	// InitialDelaySeconds is a grace period after this ClusterReadiness is
+	// created or the operator starts. Within it failing checks report
+	// Initializing and the state is Initializing rather than Unhealthy or
+	// Degraded, so bootstrap does not raise false alarms. The cluster is
+	// still not ready while checks fail. Defaults to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+
+	// RequiredCategories are the categories that must be fully healthy for
+	// the cluster to be ready. When set, a failing check of any severity but
+	// info in one of them makes the cluster Unhealthy, while failures in
+	// other categories, critical ones included, only make it Degraded. A
+	// required category without checks is Unhealthy.
+	// +optional
+	RequiredCategories []string `json:"requiredCategories,omitempty"`
+
+	// ReadinessPolicy makes more than failing critical checks block
+	// readiness. Critical failures always block it.
+	// +optional
+	ReadinessPolicy *ReadinessPolicy `json:"readinessPolicy,omitempty"`
+
+	// Gates are named subsets of the checks, each with its own readiness
+	// policy, served at /readyz/<name> and reported as GateReady-<name>
+	// conditions, so that consumers such as workloads and ingress traffic can
+	// gate on different checks. They do not change the overall state.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Gates []ReadinessGate `json:"gates,omitempty"`
+
+	// MinHealthyDuration is how long every critical check must have passed
+	// continuously before the state becomes Healthy or Degraded. Until then
+	// the state stays Unhealthy, so short green blips during install do not
+	// open gates.
+	// +optional
+	MinHealthyDuration *metav1.Duration `json:"minHealthyDuration,omitempty"`
+
+	// Suspend stops check execution and freezes the reported state, for
+	// planned maintenance that should not trip external gates.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// MaintenanceWindows are recurring periods during which this
+	// ClusterReadiness is suspended as if Suspend were set.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// AnnotateSummary writes a compact "clustergate.io/summary" annotation
+	// (e.g. "42/45 passing, failing: dns,etcd") onto this ClusterReadiness and
+	// the GateProfiles it references, for GitOps UIs that display annotations.
+	// +optional
+	AnnotateSummary bool `json:"annotateSummary,omitempty"`
+
+	// GateRuns configures GateRun audit records of this ClusterReadiness's
+	// evaluations. On-demand GateRuns are recorded even when unset.
+	// +optional
+	GateRuns *GateRunPolicy `json:"gateRuns,omitempty"`
+
+	// Notifications configures the external systems told about changes of
+	// the overall state.
+	// +optional
+	Notifications *Notifications `json:"notifications,omitempty"`
+
+	// Actions configures the changes made to the cluster as readiness
+	// changes.
+	// +optional
+	Actions *Actions `json:"actions,omitempty"`
+}
+
+// MaintenanceWindow is a recurring period of planned maintenance.
+type MaintenanceWindow struct {
+	// Schedule is a five-field cron expression (minute hour day-of-month
+	// month day-of-week) for when the window opens, e.g. "0 2 * * 6" for
+	// 02:00 every Saturday.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window stays open after each start, at most 7 days.
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA time zone Schedule is evaluated in. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// GateRunPolicy configures scheduled GateRun recording and retention.
+type GateRunPolicy struct {
+	// Record selects which evaluation cycles are recorded: "Never" (only
+	// on-demand runs), "EveryCycle" (every cycle that executed checks), or
+	// "OnStateChange" (cycles that changed the overall state). Defaults to EveryCycle.
+	// +optional
+	// +kubebuilder:validation:Enum=Never;EveryCycle;OnStateChange
+	// +kubebuilder:default=EveryCycle
+	Record GateRunRecordMode `json:"record,omitempty"`
+
+	// Retain is how many GateRuns of this ClusterReadiness to keep, scheduled
+	// and on-demand alike; the oldest are deleted first. Defaults to 10.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	Retain int32 `json:"retain,omitempty"`
+
+	// MaxAge deletes GateRuns older than this duration (e.g. "720h"),
+	// regardless of Retain.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
 }
 
+// GateRunRecordMode selects which evaluation cycles produce a GateRun.
+type GateRunRecordMode string
+
+const (
+	// GateRunRecordNever records only on-demand GateRuns.
+	GateRunRecordNever GateRunRecordMode = "Never"
+
+	// GateRunRecordEveryCycle records every evaluation cycle that executed checks.
+	GateRunRecordEveryCycle GateRunRecordMode = "EveryCycle"
+
+	// GateRunRecordOnStateChange records cycles that changed the overall state.
+	GateRunRecordOnStateChange GateRunRecordMode = "OnStateChange"
+)
+
 // ProfileRef references a GateProfile CR by name.
 type ProfileRef struct {
 	// Name is the metadata.name of the GateProfile CR.
 	Name string `json:"name"`
 
 	// ExcludeChecks is a list of check names or gateCheckRefs to exclude from this profile.
+	// Entries match a check's name, gateCheckRef or identifier
+	// ("dynamic:<gateCheckRef>") and may be glob patterns, e.g. "etcd-*".
+	// Inline checks are never excluded.
 	// +optional
 	ExcludeChecks []string `json:"excludeChecks,omitempty"`
+
+	// Version pins the profile at this spec.version: the GateProfile's
+	// current checks while it is at that version, and its recorded
+	// status.versions entry after it moves on. Unset follows the current
+	// version.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// SnapshotRef reads the profile's checks from a ConfigMap snapshot
+	// instead of the GateProfile. The ConfigMap's "spec" key holds a
+	// GateProfile spec as YAML or JSON; with Version set, the snapshot's
+	// version must match.
+	// +optional
+	SnapshotRef *ProfileSnapshotReference `json:"snapshotRef,omitempty"`
+}
+
+// ProfileSnapshotReference refers to a ConfigMap holding a GateProfile spec.
+type ProfileSnapshotReference struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
 }
 
 // CheckSpec defines a single readiness check to run.
@@ -56,6 +236,42 @@ type CheckSpec struct {
 	// +optional
 	Interval *metav1.Duration `json:"interval,omitempty"`
 
+	// Timeout bounds a single execution of this check. A check that overruns it
+	// fails with a timeout message instead of stalling the reconcile. Unset
+	// means no limit beyond the check's own timeouts.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// FailureThreshold is how many consecutive failed runs it takes for a
+	// Passing check to be reported as Failing. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+
+	// SuccessThreshold is how many consecutive successful runs it takes for a
+	// Failing check to be reported as Passing. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+
+	// RunIf is a CEL expression over cluster facts, such as
+	// `cluster.provider == "aws"` or `cluster.minor >= 30`. When it is false
+	// the check is not run and is reported as Skipped, which counts neither
+	// as passing nor failing. Available facts are cluster.provider,
+	// cluster.version, cluster.major, cluster.minor and cluster.nodeCount.
+	// +optional
+	RunIf string `json:"runIf,omitempty"`
+
+	// Suspend stops running this check and reports it as Suspended, which
+	// counts neither as passing nor failing.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// MaintenanceWindows are recurring periods during which this check is
+	// suspended as if Suspend were set.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
 	// Enabled controls whether this check is active.
 	// +optional
 	Enabled *bool `json:"enabled,omitempty"`
@@ -64,6 +280,18 @@ type CheckSpec struct {
 	// Only applicable for built-in checks.
 	// +optional
 	Config *apiextensionsv1.JSON `json:"config,omitempty"`
+
+	// Instance distinguishes several uses of the same GateCheck, typically
+	// with different Values. The check's identifier becomes
+	// "dynamic:<gateCheckRef>/<instance>". Only applicable with GateCheckRef.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Instance string `json:"instance,omitempty"`
+
+	// Values are passed to the referenced GateCheck's parameters.
+	// Only applicable with GateCheckRef.
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
 }
 
 // IsEnabled returns true if the check is enabled (defaults to true if not set).
@@ -74,12 +302,23 @@ func (c *CheckSpec) IsEnabled() bool {
 	return *c.Enabled
 }
 
+// Identifier returns a unique key for this check: the built-in's name, or
+// the DynamicIdentifier of its GateCheck and instance.
+func (c *CheckSpec) Identifier() string {
+	if c.GateCheckRef != "" {
+		return DynamicIdentifier(c.GateCheckRef, c.Instance)
+	}
+	return c.Name
+}
+
 // ClusterReadinessStatus defines the observed state of ClusterReadiness.
 type ClusterReadinessStatus struct {
-	// State is the overall cluster health: Healthy, Degraded, or Unhealthy.
+	// State is the overall cluster health: Healthy, Degraded, Unhealthy, or Initializing.
 	// Healthy means all checks are passing.
-	// Degraded means all critical checks pass but one or more warning checks are failing.
+	// Degraded means all critical checks pass but spec.degradedThreshold or more
+	// warning checks are failing.
 	// Unhealthy means one or more critical checks are failing.
+	// Initializing means checks are failing within spec.initialDelaySeconds.
 	// +optional
 	State ClusterHealthState `json:"state,omitempty"`
 
@@ -95,6 +334,42 @@ type ClusterReadinessStatus struct {
 	// +optional
 	LastChecked *metav1.Time `json:"lastChecked,omitempty"`
 
+	// ExcludedChecks lists the profile checks omitted by excludeChecks.
+	// +optional
+	ExcludedChecks []ExcludedCheck `json:"excludedChecks,omitempty"`
+
+	// Gates reports the readiness of each of spec.gates.
+	// +optional
+	Gates []GateStatus `json:"gates,omitempty"`
+
+	// ReadyMarkers are the ready markers currently maintained, with their
+	// namespaces resolved, so they are deleted once no longer configured.
+	// +optional
+	// +listType=atomic
+	ReadyMarkers []ReadyMarker `json:"readyMarkers,omitempty"`
+
+	// NodeTaint is the taint spec.actions.nodeTaint currently manages on
+	// nodes, so it is removed once changed or no longer configured.
+	// +optional
+	NodeTaint *corev1.Taint `json:"nodeTaint,omitempty"`
+
+	// SuspendedGitOps are the GitOps objects spec.actions.suspendGitOps
+	// currently holds paused, with their namespaces resolved.
+	// +optional
+	// +listType=atomic
+	SuspendedGitOps []GitOpsReference `json:"suspendedGitOps,omitempty"`
+
+	// TrafficWeight is the annotation spec.actions.trafficWeight currently
+	// manages and the objects it sets it on, so it is reset once changed or
+	// no longer configured.
+	// +optional
+	TrafficWeight *TrafficWeightStatus `json:"trafficWeight,omitempty"`
+
+	// CriticalPassingSince is when every critical check last started passing
+	// continuously. It is unset while any critical check is failing.
+	// +optional
+	CriticalPassingSince *metav1.Time `json:"criticalPassingSince,omitempty"`
+
 	// Conditions represent the latest available observations of the resource's state.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
@@ -122,6 +397,93 @@ type ReadinessSummary struct {
 
 	// WarningFailing is the number of warning checks currently failing.
 	WarningFailing int `json:"warningFailing"`
+
+	// InfoTotal is the number of info-severity checks.
+	InfoTotal int `json:"infoTotal"`
+
+	// InfoFailing is the number of info checks currently failing.
+	InfoFailing int `json:"infoFailing"`
+}
+
+// ReadinessPolicy chooses which failures, besides those of critical checks,
+// make a ClusterReadiness Unhealthy and not ready. Info checks never do.
+type ReadinessPolicy struct {
+	// BlockOnWarnings makes any failing warning check block readiness.
+	// +optional
+	BlockOnWarnings bool `json:"blockOnWarnings,omitempty"`
+
+	// BlockingCategories are categories in which any failing check, whatever
+	// its severity, blocks readiness.
+	// +optional
+	BlockingCategories []string `json:"blockingCategories,omitempty"`
+
+	// MaxFailing blocks readiness once more checks fail, whatever their
+	// severity, than this count (e.g. 3) or percentage of all checks (e.g.
+	// "10%", rounded down).
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+%?$`
+	MaxFailing *intstr.IntOrString `json:"maxFailing,omitempty"`
+}
+
+// ReadinessGate is a named subset of a ClusterReadiness's checks with its own
+// readiness rules. A check belongs to the gate when it matches Categories or
+// Checks; a gate that sets neither includes every check.
+// +kubebuilder:validation:XValidation:rule="self.name != 'namespaces'",message="gate name \"namespaces\" is reserved"
+type ReadinessGate struct {
+	// Name identifies the gate in its /readyz/<name> endpoint and its
+	// GateReady-<name> condition.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=53
+	Name string `json:"name"`
+
+	// Categories includes every check in these categories.
+	// +optional
+	Categories []string `json:"categories,omitempty"`
+
+	// Checks includes the checks whose identifier (e.g. "dns" or
+	// "dynamic:my-check") matches one of these names or glob patterns.
+	// +optional
+	Checks []string `json:"checks,omitempty"`
+
+	// RequiredCategories are the gate's categories that must be fully
+	// healthy for the gate to be ready, as for the ClusterReadiness.
+	// +optional
+	RequiredCategories []string `json:"requiredCategories,omitempty"`
+
+	// ReadinessPolicy makes more than failing critical checks block the
+	// gate's readiness.
+	// +optional
+	ReadinessPolicy *ReadinessPolicy `json:"readinessPolicy,omitempty"`
+}
+
+// GateStatus is the observed readiness of one ReadinessGate.
+type GateStatus struct {
+	// Name of the gate.
+	Name string `json:"name"`
+
+	// State is the gate's health, computed from its checks as for the
+	// ClusterReadiness.
+	State ClusterHealthState `json:"state"`
+
+	// Ready reports whether the gate's /readyz endpoint passes.
+	Ready bool `json:"ready"`
+
+	// Summary provides aggregated counts across the gate's checks.
+	// +optional
+	Summary *ReadinessSummary `json:"summary,omitempty"`
+}
+
+// ExcludedCheck is a profile check omitted by a ProfileRef's excludeChecks.
+type ExcludedCheck struct {
+	// Name is the check's identifier: its built-in name, or
+	// "dynamic:<gateCheckRef>".
+	Name string `json:"name"`
+
+	// Profile is the GateProfile the check was excluded from.
+	Profile string `json:"profile"`
+
+	// Pattern is the excludeChecks entry that matched.
+	Pattern string `json:"pattern"`
 }
 
 // CategoryStatus aggregates check results and details for one category.
@@ -136,6 +498,11 @@ type CategoryStatus struct {
 	// State indicates the health of this category: Healthy, Degraded, or Unhealthy.
 	State string `json:"state"`
 
+	// Required is set when the category is listed in the spec's
+	// requiredCategories.
+	// +optional
+	Required bool `json:"required,omitempty"`
+
 	// Total number of checks in this category.
 	Total int `json:"total"`
 
@@ -155,7 +522,8 @@ type CheckStatus struct {
 	// +optional
 	Source string `json:"source,omitempty"`
 
-	// Status indicates whether this check is Passing or Failing.
+	// Status indicates whether this check is Passing or Failing, or Unknown when
+	// the operator could not run it (e.g. script checks without a clientset).
 	Status string `json:"status"`
 
 	// Severity of this check.
@@ -168,12 +536,56 @@ type CheckStatus struct {
 	// LastChecked is when this check was last evaluated.
 	// +optional
 	LastChecked *metav1.Time `json:"lastChecked,omitempty"`
+
+	// Duration is how long the last evaluation of this check took.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// ConsecutiveFailures counts the failed runs since the check last passed.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// ConsecutiveSuccesses counts the successful runs since the check last failed.
+	// +optional
+	ConsecutiveSuccesses int32 `json:"consecutiveSuccesses,omitempty"`
+
+	// Revision identifies the check definition this result was computed
+	// from. A check whose definition changes, for example through an edit
+	// of its GateCheck, runs again immediately.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// Retries counts the consecutive runs that ended in an execution error.
+	// While nonzero the check is retried with exponential backoff instead of
+	// waiting for its next interval.
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
+
+	// History lists the check's most recent status transitions, oldest
+	// first, bounded by the spec's historyLimit.
+	// +optional
+	// +listType=atomic
+	History []CheckTransition `json:"history,omitempty"`
+}
+
+// CheckTransition records a check changing status.
+type CheckTransition struct {
+	// Status is the status the check changed to.
+	Status string `json:"status"`
+
+	// Message is the check's message when it changed status.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Time is when the check changed status.
+	Time metav1.Time `json:"time"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:printcolumn:name="Passing",type=integer,JSONPath=`.status.summary.passing`
 // +kubebuilder:printcolumn:name="Failing",type=integer,JSONPath=`.status.summary.failing`
 // +kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.summary.total`
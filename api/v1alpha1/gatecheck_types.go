@@ -4,6 +4,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // GateCheckSpec defines the desired state of GateCheck.
@@ -26,6 +27,14 @@ type GateCheckSpec struct {
 	// +optional
 	Interval *metav1.Duration `json:"interval,omitempty"`
 
+	// Parameters make this check a template: each $(params.<name>) in its
+	// string fields is replaced by the value the referencing check passes in
+	// values, or by the parameter's default.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Parameters []CheckParameter `json:"parameters,omitempty"`
+
 	// PodCheck verifies that pods matching a label selector are running and ready.
 	// +optional
 	PodCheck *PodCheckSpec `json:"podCheck,omitempty"`
@@ -45,6 +54,34 @@ type GateCheckSpec struct {
 	// ScriptCheck runs a custom script as a Kubernetes Job.
 	// +optional
 	ScriptCheck *ScriptCheckSpec `json:"scriptCheck,omitempty"`
+
+	// GitCheck lists the refs of a remote Git repository.
+	// +optional
+	GitCheck *GitCheckSpec `json:"gitCheck,omitempty"`
+
+	// BackupCheck verifies that Velero backups are recent.
+	// +optional
+	BackupCheck *BackupCheckSpec `json:"backupCheck,omitempty"`
+
+	// AlertmanagerCheck fails when too many matching alerts are firing.
+	// +optional
+	AlertmanagerCheck *AlertmanagerCheckSpec `json:"alertmanagerCheck,omitempty"`
+}
+
+// CheckParameter declares a parameter of a GateCheck template.
+type CheckParameter struct {
+	// Name of the parameter, referenced as $(params.<name>).
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	Name string `json:"name"`
+
+	// Description documents the parameter.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Default is used when the referencing check passes no value. A
+	// parameter without a default is required.
+	// +optional
+	Default *string `json:"default,omitempty"`
 }
 
 // GateCheckStatus defines the observed state of GateCheck.
@@ -52,6 +89,39 @@ type GateCheckStatus struct {
 	// Conditions represent the latest available observations of the GateCheck's state.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// LastResult is the outcome of the check's most recent execution by any
+	// ClusterReadiness. While the ClusterReadiness CRs running the check
+	// disagree, it is the failing execution of the first of them by name.
+	// +optional
+	LastResult *GateCheckResult `json:"lastResult,omitempty"`
+}
+
+// GateCheckResult is the outcome of one execution of a GateCheck.
+type GateCheckResult struct {
+	// Ready is whether the check passed, before any failure or success
+	// threshold is applied.
+	Ready bool `json:"ready"`
+
+	// Message is the check's result message. When several instances of the
+	// check ran, it is the first failing instance's, prefixed by its name.
+	Message string `json:"message"`
+
+	// LastRunTime is when the recorded execution ran. Executions with the
+	// same readiness and consumers as the recorded one are only recorded
+	// once it is older than the check's interval.
+	LastRunTime metav1.Time `json:"lastRunTime"`
+
+	// DurationMillis is how long the execution took, in milliseconds.
+	DurationMillis int64 `json:"durationMillis"`
+
+	// ClusterReadiness is the ClusterReadiness that ran the recorded execution.
+	ClusterReadiness string `json:"clusterReadiness"`
+
+	// ConsumingClusterReadiness lists the ClusterReadiness CRs referencing
+	// the check, inline or through a GateProfile.
+	// +optional
+	ConsumingClusterReadiness []string `json:"consumingClusterReadiness,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -59,6 +129,8 @@ type GateCheckStatus struct {
 // +kubebuilder:resource:scope=Cluster,shortName=gchk
 // +kubebuilder:printcolumn:name="Severity",type=string,JSONPath=`.spec.severity`
 // +kubebuilder:printcolumn:name="Category",type=string,JSONPath=`.spec.category`
+// +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.lastResult.ready`
+// +kubebuilder:printcolumn:name="Last Run",type=date,JSONPath=`.status.lastResult.lastRunTime`
 // +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.description`,priority=1
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
@@ -91,16 +163,100 @@ type PodCheckSpec struct {
 	// +optional
 	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
 
+	// DaemonSetRef names a DaemonSet in Namespace whose ready pods are compared
+	// with its desiredNumberScheduled instead of counting pods by
+	// LabelSelector against MinReady. MinReadyPercent, when set, lowers the
+	// requirement to that percentage of the desired pods.
+	// +optional
+	DaemonSetRef string `json:"daemonSetRef,omitempty"`
+
 	// MinReady is the minimum number of ready pods required for the check to pass.
 	// +optional
 	// +kubebuilder:default=1
 	MinReady int32 `json:"minReady,omitempty"`
+
+	// MinReadySeconds counts a pod as ready only once its Ready condition
+	// has been True for this long, filtering out pods that just flapped back.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+
+	// MinReadyPercent requires this percentage of the matching pods, or of
+	// PercentOf's desired replicas, to be ready, rounded up. The check
+	// requires the larger of MinReady and the percentage.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MinReadyPercent *int32 `json:"minReadyPercent,omitempty"`
+
+	// PercentOf names a workload in Namespace whose desired replicas, read
+	// from its scale subresource, are the base of MinReadyPercent.
+	// +optional
+	PercentOf *WorkloadReference `json:"percentOf,omitempty"`
+
+	// MaxRestarts fails the check when any selected pod's containers have
+	// restarted more than this many times in total, catching pods that are
+	// Ready but slowly crash-looping.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+
+	// RestartWindow counts only restarts within this window toward
+	// MaxRestarts, up to 24h. Restarts are derived from the counts observed
+	// by the operator, so restarts before a pod was first checked are only
+	// counted when the pod was created within the window.
+	// +optional
+	RestartWindow *metav1.Duration `json:"restartWindow,omitempty"`
+
+	// MinReadyPerZone requires at least this many ready pods in every
+	// failure domain, i.e. every value of SpreadByTopologyKey among the
+	// cluster's Nodes, so HA components survive the loss of a domain.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReadyPerZone int32 `json:"minReadyPerZone,omitempty"`
+
+	// SpreadByTopologyKey is the Node label defining failure domains for
+	// MinReadyPerZone.
+	// +kubebuilder:default="topology.kubernetes.io/zone"
+	// +optional
+	SpreadByTopologyKey string `json:"spreadByTopologyKey,omitempty"`
+
+	// ExpectedImage requires every selected pod to run a container with this
+	// image, so stalled rollouts fail the check. An image pinned by digest
+	// (name@sha256:...) is compared with the digest the kubelet resolved.
+	// +optional
+	ExpectedImage string `json:"expectedImage,omitempty"`
+
+	// ExpectedImageRegex requires every selected pod to run a container whose
+	// image or resolved image ID matches this regular expression.
+	// +optional
+	ExpectedImageRegex string `json:"expectedImageRegex,omitempty"`
+}
+
+// WorkloadReference names a scalable workload in the check's namespace.
+type WorkloadReference struct {
+	// APIVersion of the workload.
+	// +kubebuilder:default="apps/v1"
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Kind of the workload, e.g. Deployment or StatefulSet.
+	Kind string `json:"kind"`
+
+	// Name of the workload.
+	Name string `json:"name"`
 }
 
 // HTTPCheckSpec defines a check that performs an HTTP request and validates the response.
 type HTTPCheckSpec struct {
-	// URL is the HTTP endpoint to probe.
-	URL string `json:"url"`
+	// URL is the HTTP endpoint to probe. Mutually exclusive with ServiceRef.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// ServiceRef probes an in-cluster Service through its cluster DNS name
+	// instead of a fixed URL. Mutually exclusive with URL.
+	// +optional
+	ServiceRef *HTTPServiceReference `json:"serviceRef,omitempty"`
 
 	// Method is the HTTP method to use.
 	// +optional
@@ -111,6 +267,18 @@ type HTTPCheckSpec struct {
 	// +optional
 	ExpectedStatusCodes []int `json:"expectedStatusCodes,omitempty"`
 
+	// FollowRedirects controls whether 3xx responses are followed. When false,
+	// the redirect response itself is checked against ExpectedStatusCodes.
+	// +optional
+	// +kubebuilder:default=true
+	FollowRedirects *bool `json:"followRedirects,omitempty"`
+
+	// MaxRedirects is the number of redirects followed before the check fails.
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	MaxRedirects *int32 `json:"maxRedirects,omitempty"`
+
 	// TimeoutSeconds is the request timeout.
 	// +optional
 	// +kubebuilder:default=10
@@ -120,9 +288,116 @@ type HTTPCheckSpec struct {
 	// +optional
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
 
+	// TLS configures trusted CAs and a client certificate for HTTPS endpoints.
+	// +optional
+	TLS *HTTPTLSConfig `json:"tls,omitempty"`
+
+	// ProxyURL routes the request through an HTTP(S) proxy (e.g. "http://proxy.corp:3128").
+	// When unset, the operator's HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment applies.
+	// +optional
+	ProxyURL string `json:"proxyURL,omitempty"`
+
+	// NoProxy lists hosts, domains (".corp.example") and CIDRs that bypass the proxy,
+	// in addition to NO_PROXY from the environment.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+
+	// ResolveOverrides pins hostname resolution for this check, e.g. to probe
+	// endpoints behind split-horizon DNS.
+	// +optional
+	ResolveOverrides *HTTPResolveOverrides `json:"resolveOverrides,omitempty"`
+
 	// Headers to include in the request.
 	// +optional
 	Headers map[string]string `json:"headers,omitempty"`
+
+	// Body is sent as the request body, e.g. a query document for a POST probe.
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// ContentType sets the Content-Type header of the request body.
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+
+	// HeadersFromSecret sets request headers from Secret keys, resolved at run time,
+	// so that tokens are not stored in the GateCheck. They override Headers of the same name.
+	// +optional
+	HeadersFromSecret []HTTPHeaderFromSecret `json:"headersFromSecret,omitempty"`
+
+	// BasicAuthSecretRef names a kubernetes.io/basic-auth Secret whose "username" and
+	// "password" keys are sent as HTTP basic authentication.
+	// +optional
+	BasicAuthSecretRef *SecretReference `json:"basicAuthSecretRef,omitempty"`
+}
+
+// HTTPResolveOverrides configures how an HTTP check resolves hostnames.
+type HTTPResolveOverrides struct {
+	// Nameserver is a DNS server ("10.0.0.2" or "10.0.0.2:53") queried instead
+	// of the operator pod's resolver.
+	// +optional
+	Nameserver string `json:"nameserver,omitempty"`
+
+	// HostAliases map hostnames to fixed IPs, like /etc/hosts. They take
+	// precedence over Nameserver.
+	// +optional
+	HostAliases []HTTPHostAlias `json:"hostAliases,omitempty"`
+}
+
+// HTTPHostAlias maps a hostname to a fixed IP address.
+type HTTPHostAlias struct {
+	// Hostname to override.
+	Hostname string `json:"hostname"`
+
+	// IP address to connect to for Hostname.
+	IP string `json:"ip"`
+}
+
+// HTTPServiceReference identifies a Service port to probe over HTTP.
+type HTTPServiceReference struct {
+	// Namespace of the Service.
+	Namespace string `json:"namespace"`
+
+	// Name of the Service.
+	Name string `json:"name"`
+
+	// Port is the Service port number or name. For headless Services the
+	// port's numeric targetPort is used, since clients connect to pods directly.
+	Port intstr.IntOrString `json:"port"`
+
+	// Path of the request, e.g. "/healthz".
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Scheme is "http" or "https".
+	// +optional
+	// +kubebuilder:default=http
+	// +kubebuilder:validation:Enum=http;https
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// HTTPHeaderFromSecret sets a request header to the value of a Secret key.
+type HTTPHeaderFromSecret struct {
+	// Name of the HTTP header (e.g. "Authorization").
+	Name string `json:"name"`
+
+	// SecretRef names the Secret holding the header value.
+	SecretRef SecretReference `json:"secretRef"`
+
+	// Key within the Secret whose value is used verbatim as the header value.
+	Key string `json:"key"`
+}
+
+// HTTPTLSConfig configures TLS for an HTTP check.
+type HTTPTLSConfig struct {
+	// CABundleSecretRef names a Secret whose "ca.crt" key holds PEM-encoded CA
+	// certificates trusted in addition to the system roots.
+	// +optional
+	CABundleSecretRef *SecretReference `json:"caBundleSecretRef,omitempty"`
+
+	// ClientCertSecretRef names a kubernetes.io/tls Secret whose "tls.crt" and
+	// "tls.key" keys are presented as the client certificate for mutual TLS.
+	// +optional
+	ClientCertSecretRef *SecretReference `json:"clientCertSecretRef,omitempty"`
 }
 
 // ResourceCheckSpec defines a check that asserts conditions on a Kubernetes resource.
@@ -133,10 +408,21 @@ type ResourceCheckSpec struct {
 	// Kind of the resource (e.g. "Deployment").
 	Kind string `json:"kind"`
 
-	// Namespace of the resource. Empty for cluster-scoped resources.
+	// Namespace of the resource. Empty for cluster-scoped resources, or to
+	// select resources across all namespaces with LabelSelector.
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
 
+	// NamespaceSelector restricts a cluster-wide LabelSelector check to
+	// namespaces with matching labels. Mutually exclusive with Namespace.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ExcludeNamespaces skips resources in these namespaces in a cluster-wide
+	// LabelSelector check. Mutually exclusive with Namespace.
+	// +optional
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+
 	// Name of the resource. Mutually exclusive with LabelSelector.
 	// +optional
 	Name string `json:"name,omitempty"`
@@ -145,10 +431,109 @@ type ResourceCheckSpec struct {
 	// +optional
 	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
 
+	// MinCount is the minimum number of resources LabelSelector must match.
+	// When MinCount or MaxCount is set, the count replaces the default
+	// requirement that at least one resource matches.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinCount *int32 `json:"minCount,omitempty"`
+
+	// MaxCount is the maximum number of resources LabelSelector may match.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxCount *int32 `json:"maxCount,omitempty"`
+
+	// RequireObservedGeneration fails resources whose .status.observedGeneration
+	// is behind .metadata.generation, i.e. whose controller has not yet
+	// reconciled the latest spec even if older conditions still read True.
+	// +optional
+	RequireObservedGeneration bool `json:"requireObservedGeneration,omitempty"`
+
+	// Replicas asserts that a workload's ready, updated and available
+	// replicas have reached the desired count read from its scale subresource.
+	// +optional
+	Replicas *ReplicasAssertion `json:"replicas,omitempty"`
+
+	// Mode is MustExist (the default), MustNotExist or DryRun. MustNotExist
+	// inverts the check: it passes when no resource matches Name or
+	// LabelSelector and every other assertion, e.g. no Nodes with a given
+	// taint. DryRun creates Manifest with a server-side dry run instead, so
+	// admission webhooks, quotas and RBAC decide whether the check passes.
+	// +kubebuilder:default=MustExist
+	// +optional
+	Mode ResourceCheckMode `json:"mode,omitempty"`
+
+	// Manifest is the object created by a DryRun check. APIVersion and Kind
+	// apply when it does not set its own, Namespace overrides its namespace,
+	// and an object without a name is created with a generated one.
+	// +kubebuilder:validation:Type=object
+	// +optional
+	Manifest *apiextensionsv1.JSON `json:"manifest,omitempty"`
+
 	// Conditions to assert on the resource.
-	Conditions []ResourceConditionCheck `json:"conditions"`
+	// +optional
+	Conditions []ResourceConditionCheck `json:"conditions,omitempty"`
+
+	// CELExpressions are CEL expressions evaluated against each resource,
+	// available as `object`. Every expression must evaluate to true, e.g.
+	// "object.status.readyReplicas >= object.spec.replicas".
+	// +optional
+	CELExpressions []string `json:"celExpressions,omitempty"`
+
+	// Fields asserts on arbitrary fields of each resource, for resources
+	// without a conditions array (e.g. a PVC's .status.phase).
+	// +optional
+	Fields []ResourceFieldCheck `json:"fields,omitempty"`
 }
 
+// ResourceFieldCheck asserts on the value of a resource field.
+type ResourceFieldCheck struct {
+	// Path is a JSONPath to the field, e.g. ".status.phase". A path matching
+	// several values (e.g. ".status.containerStatuses[*].ready") requires
+	// every value to satisfy the assertion.
+	Path string `json:"path"`
+
+	// Operator compares the field with Value: eq, ne, gt, gte, lt, lte, or
+	// exists. gt, gte, lt and lte compare numerically; exists ignores Value.
+	// +kubebuilder:default=eq
+	// +kubebuilder:validation:Enum=eq;ne;gt;gte;lt;lte;exists
+	// +optional
+	Operator string `json:"operator,omitempty"`
+
+	// Value is the expected value.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// ReplicasAssertion compares a workload's replica counts with the desired
+// count of its scale subresource. Each count is required unless disabled.
+type ReplicasAssertion struct {
+	// Ready requires status.readyReplicas to reach the desired count.
+	// +kubebuilder:default=true
+	// +optional
+	Ready *bool `json:"ready,omitempty"`
+
+	// Updated requires status.updatedReplicas to reach the desired count.
+	// +kubebuilder:default=true
+	// +optional
+	Updated *bool `json:"updated,omitempty"`
+
+	// Available requires status.availableReplicas to reach the desired count.
+	// +kubebuilder:default=true
+	// +optional
+	Available *bool `json:"available,omitempty"`
+}
+
+// ResourceCheckMode selects whether a ResourceCheck requires resources to exist.
+// +kubebuilder:validation:Enum=MustExist;MustNotExist;DryRun
+type ResourceCheckMode string
+
+const (
+	ResourceMustExist    ResourceCheckMode = "MustExist"
+	ResourceMustNotExist ResourceCheckMode = "MustNotExist"
+	ResourceDryRun       ResourceCheckMode = "DryRun"
+)
+
 // ResourceConditionCheck defines an expected condition on a resource.
 type ResourceConditionCheck struct {
 	// Type is the condition type to check.
@@ -156,44 +541,158 @@ type ResourceConditionCheck struct {
 
 	// Status is the expected condition status (e.g. "True", "False").
 	Status string `json:"status"`
+
+	// Reason is the expected condition reason. Any reason matches when empty.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// MinAgeSeconds requires the condition to have held its status for at
+	// least this long, rejecting freshly transitioned or flapping conditions.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinAgeSeconds *int32 `json:"minAgeSeconds,omitempty"`
+
+	// MaxLastTransitionAgeSeconds requires the condition to have transitioned
+	// within this many seconds, for conditions expected to change recently
+	// (e.g. after a rollout).
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxLastTransitionAgeSeconds *int32 `json:"maxLastTransitionAgeSeconds,omitempty"`
 }
 
 // PromQLCheckSpec defines a check that queries Prometheus and evaluates the result.
 type PromQLCheckSpec struct {
-	// Endpoint is the Prometheus server URL.
-	Endpoint string `json:"endpoint"`
+	// Endpoint is the Prometheus server URL. Mutually exclusive with ServiceRef.
+	// When both are omitted, the Prometheus Operator's "prometheus-operated"
+	// Service is discovered in the operator's --prometheus-namespace.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
 
-	// Query is the PromQL expression to evaluate.
+	// ServiceRef targets a Prometheus Service by name and port instead of a URL.
+	// Path is used as the API path prefix. Mutually exclusive with Endpoint.
+	// +optional
+	ServiceRef *HTTPServiceReference `json:"serviceRef,omitempty"`
+
+	// Query is the PromQL expression to evaluate. It is a Go template: the
+	// operator's --cluster-name is available as {{ .ClusterName }} and the keys
+	// of VariablesFrom as {{ .Values.<key> }}, so one profile serves a fleet.
 	Query string `json:"query"`
 
+	// VariablesFrom names a ConfigMap whose keys are available to Query as
+	// {{ .Values.<key> }}.
+	// +optional
+	VariablesFrom *ConfigMapReference `json:"variablesFrom,omitempty"`
+
 	// Condition defines how to evaluate the query result.
-	Condition PromQLCondition `json:"condition"`
+	// Mutually exclusive with Conditions.
+	// +optional
+	Condition *PromQLCondition `json:"condition,omitempty"`
+
+	// Conditions evaluates several conditions against the same result,
+	// combined according to ConditionsMatch. Mutually exclusive with Condition.
+	// +optional
+	Conditions []PromQLCondition `json:"conditions,omitempty"`
+
+	// ConditionsMatch is "all" (every condition must hold) or "any" (at least one must hold).
+	// +optional
+	// +kubebuilder:default=all
+	// +kubebuilder:validation:Enum=all;any
+	ConditionsMatch string `json:"conditionsMatch,omitempty"`
 
 	// TimeoutSeconds is the query timeout.
 	// +optional
 	// +kubebuilder:default=10
 	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// BearerTokenSecretRef names a Secret whose "token" key is sent as a bearer
+	// token. Mutually exclusive with BasicAuthSecretRef.
+	// +optional
+	BearerTokenSecretRef *SecretReference `json:"bearerTokenSecretRef,omitempty"`
+
+	// BasicAuthSecretRef names a kubernetes.io/basic-auth Secret whose "username"
+	// and "password" keys are sent as HTTP basic auth credentials.
+	// +optional
+	BasicAuthSecretRef *SecretReference `json:"basicAuthSecretRef,omitempty"`
+
+	// InsecureSkipTLSVerify disables TLS certificate verification.
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// TLS configures trusted CAs and a client certificate for HTTPS endpoints.
+	// +optional
+	TLS *HTTPTLSConfig `json:"tls,omitempty"`
+
+	// Tenant selects the tenant of a multi-tenant Cortex, Mimir or Thanos endpoint.
+	// +optional
+	Tenant *PromQLTenant `json:"tenant,omitempty"`
+}
+
+// PromQLTenant sets the tenant header of a PromQL query, from a literal ID or
+// a Secret key. Exactly one of ID or SecretRef must be set.
+type PromQLTenant struct {
+	// ID is the tenant ID.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// SecretRef names a Secret holding the tenant ID.
+	// +optional
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+
+	// Key within the Secret holding the tenant ID. Defaults to "tenant".
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// Header is the tenant header name. Defaults to "X-Scope-OrgID" (Cortex,
+	// Mimir); Thanos Receive and Query Frontend use "THANOS-TENANT".
+	// +optional
+	Header string `json:"header,omitempty"`
 }
 
 // PromQLCondition defines how to evaluate a PromQL query result.
 type PromQLCondition struct {
-	// Type is either "resultCount" or "value".
-	// +kubebuilder:validation:Enum=resultCount;value
+	// Type is "resultCount", "value", or "mustBeEmpty". mustBeEmpty passes only
+	// when the query returns no series, like absent(); it takes no operator or threshold.
+	// +kubebuilder:validation:Enum=resultCount;value;mustBeEmpty
 	Type string `json:"type"`
 
 	// Operator is the comparison operator: gte, lte, eq, gt, lt.
+	// Required for resultCount and value conditions.
+	// +optional
 	// +kubebuilder:validation:Enum=gte;lte;eq;gt;lt
-	Operator string `json:"operator"`
+	Operator string `json:"operator,omitempty"`
 
 	// Threshold is the value to compare against.
-	Threshold float64 `json:"threshold"`
+	// +optional
+	Threshold float64 `json:"threshold,omitempty"`
 }
 
+// ScriptResultFormat selects how a script check's result is determined.
+type ScriptResultFormat string
+
+const (
+	// ScriptResultFormatExitCode treats exit 0 as ready and reports the logs.
+	ScriptResultFormatExitCode ScriptResultFormat = "exitCode"
+	// ScriptResultFormatJSON reads a {"ready", "message", "details", "value"}
+	// document from the termination message, or from stdout when it is empty.
+	ScriptResultFormatJSON ScriptResultFormat = "json"
+)
+
 // ScriptCheckSpec defines a check that runs a script as a Kubernetes Job.
 type ScriptCheckSpec struct {
 	// Image is the container image to run.
 	Image string `json:"image"`
 
+	// ImagePerArch maps node architectures (e.g. "amd64", "arm64") to images built for them.
+	// When set, the Job is pinned to a single architecture — the operator's own if listed,
+	// otherwise the first in lexical order — and the matching image overrides Image.
+	// +optional
+	ImagePerArch map[string]string `json:"imagePerArch,omitempty"`
+
+	// Architectures restricts the Job to nodes whose kubernetes.io/arch label is in this list.
+	// Use this when Image is not a multi-arch manifest. Ignored when ImagePerArch is set.
+	// +optional
+	Architectures []string `json:"architectures,omitempty"`
+
 	// Command is the entrypoint for the container.
 	// +optional
 	Command []string `json:"command,omitempty"`
@@ -202,18 +701,209 @@ type ScriptCheckSpec struct {
 	// +optional
 	Args []string `json:"args,omitempty"`
 
+	// ScriptFrom mounts a script from a ConfigMap and runs it. Without Command the
+	// script is executed directly, so it needs a shebang line, and Args are passed
+	// to it. With Command (e.g. ["python3"]) the script path is passed as the first
+	// argument, before Args.
+	// +optional
+	ScriptFrom *ScriptSource `json:"scriptFrom,omitempty"`
+
 	// TimeoutSeconds is the maximum time the job may run.
 	// +optional
 	// +kubebuilder:default=30
 	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 
+	// Schedule runs the script as a CronJob on this cron schedule (e.g. "*/5 * * * *")
+	// instead of creating a Job each time the check runs. The GateCheck controller
+	// creates the CronJob, owned by the GateCheck, in the operator namespace, and
+	// the check reports the latest finished run. Not supported with runOnAllNodes.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// ResultTTLSeconds reuses the result of the last Job for this check, with an
+	// identical spec, for this many seconds instead of creating a new Job. Set it
+	// close to the check interval to avoid Job churn when many readiness objects
+	// share the check or reconciles are triggered often. Failed runs are reused
+	// too; errors creating or watching the Job are not.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ResultTTLSeconds *int32 `json:"resultTTLSeconds,omitempty"`
+
+	// ResultFormat selects how the result is read. exitCode treats exit 0 as ready.
+	// json parses a document with a required "ready" bool and optional "message",
+	// "details" and "value" from /dev/termination-log, or from stdout (its last
+	// line when the whole output is not JSON). A non-zero exit is never ready.
+	// +optional
+	// +kubebuilder:validation:Enum=exitCode;json
+	// +kubebuilder:default=exitCode
+	ResultFormat ScriptResultFormat `json:"resultFormat,omitempty"`
+
 	// ServiceAccountName for the job pod.
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 
+	// ImagePullSecrets name Secrets, in the namespace the Job runs in, used to
+	// pull Image from private registries.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
 	// Env is a list of environment variables for the container.
 	// +optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// NodeSelector restricts the Job pod to nodes with these labels.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// RunOnAllNodes runs one Job per schedulable node matching NodeSelector, for
+	// node-local checks, and is ready only when every run is ready. Each Job gets
+	// the node name in the CLUSTERGATE_NODE_NAME environment variable. Cordoned
+	// nodes are skipped; Tolerations must cover any tainted nodes.
+	// +optional
+	RunOnAllNodes bool `json:"runOnAllNodes,omitempty"`
+
+	// Tolerations let the Job pod schedule onto tainted nodes.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity sets the Job pod's scheduling constraints. A kubernetes.io/arch
+	// requirement from Architectures or ImagePerArch is added to every required
+	// node selector term.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Resources sets the container's compute resource requests and limits.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// SecurityContext is the container's security context. Fields left unset
+	// default to the PodSecurity "restricted" profile: no privilege escalation
+	// and all capabilities dropped.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// PodSecurityContext is the Job pod's security context. Fields left unset
+	// default to runAsNonRoot and the RuntimeDefault seccomp profile. Images
+	// that run as root need runAsUser set to a non-zero UID.
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+}
+
+// ScriptSource selects where a script check's script is read from.
+type ScriptSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap in the namespace the Job runs in.
+	ConfigMapKeyRef corev1.ConfigMapKeySelector `json:"configMapKeyRef"`
+}
+
+// GitCheckSpec defines a check that performs an ls-remote against a Git repository.
+type GitCheckSpec struct {
+	// URL of the repository over HTTPS or SSH, e.g. "https://github.com/org/repo.git",
+	// "ssh://git@github.com/org/repo.git" or "git@github.com:org/repo.git".
+	URL string `json:"url"`
+
+	// Ref is a branch or tag that must exist in the repository. Short names are matched
+	// against refs/heads/ and refs/tags/; names starting with "refs/" must match exactly.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+
+	// SecretRef names a Secret holding credentials. HTTPS repositories use the "username"
+	// and "password" keys. SSH repositories use "identity" (a private key), "known_hosts"
+	// (required to verify the server) and optionally "password" as the key passphrase.
+	// +optional
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+
+	// TimeoutSeconds is the ls-remote timeout.
+	// +optional
+	// +kubebuilder:default=10
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// BackupCheckSpec defines a check that fails when the most recent successful
+// Velero Backup is older than a threshold.
+type BackupCheckSpec struct {
+	// Namespace where Velero Backup resources live.
+	// +optional
+	// +kubebuilder:default=velero
+	Namespace string `json:"namespace,omitempty"`
+
+	// ScheduleName limits the check to backups created by this Velero Schedule.
+	// When empty, all backups in the namespace are considered.
+	// +optional
+	ScheduleName string `json:"scheduleName,omitempty"`
+
+	// MaxAge is the maximum age of the most recent successful backup (e.g. "25h").
+	MaxAge metav1.Duration `json:"maxAge"`
+}
+
+// AlertmanagerCheckSpec defines a check against the Alertmanager v2 API that
+// fails when more than MaxFiring matching alerts are firing.
+type AlertmanagerCheckSpec struct {
+	// Endpoint is the Alertmanager URL. Mutually exclusive with ServiceRef.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// ServiceRef targets the Alertmanager Service by name and port instead of a
+	// URL. Path is used as the API path prefix. Mutually exclusive with Endpoint.
+	// +optional
+	ServiceRef *HTTPServiceReference `json:"serviceRef,omitempty"`
+
+	// Matchers selects alerts with Alertmanager label matchers, e.g.
+	// `severity="critical"` or `alertname=~"Etcd.*"`. All matchers must match.
+	// When empty, every alert counts.
+	// +optional
+	Matchers []string `json:"matchers,omitempty"`
+
+	// MaxFiring is the number of matching firing alerts tolerated. Defaults to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxFiring int32 `json:"maxFiring,omitempty"`
+
+	// IncludeSilenced also counts silenced and inhibited alerts.
+	// +optional
+	IncludeSilenced bool `json:"includeSilenced,omitempty"`
+
+	// TimeoutSeconds is the request timeout.
+	// +optional
+	// +kubebuilder:default=10
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// BearerTokenSecretRef names a Secret whose "token" key is sent as a bearer
+	// token. Mutually exclusive with BasicAuthSecretRef.
+	// +optional
+	BearerTokenSecretRef *SecretReference `json:"bearerTokenSecretRef,omitempty"`
+
+	// BasicAuthSecretRef names a kubernetes.io/basic-auth Secret whose "username"
+	// and "password" keys are sent as HTTP basic auth credentials.
+	// +optional
+	BasicAuthSecretRef *SecretReference `json:"basicAuthSecretRef,omitempty"`
+
+	// InsecureSkipTLSVerify disables TLS certificate verification.
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// TLS configures trusted CAs and a client certificate for HTTPS endpoints.
+	// +optional
+	TLS *HTTPTLSConfig `json:"tls,omitempty"`
+}
+
+// SecretReference identifies a Secret by name and namespace.
+type SecretReference struct {
+	// Name of the Secret.
+	Name string `json:"name"`
+
+	// Namespace of the Secret. Defaults to the operator namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ConfigMapReference identifies a ConfigMap by name and namespace.
+type ConfigMapReference struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap. Defaults to the operator namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // --- ProfileCheckRef for GateProfile ---
@@ -242,6 +932,37 @@ type ProfileCheckRef struct {
 	// +optional
 	Interval *metav1.Duration `json:"interval,omitempty"`
 
+	// Timeout bounds a single execution of this check. A check that overruns it
+	// fails with a timeout message instead of stalling the reconcile.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// FailureThreshold is how many consecutive failed runs it takes for a
+	// Passing check to be reported as Failing. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+
+	// SuccessThreshold is how many consecutive successful runs it takes for a
+	// Failing check to be reported as Passing. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+
+	// RunIf is a CEL expression over cluster facts; when it is false the
+	// check is reported as Skipped instead of run. See CheckSpec.RunIf.
+	// +optional
+	RunIf string `json:"runIf,omitempty"`
+
+	// Suspend stops running this check and reports it as Suspended.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// MaintenanceWindows are recurring periods during which this check is
+	// suspended as if Suspend were set.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
 	// Enabled controls whether this check is active.
 	// +optional
 	Enabled *bool `json:"enabled,omitempty"`
@@ -249,16 +970,36 @@ type ProfileCheckRef struct {
 	// Config holds check-specific configuration as arbitrary JSON.
 	// +optional
 	Config *apiextensionsv1.JSON `json:"config,omitempty"`
+
+	// Instance distinguishes several uses of the same GateCheck, typically
+	// with different Values. See CheckSpec.Instance.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Instance string `json:"instance,omitempty"`
+
+	// Values are passed to the referenced GateCheck's parameters.
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
 }
 
 // Identifier returns a unique key for this check reference.
 func (r *ProfileCheckRef) Identifier() string {
 	if r.GateCheckRef != "" {
-		return "dynamic:" + r.GateCheckRef
+		return DynamicIdentifier(r.GateCheckRef, r.Instance)
 	}
 	return r.Name
 }
 
+// DynamicIdentifier returns the identifier of a check of the GateCheck named
+// gateCheckRef: "dynamic:<gateCheckRef>", or "dynamic:<gateCheckRef>/<instance>"
+// for a named instance.
+func DynamicIdentifier(gateCheckRef, instance string) string {
+	if instance != "" {
+		return "dynamic:" + gateCheckRef + "/" + instance
+	}
+	return "dynamic:" + gateCheckRef
+}
+
 // IsEnabled returns true if the check is enabled (defaults to true if not set).
 func (r *ProfileCheckRef) IsEnabled() bool {
 	if r.Enabled == nil {
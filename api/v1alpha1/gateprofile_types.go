@@ -10,20 +10,145 @@ type GateProfileSpec struct {
 	// +optional
 	Description string `json:"description,omitempty"`
 
+	// Version names this revision of the profile's checks, e.g. "1.4.0".
+	// Each version's checks are recorded in status.versions, so that
+	// ClusterReadiness CRs pinning an older version keep using it while the
+	// profile moves on. Editing checks without changing the version updates
+	// the recorded version.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9]([-A-Za-z0-9._+]*[A-Za-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Version string `json:"version,omitempty"`
+
+	// VersionHistoryLimit is how many versions status.versions keeps,
+	// the current one included. Defaults to 10.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	VersionHistoryLimit *int32 `json:"versionHistoryLimit,omitempty"`
+
 	// Checks is the list of check references included in this profile.
-	Checks []ProfileCheckRef `json:"checks"`
+	// Ignored when Source is set.
+	// +optional
+	Checks []ProfileCheckRef `json:"checks,omitempty"`
+
+	// Source imports the profile's version and checks, and the GateChecks
+	// they reference, from a profile bundle published centrally. The imported
+	// content is recorded in status.source.
+	// +optional
+	Source *ProfileSource `json:"source,omitempty"`
+}
+
+// ProfileSource locates a profile bundle: a multi-document YAML file holding
+// one GateProfile and the GateChecks it references. Exactly one of OCIRef and
+// ConfigMapRef must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.ociRef) != has(self.configMapRef)",message="exactly one of ociRef and configMapRef must be set"
+type ProfileSource struct {
+	// OCIRef is an OCI artifact holding the bundle as a layer of media type
+	// "application/vnd.clustergate.profile.bundle.v1+yaml", e.g.
+	// "registry.example.com/platform/readiness:1.4.0" or
+	// "registry.example.com/platform/readiness@sha256:<digest>".
+	// +optional
+	OCIRef string `json:"ociRef,omitempty"`
+
+	// ConfigMapRef names a ConfigMap holding the bundle in its "bundle.yaml"
+	// key and, when verified, its signature in "bundle.yaml.sig".
+	// +optional
+	ConfigMapRef *ConfigMapReference `json:"configMapRef,omitempty"`
+
+	// SecretRef names a Secret holding the registry's "username" and
+	// "password". Anonymous pulls are used without it.
+	// +optional
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+
+	// Insecure pulls from the registry over plain HTTP.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// Verify rejects bundles without a valid signature. Without it, bundles
+	// may not carry GateChecks with script, Git or backup checks.
+	// +optional
+	Verify *ProfileVerification `json:"verify,omitempty"`
+
+	// Interval is how often the bundle is pulled again. Defaults to 10m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// ProfileVerification configures the signature check of a profile bundle.
+// Signatures are base64-encoded ECDSA (ASN.1, over the SHA-256 of the bundle)
+// or Ed25519 signatures of the bundle's bytes. OCI bundles carry theirs in the
+// "io.clustergate.profile.signature" annotation of the bundle layer.
+type ProfileVerification struct {
+	// PublicKeyRef names a Secret holding the PEM-encoded public key in its
+	// "publicKey" key.
+	PublicKeyRef SecretReference `json:"publicKeyRef"`
 }
 
 // GateProfileStatus defines the observed state of GateProfile.
 type GateProfileStatus struct {
+	// Versions records the checks of each version of the profile, newest
+	// first.
+	// +optional
+	Versions []ProfileVersion `json:"versions,omitempty"`
+
+	// Source is the content last imported from spec.source.
+	// +optional
+	Source *ProfileSourceStatus `json:"source,omitempty"`
+
 	// Conditions represent the latest available observations of the GateProfile's state.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// ProfileVersion is the recorded content of one version of a GateProfile.
+type ProfileVersion struct {
+	// Version is the spec.version recorded.
+	Version string `json:"version"`
+
+	// Checks are the profile's checks at that version.
+	Checks []ProfileCheckRef `json:"checks"`
+
+	// RecordedAt is when the version was last recorded.
+	RecordedAt metav1.Time `json:"recordedAt"`
+}
+
+// ProfileSourceStatus is the content imported from a profile bundle.
+type ProfileSourceStatus struct {
+	// Digest is the sha256 digest of the imported bundle.
+	Digest string `json:"digest"`
+
+	// Version is the bundle's profile version.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Checks are the bundle's profile checks.
+	// +optional
+	Checks []ProfileCheckRef `json:"checks,omitempty"`
+
+	// GateChecks are the names of the GateChecks created from the bundle.
+	// +optional
+	GateChecks []string `json:"gateChecks,omitempty"`
+
+	// LastSyncTime is when the bundle was last pulled.
+	LastSyncTime metav1.Time `json:"lastSyncTime"`
+}
+
+// Current returns the profile's current version and checks: those imported
+// from spec.source when it is set, the spec's otherwise.
+func (p *GateProfile) Current() (string, []ProfileCheckRef) {
+	if p.Spec.Source != nil {
+		if p.Status.Source == nil {
+			return "", nil
+		}
+		return p.Status.Source.Version, p.Status.Source.Checks
+	}
+	return p.Spec.Version, p.Spec.Checks
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,shortName=gp
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.version`
 // +kubebuilder:printcolumn:name="Description",type=string,JSONPath=`.spec.description`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
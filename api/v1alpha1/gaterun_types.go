@@ -0,0 +1,100 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GateRunTrigger records what created a GateRun.
+// +kubebuilder:validation:Enum=Scheduled;OnDemand
+type GateRunTrigger string
+
+const (
+	// GateRunScheduled runs are recorded by the operator after an evaluation cycle.
+	GateRunScheduled GateRunTrigger = "Scheduled"
+
+	// GateRunOnDemand runs are created by users and filled in by the operator
+	// from the latest evaluation.
+	GateRunOnDemand GateRunTrigger = "OnDemand"
+)
+
+// GateRunSpec identifies the evaluation a GateRun records.
+type GateRunSpec struct {
+	// ClusterReadiness is the name of the recorded ClusterReadiness.
+	ClusterReadiness string `json:"clusterReadiness"`
+
+	// Trigger records what created this run. Defaults to OnDemand.
+	// +optional
+	// +kubebuilder:default=OnDemand
+	Trigger GateRunTrigger `json:"trigger,omitempty"`
+}
+
+// GateRunStatus is the evidence captured for one evaluation. It is written
+// once, when RecordedAt is set, and never updated by the operator afterwards.
+type GateRunStatus struct {
+	// RecordedAt is when the evidence was captured.
+	// +optional
+	RecordedAt *metav1.Time `json:"recordedAt,omitempty"`
+
+	// OperatorVersion is the version of the operator that evaluated the checks.
+	// +optional
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+
+	// State is the overall health of the ClusterReadiness at RecordedAt.
+	// +optional
+	State ClusterHealthState `json:"state,omitempty"`
+
+	// Summary is the aggregated check counts at RecordedAt.
+	// +optional
+	Summary *ReadinessSummary `json:"summary,omitempty"`
+
+	// Checks is the full resolved check list with each check's result.
+	// +optional
+	Checks []GateRunCheck `json:"checks,omitempty"`
+
+	// Message explains why no evidence could be recorded, e.g. when the
+	// ClusterReadiness does not exist.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// GateRunCheck is the recorded result of a single check.
+type GateRunCheck struct {
+	CheckStatus `json:",inline"`
+
+	// Category the check was evaluated under.
+	// +optional
+	Category string `json:"category,omitempty"`
+
+	// Executed is true when the check ran in the recorded evaluation cycle,
+	// and false when its result was carried forward from an earlier one.
+	// +optional
+	Executed bool `json:"executed,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="ClusterReadiness",type=string,JSONPath=`.spec.clusterReadiness`
+// +kubebuilder:printcolumn:name="Trigger",type=string,JSONPath=`.spec.trigger`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Recorded",type=date,JSONPath=`.status.recordedAt`
+
+// GateRun is point-in-time evidence of a ClusterReadiness evaluation: the
+// resolved checks, their results and durations, and the operator version.
+type GateRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+	Spec   GateRunSpec   `json:"spec,omitempty"`
+	Status GateRunStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GateRunList contains a list of GateRun.
+type GateRunList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GateRun `json:"items"`
+}
@@ -22,6 +22,9 @@ func init() {
 	SchemeBuilder.Register(
 		&ClusterReadiness{}, &ClusterReadinessList{},
 		&GateCheck{}, &GateCheckList{},
+		&NamespaceGateCheck{}, &NamespaceGateCheckList{},
 		&GateProfile{}, &GateProfileList{},
+		&NamespaceReadiness{}, &NamespaceReadinessList{},
+		&GateRun{}, &GateRunList{},
 	)
 }
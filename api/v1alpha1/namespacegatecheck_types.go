@@ -0,0 +1,37 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=nsgchk
+// +kubebuilder:printcolumn:name="Severity",type=string,JSONPath=`.spec.severity`
+// +kubebuilder:printcolumn:name="Category",type=string,JSONPath=`.spec.category`
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=`.status.conditions[?(@.type=="Valid")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NamespaceGateCheck is the namespaced counterpart of GateCheck. It lets an
+// application team contribute a readiness check for its own namespace to the
+// ClusterReadiness objects whose checkNamespaces select that namespace,
+// without cluster-scoped RBAC. Like a NamespaceReadiness check, every
+// namespaced reference in it is confined to its own namespace, and only
+// podCheck, httpCheck, resourceCheck, promqlCheck and alertmanagerCheck are
+// supported.
+type NamespaceGateCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GateCheckSpec   `json:"spec,omitempty"`
+	Status GateCheckStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NamespaceGateCheckList contains a list of NamespaceGateCheck.
+type NamespaceGateCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespaceGateCheck `json:"items"`
+}
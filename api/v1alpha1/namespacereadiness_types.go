@@ -0,0 +1,79 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// NamespaceReadinessSpec defines the desired state of NamespaceReadiness.
+type NamespaceReadinessSpec struct {
+	// Interval is the default interval for checks that don't specify their own (e.g. "60s", "5m").
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// DegradedThreshold is how many warning checks must fail before the state
+	// becomes Degraded, as a count or a percentage of warning checks. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+%?$`
+	DegradedThreshold *intstr.IntOrString `json:"degradedThreshold,omitempty"`
+
+	// JitterPercent delays each run of a check by up to this percentage of its
+	// interval. Defaults to 0 (no jitter).
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	JitterPercent int32 `json:"jitterPercent,omitempty"`
+
+	// HistoryLimit is how many status transitions each check keeps in its
+	// history. Defaults to 10; 0 disables the history.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=50
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+
+	// Checks is the list of checks to run. Every namespaced reference in a
+	// check (pods, resources, Services, Secrets) is confined to the
+	// NamespaceReadiness's own namespace. Only podCheck, httpCheck,
+	// resourceCheck, promqlCheck and alertmanagerCheck are supported.
+	// +optional
+	Checks []NamespaceCheck `json:"checks,omitempty"`
+}
+
+// NamespaceCheck defines a single namespace-scoped readiness check.
+type NamespaceCheck struct {
+	// Name identifies the check in status, /readyz and metrics.
+	Name string `json:"name"`
+
+	GateCheckSpec `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,shortName=nsr
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Passing",type=integer,JSONPath=`.status.summary.passing`
+// +kubebuilder:printcolumn:name="Failing",type=integer,JSONPath=`.status.summary.failing`
+// +kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.summary.total`
+// +kubebuilder:printcolumn:name="Last Checked",type=date,JSONPath=`.status.lastChecked`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NamespaceReadiness is the Schema for the namespacereadinesses API. It is the
+// namespaced sibling of ClusterReadiness, letting application teams gate
+// rollouts of their own namespace. Its status has the same layout as
+// ClusterReadiness.
+type NamespaceReadiness struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NamespaceReadinessSpec `json:"spec,omitempty"`
+	Status ClusterReadinessStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NamespaceReadinessList contains a list of NamespaceReadiness.
+type NamespaceReadinessList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespaceReadiness `json:"items"`
+}
@@ -0,0 +1,244 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Notifications configures where a ClusterReadiness sends its state changes.
+type Notifications struct {
+	// Webhooks receive a JSON payload each time the overall state changes.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Webhooks []WebhookNotification `json:"webhooks,omitempty"`
+
+	// Providers send a message to a chat service each time the overall
+	// state changes.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Providers []NotificationProvider `json:"providers,omitempty"`
+
+	// Incidents open an incident in an incident management service when a
+	// check starts failing, and resolve it when the check passes again.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Incidents []IncidentNotification `json:"incidents,omitempty"`
+
+	// Alertmanagers receive an alert for each failing check through the
+	// Alertmanager v2 API, so existing routing and silences apply to them.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Alertmanagers []AlertmanagerNotification `json:"alertmanagers,omitempty"`
+}
+
+// WebhookNotification POSTs state changes to a URL as JSON.
+// +kubebuilder:validation:XValidation:rule="has(self.url) || has(self.secretRef)",message="url or secretRef is required"
+type WebhookNotification struct {
+	// Name identifies the webhook in logs and metrics.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// URL receives the payload. It can instead be set by the "url" key of
+	// SecretRef, for URLs that embed credentials.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url,omitempty"`
+
+	// SecretRef names a Secret authenticating the requests: its "token" key
+	// is sent as a bearer token, or its "username" and "password" keys as
+	// basic auth. Its "url" key, when present, overrides URL.
+	// +optional
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+
+	// Severities limits the failing checks a payload lists to these
+	// severities. When set, a change is only sent when it lists a failing
+	// check, or when the state became Healthy. Empty means every severity.
+	// +optional
+	Severities []Severity `json:"severities,omitempty"`
+
+	// GroupWait delays a state change notification by this long, so
+	// changes in quick succession are sent as one from the state last
+	// notified to the latest state, and a change that reverts within it is
+	// not sent. Recoveries to Healthy are never delayed.
+	// +optional
+	GroupWait *metav1.Duration `json:"groupWait,omitempty"`
+
+	// RepeatInterval re-sends the notification of a Degraded, Unhealthy or
+	// Initializing state at this interval while it lasts, with oldState
+	// equal to newState. Repeats are sent at the next evaluation after the
+	// interval has passed. Unset sends each change once.
+	// +optional
+	RepeatInterval *metav1.Duration `json:"repeatInterval,omitempty"`
+
+	// Retries is how many times a failed delivery is retried, with
+	// exponential backoff from one second. Connection errors, 429 and 5xx
+	// responses are retried; other responses are not. Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	Retries *int32 `json:"retries,omitempty"`
+
+	// Timeout bounds each delivery attempt. Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// NotificationProviderType selects the service a NotificationProvider posts to.
+// +kubebuilder:validation:Enum=slack;teams
+type NotificationProviderType string
+
+const (
+	// NotificationProviderSlack posts to a Slack incoming webhook.
+	NotificationProviderSlack NotificationProviderType = "slack"
+
+	// NotificationProviderTeams posts an Adaptive Card to a Microsoft Teams
+	// workflow webhook.
+	NotificationProviderTeams NotificationProviderType = "teams"
+)
+
+// NotificationProvider posts state changes to a chat service as a message.
+type NotificationProvider struct {
+	// Name identifies the provider in logs and metrics.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Type is the chat service: slack or teams.
+	Type NotificationProviderType `json:"type"`
+
+	// SecretRef names the Secret whose "url" key holds the service's
+	// incoming webhook URL.
+	SecretRef SecretReference `json:"secretRef"`
+
+	// Severities limits the failing checks a message lists to these
+	// severities. A change is only sent when it lists a failing check, or
+	// when the state became Healthy. Empty means every severity.
+	// +optional
+	Severities []Severity `json:"severities,omitempty"`
+
+	// Categories limits the failing checks a message lists to these
+	// categories, like Severities. Empty means every category.
+	// +optional
+	Categories []string `json:"categories,omitempty"`
+
+	// Template is a Go text/template rendering the message from the state
+	// change, with the fields of the webhook payload: .ClusterReadiness,
+	// .OldState, .NewState, .Message, .Time and .FailingChecks, each with
+	// .Name, .Status, .Severity, .Category and .Message. Defaults to a
+	// summary listing the failing checks.
+	// +optional
+	Template string `json:"template,omitempty"`
+
+	// GroupWait delays a state change notification by this long, so
+	// changes in quick succession are sent as one from the state last
+	// notified to the latest state, and a change that reverts within it is
+	// not sent. Recoveries to Healthy are never delayed.
+	// +optional
+	GroupWait *metav1.Duration `json:"groupWait,omitempty"`
+
+	// RepeatInterval re-sends the notification of a Degraded, Unhealthy or
+	// Initializing state at this interval while it lasts, with oldState
+	// equal to newState. Repeats are sent at the next evaluation after the
+	// interval has passed. Unset sends each change once.
+	// +optional
+	RepeatInterval *metav1.Duration `json:"repeatInterval,omitempty"`
+
+	// Retries is how many times a failed delivery is retried. Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	Retries *int32 `json:"retries,omitempty"`
+
+	// Timeout bounds each delivery attempt. Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// IncidentProviderType selects the service an IncidentNotification manages
+// incidents in.
+// +kubebuilder:validation:Enum=pagerduty;opsgenie
+type IncidentProviderType string
+
+const (
+	// IncidentProviderPagerDuty sends PagerDuty Events API v2 events.
+	IncidentProviderPagerDuty IncidentProviderType = "pagerduty"
+
+	// IncidentProviderOpsgenie creates and closes Opsgenie alerts.
+	IncidentProviderOpsgenie IncidentProviderType = "opsgenie"
+)
+
+// IncidentNotification opens an incident per failing check. Incidents are
+// keyed by the ClusterReadiness name and check identifier, so a check that
+// fails again while its incident is open updates that incident instead of
+// opening another one.
+type IncidentNotification struct {
+	// Name identifies the integration in logs and metrics.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Type is the incident management service: pagerduty or opsgenie.
+	Type IncidentProviderType `json:"type"`
+
+	// SecretRef names the Secret holding the credentials: the "routingKey"
+	// key of a PagerDuty Events API v2 integration, or an Opsgenie API
+	// integration's "apiKey". Its "url" key, when present, overrides the
+	// service's API endpoint, e.g. https://api.eu.opsgenie.com.
+	SecretRef SecretReference `json:"secretRef"`
+
+	// Severities are the severities of the checks that open incidents.
+	// Defaults to critical.
+	// +optional
+	Severities []Severity `json:"severities,omitempty"`
+
+	// Retries is how many times a failed delivery is retried. Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	Retries *int32 `json:"retries,omitempty"`
+
+	// Timeout bounds each delivery attempt. Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// AlertmanagerNotification pushes an alert for each failing check to an
+// Alertmanager. Firing alerts are sent again at each evaluation, and a
+// resolved alert is sent when a check stops failing.
+type AlertmanagerNotification struct {
+	// Name identifies the Alertmanager in logs and metrics.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// URL is the Alertmanager's base URL, e.g.
+	// http://alertmanager-operated.monitoring:9093. Alerts are POSTed to
+	// its /api/v2/alerts.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// SecretRef names a Secret authenticating the requests, like a
+	// webhook's: its "token" key is sent as a bearer token, or its
+	// "username" and "password" keys as basic auth.
+	// +optional
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+
+	// Severities are the severities of the checks alerted on. Empty means
+	// every severity.
+	// +optional
+	Severities []Severity `json:"severities,omitempty"`
+
+	// Labels are added to every alert, e.g. to route them.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Retries is how many times a failed delivery is retried. Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	Retries *int32 `json:"retries,omitempty"`
+
+	// Timeout bounds each delivery attempt. Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
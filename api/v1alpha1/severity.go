@@ -11,6 +11,7 @@ const (
 	// SeverityWarning indicates the check is reported but does not block readiness.
 	SeverityWarning Severity = "warning"
 
-	// SeverityInfo indicates the check is purely diagnostic.
+	// SeverityInfo indicates the check is purely diagnostic: it is reported
+	// but never affects readiness or the health state.
 	SeverityInfo Severity = "info"
 )
@@ -9,8 +9,147 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Actions) DeepCopyInto(out *Actions) {
+	*out = *in
+	if in.ReadyMarkers != nil {
+		in, out := &in.ReadyMarkers, &out.ReadyMarkers
+		*out = make([]ReadyMarker, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeTaint != nil {
+		in, out := &in.NodeTaint, &out.NodeTaint
+		*out = new(NodeTaintAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SuspendGitOps != nil {
+		in, out := &in.SuspendGitOps, &out.SuspendGitOps
+		*out = new(SuspendGitOpsAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrafficWeight != nil {
+		in, out := &in.TrafficWeight, &out.TrafficWeight
+		*out = new(TrafficWeightAction)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Actions.
+func (in *Actions) DeepCopy() *Actions {
+	if in == nil {
+		return nil
+	}
+	out := new(Actions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerCheckSpec) DeepCopyInto(out *AlertmanagerCheckSpec) {
+	*out = *in
+	if in.ServiceRef != nil {
+		in, out := &in.ServiceRef, &out.ServiceRef
+		*out = new(HTTPServiceReference)
+		**out = **in
+	}
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BearerTokenSecretRef != nil {
+		in, out := &in.BearerTokenSecretRef, &out.BearerTokenSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.BasicAuthSecretRef != nil {
+		in, out := &in.BasicAuthSecretRef, &out.BasicAuthSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(HTTPTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerCheckSpec.
+func (in *AlertmanagerCheckSpec) DeepCopy() *AlertmanagerCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerNotification) DeepCopyInto(out *AlertmanagerNotification) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.Severities != nil {
+		in, out := &in.Severities, &out.Severities
+		*out = make([]Severity, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerNotification.
+func (in *AlertmanagerNotification) DeepCopy() *AlertmanagerNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupCheckSpec) DeepCopyInto(out *BackupCheckSpec) {
+	*out = *in
+	out.MaxAge = in.MaxAge
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupCheckSpec.
+func (in *BackupCheckSpec) DeepCopy() *BackupCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CategoryStatus) DeepCopyInto(out *CategoryStatus) {
 	*out = *in
@@ -33,6 +172,26 @@ func (in *CategoryStatus) DeepCopy() *CategoryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckParameter) DeepCopyInto(out *CheckParameter) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckParameter.
+func (in *CheckParameter) DeepCopy() *CheckParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CheckSpec) DeepCopyInto(out *CheckSpec) {
 	*out = *in
@@ -46,6 +205,26 @@ func (in *CheckSpec) DeepCopyInto(out *CheckSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SuccessThreshold != nil {
+		in, out := &in.SuccessThreshold, &out.SuccessThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
@@ -56,6 +235,13 @@ func (in *CheckSpec) DeepCopyInto(out *CheckSpec) {
 		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckSpec.
@@ -75,6 +261,18 @@ func (in *CheckStatus) DeepCopyInto(out *CheckStatus) {
 		in, out := &in.LastChecked, &out.LastChecked
 		*out = (*in).DeepCopy()
 	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]CheckTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckStatus.
@@ -87,6 +285,22 @@ func (in *CheckStatus) DeepCopy() *CheckStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckTransition) DeepCopyInto(out *CheckTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckTransition.
+func (in *CheckTransition) DeepCopy() *CheckTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterReadiness) DeepCopyInto(out *ClusterReadiness) {
 	*out = *in
@@ -164,6 +378,63 @@ func (in *ClusterReadinessSpec) DeepCopyInto(out *ClusterReadinessSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CheckNamespaces != nil {
+		in, out := &in.CheckNamespaces, &out.CheckNamespaces
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DegradedThreshold != nil {
+		in, out := &in.DegradedThreshold, &out.DegradedThreshold
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RequiredCategories != nil {
+		in, out := &in.RequiredCategories, &out.RequiredCategories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReadinessPolicy != nil {
+		in, out := &in.ReadinessPolicy, &out.ReadinessPolicy
+		*out = new(ReadinessPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gates != nil {
+		in, out := &in.Gates, &out.Gates
+		*out = make([]ReadinessGate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MinHealthyDuration != nil {
+		in, out := &in.MinHealthyDuration, &out.MinHealthyDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.GateRuns != nil {
+		in, out := &in.GateRuns, &out.GateRuns
+		*out = new(GateRunPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(Notifications)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = new(Actions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReadinessSpec.
@@ -195,6 +466,42 @@ func (in *ClusterReadinessStatus) DeepCopyInto(out *ClusterReadinessStatus) {
 		in, out := &in.LastChecked, &out.LastChecked
 		*out = (*in).DeepCopy()
 	}
+	if in.ExcludedChecks != nil {
+		in, out := &in.ExcludedChecks, &out.ExcludedChecks
+		*out = make([]ExcludedCheck, len(*in))
+		copy(*out, *in)
+	}
+	if in.Gates != nil {
+		in, out := &in.Gates, &out.Gates
+		*out = make([]GateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReadyMarkers != nil {
+		in, out := &in.ReadyMarkers, &out.ReadyMarkers
+		*out = make([]ReadyMarker, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeTaint != nil {
+		in, out := &in.NodeTaint, &out.NodeTaint
+		*out = new(corev1.Taint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SuspendedGitOps != nil {
+		in, out := &in.SuspendedGitOps, &out.SuspendedGitOps
+		*out = make([]GitOpsReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.TrafficWeight != nil {
+		in, out := &in.TrafficWeight, &out.TrafficWeight
+		*out = new(TrafficWeightStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CriticalPassingSince != nil {
+		in, out := &in.CriticalPassingSince, &out.CriticalPassingSince
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -214,6 +521,36 @@ func (in *ClusterReadinessStatus) DeepCopy() *ClusterReadinessStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
+func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExcludedCheck) DeepCopyInto(out *ExcludedCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExcludedCheck.
+func (in *ExcludedCheck) DeepCopy() *ExcludedCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ExcludedCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GateCheck) DeepCopyInto(out *GateCheck) {
 	*out = *in
@@ -273,6 +610,27 @@ func (in *GateCheckList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateCheckResult) DeepCopyInto(out *GateCheckResult) {
+	*out = *in
+	in.LastRunTime.DeepCopyInto(&out.LastRunTime)
+	if in.ConsumingClusterReadiness != nil {
+		in, out := &in.ConsumingClusterReadiness, &out.ConsumingClusterReadiness
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateCheckResult.
+func (in *GateCheckResult) DeepCopy() *GateCheckResult {
+	if in == nil {
+		return nil
+	}
+	out := new(GateCheckResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GateCheckSpec) DeepCopyInto(out *GateCheckSpec) {
 	*out = *in
@@ -281,6 +639,13 @@ func (in *GateCheckSpec) DeepCopyInto(out *GateCheckSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]CheckParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PodCheck != nil {
 		in, out := &in.PodCheck, &out.PodCheck
 		*out = new(PodCheckSpec)
@@ -306,6 +671,21 @@ func (in *GateCheckSpec) DeepCopyInto(out *GateCheckSpec) {
 		*out = new(ScriptCheckSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GitCheck != nil {
+		in, out := &in.GitCheck, &out.GitCheck
+		*out = new(GitCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackupCheck != nil {
+		in, out := &in.BackupCheck, &out.BackupCheck
+		*out = new(BackupCheckSpec)
+		**out = **in
+	}
+	if in.AlertmanagerCheck != nil {
+		in, out := &in.AlertmanagerCheck, &out.AlertmanagerCheck
+		*out = new(AlertmanagerCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateCheckSpec.
@@ -328,6 +708,11 @@ func (in *GateCheckStatus) DeepCopyInto(out *GateCheckStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastResult != nil {
+		in, out := &in.LastResult, &out.LastResult
+		*out = new(GateCheckResult)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateCheckStatus.
@@ -402,6 +787,11 @@ func (in *GateProfileList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GateProfileSpec) DeepCopyInto(out *GateProfileSpec) {
 	*out = *in
+	if in.VersionHistoryLimit != nil {
+		in, out := &in.VersionHistoryLimit, &out.VersionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Checks != nil {
 		in, out := &in.Checks, &out.Checks
 		*out = make([]ProfileCheckRef, len(*in))
@@ -409,6 +799,11 @@ func (in *GateProfileSpec) DeepCopyInto(out *GateProfileSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(ProfileSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateProfileSpec.
@@ -424,6 +819,18 @@ func (in *GateProfileSpec) DeepCopy() *GateProfileSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GateProfileStatus) DeepCopyInto(out *GateProfileStatus) {
 	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]ProfileVersion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(ProfileSourceStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -444,29 +851,270 @@ func (in *GateProfileStatus) DeepCopy() *GateProfileStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPCheckSpec) DeepCopyInto(out *HTTPCheckSpec) {
+func (in *GateRun) DeepCopyInto(out *GateRun) {
 	*out = *in
-	if in.ExpectedStatusCodes != nil {
-		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
-		*out = make([]int, len(*in))
-		copy(*out, *in)
-	}
-	if in.TimeoutSeconds != nil {
-		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Headers != nil {
-		in, out := &in.Headers, &out.Headers
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPCheckSpec.
-func (in *HTTPCheckSpec) DeepCopy() *HTTPCheckSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateRun.
+func (in *GateRun) DeepCopy() *GateRun {
+	if in == nil {
+		return nil
+	}
+	out := new(GateRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GateRun) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateRunCheck) DeepCopyInto(out *GateRunCheck) {
+	*out = *in
+	in.CheckStatus.DeepCopyInto(&out.CheckStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateRunCheck.
+func (in *GateRunCheck) DeepCopy() *GateRunCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(GateRunCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateRunList) DeepCopyInto(out *GateRunList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GateRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateRunList.
+func (in *GateRunList) DeepCopy() *GateRunList {
+	if in == nil {
+		return nil
+	}
+	out := new(GateRunList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GateRunList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateRunPolicy) DeepCopyInto(out *GateRunPolicy) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateRunPolicy.
+func (in *GateRunPolicy) DeepCopy() *GateRunPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GateRunPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateRunSpec) DeepCopyInto(out *GateRunSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateRunSpec.
+func (in *GateRunSpec) DeepCopy() *GateRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GateRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateRunStatus) DeepCopyInto(out *GateRunStatus) {
+	*out = *in
+	if in.RecordedAt != nil {
+		in, out := &in.RecordedAt, &out.RecordedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(ReadinessSummary)
+		**out = **in
+	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]GateRunCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateRunStatus.
+func (in *GateRunStatus) DeepCopy() *GateRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GateRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GateStatus) DeepCopyInto(out *GateStatus) {
+	*out = *in
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(ReadinessSummary)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GateStatus.
+func (in *GateStatus) DeepCopy() *GateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitCheckSpec) DeepCopyInto(out *GitCheckSpec) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitCheckSpec.
+func (in *GitCheckSpec) DeepCopy() *GitCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsReference) DeepCopyInto(out *GitOpsReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsReference.
+func (in *GitOpsReference) DeepCopy() *GitOpsReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPCheckSpec) DeepCopyInto(out *HTTPCheckSpec) {
+	*out = *in
+	if in.ServiceRef != nil {
+		in, out := &in.ServiceRef, &out.ServiceRef
+		*out = new(HTTPServiceReference)
+		**out = **in
+	}
+	if in.ExpectedStatusCodes != nil {
+		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.FollowRedirects != nil {
+		in, out := &in.FollowRedirects, &out.FollowRedirects
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxRedirects != nil {
+		in, out := &in.MaxRedirects, &out.MaxRedirects
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(HTTPTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResolveOverrides != nil {
+		in, out := &in.ResolveOverrides, &out.ResolveOverrides
+		*out = new(HTTPResolveOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.HeadersFromSecret != nil {
+		in, out := &in.HeadersFromSecret, &out.HeadersFromSecret
+		*out = make([]HTTPHeaderFromSecret, len(*in))
+		copy(*out, *in)
+	}
+	if in.BasicAuthSecretRef != nil {
+		in, out := &in.BasicAuthSecretRef, &out.BasicAuthSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPCheckSpec.
+func (in *HTTPCheckSpec) DeepCopy() *HTTPCheckSpec {
 	if in == nil {
 		return nil
 	}
@@ -475,6 +1123,421 @@ func (in *HTTPCheckSpec) DeepCopy() *HTTPCheckSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeaderFromSecret) DeepCopyInto(out *HTTPHeaderFromSecret) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeaderFromSecret.
+func (in *HTTPHeaderFromSecret) DeepCopy() *HTTPHeaderFromSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeaderFromSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHostAlias) DeepCopyInto(out *HTTPHostAlias) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHostAlias.
+func (in *HTTPHostAlias) DeepCopy() *HTTPHostAlias {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHostAlias)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPResolveOverrides) DeepCopyInto(out *HTTPResolveOverrides) {
+	*out = *in
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]HTTPHostAlias, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPResolveOverrides.
+func (in *HTTPResolveOverrides) DeepCopy() *HTTPResolveOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPResolveOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPServiceReference) DeepCopyInto(out *HTTPServiceReference) {
+	*out = *in
+	out.Port = in.Port
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPServiceReference.
+func (in *HTTPServiceReference) DeepCopy() *HTTPServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPTLSConfig) DeepCopyInto(out *HTTPTLSConfig) {
+	*out = *in
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPTLSConfig.
+func (in *HTTPTLSConfig) DeepCopy() *HTTPTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IncidentNotification) DeepCopyInto(out *IncidentNotification) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.Severities != nil {
+		in, out := &in.Severities, &out.Severities
+		*out = make([]Severity, len(*in))
+		copy(*out, *in)
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IncidentNotification.
+func (in *IncidentNotification) DeepCopy() *IncidentNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(IncidentNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceCheck) DeepCopyInto(out *NamespaceCheck) {
+	*out = *in
+	in.GateCheckSpec.DeepCopyInto(&out.GateCheckSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceCheck.
+func (in *NamespaceCheck) DeepCopy() *NamespaceCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceGateCheck) DeepCopyInto(out *NamespaceGateCheck) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceGateCheck.
+func (in *NamespaceGateCheck) DeepCopy() *NamespaceGateCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceGateCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceGateCheck) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceGateCheckList) DeepCopyInto(out *NamespaceGateCheckList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespaceGateCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceGateCheckList.
+func (in *NamespaceGateCheckList) DeepCopy() *NamespaceGateCheckList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceGateCheckList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceGateCheckList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceReadiness) DeepCopyInto(out *NamespaceReadiness) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceReadiness.
+func (in *NamespaceReadiness) DeepCopy() *NamespaceReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceReadiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceReadiness) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceReadinessList) DeepCopyInto(out *NamespaceReadinessList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespaceReadiness, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceReadinessList.
+func (in *NamespaceReadinessList) DeepCopy() *NamespaceReadinessList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceReadinessList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceReadinessList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceReadinessSpec) DeepCopyInto(out *NamespaceReadinessSpec) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.DegradedThreshold != nil {
+		in, out := &in.DegradedThreshold, &out.DegradedThreshold
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]NamespaceCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceReadinessSpec.
+func (in *NamespaceReadinessSpec) DeepCopy() *NamespaceReadinessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceReadinessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTaintAction) DeepCopyInto(out *NodeTaintAction) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTaintAction.
+func (in *NodeTaintAction) DeepCopy() *NodeTaintAction {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTaintAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationProvider) DeepCopyInto(out *NotificationProvider) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.Severities != nil {
+		in, out := &in.Severities, &out.Severities
+		*out = make([]Severity, len(*in))
+		copy(*out, *in)
+	}
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GroupWait != nil {
+		in, out := &in.GroupWait, &out.GroupWait
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RepeatInterval != nil {
+		in, out := &in.RepeatInterval, &out.RepeatInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationProvider.
+func (in *NotificationProvider) DeepCopy() *NotificationProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Notifications) DeepCopyInto(out *Notifications) {
+	*out = *in
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]WebhookNotification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]NotificationProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Incidents != nil {
+		in, out := &in.Incidents, &out.Incidents
+		*out = make([]IncidentNotification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Alertmanagers != nil {
+		in, out := &in.Alertmanagers, &out.Alertmanagers
+		*out = make([]AlertmanagerNotification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notifications.
+func (in *Notifications) DeepCopy() *Notifications {
+	if in == nil {
+		return nil
+	}
+	out := new(Notifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodCheckSpec) DeepCopyInto(out *PodCheckSpec) {
 	*out = *in
@@ -483,120 +1546,453 @@ func (in *PodCheckSpec) DeepCopyInto(out *PodCheckSpec) {
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MinReadyPercent != nil {
+		in, out := &in.MinReadyPercent, &out.MinReadyPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PercentOf != nil {
+		in, out := &in.PercentOf, &out.PercentOf
+		*out = new(WorkloadReference)
+		**out = **in
+	}
+	if in.MaxRestarts != nil {
+		in, out := &in.MaxRestarts, &out.MaxRestarts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RestartWindow != nil {
+		in, out := &in.RestartWindow, &out.RestartWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckSpec.
+func (in *PodCheckSpec) DeepCopy() *PodCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileCheckRef) DeepCopyInto(out *ProfileCheckRef) {
+	*out = *in
+	if in.Severity != nil {
+		in, out := &in.Severity, &out.Severity
+		*out = new(Severity)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SuccessThreshold != nil {
+		in, out := &in.SuccessThreshold, &out.SuccessThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileCheckRef.
+func (in *ProfileCheckRef) DeepCopy() *ProfileCheckRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileCheckRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileRef) DeepCopyInto(out *ProfileRef) {
+	*out = *in
+	if in.ExcludeChecks != nil {
+		in, out := &in.ExcludeChecks, &out.ExcludeChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SnapshotRef != nil {
+		in, out := &in.SnapshotRef, &out.SnapshotRef
+		*out = new(ProfileSnapshotReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileRef.
+func (in *ProfileRef) DeepCopy() *ProfileRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileSnapshotReference) DeepCopyInto(out *ProfileSnapshotReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileSnapshotReference.
+func (in *ProfileSnapshotReference) DeepCopy() *ProfileSnapshotReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileSnapshotReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileSource) DeepCopyInto(out *ProfileSource) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(ConfigMapReference)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(ProfileVerification)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileSource.
+func (in *ProfileSource) DeepCopy() *ProfileSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileSourceStatus) DeepCopyInto(out *ProfileSourceStatus) {
+	*out = *in
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]ProfileCheckRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GateChecks != nil {
+		in, out := &in.GateChecks, &out.GateChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastSyncTime.DeepCopyInto(&out.LastSyncTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileSourceStatus.
+func (in *ProfileSourceStatus) DeepCopy() *ProfileSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileVerification) DeepCopyInto(out *ProfileVerification) {
+	*out = *in
+	out.PublicKeyRef = in.PublicKeyRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileVerification.
+func (in *ProfileVerification) DeepCopy() *ProfileVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileVersion) DeepCopyInto(out *ProfileVersion) {
+	*out = *in
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]ProfileCheckRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.RecordedAt.DeepCopyInto(&out.RecordedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileVersion.
+func (in *ProfileVersion) DeepCopy() *ProfileVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromQLCheckSpec) DeepCopyInto(out *PromQLCheckSpec) {
+	*out = *in
+	if in.ServiceRef != nil {
+		in, out := &in.ServiceRef, &out.ServiceRef
+		*out = new(HTTPServiceReference)
+		**out = **in
+	}
+	if in.VariablesFrom != nil {
+		in, out := &in.VariablesFrom, &out.VariablesFrom
+		*out = new(ConfigMapReference)
+		**out = **in
+	}
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(PromQLCondition)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]PromQLCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BearerTokenSecretRef != nil {
+		in, out := &in.BearerTokenSecretRef, &out.BearerTokenSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.BasicAuthSecretRef != nil {
+		in, out := &in.BasicAuthSecretRef, &out.BasicAuthSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(HTTPTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tenant != nil {
+		in, out := &in.Tenant, &out.Tenant
+		*out = new(PromQLTenant)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromQLCheckSpec.
+func (in *PromQLCheckSpec) DeepCopy() *PromQLCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PromQLCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromQLCondition) DeepCopyInto(out *PromQLCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromQLCondition.
+func (in *PromQLCondition) DeepCopy() *PromQLCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PromQLCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromQLTenant) DeepCopyInto(out *PromQLTenant) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckSpec.
-func (in *PodCheckSpec) DeepCopy() *PodCheckSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromQLTenant.
+func (in *PromQLTenant) DeepCopy() *PromQLTenant {
 	if in == nil {
 		return nil
 	}
-	out := new(PodCheckSpec)
+	out := new(PromQLTenant)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProfileCheckRef) DeepCopyInto(out *ProfileCheckRef) {
+func (in *ReadinessGate) DeepCopyInto(out *ReadinessGate) {
 	*out = *in
-	if in.Severity != nil {
-		in, out := &in.Severity, &out.Severity
-		*out = new(Severity)
-		**out = **in
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Interval != nil {
-		in, out := &in.Interval, &out.Interval
-		*out = new(v1.Duration)
-		**out = **in
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	if in.RequiredCategories != nil {
+		in, out := &in.RequiredCategories, &out.RequiredCategories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = new(apiextensionsv1.JSON)
+	if in.ReadinessPolicy != nil {
+		in, out := &in.ReadinessPolicy, &out.ReadinessPolicy
+		*out = new(ReadinessPolicy)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileCheckRef.
-func (in *ProfileCheckRef) DeepCopy() *ProfileCheckRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessGate.
+func (in *ReadinessGate) DeepCopy() *ReadinessGate {
 	if in == nil {
 		return nil
 	}
-	out := new(ProfileCheckRef)
+	out := new(ReadinessGate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProfileRef) DeepCopyInto(out *ProfileRef) {
+func (in *ReadinessPolicy) DeepCopyInto(out *ReadinessPolicy) {
 	*out = *in
-	if in.ExcludeChecks != nil {
-		in, out := &in.ExcludeChecks, &out.ExcludeChecks
+	if in.BlockingCategories != nil {
+		in, out := &in.BlockingCategories, &out.BlockingCategories
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.MaxFailing != nil {
+		in, out := &in.MaxFailing, &out.MaxFailing
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileRef.
-func (in *ProfileRef) DeepCopy() *ProfileRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessPolicy.
+func (in *ReadinessPolicy) DeepCopy() *ReadinessPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ProfileRef)
+	out := new(ReadinessPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PromQLCheckSpec) DeepCopyInto(out *PromQLCheckSpec) {
+func (in *ReadinessSummary) DeepCopyInto(out *ReadinessSummary) {
 	*out = *in
-	out.Condition = in.Condition
-	if in.TimeoutSeconds != nil {
-		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
-		*out = new(int32)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromQLCheckSpec.
-func (in *PromQLCheckSpec) DeepCopy() *PromQLCheckSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessSummary.
+func (in *ReadinessSummary) DeepCopy() *ReadinessSummary {
 	if in == nil {
 		return nil
 	}
-	out := new(PromQLCheckSpec)
+	out := new(ReadinessSummary)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PromQLCondition) DeepCopyInto(out *PromQLCondition) {
+func (in *ReadyMarker) DeepCopyInto(out *ReadyMarker) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromQLCondition.
-func (in *PromQLCondition) DeepCopy() *PromQLCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadyMarker.
+func (in *ReadyMarker) DeepCopy() *ReadyMarker {
 	if in == nil {
 		return nil
 	}
-	out := new(PromQLCondition)
+	out := new(ReadyMarker)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReadinessSummary) DeepCopyInto(out *ReadinessSummary) {
+func (in *ReplicasAssertion) DeepCopyInto(out *ReplicasAssertion) {
 	*out = *in
+	if in.Ready != nil {
+		in, out := &in.Ready, &out.Ready
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Updated != nil {
+		in, out := &in.Updated, &out.Updated
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Available != nil {
+		in, out := &in.Available, &out.Available
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessSummary.
-func (in *ReadinessSummary) DeepCopy() *ReadinessSummary {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicasAssertion.
+func (in *ReplicasAssertion) DeepCopy() *ReplicasAssertion {
 	if in == nil {
 		return nil
 	}
-	out := new(ReadinessSummary)
+	out := new(ReplicasAssertion)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -604,14 +2000,56 @@ func (in *ReadinessSummary) DeepCopy() *ReadinessSummary {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceCheckSpec) DeepCopyInto(out *ResourceCheckSpec) {
 	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludeNamespaces != nil {
+		in, out := &in.ExcludeNamespaces, &out.ExcludeNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.LabelSelector != nil {
 		in, out := &in.LabelSelector, &out.LabelSelector
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MinCount != nil {
+		in, out := &in.MinCount, &out.MinCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxCount != nil {
+		in, out := &in.MaxCount, &out.MaxCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(ReplicasAssertion)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Manifest != nil {
+		in, out := &in.Manifest, &out.Manifest
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]ResourceConditionCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CELExpressions != nil {
+		in, out := &in.CELExpressions, &out.CELExpressions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]ResourceFieldCheck, len(*in))
 		copy(*out, *in)
 	}
 }
@@ -629,6 +2067,16 @@ func (in *ResourceCheckSpec) DeepCopy() *ResourceCheckSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceConditionCheck) DeepCopyInto(out *ResourceConditionCheck) {
 	*out = *in
+	if in.MinAgeSeconds != nil {
+		in, out := &in.MinAgeSeconds, &out.MinAgeSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxLastTransitionAgeSeconds != nil {
+		in, out := &in.MaxLastTransitionAgeSeconds, &out.MaxLastTransitionAgeSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceConditionCheck.
@@ -641,9 +2089,36 @@ func (in *ResourceConditionCheck) DeepCopy() *ResourceConditionCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceFieldCheck) DeepCopyInto(out *ResourceFieldCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFieldCheck.
+func (in *ResourceFieldCheck) DeepCopy() *ResourceFieldCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceFieldCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScriptCheckSpec) DeepCopyInto(out *ScriptCheckSpec) {
 	*out = *in
+	if in.ImagePerArch != nil {
+		in, out := &in.ImagePerArch, &out.ImagePerArch
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Command != nil {
 		in, out := &in.Command, &out.Command
 		*out = make([]string, len(*in))
@@ -654,11 +2129,26 @@ func (in *ScriptCheckSpec) DeepCopyInto(out *ScriptCheckSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ScriptFrom != nil {
+		in, out := &in.ScriptFrom, &out.ScriptFrom
+		*out = new(ScriptSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TimeoutSeconds != nil {
 		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ResultTTLSeconds != nil {
+		in, out := &in.ResultTTLSeconds, &out.ResultTTLSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]corev1.EnvVar, len(*in))
@@ -666,6 +2156,40 @@ func (in *ScriptCheckSpec) DeepCopyInto(out *ScriptCheckSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptCheckSpec.
@@ -677,3 +2201,169 @@ func (in *ScriptCheckSpec) DeepCopy() *ScriptCheckSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScriptSource) DeepCopyInto(out *ScriptSource) {
+	*out = *in
+	in.ConfigMapKeyRef.DeepCopyInto(&out.ConfigMapKeyRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptSource.
+func (in *ScriptSource) DeepCopy() *ScriptSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ScriptSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretReference) DeepCopyInto(out *SecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SuspendGitOpsAction) DeepCopyInto(out *SuspendGitOpsAction) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]GitOpsReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SuspendGitOpsAction.
+func (in *SuspendGitOpsAction) DeepCopy() *SuspendGitOpsAction {
+	if in == nil {
+		return nil
+	}
+	out := new(SuspendGitOpsAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficTarget) DeepCopyInto(out *TrafficTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficTarget.
+func (in *TrafficTarget) DeepCopy() *TrafficTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficWeightAction) DeepCopyInto(out *TrafficWeightAction) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]TrafficTarget, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficWeightAction.
+func (in *TrafficWeightAction) DeepCopy() *TrafficWeightAction {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficWeightAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficWeightStatus) DeepCopyInto(out *TrafficWeightStatus) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]TrafficTarget, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficWeightStatus.
+func (in *TrafficWeightStatus) DeepCopy() *TrafficWeightStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficWeightStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookNotification) DeepCopyInto(out *WebhookNotification) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.Severities != nil {
+		in, out := &in.Severities, &out.Severities
+		*out = make([]Severity, len(*in))
+		copy(*out, *in)
+	}
+	if in.GroupWait != nil {
+		in, out := &in.GroupWait, &out.GroupWait
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RepeatInterval != nil {
+		in, out := &in.RepeatInterval, &out.RepeatInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookNotification.
+func (in *WebhookNotification) DeepCopy() *WebhookNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadReference) DeepCopyInto(out *WorkloadReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadReference.
+func (in *WorkloadReference) DeepCopy() *WorkloadReference {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadReference)
+	in.DeepCopyInto(out)
+	return out
+}
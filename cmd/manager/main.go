@@ -4,22 +4,36 @@ import (
 	"flag"
 	"net/http"
 	"os"
+	"time"
+	// Embed the time zone database for maintenance window time zones, as
+	// the distroless base image does not ship one.
+	_ "time/tzdata"
 
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
 	"github.com/clustergate/clustergate/internal/checks"
 	"github.com/clustergate/clustergate/internal/checks/builtin"
 	"github.com/clustergate/clustergate/internal/checks/dynamic"
 	"github.com/clustergate/clustergate/internal/controller"
-	_ "github.com/clustergate/clustergate/internal/metrics" // register prometheus collectors
+	"github.com/clustergate/clustergate/internal/library"
+	"github.com/clustergate/clustergate/internal/metrics"
 	"github.com/clustergate/clustergate/internal/server"
+	"github.com/clustergate/clustergate/internal/version"
+	clustergatewebhook "github.com/clustergate/clustergate/internal/webhook"
 )
 
 var (
@@ -40,6 +54,16 @@ func main() {
 		leaderElect                  bool
 		enableCloudControllerManager bool
 		namespace                    string
+		prometheusNamespace          string
+		clusterName                  string
+		readinessGCInterval          time.Duration
+		maxConcurrentScriptJobs      int
+		scriptJobGCInterval          time.Duration
+		scriptJobTTL                 time.Duration
+		installDefaultProfiles       bool
+		enableWebhooks               bool
+		webhookCertDir               string
+		minCheckInterval             time.Duration
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metrics endpoint binds to.")
@@ -51,6 +75,27 @@ func main() {
 		"Enable the cloud-controller-manager health check. Set to true for cloud-provider Kubernetes clusters.")
 	flag.StringVar(&namespace, "namespace", "clustergate-system",
 		"The namespace where the operator runs. Used for creating script check Jobs.")
+	flag.StringVar(&prometheusNamespace, "prometheus-namespace", "monitoring",
+		"The namespace searched for the Prometheus Operator's prometheus-operated Service when a PromQL check sets neither endpoint nor serviceRef. Empty disables discovery.")
+	flag.StringVar(&clusterName, "cluster-name", "",
+		"The name of this cluster, available to PromQL query templates as {{ .ClusterName }}.")
+	flag.IntVar(&maxConcurrentScriptJobs, "max-concurrent-script-jobs", 5,
+		"The maximum number of script check Jobs run at once; further script checks queue. 0 disables the limit.")
+	flag.DurationVar(&scriptJobGCInterval, "script-job-gc-interval", 10*time.Minute,
+		"How often orphaned script check Jobs and pods are garbage-collected.")
+	flag.DurationVar(&scriptJobTTL, "script-job-ttl", 10*time.Minute,
+		"How long past its deadline a script check Job, or a pod without its Job, is kept before it is treated as orphaned.")
+	flag.DurationVar(&readinessGCInterval, "readiness-gc-interval", 5*time.Minute,
+		"How often readyz state is reconciled against existing ClusterReadiness CRs to evict stale entries.")
+	flag.BoolVar(&installDefaultProfiles, "install-default-profiles", false,
+		"Create and update the bundled GateProfiles and GateChecks (clustergate-baseline, -cloud, -bare-metal, -observability) at startup. "+
+			"Objects without the app.kubernetes.io/managed-by=clustergate label are left alone.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the validating admission webhooks for ClusterReadiness, GateChecks and GateProfiles, and the workload gate, on port 9443.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs",
+		"The directory holding the webhook server's tls.crt and tls.key.")
+	flag.DurationVar(&minCheckInterval, "min-check-interval", 10*time.Second,
+		"The shortest ClusterReadiness interval the validating webhook accepts. 0 accepts any.")
 
 	opts := zap.Options{Development: true}
 	opts.BindFlags(flag.CommandLine)
@@ -63,6 +108,24 @@ func main() {
 		Metrics: metricsserver.Options{
 			BindAddress: metricsAddr,
 		},
+		// Reads go through the informer cache. Script check Jobs and CronJobs
+		// are the only ones the operator reads, so their caches are limited to
+		// them. Secrets and ConfigMaps are read live rather than caching every
+		// one in the cluster.
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				&batchv1.Job{}:     {Label: labels.SelectorFromSet(dynamic.ScriptJobLabels)},
+				&batchv1.CronJob{}: {Label: labels.SelectorFromSet(dynamic.ScriptJobLabels)},
+			},
+		},
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				DisableFor: []client.Object{&corev1.Secret{}, &corev1.ConfigMap{}},
+			},
+		},
+		WebhookServer: webhook.NewServer(webhook.Options{
+			CertDir: webhookCertDir,
+		}),
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         leaderElect,
 		LeaderElectionID:       "clustergate.clustergate.io",
@@ -78,40 +141,143 @@ func main() {
 
 	// Shared readiness state between controller and HTTP server.
 	readinessState := server.NewReadinessState()
+	// Readiness of ClusterReadiness gates, served at /readyz/{gate}.
+	gateReadinessState := server.NewReadinessState()
 
 	// Create the dynamic executor for GateCheck CRs.
-	dynamicExecutor, err := dynamic.NewExecutor(mgr.GetClient(), mgr.GetConfig(), namespace)
+	// A partially initialized executor keeps running; affected checks report Unknown.
+	dynamicExecutor := dynamic.NewExecutor(mgr.GetClient(), mgr.GetConfig(), namespace,
+		dynamic.WithPrometheusNamespace(prometheusNamespace),
+		dynamic.WithClusterName(clusterName),
+		dynamic.WithMaxConcurrentScriptJobs(maxConcurrentScriptJobs))
+	if err := dynamicExecutor.Ready(); err != nil {
+		setupLog.Error(err, "dynamic executor initialized in degraded mode")
+		metrics.DynamicExecutorReady.Set(0)
+	} else {
+		metrics.DynamicExecutorReady.Set(1)
+	}
+
+	// The discovery client provides the API server version to runIf expressions.
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
 	if err != nil {
-		setupLog.Error(err, "unable to create dynamic executor")
+		setupLog.Error(err, "unable to create discovery client")
 		os.Exit(1)
 	}
 
 	// Set up the ClusterReadiness reconciler.
 	if err := (&controller.ClusterReadinessReconciler{
+		Client:             mgr.GetClient(),
+		ReadinessState:     readinessState,
+		GateReadinessState: gateReadinessState,
+		DynamicExecutor:    dynamicExecutor,
+		ServerVersion:      discoveryClient,
+		StartedAt:          time.Now(),
+		Recorder:           mgr.GetEventRecorder("clustergate"),
+		Namespace:          namespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterReadiness")
+		os.Exit(1)
+	}
+
+	// Set up the NamespaceReadiness reconciler. Its state backs the
+	// per-namespace readyz paths and never affects the cluster /readyz.
+	namespaceReadinessState := server.NewReadinessState()
+	if err := (&controller.NamespaceReadinessReconciler{
 		Client:          mgr.GetClient(),
-		ReadinessState:  readinessState,
+		ReadinessState:  namespaceReadinessState,
 		DynamicExecutor: dynamicExecutor,
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ClusterReadiness")
+		setupLog.Error(err, "unable to create controller", "controller", "NamespaceReadiness")
 		os.Exit(1)
 	}
 
+	// Evict readyz state for ClusterReadiness and NamespaceReadiness CRs that no
+	// longer exist.
+	if err := mgr.Add(&controller.ReadinessStateGC{
+		Client:                  mgr.GetClient(),
+		ReadinessState:          readinessState,
+		GateReadinessState:      gateReadinessState,
+		NamespaceReadinessState: namespaceReadinessState,
+		Interval:                readinessGCInterval,
+	}); err != nil {
+		setupLog.Error(err, "unable to set up readiness state garbage collector")
+		os.Exit(1)
+	}
+
+	// Serve the last persisted evaluation until the first reconcile, and on standbys.
+	if err := mgr.Add(&controller.ReadinessStateRestorer{
+		Client:                  mgr.GetClient(),
+		ReadinessState:          readinessState,
+		GateReadinessState:      gateReadinessState,
+		NamespaceReadinessState: namespaceReadinessState,
+	}); err != nil {
+		setupLog.Error(err, "unable to set up readiness state restorer")
+		os.Exit(1)
+	}
+
+	// Delete script check Jobs left behind when the operator exits mid-check.
+	if err := mgr.Add(&dynamic.ScriptJobJanitor{
+		Client:    mgr.GetClient(),
+		Namespace: namespace,
+		Interval:  scriptJobGCInterval,
+		TTL:       scriptJobTTL,
+	}); err != nil {
+		setupLog.Error(err, "unable to set up script job janitor")
+		os.Exit(1)
+	}
+
+	// Install the bundled default profile library.
+	if installDefaultProfiles {
+		if err := mgr.Add(&library.Installer{Client: mgr.GetClient()}); err != nil {
+			setupLog.Error(err, "unable to set up default profile installer")
+			os.Exit(1)
+		}
+	}
+
 	// Set up the GateCheck validation reconciler.
 	if err := (&controller.GateCheckReconciler{
-		Client: mgr.GetClient(),
+		Client:    mgr.GetClient(),
+		Namespace: namespace,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GateCheck")
 		os.Exit(1)
 	}
 
-	// Set up the GateProfile validation reconciler.
-	if err := (&controller.GateProfileReconciler{
+	// Set up the NamespaceGateCheck validation reconciler.
+	if err := (&controller.NamespaceGateCheckReconciler{
 		Client: mgr.GetClient(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NamespaceGateCheck")
+		os.Exit(1)
+	}
+
+	// Set up the GateProfile reconciler that validates profiles and imports
+	// their sources.
+	if err := (&controller.GateProfileReconciler{
+		Client:    mgr.GetClient(),
+		Namespace: namespace,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "GateProfile")
 		os.Exit(1)
 	}
 
+	// Set up the GateRun reconciler that records on-demand audit runs.
+	if err := (&controller.GateRunReconciler{
+		Client: mgr.GetClient(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GateRun")
+		os.Exit(1)
+	}
+
+	// Reject invalid ClusterReadiness CRs, GateChecks and GateProfiles at admission,
+	// and gate new workloads in opted-in namespaces.
+	if enableWebhooks {
+		if err := clustergatewebhook.SetupWithManager(mgr, minCheckInterval); err != nil {
+			setupLog.Error(err, "unable to set up webhooks")
+			os.Exit(1)
+		}
+	}
+
 	// Standard liveness/readiness probes for the operator pod itself.
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
@@ -121,18 +287,27 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if enableWebhooks {
+		if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+			setupLog.Error(err, "unable to set up webhook ready check")
+			os.Exit(1)
+		}
+	}
 
 	// Start the cluster readyz HTTP server for external consumers.
 	go func() {
 		mux := http.NewServeMux()
 		mux.HandleFunc("/readyz", server.ReadyzHandler(readinessState))
+		mux.HandleFunc("/readyz/{gate}", server.GateReadyzHandler(gateReadinessState))
+		mux.HandleFunc("/readyz/namespaces/{namespace}", server.NamespaceReadyzHandler(namespaceReadinessState))
+		mux.HandleFunc("/readyz/namespaces/{namespace}/{name}", server.NamespaceReadyzHandler(namespaceReadinessState))
 		setupLog.Info("starting cluster readyz server", "addr", readyzAddr)
 		if err := http.ListenAndServe(readyzAddr, mux); err != nil {
 			setupLog.Error(err, "cluster readyz server failed")
 		}
 	}()
 
-	setupLog.Info("starting manager")
+	setupLog.Info("starting manager", "version", version.Version)
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
@@ -0,0 +1,149 @@
+// Package bundle reads profile bundles: multi-document YAML files of
+// GateProfiles and GateChecks, optionally signed and published to an OCI
+// registry.
+package bundle
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+// Parse strictly decodes the GateChecks and GateProfiles of a multi-document
+// YAML file, in file order.
+func Parse(data []byte) ([]client.Object, error) {
+	var objs []client.Object
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for i := 0; ; i++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj, err := decode(doc)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		objs = append(objs, obj)
+	}
+}
+
+// decode strictly decodes a GateCheck or GateProfile manifest.
+func decode(doc []byte) (client.Object, error) {
+	var meta metav1.TypeMeta
+	if err := yaml.Unmarshal(doc, &meta); err != nil {
+		return nil, err
+	}
+	var obj client.Object
+	switch meta.Kind {
+	case "GateCheck":
+		obj = &clustergatev1alpha1.GateCheck{}
+	case "GateProfile":
+		obj = &clustergatev1alpha1.GateProfile{}
+	default:
+		return nil, fmt.Errorf("unsupported kind %q", meta.Kind)
+	}
+	if err := yaml.UnmarshalStrict(doc, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// Bundle is the content of a profile bundle.
+type Bundle struct {
+	// Profile is the bundle's GateProfile.
+	Profile *clustergatev1alpha1.GateProfile
+	// GateChecks are the bundle's GateChecks.
+	GateChecks []*clustergatev1alpha1.GateCheck
+}
+
+// Load parses a profile bundle: exactly one GateProfile, without a source of
+// its own, and any number of GateChecks.
+func Load(data []byte) (*Bundle, error) {
+	objs, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	var b Bundle
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *clustergatev1alpha1.GateProfile:
+			if b.Profile != nil {
+				return nil, fmt.Errorf("bundle holds more than one GateProfile")
+			}
+			if o.Spec.Source != nil {
+				return nil, fmt.Errorf("bundled GateProfile %s must not set a source", o.Name)
+			}
+			b.Profile = o
+		case *clustergatev1alpha1.GateCheck:
+			b.GateChecks = append(b.GateChecks, o)
+		}
+	}
+	if b.Profile == nil {
+		return nil, fmt.Errorf("bundle holds no GateProfile")
+	}
+	return &b, nil
+}
+
+// Digest returns the "sha256:<hex>" digest of data.
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Verify checks signature, base64-encoded, against data with the PEM-encoded
+// ECDSA or Ed25519 public key. ECDSA signatures are ASN.1 signatures of the
+// SHA-256 of data.
+func Verify(publicKeyPEM []byte, data []byte, signature string) error {
+	if signature == "" {
+		return fmt.Errorf("bundle is not signed")
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signature))
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+	block, _ := pem.Decode(publicKeyPEM)
+	if block == nil {
+		return fmt.Errorf("public key is not PEM-encoded")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("parsing public key: %w", err)
+	}
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		sum := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(key, sum[:], sig) {
+			return fmt.Errorf("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, sig) {
+			return fmt.Errorf("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+	return nil
+}
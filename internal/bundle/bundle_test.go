@@ -0,0 +1,122 @@
+package bundle
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+)
+
+const testBundle = `apiVersion: clustergate.io/v1alpha1
+kind: GateCheck
+metadata:
+  name: platform-ingress
+spec:
+  category: networking
+  severity: critical
+  httpCheck:
+    url: http://ingress.example.com/healthz
+---
+apiVersion: clustergate.io/v1alpha1
+kind: GateProfile
+metadata:
+  name: platform
+spec:
+  version: 1.4.0
+  checks:
+    - name: dns
+    - gateCheckRef: platform-ingress
+`
+
+func TestLoad(t *testing.T) {
+	b, err := Load([]byte(testBundle))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.Profile.Spec.Version != "1.4.0" || len(b.Profile.Spec.Checks) != 2 {
+		t.Errorf("profile = %+v, want version 1.4.0 with 2 checks", b.Profile.Spec)
+	}
+	if len(b.GateChecks) != 1 || b.GateChecks[0].Name != "platform-ingress" {
+		t.Errorf("gateChecks = %+v, want platform-ingress", b.GateChecks)
+	}
+
+	profile := testBundle[strings.Index(testBundle, "apiVersion: clustergate.io/v1alpha1\nkind: GateProfile"):]
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "no profile", data: testBundle[:strings.Index(testBundle, "---")], wantErr: "bundle holds no GateProfile"},
+		{name: "two profiles", data: testBundle + "---\n" + profile, wantErr: "bundle holds more than one GateProfile"},
+		{name: "nested source", data: profile + "  source:\n    ociRef: registry.example.com/p\n", wantErr: "bundled GateProfile platform must not set a source"},
+		{name: "unknown kind", data: "apiVersion: v1\nkind: ConfigMap\n", wantErr: `unsupported kind "ConfigMap"`},
+		{name: "unknown field", data: profile + "  bogus: true\n", wantErr: "unknown field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load([]byte(tt.data)); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	data := []byte(testBundle)
+
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(data)
+	ecSig, err := ecPriv.Sign(rand.Reader, sum[:], crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edSig := ed25519.Sign(edPriv, data)
+
+	tests := []struct {
+		name      string
+		key       crypto.PublicKey
+		signature []byte
+		data      []byte
+		wantErr   string
+	}{
+		{name: "ed25519", key: edPub, signature: edSig, data: data},
+		{name: "ecdsa", key: &ecPriv.PublicKey, signature: ecSig, data: data},
+		{name: "tampered", key: edPub, signature: edSig, data: append([]byte("# x\n"), data...), wantErr: "invalid signature"},
+		{name: "wrong key", key: &ecPriv.PublicKey, signature: edSig, data: data, wantErr: "invalid signature"},
+		{name: "unsigned", key: edPub, data: data, wantErr: "bundle is not signed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			der, err := x509.MarshalPKIXPublicKey(tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+			var signature string
+			if tt.signature != nil {
+				signature = base64.StdEncoding.EncodeToString(tt.signature)
+			}
+			err = Verify(keyPEM, tt.data, signature)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package bundle
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// MediaType is the media type of the bundle layer of an OCI artifact.
+	MediaType = "application/vnd.clustergate.profile.bundle.v1+yaml"
+
+	// SignatureAnnotation is the bundle layer annotation holding the
+	// bundle's signature.
+	SignatureAnnotation = "io.clustergate.profile.signature"
+
+	// maxSize bounds the size of pulled manifests and bundles.
+	maxSize = 4 << 20
+
+	manifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+)
+
+// Reference is a parsed OCI artifact reference.
+type Reference struct {
+	// Registry is the registry host, with its port if any.
+	Registry string
+	// Repository is the repository path within the registry.
+	Repository string
+	// Tag is the tag referenced, when Digest is empty.
+	Tag string
+	// Digest pins the manifest, e.g. "sha256:<hex>".
+	Digest string
+}
+
+// ParseReference parses "registry/repository[:tag][@digest]". The registry
+// host is required; the tag defaults to "latest".
+func ParseReference(ref string) (Reference, error) {
+	var r Reference
+	name := ref
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, r.Digest = name[:i], name[i+1:]
+		if !strings.HasPrefix(r.Digest, "sha256:") {
+			return r, fmt.Errorf("reference %q: unsupported digest %q", ref, r.Digest)
+		}
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, r.Tag = name[:i], name[i+1:]
+	}
+	if r.Tag == "" && r.Digest == "" {
+		r.Tag = "latest"
+	}
+	registry, repository, ok := strings.Cut(name, "/")
+	if !ok || repository == "" || !(strings.ContainsAny(registry, ".:") || registry == "localhost") {
+		return r, fmt.Errorf("reference %q must be registry/repository[:tag][@digest]", ref)
+	}
+	r.Registry, r.Repository = registry, repository
+	return r, nil
+}
+
+// PullOptions configure Pull.
+type PullOptions struct {
+	// Username and Password authenticate to the registry, directly or to its
+	// token service. Both empty pulls anonymously.
+	Username string
+	Password string
+	// Insecure uses plain HTTP.
+	Insecure bool
+}
+
+// Artifact is a pulled bundle.
+type Artifact struct {
+	// Data is the bundle.
+	Data []byte
+	// Digest is the digest of the bundle layer.
+	Digest string
+	// Signature is the bundle layer's SignatureAnnotation.
+	Signature string
+}
+
+// Pull fetches the bundle layer of the OCI artifact ref over the registry
+// HTTP API, verifying the manifest against a pinned digest and the layer
+// against its descriptor.
+func Pull(ctx context.Context, httpClient *http.Client, ref string, opts PullOptions) (*Artifact, error) {
+	r, err := ParseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+	p := &puller{client: httpClient, ref: r, opts: opts}
+	scheme := "https"
+	if opts.Insecure {
+		scheme = "http"
+	}
+	p.base = fmt.Sprintf("%s://%s/v2/%s", scheme, r.Registry, r.Repository)
+
+	tagOrDigest := r.Digest
+	if tagOrDigest == "" {
+		tagOrDigest = r.Tag
+	}
+	raw, err := p.get(ctx, "/manifests/"+tagOrDigest, manifestMediaType)
+	if err != nil {
+		return nil, fmt.Errorf("fetching manifest: %w", err)
+	}
+	if r.Digest != "" && Digest(raw) != r.Digest {
+		return nil, fmt.Errorf("manifest digest %s does not match %s", Digest(raw), r.Digest)
+	}
+	var manifest struct {
+		Layers []struct {
+			MediaType   string            `json:"mediaType"`
+			Digest      string            `json:"digest"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"layers"`
+	}
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("decoding manifest: %w", err)
+	}
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != MediaType {
+			continue
+		}
+		data, err := p.get(ctx, "/blobs/"+layer.Digest, "")
+		if err != nil {
+			return nil, fmt.Errorf("fetching bundle layer: %w", err)
+		}
+		if Digest(data) != layer.Digest {
+			return nil, fmt.Errorf("bundle layer digest %s does not match %s", Digest(data), layer.Digest)
+		}
+		return &Artifact{Data: data, Digest: layer.Digest, Signature: layer.Annotations[SignatureAnnotation]}, nil
+	}
+	return nil, fmt.Errorf("manifest has no %s layer", MediaType)
+}
+
+// puller fetches from one repository, authenticating on the registry's
+// challenge.
+type puller struct {
+	client *http.Client
+	ref    Reference
+	opts   PullOptions
+	base   string
+	// authorization is the Authorization header answering the challenge.
+	authorization string
+}
+
+// get fetches base+path, answering an authentication challenge once.
+func (p *puller) get(ctx context.Context, path, accept string) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.base+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if p.authorization != "" {
+			req.Header.Set("Authorization", p.authorization)
+		}
+		resp, err := p.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized && attempt == 0:
+			if err := p.authenticate(ctx, resp.Header.Get("WWW-Authenticate")); err != nil {
+				return nil, err
+			}
+			continue
+		case resp.StatusCode != http.StatusOK:
+			return nil, fmt.Errorf("GET %s: %s", req.URL, resp.Status)
+		case len(body) > maxSize:
+			return nil, fmt.Errorf("GET %s: larger than %d bytes", req.URL, maxSize)
+		}
+		return body, nil
+	}
+}
+
+// authenticate answers a Basic or Bearer challenge.
+func (p *puller) authenticate(ctx context.Context, challenge string) error {
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if p.opts.Username == "" && p.opts.Password == "" {
+			return fmt.Errorf("registry requires credentials")
+		}
+		p.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(p.opts.Username+":"+p.opts.Password))
+		return nil
+	case "bearer":
+		return p.fetchToken(ctx, params)
+	default:
+		return fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+}
+
+// fetchToken gets a pull token from the token service of a Bearer challenge.
+func (p *puller) fetchToken(ctx context.Context, params map[string]string) error {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Scheme == "" {
+		return fmt.Errorf("invalid token realm %q", params["realm"])
+	}
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + p.ref.Repository + ":pull"
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if p.opts.Username != "" || p.opts.Password != "" {
+		req.SetBasicAuth(p.opts.Username, p.opts.Password)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetching registry token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching registry token: %s", resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxSize)).Decode(&token); err != nil {
+		return fmt.Errorf("decoding registry token: %w", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return fmt.Errorf("registry token service returned no token")
+	}
+	p.authorization = "Bearer " + token.Token
+	return nil
+}
+
+// parseChallenge splits a WWW-Authenticate header into its scheme and
+// parameters, e.g. `Bearer realm="https://auth",service="registry"`.
+func parseChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := map[string]string{}
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, ", "), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key != "" {
+			params[strings.ToLower(strings.TrimSpace(key))] = value
+		}
+	}
+	return scheme, params
+}
@@ -0,0 +1,127 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    Reference
+		wantErr bool
+	}{
+		{ref: "registry.example.com/platform/readiness:1.4.0", want: Reference{Registry: "registry.example.com", Repository: "platform/readiness", Tag: "1.4.0"}},
+		{ref: "localhost:5000/readiness", want: Reference{Registry: "localhost:5000", Repository: "readiness", Tag: "latest"}},
+		{ref: "registry.example.com/readiness@sha256:abc", want: Reference{Registry: "registry.example.com", Repository: "readiness", Digest: "sha256:abc"}},
+		{ref: "registry.example.com/readiness:1.0@sha256:abc", want: Reference{Registry: "registry.example.com", Repository: "readiness", Tag: "1.0", Digest: "sha256:abc"}},
+		{ref: "platform/readiness:1.4.0", wantErr: true},
+		{ref: "registry.example.com/readiness@md5:abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := ParseReference(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// testRegistry serves one bundle artifact at platform/readiness:1.4.0 behind
+// a Bearer token challenge.
+func testRegistry(t *testing.T, data []byte, signature string) (*httptest.Server, string) {
+	t.Helper()
+	layerDigest := Digest(data)
+	manifest, err := json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"mediaType":     manifestMediaType,
+		"layers": []map[string]any{{
+			"mediaType":   MediaType,
+			"digest":      layerDigest,
+			"size":        len(data),
+			"annotations": map[string]string{SignatureAnnotation: signature},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if user != "puller" || pass != "s3cret" || r.URL.Query().Get("scope") != "repository:platform/readiness:pull" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"token":"t0ken"}`))
+	})
+	mux.HandleFunc("/v2/platform/readiness/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t0ken" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/platform/readiness/manifests/1.4.0", "/v2/platform/readiness/manifests/" + Digest(manifest):
+			_, _ = w.Write(manifest)
+		case "/v2/platform/readiness/blobs/" + layerDigest:
+			_, _ = w.Write(data)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, Digest(manifest)
+}
+
+func TestPull(t *testing.T) {
+	data := []byte(testBundle)
+	srv, manifestDigest := testRegistry(t, data, "c2ln")
+	registry := strings.TrimPrefix(srv.URL, "http://")
+	creds := PullOptions{Username: "puller", Password: "s3cret", Insecure: true}
+
+	for _, ref := range []string{registry + "/platform/readiness:1.4.0", registry + "/platform/readiness@" + manifestDigest} {
+		artifact, err := Pull(context.Background(), srv.Client(), ref, creds)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", ref, err)
+		}
+		if string(artifact.Data) != testBundle || artifact.Signature != "c2ln" || artifact.Digest != Digest(data) {
+			t.Errorf("%s: artifact = %+v", ref, artifact)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		ref     string
+		opts    PullOptions
+		wantErr string
+	}{
+		{name: "wrong credentials", ref: registry + "/platform/readiness:1.4.0", opts: PullOptions{Insecure: true}, wantErr: "fetching registry token: 401"},
+		{name: "unknown tag", ref: registry + "/platform/readiness:9.9.9", opts: creds, wantErr: "404"},
+		{name: "unknown digest", ref: registry + "/platform/readiness:1.4.0@sha256:0000", opts: creds, wantErr: "404"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Pull(context.Background(), srv.Client(), tt.ref, tt.opts); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:a/b:pull"`)
+	if scheme != "Bearer" || params["realm"] != "https://auth.example.com/token" || params["service"] != "registry.example.com" || params["scope"] != "repository:a/b:pull" {
+		t.Errorf("got %s %v", scheme, params)
+	}
+}
@@ -7,12 +7,14 @@ import (
 	"github.com/clustergate/clustergate/internal/checks"
 	"github.com/clustergate/clustergate/internal/checks/controlplane"
 	"github.com/clustergate/clustergate/internal/checks/dns"
+	"github.com/clustergate/clustergate/internal/checks/gatewayapi"
 )
 
 // RegisterAll registers all built-in readiness checks into the global registry.
 func RegisterAll(c client.Client, cfg *rest.Config, enableCloudControllerManager bool) {
 	RegisterControlPlane(c, cfg, enableCloudControllerManager)
 	checks.Register(dns.New(c))
+	checks.Register(gatewayapi.New(c))
 }
 
 // RegisterControlPlane registers only the control plane checks.
@@ -0,0 +1,163 @@
+package dynamic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+)
+
+// alertmanagerAlert is the subset of an Alertmanager v2 API alert the check reads.
+type alertmanagerAlert struct {
+	Labels map[string]string `json:"labels"`
+}
+
+func (e *Executor) executeAlertmanagerCheck(ctx context.Context, spec *clustergatev1alpha1.AlertmanagerCheckSpec) (checks.Result, error) {
+	timeout := 10 * time.Second
+	if spec.TimeoutSeconds != nil {
+		timeout = time.Duration(*spec.TimeoutSeconds) * time.Second
+	}
+
+	tlsConfig, err := e.tlsConfig(ctx, spec.InsecureSkipTLSVerify, spec.TLS)
+	if err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("invalid TLS configuration: %v", err),
+		}, nil
+	}
+	httpClient := httpClientWithTLS(tlsConfig, timeout)
+
+	endpoint, err := e.alertmanagerEndpoint(ctx, spec)
+	if err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("failed to resolve Alertmanager endpoint: %v", err),
+		}, nil
+	}
+
+	alertsURL, err := url.Parse(endpoint)
+	if err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("invalid Alertmanager endpoint URL: %v", err),
+		}, nil
+	}
+	alertsURL.Path = strings.TrimSuffix(alertsURL.Path, "/") + "/api/v2/alerts"
+	params := url.Values{}
+	params.Set("active", "true")
+	params.Set("silenced", strconv.FormatBool(spec.IncludeSilenced))
+	params.Set("inhibited", strconv.FormatBool(spec.IncludeSilenced))
+	for _, m := range spec.Matchers {
+		params.Add("filter", m)
+	}
+	alertsURL.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, alertsURL.String(), nil)
+	if err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("failed to create request: %v", err),
+		}, nil
+	}
+	if err := e.applyCredentials(ctx, spec.BearerTokenSecretRef, spec.BasicAuthSecretRef, req); err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("failed to resolve credentials: %v", err),
+		}, nil
+	}
+
+	details := map[string]string{
+		"endpoint": endpoint,
+		"matchers": strings.Join(spec.Matchers, ","),
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("Alertmanager request failed: %v", err),
+			Details: details,
+		}, nil
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("failed to read Alertmanager response: %v", err),
+		}, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		details["statusCode"] = fmt.Sprintf("%d", resp.StatusCode)
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("Alertmanager returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body))),
+			Details: details,
+		}, nil
+	}
+
+	var alerts []alertmanagerAlert
+	if err := json.Unmarshal(body, &alerts); err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("failed to parse Alertmanager response: %v", err),
+		}, nil
+	}
+
+	details["firing"] = fmt.Sprintf("%d", len(alerts))
+	if len(alerts) <= int(spec.MaxFiring) {
+		return checks.Result{
+			Ready:   true,
+			Message: fmt.Sprintf("%d matching alerts firing (max %d)", len(alerts), spec.MaxFiring),
+			Details: details,
+		}, nil
+	}
+
+	firing := make([]string, 0, len(alerts))
+	for _, alert := range alerts {
+		firing = append(firing, formatAlert(alert.Labels))
+	}
+	details["firingAlerts"] = capSeries(firing)
+	return checks.Result{
+		Ready:   false,
+		Message: fmt.Sprintf("%d matching alerts firing, expected at most %d: %s", len(alerts), spec.MaxFiring, details["firingAlerts"]),
+		Details: details,
+	}, nil
+}
+
+// alertmanagerEndpoint returns the Alertmanager URL: spec.Endpoint or the URL
+// of spec.ServiceRef.
+func (e *Executor) alertmanagerEndpoint(ctx context.Context, spec *clustergatev1alpha1.AlertmanagerCheckSpec) (string, error) {
+	switch {
+	case spec.Endpoint != "" && spec.ServiceRef != nil:
+		return "", fmt.Errorf("endpoint and serviceRef are mutually exclusive")
+	case spec.Endpoint != "":
+		return spec.Endpoint, nil
+	case spec.ServiceRef != nil:
+		return e.serviceURL(ctx, spec.ServiceRef)
+	default:
+		return "", fmt.Errorf("one of endpoint or serviceRef must be set")
+	}
+}
+
+// formatAlert renders an alert as its name and remaining labels, e.g.
+// EtcdNoLeader{severity="critical"}.
+func formatAlert(labels map[string]string) string {
+	metric := make(map[string]string, len(labels))
+	for name, value := range labels {
+		metric[name] = value
+	}
+	metric["__name__"] = metric["alertname"]
+	delete(metric, "alertname")
+	return formatSeries(metric)
+}
@@ -0,0 +1,119 @@
+package dynamic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+func TestAlertmanagerCheck(t *testing.T) {
+	firing := []map[string]interface{}{
+		{"labels": map[string]string{"alertname": "EtcdNoLeader", "severity": "critical", "instance": "etcd-0"}},
+		{"labels": map[string]string{"alertname": "KubeAPIDown", "severity": "critical"}},
+	}
+
+	tests := []struct {
+		name        string
+		alerts      []map[string]interface{}
+		statusCode  int
+		spec        clustergatev1alpha1.AlertmanagerCheckSpec
+		wantReady   bool
+		wantMessage string
+		wantQuery   url.Values
+	}{
+		{
+			name:        "no alerts firing",
+			alerts:      []map[string]interface{}{},
+			spec:        clustergatev1alpha1.AlertmanagerCheckSpec{Matchers: []string{`severity="critical"`}},
+			wantReady:   true,
+			wantMessage: "0 matching alerts firing (max 0)",
+			wantQuery: url.Values{
+				"active": {"true"}, "silenced": {"false"}, "inhibited": {"false"}, "filter": {`severity="critical"`},
+			},
+		},
+		{
+			name:        "alerts firing",
+			alerts:      firing,
+			spec:        clustergatev1alpha1.AlertmanagerCheckSpec{Matchers: []string{`severity="critical"`, `alertname=~"Etcd.*|KubeAPI.*"`}},
+			wantMessage: `2 matching alerts firing, expected at most 0: EtcdNoLeader{instance="etcd-0",severity="critical"}, KubeAPIDown{severity="critical"}`,
+			wantQuery: url.Values{
+				"active": {"true"}, "silenced": {"false"}, "inhibited": {"false"},
+				"filter": {`severity="critical"`, `alertname=~"Etcd.*|KubeAPI.*"`},
+			},
+		},
+		{
+			name:        "within maxFiring",
+			alerts:      firing,
+			spec:        clustergatev1alpha1.AlertmanagerCheckSpec{MaxFiring: 2, IncludeSilenced: true},
+			wantReady:   true,
+			wantMessage: "2 matching alerts firing (max 2)",
+			wantQuery:   url.Values{"active": {"true"}, "silenced": {"true"}, "inhibited": {"true"}},
+		},
+		{
+			name:        "alertmanager error",
+			statusCode:  http.StatusServiceUnavailable,
+			wantMessage: "Alertmanager returned HTTP 503",
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).Build()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received *http.Request
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				if tt.statusCode != 0 {
+					http.Error(w, "unavailable", tt.statusCode)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(tt.alerts)
+			}))
+			defer srv.Close()
+
+			spec := tt.spec
+			spec.Endpoint = srv.URL + "/alertmanager"
+			result, err := newTestExecutor(c).Execute(context.Background(), "alerts", clustergatev1alpha1.GateCheckSpec{
+				AlertmanagerCheck: &spec,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady || !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("result = %v %q, want %v %q", result.Ready, result.Message, tt.wantReady, tt.wantMessage)
+			}
+			if received.URL.Path != "/alertmanager/api/v2/alerts" {
+				t.Errorf("path = %q, want /alertmanager/api/v2/alerts", received.URL.Path)
+			}
+			if tt.wantQuery != nil {
+				got := received.URL.Query()
+				for key, want := range tt.wantQuery {
+					if strings.Join(got[key], "|") != strings.Join(want, "|") {
+						t.Errorf("query %s = %q, want %q", key, got[key], want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestAlertmanagerCheck_Endpoint(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).Build()
+	result, err := newTestExecutor(c).Execute(context.Background(), "alerts", clustergatev1alpha1.GateCheckSpec{
+		AlertmanagerCheck: &clustergatev1alpha1.AlertmanagerCheckSpec{},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Ready || !strings.Contains(result.Message, "one of endpoint or serviceRef must be set") {
+		t.Errorf("result = %v %q, want failure for a missing endpoint", result.Ready, result.Message)
+	}
+}
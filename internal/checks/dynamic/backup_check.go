@@ -0,0 +1,126 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+)
+
+const (
+	veleroScheduleLabel    = "velero.io/schedule-name"
+	veleroPhaseCompleted   = "Completed"
+	defaultVeleroNamespace = "velero"
+)
+
+var veleroBackupListGVK = schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "BackupList"}
+
+func (e *Executor) executeBackupCheck(ctx context.Context, spec *clustergatev1alpha1.BackupCheckSpec) (checks.Result, error) {
+	namespace := spec.Namespace
+	if namespace == "" {
+		namespace = defaultVeleroNamespace
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(veleroBackupListGVK)
+	opts := []client.ListOption{client.InNamespace(namespace)}
+	if spec.ScheduleName != "" {
+		opts = append(opts, client.MatchingLabels{veleroScheduleLabel: spec.ScheduleName})
+	}
+	if err := e.client.List(ctx, list, opts...); err != nil {
+		if meta.IsNoMatchError(err) {
+			return checks.Result{
+				Ready:   false,
+				Message: "Velero Backup CRD is not installed",
+			}, nil
+		}
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("failed to list Velero backups: %v", err),
+		}, nil
+	}
+
+	details := map[string]string{
+		"namespace": namespace,
+		"backups":   fmt.Sprintf("%d", len(list.Items)),
+		"maxAge":    spec.MaxAge.Duration.String(),
+	}
+	if spec.ScheduleName != "" {
+		details["schedule"] = spec.ScheduleName
+	}
+
+	// Track the newest backup of any phase for context, and the newest successful one for the verdict.
+	var latest, latestSuccess *unstructured.Unstructured
+	var latestTime, latestSuccessTime time.Time
+	for i := range list.Items {
+		b := &list.Items[i]
+		t := backupTimestamp(b)
+		if latest == nil || t.After(latestTime) {
+			latest, latestTime = b, t
+		}
+		phase, _, _ := unstructured.NestedString(b.Object, "status", "phase")
+		if phase == veleroPhaseCompleted && (latestSuccess == nil || t.After(latestSuccessTime)) {
+			latestSuccess, latestSuccessTime = b, t
+		}
+	}
+
+	if latest != nil {
+		phase, _, _ := unstructured.NestedString(latest.Object, "status", "phase")
+		details["latestBackup"] = latest.GetName()
+		details["latestPhase"] = phase
+	}
+
+	if latestSuccess == nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("no successful Velero backup found in %s", scheduleScope(namespace, spec.ScheduleName)),
+			Details: details,
+		}, nil
+	}
+
+	age := time.Since(latestSuccessTime).Truncate(time.Second)
+	details["lastSuccessfulBackup"] = latestSuccess.GetName()
+	details["lastSuccessfulAt"] = latestSuccessTime.UTC().Format(time.RFC3339)
+	details["age"] = age.String()
+
+	if age > spec.MaxAge.Duration {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("latest successful backup %s is %s old, exceeds maxAge %s", latestSuccess.GetName(), age, spec.MaxAge.Duration),
+			Details: details,
+		}, nil
+	}
+
+	return checks.Result{
+		Ready:   true,
+		Message: fmt.Sprintf("latest successful backup %s is %s old", latestSuccess.GetName(), age),
+		Details: details,
+	}, nil
+}
+
+// backupTimestamp returns when a backup finished, falling back to its start
+// and creation times for backups that are still running or lack status.
+func backupTimestamp(b *unstructured.Unstructured) time.Time {
+	for _, field := range []string{"completionTimestamp", "startTimestamp"} {
+		if s, ok, _ := unstructured.NestedString(b.Object, "status", field); ok {
+			if t, err := time.Parse(time.RFC3339, s); err == nil {
+				return t
+			}
+		}
+	}
+	return b.GetCreationTimestamp().Time
+}
+
+func scheduleScope(namespace, schedule string) string {
+	if schedule == "" {
+		return "namespace " + namespace
+	}
+	return fmt.Sprintf("schedule %s/%s", namespace, schedule)
+}
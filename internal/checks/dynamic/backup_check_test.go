@@ -0,0 +1,117 @@
+package dynamic
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+func veleroBackup(name, schedule, phase string, completedAgo time.Duration) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase":               phase,
+			"completionTimestamp": time.Now().Add(-completedAgo).UTC().Format(time.RFC3339),
+		},
+	}}
+	obj.SetAPIVersion("velero.io/v1")
+	obj.SetKind("Backup")
+	obj.SetNamespace("velero")
+	obj.SetName(name)
+	if schedule != "" {
+		obj.SetLabels(map[string]string{"velero.io/schedule-name": schedule})
+	}
+	return obj
+}
+
+func TestBackupCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		objs        []client.Object
+		schedule    string
+		wantReady   bool
+		wantBackup  string
+		wantMessage string
+	}{
+		{
+			name:        "no backups",
+			wantReady:   false,
+			wantMessage: "no successful Velero backup found",
+		},
+		{
+			name: "recent completed backup",
+			objs: []client.Object{
+				veleroBackup("daily-1", "daily", "Completed", 2*time.Hour),
+			},
+			wantReady:  true,
+			wantBackup: "daily-1",
+		},
+		{
+			name: "stale completed backup",
+			objs: []client.Object{
+				veleroBackup("daily-1", "daily", "Completed", 30*time.Hour),
+			},
+			wantReady:   false,
+			wantMessage: "exceeds maxAge",
+		},
+		{
+			name: "newer failed backup does not count",
+			objs: []client.Object{
+				veleroBackup("daily-1", "daily", "Completed", 30*time.Hour),
+				veleroBackup("daily-2", "daily", "PartiallyFailed", time.Hour),
+			},
+			wantReady:  false,
+			wantBackup: "daily-1",
+		},
+		{
+			name: "picks newest successful backup",
+			objs: []client.Object{
+				veleroBackup("daily-1", "daily", "Completed", 30*time.Hour),
+				veleroBackup("daily-2", "daily", "Completed", time.Hour),
+			},
+			wantReady:  true,
+			wantBackup: "daily-2",
+		},
+		{
+			name: "schedule filter ignores other schedules",
+			objs: []client.Object{
+				veleroBackup("daily-1", "daily", "Completed", 30*time.Hour),
+				veleroBackup("hourly-1", "hourly", "Completed", time.Hour),
+			},
+			schedule:   "daily",
+			wantReady:  false,
+			wantBackup: "daily-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(tt.objs...).Build()
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				BackupCheck: &clustergatev1alpha1.BackupCheckSpec{
+					ScheduleName: tt.schedule,
+					MaxAge:       metav1.Duration{Duration: 25 * time.Hour},
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady {
+				t.Fatalf("ready = %v, want %v: %s", result.Ready, tt.wantReady, result.Message)
+			}
+			if tt.wantBackup != "" && result.Details["lastSuccessfulBackup"] != tt.wantBackup {
+				t.Errorf("lastSuccessfulBackup = %q, want %q", result.Details["lastSuccessfulBackup"], tt.wantBackup)
+			}
+			if tt.wantMessage != "" && !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("message %q does not contain %q", result.Message, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+)
+
+// celCostLimit bounds the evaluation cost of a single CEL expression so a
+// pathological expression cannot stall a reconcile.
+const celCostLimit = 1000000
+
+// celEnv declares the variables available to resource check expressions.
+func celEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		cel.CrossTypeNumericComparisons(true),
+	)
+}
+
+// compileCELExpression compiles expr into a program that evaluates to a bool.
+func compileCELExpression(env *cel.Env, expr string) (cel.Program, error) {
+	ast, issues := env.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("expression must evaluate to bool, got %s", ast.OutputType())
+	}
+	return env.Program(ast, cel.CostLimit(celCostLimit))
+}
+
+// ValidateCELExpressions reports the first expression that does not compile.
+func ValidateCELExpressions(exprs []string) error {
+	_, err := compileResourceExpressions(exprs)
+	return err
+}
+
+// evalCELExpression evaluates prg against object and returns its bool result.
+func evalCELExpression(ctx context.Context, prg cel.Program, object map[string]interface{}) (bool, error) {
+	out, _, err := prg.ContextEval(ctx, map[string]interface{}{"object": object})
+	if err != nil {
+		return false, err
+	}
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression returned %s, want bool", out.Type())
+	}
+	return result, nil
+}
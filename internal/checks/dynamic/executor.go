@@ -3,10 +3,14 @@ package dynamic
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -15,30 +19,99 @@ import (
 	"github.com/clustergate/clustergate/internal/checks"
 )
 
+// ErrExecutorUnavailable is returned for checks that need a capability the
+// executor failed to initialize. Their result is reported as Unknown rather
+// than Failing.
+var ErrExecutorUnavailable = errors.New("dynamic executor unavailable")
+
 // Executor evaluates GateCheck specs at runtime.
 type Executor struct {
-	client     client.Client
-	httpClient *http.Client
-	clientset  kubernetes.Interface
-	namespace  string
+	client              client.Client
+	httpClient          *http.Client
+	clientset           kubernetes.Interface
+	namespace           string
+	prometheusNamespace string
+	clusterName         string
+	initErr             error
+	restarts            restartTracker
+	scripts             scriptResultCache
+	scriptSlots         chan struct{}
+}
+
+// ExecutorOption configures optional Executor behavior.
+type ExecutorOption func(*Executor)
+
+// WithPrometheusNamespace sets the namespace searched for the Prometheus
+// Operator's Service when a PromQL check names neither an endpoint nor a
+// serviceRef. An empty namespace disables discovery.
+func WithPrometheusNamespace(namespace string) ExecutorOption {
+	return func(e *Executor) {
+		e.prometheusNamespace = namespace
+	}
+}
+
+// WithClusterName sets the cluster name available to PromQL query templates
+// as {{ .ClusterName }}.
+func WithClusterName(name string) ExecutorOption {
+	return func(e *Executor) {
+		e.clusterName = name
+	}
+}
+
+// WithMaxConcurrentScriptJobs limits how many script check Jobs the executor
+// runs at once. Further script checks queue until a Job finishes. Zero or
+// less means no limit.
+func WithMaxConcurrentScriptJobs(n int) ExecutorOption {
+	return func(e *Executor) {
+		if n > 0 {
+			e.scriptSlots = make(chan struct{}, n)
+		} else {
+			e.scriptSlots = nil
+		}
+	}
 }
 
 // NewExecutor creates a new dynamic check executor.
 // The rest.Config is used to build a kubernetes.Clientset for Job-based checks.
 // namespace is the namespace where script check Jobs will be created.
-func NewExecutor(c client.Client, cfg *rest.Config, namespace string) (*Executor, error) {
-	cs, err := kubernetes.NewForConfig(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create clientset for script checks: %w", err)
-	}
-	return &Executor{
+// If the clientset cannot be built the executor still runs every other check
+// type; Ready reports the failure.
+func NewExecutor(c client.Client, cfg *rest.Config, namespace string, opts ...ExecutorOption) *Executor {
+	e := &Executor{
 		client: c,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		clientset: cs,
 		namespace: namespace,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	cs, err := kubernetes.NewForConfig(protobufConfig(cfg))
+	if err != nil {
+		e.initErr = fmt.Errorf("failed to create clientset for script checks: %w", err)
+	} else {
+		e.clientset = cs
+	}
+	return e
+}
+
+// protobufConfig returns a copy of cfg that talks protobuf to the API server,
+// falling back to JSON. The controller-runtime client already negotiates
+// protobuf for built-in types; the clientset needs it set explicitly.
+func protobufConfig(cfg *rest.Config) *rest.Config {
+	if cfg == nil {
+		return nil
+	}
+	pb := rest.CopyConfig(cfg)
+	pb.ContentType = runtime.ContentTypeProtobuf
+	pb.AcceptContentTypes = runtime.ContentTypeProtobuf + "," + runtime.ContentTypeJSON
+	return pb
+}
+
+// Ready returns the initialization error, or nil if every check type can run.
+func (e *Executor) Ready() error {
+	return e.initErr
 }
 
 // Execute runs the appropriate check type from a GateCheckSpec.
@@ -54,17 +127,52 @@ func (e *Executor) Execute(ctx context.Context, checkName string, spec clusterga
 	case spec.PromQLCheck != nil:
 		return e.executePromQLCheck(ctx, spec.PromQLCheck)
 	case spec.ScriptCheck != nil:
-		return executeScriptCheck(ctx, e.clientset, e.namespace, checkName, spec.ScriptCheck)
+		if e.clientset == nil {
+			return checks.Result{
+				Ready:   false,
+				Message: "script checks are unavailable: the executor has no clientset",
+			}, fmt.Errorf("%w: %v", ErrExecutorUnavailable, e.initErr)
+		}
+		return e.runScriptCheck(ctx, checkName, spec.ScriptCheck)
+	case spec.GitCheck != nil:
+		return e.executeGitCheck(ctx, spec.GitCheck)
+	case spec.BackupCheck != nil:
+		return e.executeBackupCheck(ctx, spec.BackupCheck)
+	case spec.AlertmanagerCheck != nil:
+		return e.executeAlertmanagerCheck(ctx, spec.AlertmanagerCheck)
 	default:
 		return checks.Result{}, fmt.Errorf("no check type specified in GateCheck")
 	}
 }
 
+// getSecret fetches the referenced Secret, defaulting to the operator namespace.
+func (e *Executor) getSecret(ctx context.Context, ref *clustergatev1alpha1.SecretReference) (*corev1.Secret, error) {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = e.namespace
+	}
+	var secret corev1.Secret
+	if err := e.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get Secret %s/%s: %w", namespace, ref.Name, err)
+	}
+	return &secret, nil
+}
+
 // httpClientForSpec returns an HTTP client configured for the check spec.
 func httpClientForSpec(insecureSkipTLS bool, timeout time.Duration) *http.Client {
-	transport := http.DefaultTransport.(*http.Transport).Clone()
+	var tlsConfig *tls.Config
 	if insecureSkipTLS {
-		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+		tlsConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+	}
+	return httpClientWithTLS(tlsConfig, timeout)
+}
+
+// httpClientWithTLS returns an HTTP client using the given TLS configuration.
+// A nil tlsConfig keeps the transport defaults.
+func httpClientWithTLS(tlsConfig *tls.Config, timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
 	}
 	return &http.Client{
 		Transport: transport,
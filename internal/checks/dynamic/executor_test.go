@@ -0,0 +1,39 @@
+package dynamic
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+func TestExecutor_DegradedWithoutClientset(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).Build()
+	executor := newTestExecutor(c)
+	executor.initErr = errors.New("bad rest.Config")
+
+	if err := executor.Ready(); err == nil {
+		t.Fatal("expected Ready to report the initialization error")
+	}
+
+	_, err := executor.Execute(context.Background(), "script", clustergatev1alpha1.GateCheckSpec{
+		ScriptCheck: &clustergatev1alpha1.ScriptCheckSpec{Image: "busybox", Command: []string{"true"}},
+	})
+	if !errors.Is(err, ErrExecutorUnavailable) {
+		t.Errorf("script check error = %v, want ErrExecutorUnavailable", err)
+	}
+
+	// Check types that do not need the clientset keep working.
+	result, err := executor.Execute(context.Background(), "pods", clustergatev1alpha1.GateCheckSpec{
+		PodCheck: &clustergatev1alpha1.PodCheckSpec{Namespace: "default", MinReady: 0},
+	})
+	if err != nil {
+		t.Fatalf("pod check error = %v", err)
+	}
+	if result.Message == "" {
+		t.Error("expected pod check to run")
+	}
+}
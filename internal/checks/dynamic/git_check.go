@@ -0,0 +1,445 @@
+package dynamic
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // known_hosts hashes hostnames with HMAC-SHA1
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+)
+
+const (
+	gitUploadPack      = "git-upload-pack"
+	gitAdvertisementCT = "application/x-git-upload-pack-advertisement"
+	defaultGitSSHUser  = "git"
+	defaultGitSSHPort  = "22"
+)
+
+// gitEndpoint is a parsed Git remote URL.
+type gitEndpoint struct {
+	// ssh is true for SSH remotes; otherwise url holds the HTTP(S) location.
+	ssh  bool
+	url  *url.URL
+	user string
+	addr string
+	path string
+}
+
+// String returns the remote location with any embedded password redacted.
+func (ep *gitEndpoint) String() string {
+	if ep.ssh {
+		return fmt.Sprintf("ssh://%s@%s/%s", ep.user, ep.addr, strings.TrimPrefix(ep.path, "/"))
+	}
+	return ep.url.Redacted()
+}
+
+// gitCredentials holds the keys read from a GitCheck's credentials Secret.
+type gitCredentials struct {
+	username   string
+	password   string
+	identity   []byte
+	knownHosts []byte
+}
+
+func (e *Executor) executeGitCheck(ctx context.Context, spec *clustergatev1alpha1.GitCheckSpec) (checks.Result, error) {
+	timeout := 10 * time.Second
+	if spec.TimeoutSeconds != nil {
+		timeout = time.Duration(*spec.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ep, err := parseGitURL(spec.URL)
+	if err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("invalid Git repository URL: %v", err),
+		}, nil
+	}
+
+	creds := &gitCredentials{}
+	if spec.SecretRef != nil {
+		creds, err = e.gitCredentials(ctx, spec.SecretRef)
+		if err != nil {
+			return checks.Result{
+				Ready:   false,
+				Message: err.Error(),
+			}, nil
+		}
+	}
+
+	start := time.Now()
+	var refs map[string]string
+	if ep.ssh {
+		refs, err = lsRemoteSSH(ctx, ep, creds)
+	} else {
+		refs, err = lsRemoteHTTP(ctx, ep, creds, timeout)
+	}
+	elapsed := time.Since(start)
+
+	details := map[string]string{
+		"url":          ep.String(),
+		"responseTime": elapsed.String(),
+	}
+	if err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("ls-remote %s failed: %v", ep, err),
+			Details: details,
+		}, nil
+	}
+	details["refs"] = strconv.Itoa(len(refs))
+
+	if spec.Ref == "" {
+		return checks.Result{
+			Ready:   true,
+			Message: fmt.Sprintf("repository %s is reachable (%d refs)", ep, len(refs)),
+			Details: details,
+		}, nil
+	}
+
+	name, commit, ok := matchGitRef(refs, spec.Ref)
+	if !ok {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("ref %q not found in %s", spec.Ref, ep),
+			Details: details,
+		}, nil
+	}
+	details["ref"] = name
+	details["commit"] = commit
+
+	return checks.Result{
+		Ready:   true,
+		Message: fmt.Sprintf("%s exists in %s at %s", name, ep, commit),
+		Details: details,
+	}, nil
+}
+
+// gitCredentials reads the keys of the credentials Secret.
+func (e *Executor) gitCredentials(ctx context.Context, ref *clustergatev1alpha1.SecretReference) (*gitCredentials, error) {
+	secret, err := e.getSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &gitCredentials{
+		username:   string(secret.Data["username"]),
+		password:   string(secret.Data["password"]),
+		identity:   secret.Data["identity"],
+		knownHosts: secret.Data["known_hosts"],
+	}, nil
+}
+
+// parseGitURL accepts http(s):// and ssh:// URLs as well as scp-like
+// "[user@]host:path" SSH locations.
+func parseGitURL(raw string) (*gitEndpoint, error) {
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		if u.Host == "" {
+			return nil, fmt.Errorf("missing host in %q", raw)
+		}
+		switch u.Scheme {
+		case "http", "https":
+			return &gitEndpoint{url: u}, nil
+		case "ssh":
+			user := defaultGitSSHUser
+			if u.User != nil && u.User.Username() != "" {
+				user = u.User.Username()
+			}
+			port := u.Port()
+			if port == "" {
+				port = defaultGitSSHPort
+			}
+			return &gitEndpoint{
+				ssh:  true,
+				user: user,
+				addr: net.JoinHostPort(u.Hostname(), port),
+				path: u.Path,
+			}, nil
+		default:
+			return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+		}
+	}
+
+	host, path, ok := strings.Cut(raw, ":")
+	if !ok || host == "" || path == "" || strings.Contains(host, "/") {
+		return nil, fmt.Errorf("%q is neither a URL nor a [user@]host:path location", raw)
+	}
+	user := defaultGitSSHUser
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		user, host = host[:i], host[i+1:]
+	}
+	return &gitEndpoint{
+		ssh:  true,
+		user: user,
+		addr: net.JoinHostPort(host, defaultGitSSHPort),
+		path: path,
+	}, nil
+}
+
+// lsRemoteHTTP fetches the ref advertisement over the smart HTTP protocol.
+func lsRemoteHTTP(ctx context.Context, ep *gitEndpoint, creds *gitCredentials, timeout time.Duration) (map[string]string, error) {
+	u := *ep.url
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/info/refs"
+	u.RawQuery = "service=" + gitUploadPack
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "git/clustergate")
+	if creds.username != "" || creds.password != "" {
+		req.SetBasicAuth(creds.username, creds.password)
+	}
+
+	resp, err := httpClientForSpec(false, timeout).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != gitAdvertisementCT {
+		return nil, fmt.Errorf("server does not speak the smart HTTP protocol (Content-Type %q)", ct)
+	}
+
+	service, err := readPktLine(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(string(service), "\n") != "# service="+gitUploadPack {
+		return nil, fmt.Errorf("unexpected service announcement %q", service)
+	}
+	if flush, err := readPktLine(resp.Body); err != nil {
+		return nil, err
+	} else if flush != nil {
+		return nil, errors.New("missing flush after service announcement")
+	}
+	return readRefAdvertisement(resp.Body)
+}
+
+// lsRemoteSSH runs git-upload-pack over SSH and reads its ref advertisement.
+func lsRemoteSSH(ctx context.Context, ep *gitEndpoint, creds *gitCredentials) (map[string]string, error) {
+	if len(creds.identity) == 0 {
+		return nil, errors.New("SSH remotes require an identity key in the credentials Secret")
+	}
+	if len(creds.knownHosts) == 0 {
+		return nil, errors.New("SSH remotes require known_hosts in the credentials Secret")
+	}
+
+	var signer ssh.Signer
+	var err error
+	if creds.password != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(creds.identity, []byte(creds.password))
+	} else {
+		signer, err = ssh.ParsePrivateKey(creds.identity)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid identity key: %w", err)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", ep.addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, ep.addr, &ssh.ClientConfig{
+		User:            ep.user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: knownHostsCallback(creds.knownHosts),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sshClient := ssh.NewClient(sshConn, chans, reqs)
+	defer sshClient.Close()
+
+	session, err := sshClient.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := session.Start(gitUploadPack + " " + shellQuote(ep.path)); err != nil {
+		return nil, err
+	}
+
+	refs, err := readRefAdvertisement(stdout)
+	// A flush tells upload-pack we want no objects, so it exits cleanly.
+	_, _ = stdin.Write([]byte("0000"))
+	_ = stdin.Close()
+	return refs, err
+}
+
+// knownHostsCallback verifies host keys against known_hosts content, including
+// hashed hostnames and @revoked markers.
+func knownHostsCallback(data []byte) ssh.HostKeyCallback {
+	return func(hostname string, _ net.Addr, key ssh.PublicKey) error {
+		host := knownhosts.Normalize(hostname)
+		wire := key.Marshal()
+		trusted := false
+		for rest := data; len(rest) > 0; {
+			marker, hosts, pub, _, next, err := ssh.ParseKnownHosts(rest)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("invalid known_hosts: %w", err)
+			}
+			rest = next
+			if !bytes.Equal(pub.Marshal(), wire) || !knownHostMatches(hosts, host) {
+				continue
+			}
+			switch marker {
+			case "revoked":
+				return fmt.Errorf("host key for %s is revoked", host)
+			case "":
+				trusted = true
+			}
+		}
+		if !trusted {
+			return fmt.Errorf("host key for %s not found in known_hosts", host)
+		}
+		return nil
+	}
+}
+
+func knownHostMatches(patterns []string, host string) bool {
+	for _, p := range patterns {
+		if p == host {
+			return true
+		}
+		// Hashed entries have the form |1|base64(salt)|base64(HMAC-SHA1(salt, host)).
+		parts := strings.Split(p, "|")
+		if len(parts) != 4 || parts[0] != "" || parts[1] != "1" {
+			continue
+		}
+		salt, err := base64.StdEncoding.DecodeString(parts[2])
+		if err != nil {
+			continue
+		}
+		hash, err := base64.StdEncoding.DecodeString(parts[3])
+		if err != nil {
+			continue
+		}
+		mac := hmac.New(sha1.New, salt)
+		mac.Write([]byte(host))
+		if hmac.Equal(mac.Sum(nil), hash) {
+			return true
+		}
+	}
+	return false
+}
+
+// readRefAdvertisement parses pkt-lines of the form "<sha> <ref>[\x00<caps>]"
+// up to the terminating flush packet.
+func readRefAdvertisement(r io.Reader) (map[string]string, error) {
+	refs := map[string]string{}
+	for {
+		pkt, err := readPktLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if pkt == nil {
+			return refs, nil
+		}
+		line := strings.TrimSuffix(string(pkt), "\n")
+		if msg, ok := strings.CutPrefix(line, "ERR "); ok {
+			return nil, fmt.Errorf("remote error: %s", msg)
+		}
+		if i := strings.IndexByte(line, 0); i >= 0 {
+			line = line[:i]
+		}
+		sha, name, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("malformed ref advertisement %q", line)
+		}
+		// Empty repositories advertise only their capabilities.
+		if name == "capabilities^{}" {
+			continue
+		}
+		refs[name] = sha
+	}
+}
+
+// readPktLine reads one pkt-line. A flush packet ("0000") returns nil data.
+func readPktLine(r io.Reader) ([]byte, error) {
+	var hdr [4]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, fmt.Errorf("reading pkt-line: %w", err)
+	}
+	n, err := strconv.ParseUint(string(hdr[:]), 16, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pkt-line length %q", hdr[:])
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	if n < 4 {
+		return nil, fmt.Errorf("invalid pkt-line length %q", hdr[:])
+	}
+	buf := make([]byte, n-4)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("reading pkt-line: %w", err)
+	}
+	return buf, nil
+}
+
+// matchGitRef resolves a short or fully-qualified ref name, preferring the
+// peeled commit for annotated tags.
+func matchGitRef(refs map[string]string, ref string) (string, string, bool) {
+	candidates := []string{ref}
+	if !strings.HasPrefix(ref, "refs/") && ref != "HEAD" {
+		candidates = []string{"refs/heads/" + ref, "refs/tags/" + ref}
+	}
+	for _, name := range candidates {
+		sha, ok := refs[name]
+		if !ok {
+			continue
+		}
+		if peeled, ok := refs[name+"^{}"]; ok {
+			sha = peeled
+		}
+		return name, sha, true
+	}
+	return "", "", false
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
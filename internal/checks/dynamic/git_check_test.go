@@ -0,0 +1,411 @@
+package dynamic
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+func pktLine(s string) string {
+	return fmt.Sprintf("%04x%s", len(s)+4, s)
+}
+
+// refAdvertisement builds an upload-pack ref advertisement without the HTTP service header.
+func refAdvertisement(refs ...string) string {
+	var b strings.Builder
+	for i, r := range refs {
+		if i == 0 {
+			r += "\x00multi_ack side-band-64k"
+		}
+		b.WriteString(pktLine(r + "\n"))
+	}
+	b.WriteString("0000")
+	return b.String()
+}
+
+var testGitRefs = []string{
+	"1111111111111111111111111111111111111111 HEAD",
+	"1111111111111111111111111111111111111111 refs/heads/main",
+	"2222222222222222222222222222222222222222 refs/tags/v1.0.0",
+	"3333333333333333333333333333333333333333 refs/tags/v1.0.0^{}",
+}
+
+func gitHTTPServer(t *testing.T, user, pass string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/org/repo.git/info/refs" || r.URL.Query().Get("service") != "git-upload-pack" {
+			http.NotFound(w, r)
+			return
+		}
+		if user != "" {
+			u, p, ok := r.BasicAuth()
+			if !ok || u != user || p != pass {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/x-git-upload-pack-advertisement")
+		fmt.Fprint(w, pktLine("# service=git-upload-pack\n")+"0000"+refAdvertisement(testGitRefs...))
+	}))
+}
+
+func TestGitCheck_HTTP(t *testing.T) {
+	srv := gitHTTPServer(t, "", "")
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		ref        string
+		wantReady  bool
+		wantRef    string
+		wantCommit string
+	}{
+		{name: "reachable", wantReady: true},
+		{name: "branch", ref: "main", wantReady: true, wantRef: "refs/heads/main", wantCommit: "1111111111111111111111111111111111111111"},
+		{name: "annotated tag is peeled", ref: "v1.0.0", wantReady: true, wantRef: "refs/tags/v1.0.0", wantCommit: "3333333333333333333333333333333333333333"},
+		{name: "fully qualified", ref: "refs/heads/main", wantReady: true, wantRef: "refs/heads/main"},
+		{name: "missing branch", ref: "release-2.0", wantReady: false},
+		{name: "qualified ref must match exactly", ref: "refs/heads/v1.0.0", wantReady: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).Build()
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				GitCheck: &clustergatev1alpha1.GitCheckSpec{
+					URL: srv.URL + "/org/repo.git",
+					Ref: tt.ref,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady {
+				t.Fatalf("ready = %v, want %v: %s", result.Ready, tt.wantReady, result.Message)
+			}
+			if result.Details["refs"] != "4" {
+				t.Errorf("refs = %q, want 4", result.Details["refs"])
+			}
+			if tt.wantRef != "" && result.Details["ref"] != tt.wantRef {
+				t.Errorf("ref = %q, want %q", result.Details["ref"], tt.wantRef)
+			}
+			if tt.wantCommit != "" && result.Details["commit"] != tt.wantCommit {
+				t.Errorf("commit = %q, want %q", result.Details["commit"], tt.wantCommit)
+			}
+		})
+	}
+}
+
+func TestGitCheck_HTTPBasicAuthFromSecret(t *testing.T) {
+	srv := gitHTTPServer(t, "bot", "s3cret")
+	defer srv.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-creds", Namespace: "flux-system"},
+		Data: map[string][]byte{
+			"username": []byte("bot"),
+			"password": []byte("s3cret"),
+		},
+	}
+	spec := clustergatev1alpha1.GateCheckSpec{
+		GitCheck: &clustergatev1alpha1.GitCheckSpec{
+			URL: srv.URL + "/org/repo.git",
+			Ref: "main",
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(secret).Build()
+	result, err := newTestExecutor(c).Execute(context.Background(), "test", spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Ready {
+		t.Fatal("expected ready=false without credentials")
+	}
+	if !strings.Contains(result.Message, "401") {
+		t.Errorf("expected 401 in message, got: %s", result.Message)
+	}
+
+	spec.GitCheck.SecretRef = &clustergatev1alpha1.SecretReference{Name: "git-creds", Namespace: "flux-system"}
+	result, err = newTestExecutor(c).Execute(context.Background(), "test", spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Ready {
+		t.Errorf("expected ready=true with credentials: %s", result.Message)
+	}
+}
+
+func TestGitCheck_MissingSecret(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).Build()
+	result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+		GitCheck: &clustergatev1alpha1.GitCheckSpec{
+			URL:       "https://git.example.com/org/repo.git",
+			SecretRef: &clustergatev1alpha1.SecretReference{Name: "missing", Namespace: "default"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Ready {
+		t.Error("expected ready=false for missing Secret")
+	}
+	if !strings.Contains(result.Message, "default/missing") {
+		t.Errorf("expected Secret name in message, got: %s", result.Message)
+	}
+}
+
+func TestGitCheck_NotSmartHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, "1111111111111111111111111111111111111111\trefs/heads/main\n")
+	}))
+	defer srv.Close()
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).Build()
+	result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+		GitCheck: &clustergatev1alpha1.GitCheckSpec{URL: srv.URL + "/repo.git"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Ready {
+		t.Error("expected ready=false for dumb HTTP server")
+	}
+}
+
+func TestParseGitURL(t *testing.T) {
+	tests := []struct {
+		raw      string
+		wantSSH  bool
+		wantUser string
+		wantAddr string
+		wantPath string
+		wantErr  bool
+	}{
+		{raw: "https://github.com/org/repo.git"},
+		{raw: "ssh://git@github.com/org/repo.git", wantSSH: true, wantUser: "git", wantAddr: "github.com:22", wantPath: "/org/repo.git"},
+		{raw: "ssh://deploy@git.internal:2222/repo", wantSSH: true, wantUser: "deploy", wantAddr: "git.internal:2222", wantPath: "/repo"},
+		{raw: "ssh://github.com/org/repo.git", wantSSH: true, wantUser: "git", wantAddr: "github.com:22", wantPath: "/org/repo.git"},
+		{raw: "git@github.com:org/repo.git", wantSSH: true, wantUser: "git", wantAddr: "github.com:22", wantPath: "org/repo.git"},
+		{raw: "gitlab.com:group/repo.git", wantSSH: true, wantUser: "git", wantAddr: "gitlab.com:22", wantPath: "group/repo.git"},
+		{raw: "git://github.com/org/repo.git", wantErr: true},
+		{raw: "/srv/git/repo.git", wantErr: true},
+		{raw: "https:///repo.git", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			ep, err := parseGitURL(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", ep)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ep.ssh != tt.wantSSH {
+				t.Fatalf("ssh = %v, want %v", ep.ssh, tt.wantSSH)
+			}
+			if !tt.wantSSH {
+				return
+			}
+			if ep.user != tt.wantUser || ep.addr != tt.wantAddr || ep.path != tt.wantPath {
+				t.Errorf("got user=%q addr=%q path=%q, want %q %q %q", ep.user, ep.addr, ep.path, tt.wantUser, tt.wantAddr, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestKnownHostsCallback(t *testing.T) {
+	key := newTestSSHSigner(t).PublicKey()
+	other := newTestSSHSigner(t).PublicKey()
+	line := func(host string, k ssh.PublicKey) string {
+		return host + " " + string(ssh.MarshalAuthorizedKey(k))
+	}
+	salt := []byte("0123456789abcdef0123")
+	mac := hmac.New(sha1.New, salt)
+	mac.Write([]byte("git.example.com"))
+	hashed := "|1|" + base64.StdEncoding.EncodeToString(salt) + "|" + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name       string
+		knownHosts string
+		hostname   string
+		wantErr    bool
+	}{
+		{name: "plain match", knownHosts: line("git.example.com", key), hostname: "git.example.com:22"},
+		{name: "non-default port", knownHosts: line("[git.example.com]:2222", key), hostname: "git.example.com:2222"},
+		{name: "hashed match", knownHosts: line(hashed, key), hostname: "git.example.com:22"},
+		{name: "different key", knownHosts: line("git.example.com", other), hostname: "git.example.com:22", wantErr: true},
+		{name: "different host", knownHosts: line("other.example.com", key), hostname: "git.example.com:22", wantErr: true},
+		{name: "revoked", knownHosts: line("git.example.com", key) + "@revoked " + line("git.example.com", key), hostname: "git.example.com:22", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := knownHostsCallback([]byte(tt.knownHosts))(tt.hostname, nil, key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGitCheck_SSH(t *testing.T) {
+	hostKey := newTestSSHSigner(t)
+	clientKey, clientPEM := newTestSSHKeyPEM(t)
+
+	addr, commands := startTestGitSSHServer(t, hostKey, clientKey.PublicKey())
+	host, port, _ := net.SplitHostPort(addr)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-ssh", Namespace: "flux-system"},
+		Data: map[string][]byte{
+			"identity":    clientPEM,
+			"known_hosts": []byte(fmt.Sprintf("[%s]:%s %s", host, port, ssh.MarshalAuthorizedKey(hostKey.PublicKey()))),
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(secret).Build()
+
+	result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+		GitCheck: &clustergatev1alpha1.GitCheckSpec{
+			URL:       fmt.Sprintf("ssh://git@%s/org/repo.git", addr),
+			Ref:       "v1.0.0",
+			SecretRef: &clustergatev1alpha1.SecretReference{Name: "git-ssh", Namespace: "flux-system"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Ready {
+		t.Fatalf("expected ready=true, got false: %s", result.Message)
+	}
+	if got := result.Details["commit"]; got != "3333333333333333333333333333333333333333" {
+		t.Errorf("commit = %q", got)
+	}
+	if got := <-commands; got != "git-upload-pack '/org/repo.git'" {
+		t.Errorf("command = %q", got)
+	}
+}
+
+func TestGitCheck_SSHRequiresKnownHosts(t *testing.T) {
+	_, clientPEM := newTestSSHKeyPEM(t)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-ssh", Namespace: "flux-system"},
+		Data:       map[string][]byte{"identity": clientPEM},
+	}
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(secret).Build()
+
+	result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+		GitCheck: &clustergatev1alpha1.GitCheckSpec{
+			URL:       "git@github.com:org/repo.git",
+			SecretRef: &clustergatev1alpha1.SecretReference{Name: "git-ssh", Namespace: "flux-system"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Ready || !strings.Contains(result.Message, "known_hosts") {
+		t.Errorf("expected known_hosts failure, got ready=%v: %s", result.Ready, result.Message)
+	}
+}
+
+func newTestSSHSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+	signer, _ := newTestSSHKeyPEM(t)
+	return signer
+}
+
+func newTestSSHKeyPEM(t *testing.T) (ssh.Signer, []byte) {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, pem.EncodeToMemory(block)
+}
+
+// startTestGitSSHServer serves a fixed ref advertisement to exec requests from the
+// authorized key and reports each executed command.
+func startTestGitSSHServer(t *testing.T, hostKey ssh.Signer, authorized ssh.PublicKey) (string, <-chan string) {
+	t.Helper()
+	cfg := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(authorized.Marshal()) {
+				return nil, fmt.Errorf("unauthorized")
+			}
+			return nil, nil
+		},
+	}
+	cfg.AddHostKey(hostKey)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	commands := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, chans, reqs, err := ssh.NewServerConn(conn, cfg)
+		if err != nil {
+			return
+		}
+		go ssh.DiscardRequests(reqs)
+		for newCh := range chans {
+			ch, chReqs, err := newCh.Accept()
+			if err != nil {
+				return
+			}
+			for req := range chReqs {
+				if req.Type != "exec" {
+					req.Reply(false, nil)
+					continue
+				}
+				var payload struct{ Command string }
+				ssh.Unmarshal(req.Payload, &payload)
+				req.Reply(true, nil)
+				commands <- payload.Command
+				fmt.Fprint(ch, refAdvertisement(testGitRefs...))
+				buf := make([]byte, 4)
+				ch.Read(buf)
+				ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+				ch.Close()
+			}
+		}
+	}()
+	return ln.Addr().String(), commands
+}
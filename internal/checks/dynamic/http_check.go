@@ -2,12 +2,22 @@ package dynamic
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
+	"golang.org/x/net/http/httpproxy"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
 	"github.com/clustergate/clustergate/internal/checks"
 )
@@ -28,9 +38,49 @@ func (e *Executor) executeHTTPCheck(ctx context.Context, spec *clustergatev1alph
 		expectedCodes = []int{http.StatusOK}
 	}
 
-	httpClient := httpClientForSpec(spec.InsecureSkipTLSVerify, timeout)
+	target, err := e.httpTargetURL(ctx, spec)
+	if err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("failed to resolve target: %v", err),
+		}, nil
+	}
+
+	tlsConfig, err := e.tlsConfig(ctx, spec.InsecureSkipTLSVerify, spec.TLS)
+	if err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("invalid TLS configuration: %v", err),
+		}, nil
+	}
+	httpClient := httpClientWithTLS(tlsConfig, timeout)
+	httpClient.CheckRedirect = httpRedirectPolicy(spec)
+	if spec.ResolveOverrides != nil {
+		dial, err := httpDialContext(spec.ResolveOverrides)
+		if err != nil {
+			return checks.Result{
+				Ready:   false,
+				Message: fmt.Sprintf("invalid resolveOverrides: %v", err),
+			}, nil
+		}
+		httpClient.Transport.(*http.Transport).DialContext = dial
+	}
+	if spec.ProxyURL != "" || len(spec.NoProxy) > 0 {
+		proxy, err := httpProxyFunc(spec.ProxyURL, spec.NoProxy)
+		if err != nil {
+			return checks.Result{
+				Ready:   false,
+				Message: fmt.Sprintf("invalid proxy configuration: %v", err),
+			}, nil
+		}
+		httpClient.Transport.(*http.Transport).Proxy = proxy
+	}
 
-	req, err := http.NewRequestWithContext(ctx, method, spec.URL, nil)
+	var body io.Reader
+	if spec.Body != "" {
+		body = strings.NewReader(spec.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
 	if err != nil {
 		return checks.Result{
 			Ready:   false,
@@ -38,9 +88,18 @@ func (e *Executor) executeHTTPCheck(ctx context.Context, spec *clustergatev1alph
 		}, nil
 	}
 
+	if spec.ContentType != "" {
+		req.Header.Set("Content-Type", spec.ContentType)
+	}
 	for k, v := range spec.Headers {
 		req.Header.Set(k, v)
 	}
+	if err := e.applySecretCredentials(ctx, spec, req); err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("failed to resolve credentials: %v", err),
+		}, nil
+	}
 
 	start := time.Now()
 	resp, err := httpClient.Do(req)
@@ -50,7 +109,7 @@ func (e *Executor) executeHTTPCheck(ctx context.Context, spec *clustergatev1alph
 			Ready:   false,
 			Message: fmt.Sprintf("HTTP request failed: %v", err),
 			Details: map[string]string{
-				"url":          spec.URL,
+				"url":          target,
 				"method":       method,
 				"responseTime": elapsed.String(),
 			},
@@ -60,17 +119,20 @@ func (e *Executor) executeHTTPCheck(ctx context.Context, spec *clustergatev1alph
 	io.Copy(io.Discard, resp.Body)
 
 	details := map[string]string{
-		"url":          spec.URL,
+		"url":          target,
 		"method":       method,
 		"statusCode":   fmt.Sprintf("%d", resp.StatusCode),
 		"responseTime": elapsed.String(),
 	}
+	if final := resp.Request.URL.String(); final != target {
+		details["finalURL"] = final
+	}
 
 	for _, code := range expectedCodes {
 		if resp.StatusCode == code {
 			return checks.Result{
 				Ready:   true,
-				Message: fmt.Sprintf("%s %s returned %d", method, spec.URL, resp.StatusCode),
+				Message: fmt.Sprintf("%s %s returned %d", method, target, resp.StatusCode),
 				Details: details,
 			}, nil
 		}
@@ -83,7 +145,209 @@ func (e *Executor) executeHTTPCheck(ctx context.Context, spec *clustergatev1alph
 
 	return checks.Result{
 		Ready:   false,
-		Message: fmt.Sprintf("%s %s returned %d, expected one of [%s]", method, spec.URL, resp.StatusCode, strings.Join(expectedStr, ", ")),
+		Message: fmt.Sprintf("%s %s returned %d, expected one of [%s]", method, target, resp.StatusCode, strings.Join(expectedStr, ", ")),
 		Details: details,
 	}, nil
 }
+
+// httpRedirectPolicy returns the client's CheckRedirect function. Redirects
+// are followed up to maxRedirects (default 10) unless followRedirects is false.
+func httpRedirectPolicy(spec *clustergatev1alpha1.HTTPCheckSpec) func(*http.Request, []*http.Request) error {
+	if spec.FollowRedirects != nil && !*spec.FollowRedirects {
+		return func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	maxRedirects := 10
+	if spec.MaxRedirects != nil {
+		maxRedirects = int(*spec.MaxRedirects)
+	}
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) > maxRedirects {
+			return fmt.Errorf("stopped after %d redirects (maxRedirects)", maxRedirects)
+		}
+		return nil
+	}
+}
+
+// httpTargetURL returns the URL to probe: spec.URL, or the cluster DNS URL of
+// the referenced Service port.
+func (e *Executor) httpTargetURL(ctx context.Context, spec *clustergatev1alpha1.HTTPCheckSpec) (string, error) {
+	ref := spec.ServiceRef
+	if ref == nil {
+		if spec.URL == "" {
+			return "", fmt.Errorf("one of url or serviceRef must be set")
+		}
+		return spec.URL, nil
+	}
+	if spec.URL != "" {
+		return "", fmt.Errorf("url and serviceRef are mutually exclusive")
+	}
+	return e.serviceURL(ctx, ref)
+}
+
+// serviceURL returns the cluster DNS URL of the referenced Service port.
+func (e *Executor) serviceURL(ctx context.Context, ref *clustergatev1alpha1.HTTPServiceReference) (string, error) {
+	var svc corev1.Service
+	if err := e.client.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, &svc); err != nil {
+		return "", fmt.Errorf("failed to get Service %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+
+	var port *corev1.ServicePort
+	for i, sp := range svc.Spec.Ports {
+		if (ref.Port.Type == intstr.String && sp.Name == ref.Port.StrVal) ||
+			(ref.Port.Type == intstr.Int && sp.Port == ref.Port.IntVal) {
+			port = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if port == nil {
+		return "", fmt.Errorf("Service %s/%s has no port %s", ref.Namespace, ref.Name, ref.Port.String())
+	}
+
+	portNumber := port.Port
+	// Headless Services resolve to pod IPs, so the Service port mapping does not apply.
+	if svc.Spec.ClusterIP == corev1.ClusterIPNone && port.TargetPort.Type == intstr.Int && port.TargetPort.IntVal > 0 {
+		portNumber = port.TargetPort.IntVal
+	}
+
+	scheme := ref.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{
+		Scheme: scheme,
+		Host:   net.JoinHostPort(fmt.Sprintf("%s.%s.svc", ref.Name, ref.Namespace), strconv.Itoa(int(portNumber))),
+		Path:   "/" + strings.TrimPrefix(ref.Path, "/"),
+	}
+	return u.String(), nil
+}
+
+// tlsConfig builds the TLS configuration for an HTTP or PromQL check from its
+// CA bundle and client certificate Secrets. It returns nil when the defaults apply.
+func (e *Executor) tlsConfig(ctx context.Context, insecureSkipVerify bool, cfg *clustergatev1alpha1.HTTPTLSConfig) (*tls.Config, error) {
+	if !insecureSkipVerify && cfg == nil {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecureSkipVerify} //nolint:gosec
+	if cfg == nil {
+		return tlsConfig, nil
+	}
+
+	if ref := cfg.CABundleSecretRef; ref != nil {
+		secret, err := e.getSecret(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(secret.Data["ca.crt"]) {
+			return nil, fmt.Errorf("Secret %s has no PEM certificates in key ca.crt", ref.Name)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if ref := cfg.ClientCertSecretRef; ref != nil {
+		secret, err := e.getSecret(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate in Secret %s: %w", ref.Name, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// applySecretCredentials sets the headers and basic auth credentials that the
+// check sources from Secrets.
+func (e *Executor) applySecretCredentials(ctx context.Context, spec *clustergatev1alpha1.HTTPCheckSpec, req *http.Request) error {
+	for _, h := range spec.HeadersFromSecret {
+		secret, err := e.getSecret(ctx, &h.SecretRef)
+		if err != nil {
+			return err
+		}
+		value, ok := secret.Data[h.Key]
+		if !ok {
+			return fmt.Errorf("Secret %s has no key %q for header %s", h.SecretRef.Name, h.Key, h.Name)
+		}
+		req.Header.Set(h.Name, strings.TrimSpace(string(value)))
+	}
+
+	if ref := spec.BasicAuthSecretRef; ref != nil {
+		secret, err := e.getSecret(ctx, ref)
+		if err != nil {
+			return err
+		}
+		username, ok := secret.Data[corev1.BasicAuthUsernameKey]
+		if !ok {
+			return fmt.Errorf("Secret %s has no key %q", ref.Name, corev1.BasicAuthUsernameKey)
+		}
+		req.SetBasicAuth(string(username), string(secret.Data[corev1.BasicAuthPasswordKey]))
+	}
+	return nil
+}
+
+// httpProxyFunc returns a transport proxy function that sends requests through
+// proxyURL, or the environment's proxy when proxyURL is empty, skipping hosts
+// that match noProxy or NO_PROXY.
+func httpProxyFunc(proxyURL string, noProxy []string) (func(*http.Request) (*url.URL, error), error) {
+	cfg := httpproxy.FromEnvironment()
+	if proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("proxyURL %q must be an absolute URL", proxyURL)
+		}
+		cfg.HTTPProxy = proxyURL
+		cfg.HTTPSProxy = proxyURL
+	}
+	if len(noProxy) > 0 {
+		cfg.NoProxy = strings.Join(append([]string{cfg.NoProxy}, noProxy...), ",")
+	}
+	proxy := cfg.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}, nil
+}
+
+// httpDialContext returns a dial function that connects host aliases to their
+// fixed IPs and resolves other hostnames through the configured nameserver.
+func httpDialContext(overrides *clustergatev1alpha1.HTTPResolveOverrides) (func(ctx context.Context, network, addr string) (net.Conn, error), error) {
+	aliases := make(map[string]string, len(overrides.HostAliases))
+	for _, a := range overrides.HostAliases {
+		if net.ParseIP(a.IP) == nil {
+			return nil, fmt.Errorf("host alias %s: %q is not an IP address", a.Hostname, a.IP)
+		}
+		aliases[strings.ToLower(a.Hostname)] = a.IP
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if ns := overrides.Nameserver; ns != "" {
+		if _, _, err := net.SplitHostPort(ns); err != nil {
+			ns = net.JoinHostPort(ns, "53")
+		}
+		dialer.Resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, ns)
+			},
+		}
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		if ip, ok := aliases[strings.ToLower(host)]; ok {
+			addr = net.JoinHostPort(ip, port)
+		}
+		return dialer.DialContext(ctx, network, addr)
+	}, nil
+}
@@ -2,11 +2,28 @@ package dynamic
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
 	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
@@ -176,7 +193,7 @@ func TestPromQLCheck_ResultCountPassing(t *testing.T) {
 		PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
 			Endpoint: srv.URL,
 			Query:    `up{job="etcd"} == 1`,
-			Condition: clustergatev1alpha1.PromQLCondition{
+			Condition: &clustergatev1alpha1.PromQLCondition{
 				Type:      "resultCount",
 				Operator:  "gte",
 				Threshold: 3,
@@ -212,7 +229,7 @@ func TestPromQLCheck_ResultCountFailing(t *testing.T) {
 		PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
 			Endpoint: srv.URL,
 			Query:    "up == 1",
-			Condition: clustergatev1alpha1.PromQLCondition{
+			Condition: &clustergatev1alpha1.PromQLCondition{
 				Type:      "resultCount",
 				Operator:  "gte",
 				Threshold: 3,
@@ -248,7 +265,7 @@ func TestPromQLCheck_ValuePassing(t *testing.T) {
 		PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
 			Endpoint: srv.URL,
 			Query:    "error_rate",
-			Condition: clustergatev1alpha1.PromQLCondition{
+			Condition: &clustergatev1alpha1.PromQLCondition{
 				Type:      "value",
 				Operator:  "lt",
 				Threshold: 0.01,
@@ -284,7 +301,7 @@ func TestPromQLCheck_ValueFailing(t *testing.T) {
 		PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
 			Endpoint: srv.URL,
 			Query:    "error_rate",
-			Condition: clustergatev1alpha1.PromQLCondition{
+			Condition: &clustergatev1alpha1.PromQLCondition{
 				Type:      "value",
 				Operator:  "lt",
 				Threshold: 0.01,
@@ -317,7 +334,7 @@ func TestPromQLCheck_NoResults(t *testing.T) {
 		PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
 			Endpoint: srv.URL,
 			Query:    "up",
-			Condition: clustergatev1alpha1.PromQLCondition{
+			Condition: &clustergatev1alpha1.PromQLCondition{
 				Type:      "value",
 				Operator:  "gte",
 				Threshold: 1,
@@ -343,7 +360,7 @@ func TestPromQLCheck_PrometheusHTTPError(t *testing.T) {
 		PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
 			Endpoint: srv.URL,
 			Query:    "up",
-			Condition: clustergatev1alpha1.PromQLCondition{
+			Condition: &clustergatev1alpha1.PromQLCondition{
 				Type:      "resultCount",
 				Operator:  "gte",
 				Threshold: 1,
@@ -374,7 +391,7 @@ func TestPromQLCheck_PrometheusQueryError(t *testing.T) {
 		PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
 			Endpoint: srv.URL,
 			Query:    "invalid{",
-			Condition: clustergatev1alpha1.PromQLCondition{
+			Condition: &clustergatev1alpha1.PromQLCondition{
 				Type:      "resultCount",
 				Operator:  "gte",
 				Threshold: 1,
@@ -409,7 +426,7 @@ func TestPromQLCheck_UnknownConditionType(t *testing.T) {
 		PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
 			Endpoint: srv.URL,
 			Query:    "up",
-			Condition: clustergatev1alpha1.PromQLCondition{
+			Condition: &clustergatev1alpha1.PromQLCondition{
 				Type:      "invalid_type",
 				Operator:  "gte",
 				Threshold: 1,
@@ -434,3 +451,589 @@ func TestExecutor_NoCheckType(t *testing.T) {
 		t.Error("expected error when no check type specified")
 	}
 }
+
+// newTestClientCert issues a self-signed client certificate and returns it
+// PEM-encoded together with its key and a pool trusting it.
+func newTestClientCert(t *testing.T) (certPEM, keyPEM []byte, pool *x509.CertPool) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "clustergate"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool = x509.NewCertPool()
+	pool.AddCert(cert)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		pool
+}
+
+func TestHTTPCheck_TLS(t *testing.T) {
+	clientCert, clientKey, clientPool := newTestClientCert(t)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientPool}
+	srv.StartTLS()
+	defer srv.Close()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	objs := []client.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "server-ca", Namespace: "default"},
+			Data:       map[string][]byte{"ca.crt": serverCA},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: "default"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{"tls.crt": clientCert, "tls.key": clientKey},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "default"},
+		},
+	}
+	ref := func(name string) *clustergatev1alpha1.SecretReference {
+		return &clustergatev1alpha1.SecretReference{Name: name, Namespace: "default"}
+	}
+
+	tests := []struct {
+		name        string
+		tls         *clustergatev1alpha1.HTTPTLSConfig
+		wantReady   bool
+		wantMessage string
+	}{
+		{
+			name:      "untrusted server",
+			wantReady: false,
+		},
+		{
+			name:      "trusted CA without client cert",
+			tls:       &clustergatev1alpha1.HTTPTLSConfig{CABundleSecretRef: ref("server-ca")},
+			wantReady: false,
+		},
+		{
+			name: "mutual TLS",
+			tls: &clustergatev1alpha1.HTTPTLSConfig{
+				CABundleSecretRef:   ref("server-ca"),
+				ClientCertSecretRef: ref("client-cert"),
+			},
+			wantReady: true,
+		},
+		{
+			name:        "CA secret without certificates",
+			tls:         &clustergatev1alpha1.HTTPTLSConfig{CABundleSecretRef: ref("empty")},
+			wantReady:   false,
+			wantMessage: "invalid TLS configuration",
+		},
+		{
+			name:        "missing client cert secret",
+			tls:         &clustergatev1alpha1.HTTPTLSConfig{ClientCertSecretRef: ref("missing")},
+			wantReady:   false,
+			wantMessage: "default/missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(objs...).Build()
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{
+					URL: srv.URL,
+					TLS: tt.tls,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady {
+				t.Fatalf("ready = %v, want %v: %s", result.Ready, tt.wantReady, result.Message)
+			}
+			if tt.wantMessage != "" && !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("message %q does not contain %q", result.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestHTTPCheck_SecretCredentials(t *testing.T) {
+	var received *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-token", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("Bearer s3cret\n")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "basic", Namespace: "default"},
+			Type:       corev1.SecretTypeBasicAuth,
+			Data: map[string][]byte{
+				corev1.BasicAuthUsernameKey: []byte("admin"),
+				corev1.BasicAuthPasswordKey: []byte("hunter2"),
+			},
+		},
+	).Build()
+
+	tests := []struct {
+		name        string
+		headers     []clustergatev1alpha1.HTTPHeaderFromSecret
+		basicAuth   *clustergatev1alpha1.SecretReference
+		wantReady   bool
+		wantMessage string
+	}{
+		{
+			name: "header from secret overrides plain header",
+			headers: []clustergatev1alpha1.HTTPHeaderFromSecret{{
+				Name:      "Authorization",
+				SecretRef: clustergatev1alpha1.SecretReference{Name: "api-token", Namespace: "default"},
+				Key:       "token",
+			}},
+			wantReady: true,
+		},
+		{
+			name:      "basic auth",
+			basicAuth: &clustergatev1alpha1.SecretReference{Name: "basic", Namespace: "default"},
+			wantReady: true,
+		},
+		{
+			name: "missing key",
+			headers: []clustergatev1alpha1.HTTPHeaderFromSecret{{
+				Name:      "X-Token",
+				SecretRef: clustergatev1alpha1.SecretReference{Name: "api-token", Namespace: "default"},
+				Key:       "missing",
+			}},
+			wantMessage: `has no key "missing"`,
+		},
+		{
+			name:        "missing secret",
+			basicAuth:   &clustergatev1alpha1.SecretReference{Name: "absent", Namespace: "default"},
+			wantMessage: "failed to resolve credentials",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{
+					URL:                srv.URL,
+					Headers:            map[string]string{"Authorization": "plain"},
+					HeadersFromSecret:  tt.headers,
+					BasicAuthSecretRef: tt.basicAuth,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady {
+				t.Fatalf("ready = %v, want %v: %s", result.Ready, tt.wantReady, result.Message)
+			}
+			if !tt.wantReady {
+				if !strings.Contains(result.Message, tt.wantMessage) {
+					t.Errorf("message %q does not contain %q", result.Message, tt.wantMessage)
+				}
+				if received != nil {
+					t.Error("request should not be sent when credentials cannot be resolved")
+				}
+				return
+			}
+			if tt.basicAuth != nil {
+				user, pass, ok := received.BasicAuth()
+				if !ok || user != "admin" || pass != "hunter2" {
+					t.Errorf("basic auth = %q/%q (%v), want admin/hunter2", user, pass, ok)
+				}
+			} else if got := received.Header.Get("Authorization"); got != "Bearer s3cret" {
+				t.Errorf("Authorization = %q, want %q", got, "Bearer s3cret")
+			}
+		})
+	}
+}
+
+func TestHTTPCheck_RequestBody(t *testing.T) {
+	var gotMethod, gotContentType, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotContentType = r.Header.Get("Content-Type")
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).Build()
+	result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+		HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{
+			URL:         srv.URL,
+			Method:      http.MethodPost,
+			Body:        `{"query":"health"}`,
+			ContentType: "application/json",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Ready {
+		t.Fatalf("expected ready, got: %s", result.Message)
+	}
+	if gotMethod != http.MethodPost {
+		t.Errorf("method = %q, want POST", gotMethod)
+	}
+	if gotContentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", gotContentType)
+	}
+	if gotBody != `{"query":"health"}` {
+		t.Errorf("body = %q", gotBody)
+	}
+}
+
+func TestHTTPCheck_Proxy(t *testing.T) {
+	timeout := int32(2)
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute target URL.
+		proxied = append(proxied, r.URL.String())
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	tests := []struct {
+		name        string
+		proxyURL    string
+		noProxy     []string
+		wantReady   bool
+		wantProxied bool
+		wantMessage string
+	}{
+		{
+			name:        "request goes through proxy",
+			proxyURL:    proxy.URL,
+			wantReady:   true,
+			wantProxied: true,
+		},
+		{
+			name:     "noProxy host bypasses proxy",
+			proxyURL: proxy.URL,
+			noProxy:  []string{".invalid"},
+		},
+		{
+			name:        "relative proxy URL is rejected",
+			proxyURL:    "proxy:3128",
+			wantMessage: "invalid proxy configuration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxied = nil
+			c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).Build()
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{
+					URL:            "http://backend.invalid/healthz",
+					ProxyURL:       tt.proxyURL,
+					NoProxy:        tt.noProxy,
+					TimeoutSeconds: &timeout,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady {
+				t.Fatalf("ready = %v, want %v: %s", result.Ready, tt.wantReady, result.Message)
+			}
+			if got := len(proxied) > 0; got != tt.wantProxied {
+				t.Errorf("proxied = %v, want %v", proxied, tt.wantProxied)
+			}
+			if tt.wantMessage != "" && !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("message %q does not contain %q", result.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestHTTPTargetURL_ServiceRef(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: "10.0.0.10",
+				Ports: []corev1.ServicePort{
+					{Name: "api", Port: 8200, TargetPort: intstr.FromInt32(8201)},
+					{Name: "metrics", Port: 9102},
+				},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "db"},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: corev1.ClusterIPNone,
+				Ports:     []corev1.ServicePort{{Name: "client", Port: 80, TargetPort: intstr.FromInt32(2379)}},
+			},
+		},
+	).Build()
+
+	tests := []struct {
+		name    string
+		spec    clustergatev1alpha1.HTTPCheckSpec
+		want    string
+		wantErr string
+	}{
+		{
+			name: "plain url",
+			spec: clustergatev1alpha1.HTTPCheckSpec{URL: "https://example.com/healthz"},
+			want: "https://example.com/healthz",
+		},
+		{
+			name: "named port",
+			spec: clustergatev1alpha1.HTTPCheckSpec{ServiceRef: &clustergatev1alpha1.HTTPServiceReference{
+				Namespace: "vault", Name: "vault", Port: intstr.FromString("api"), Path: "v1/sys/health", Scheme: "https",
+			}},
+			want: "https://vault.vault.svc:8200/v1/sys/health",
+		},
+		{
+			name: "numeric port defaults to http",
+			spec: clustergatev1alpha1.HTTPCheckSpec{ServiceRef: &clustergatev1alpha1.HTTPServiceReference{
+				Namespace: "vault", Name: "vault", Port: intstr.FromInt32(9102), Path: "/metrics",
+			}},
+			want: "http://vault.vault.svc:9102/metrics",
+		},
+		{
+			name: "headless service uses target port",
+			spec: clustergatev1alpha1.HTTPCheckSpec{ServiceRef: &clustergatev1alpha1.HTTPServiceReference{
+				Namespace: "db", Name: "etcd", Port: intstr.FromString("client"), Path: "/health",
+			}},
+			want: "http://etcd.db.svc:2379/health",
+		},
+		{
+			name: "unknown port",
+			spec: clustergatev1alpha1.HTTPCheckSpec{ServiceRef: &clustergatev1alpha1.HTTPServiceReference{
+				Namespace: "vault", Name: "vault", Port: intstr.FromInt32(443),
+			}},
+			wantErr: "has no port 443",
+		},
+		{
+			name: "missing service",
+			spec: clustergatev1alpha1.HTTPCheckSpec{ServiceRef: &clustergatev1alpha1.HTTPServiceReference{
+				Namespace: "vault", Name: "absent", Port: intstr.FromInt32(80),
+			}},
+			wantErr: "failed to get Service vault/absent",
+		},
+		{
+			name: "url and serviceRef",
+			spec: clustergatev1alpha1.HTTPCheckSpec{URL: "http://x", ServiceRef: &clustergatev1alpha1.HTTPServiceReference{
+				Namespace: "vault", Name: "vault", Port: intstr.FromInt32(9102),
+			}},
+			wantErr: "mutually exclusive",
+		},
+		{
+			name:    "neither url nor serviceRef",
+			spec:    clustergatev1alpha1.HTTPCheckSpec{},
+			wantErr: "one of url or serviceRef",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTestExecutor(c).httpTargetURL(context.Background(), &tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("url = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// serveDNS answers every A query with 127.0.0.1 and every other query with no
+// records, returning the resolver address.
+func serveDNS(t *testing.T) string {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { pc.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := pc.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			query := buf[:n]
+			// Skip the header and question name to find the query type.
+			end := 12
+			for end < n && query[end] != 0 {
+				end += int(query[end]) + 1
+			}
+			end += 5
+			if end > n {
+				continue
+			}
+			qtype := binary.BigEndian.Uint16(query[end-4 : end-2])
+
+			resp := append([]byte{}, query[:end]...)
+			resp[2], resp[3] = 0x81, 0x80 // response, recursion available, no error
+			resp[6], resp[7] = 0, 0       // answer count
+			resp[8], resp[9], resp[10], resp[11] = 0, 0, 0, 0
+			if qtype == 1 {
+				resp[7] = 1
+				resp = append(resp, 0xc0, 0x0c, 0, 1, 0, 1, 0, 0, 0, 60, 0, 4, 127, 0, 0, 1)
+			}
+			pc.WriteTo(resp, addr)
+		}
+	}()
+	return pc.LocalAddr().String()
+}
+
+func TestHTTPCheck_ResolveOverrides(t *testing.T) {
+	var gotHost string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost = r.Host
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	_, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+
+	tests := []struct {
+		name        string
+		overrides   *clustergatev1alpha1.HTTPResolveOverrides
+		wantReady   bool
+		wantMessage string
+	}{
+		{
+			name: "host alias",
+			overrides: &clustergatev1alpha1.HTTPResolveOverrides{
+				HostAliases: []clustergatev1alpha1.HTTPHostAlias{{Hostname: "Internal.Example", IP: "127.0.0.1"}},
+			},
+			wantReady: true,
+		},
+		{
+			name:      "nameserver",
+			overrides: &clustergatev1alpha1.HTTPResolveOverrides{Nameserver: serveDNS(t)},
+			wantReady: true,
+		},
+		{
+			name: "invalid alias IP",
+			overrides: &clustergatev1alpha1.HTTPResolveOverrides{
+				HostAliases: []clustergatev1alpha1.HTTPHostAlias{{Hostname: "internal.example", IP: "not-an-ip"}},
+			},
+			wantMessage: "invalid resolveOverrides",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotHost = ""
+			c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).Build()
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{
+					URL:              "http://internal.example:" + port + "/healthz",
+					ResolveOverrides: tt.overrides,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady {
+				t.Fatalf("ready = %v, want %v: %s", result.Ready, tt.wantReady, result.Message)
+			}
+			if tt.wantReady && gotHost != "internal.example:"+port {
+				t.Errorf("Host = %q, want the original hostname", gotHost)
+			}
+			if tt.wantMessage != "" && !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("message %q does not contain %q", result.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestHTTPCheck_Redirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	boolPtr := func(b bool) *bool { return &b }
+	int32Ptr := func(i int32) *int32 { return &i }
+
+	tests := []struct {
+		name          string
+		path          string
+		follow        *bool
+		maxRedirects  *int32
+		expectedCodes []int
+		wantReady     bool
+		wantMessage   string
+	}{
+		{name: "follows by default", path: "/moved", wantReady: true},
+		{name: "redirect loop fails", path: "/loop", wantMessage: "stopped after 10 redirects"},
+		{name: "maxRedirects 0 rejects any redirect", path: "/moved", maxRedirects: int32Ptr(0), wantMessage: "stopped after 0 redirects"},
+		{name: "not following reports the 302", path: "/moved", follow: boolPtr(false), wantMessage: "returned 302"},
+		{name: "302 can be expected", path: "/moved", follow: boolPtr(false), expectedCodes: []int{http.StatusFound}, wantReady: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).Build()
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{
+					URL:                 srv.URL + tt.path,
+					FollowRedirects:     tt.follow,
+					MaxRedirects:        tt.maxRedirects,
+					ExpectedStatusCodes: tt.expectedCodes,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady {
+				t.Fatalf("ready = %v, want %v: %s", result.Ready, tt.wantReady, result.Message)
+			}
+			if tt.wantMessage != "" && !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("message %q does not contain %q", result.Message, tt.wantMessage)
+			}
+		})
+	}
+}
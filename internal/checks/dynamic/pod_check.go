@@ -3,9 +3,15 @@ package dynamic
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -14,6 +20,10 @@ import (
 )
 
 func (e *Executor) executePodCheck(ctx context.Context, spec *clustergatev1alpha1.PodCheckSpec) (checks.Result, error) {
+	if spec.DaemonSetRef != "" {
+		return e.executeDaemonSetCheck(ctx, spec)
+	}
+
 	selector, err := convertLabelSelector(spec.LabelSelector)
 	if err != nil {
 		return checks.Result{}, fmt.Errorf("invalid label selector: %w", err)
@@ -30,35 +40,373 @@ func (e *Executor) executePodCheck(ctx context.Context, spec *clustergatev1alpha
 		}, nil
 	}
 
+	now := time.Now()
 	readyCount := int32(0)
 	for _, pod := range podList.Items {
-		if pod.Status.Phase == corev1.PodRunning && isPodReady(&pod) {
+		if podAvailable(&pod, spec.MinReadySeconds, now) {
 			readyCount++
 		}
 	}
 
+	minReady := spec.MinReady
+	if spec.MinReadyPercent != nil {
+		base := int64(len(podList.Items))
+		if spec.PercentOf != nil {
+			base, err = e.workloadReplicas(ctx, spec.Namespace, spec.PercentOf)
+			if err != nil {
+				return checks.Result{
+					Ready:   false,
+					Message: fmt.Sprintf("failed to read desired replicas of %s/%s: %v", spec.PercentOf.Kind, spec.PercentOf.Name, err),
+				}, nil
+			}
+		}
+		// Round up so that e.g. 50% of 3 replicas requires 2.
+		if required := int32((base*int64(*spec.MinReadyPercent) + 99) / 100); required > minReady {
+			minReady = required
+		}
+	}
+
 	details := map[string]string{
 		"namespace": spec.Namespace,
 		"totalPods": fmt.Sprintf("%d", len(podList.Items)),
 		"readyPods": fmt.Sprintf("%d", readyCount),
-		"minReady":  fmt.Sprintf("%d", spec.MinReady),
+		"minReady":  fmt.Sprintf("%d", minReady),
+	}
+	var initBlocked, ephemeralFailed []string
+	for _, pod := range podList.Items {
+		if status := initContainerStatus(&pod); status != "" {
+			initBlocked = append(initBlocked, fmt.Sprintf("%s (%s)", pod.Name, status))
+		}
+		for _, failure := range ephemeralContainerFailures(&pod) {
+			ephemeralFailed = append(ephemeralFailed, fmt.Sprintf("%s (%s)", pod.Name, failure))
+		}
+	}
+	if len(initBlocked) > 0 {
+		details["initBlockedPods"] = capSeries(initBlocked)
+	}
+	if len(ephemeralFailed) > 0 {
+		details["failedEphemeralContainers"] = capSeries(ephemeralFailed)
+	}
+
+	if spec.ExpectedImage != "" || spec.ExpectedImageRegex != "" {
+		var imageRegex *regexp.Regexp
+		if spec.ExpectedImageRegex != "" {
+			imageRegex, err = regexp.Compile(spec.ExpectedImageRegex)
+			if err != nil {
+				return checks.Result{
+					Ready:   false,
+					Message: fmt.Sprintf("invalid expectedImageRegex: %v", err),
+				}, nil
+			}
+		}
+		var stale []string
+		for _, pod := range podList.Items {
+			if !podRunsImage(&pod, spec.ExpectedImage, imageRegex) {
+				stale = append(stale, pod.Name)
+			}
+		}
+		if len(stale) > 0 {
+			details["staleImagePods"] = capSeries(stale)
+			return checks.Result{
+				Ready:   false,
+				Message: fmt.Sprintf("%d/%d pods not running the expected image: %s", len(stale), len(podList.Items), details["staleImagePods"]),
+				Details: details,
+			}, nil
+		}
+	}
+
+	var restartMsg string
+	if spec.MaxRestarts != nil {
+		restartMsg = e.checkPodRestarts(spec, podList.Items, details)
+	}
+
+	if readyCount < minReady {
+		msg := fmt.Sprintf("only %d/%d pods ready, need at least %d", readyCount, len(podList.Items), minReady)
+		if len(initBlocked) > 0 {
+			msg += fmt.Sprintf("; %d blocked in init containers: %s", len(initBlocked), details["initBlockedPods"])
+		}
+		return checks.Result{
+			Ready:   false,
+			Message: msg,
+			Details: details,
+		}, nil
+	}
+
+	if spec.MinReadyPerZone > 0 {
+		spreadMsg, err := e.checkPodSpread(ctx, spec, podList.Items, details, now)
+		if err != nil {
+			return checks.Result{
+				Ready:   false,
+				Message: fmt.Sprintf("failed to list nodes: %v", err),
+				Details: details,
+			}, nil
+		}
+		if spreadMsg != "" {
+			return checks.Result{
+				Ready:   false,
+				Message: spreadMsg,
+				Details: details,
+			}, nil
+		}
 	}
 
-	if readyCount >= spec.MinReady {
+	if restartMsg != "" {
 		return checks.Result{
-			Ready:   true,
-			Message: fmt.Sprintf("%d/%d pods ready (minimum %d)", readyCount, len(podList.Items), spec.MinReady),
+			Ready:   false,
+			Message: restartMsg,
 			Details: details,
 		}, nil
 	}
 
 	return checks.Result{
-		Ready:   false,
-		Message: fmt.Sprintf("only %d/%d pods ready, need at least %d", readyCount, len(podList.Items), spec.MinReady),
+		Ready:   true,
+		Message: fmt.Sprintf("%d/%d pods ready (minimum %d)", readyCount, len(podList.Items), minReady),
+		Details: details,
+	}, nil
+}
+
+// executeDaemonSetCheck compares the ready pods of spec.DaemonSetRef with the
+// number of nodes it should run on.
+func (e *Executor) executeDaemonSetCheck(ctx context.Context, spec *clustergatev1alpha1.PodCheckSpec) (checks.Result, error) {
+	var ds appsv1.DaemonSet
+	if err := e.client.Get(ctx, client.ObjectKey{Namespace: spec.Namespace, Name: spec.DaemonSetRef}, &ds); err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("failed to get DaemonSet %s/%s: %v", spec.Namespace, spec.DaemonSetRef, err),
+		}, nil
+	}
+
+	desired := ds.Status.DesiredNumberScheduled
+	required := desired
+	if spec.MinReadyPercent != nil {
+		required = int32((int64(desired)*int64(*spec.MinReadyPercent) + 99) / 100)
+	}
+	details := map[string]string{
+		"namespace":    spec.Namespace,
+		"daemonSet":    ds.Name,
+		"desired":      fmt.Sprintf("%d", desired),
+		"ready":        fmt.Sprintf("%d", ds.Status.NumberReady),
+		"updated":      fmt.Sprintf("%d", ds.Status.UpdatedNumberScheduled),
+		"available":    fmt.Sprintf("%d", ds.Status.NumberAvailable),
+		"misscheduled": fmt.Sprintf("%d", ds.Status.NumberMisscheduled),
+		"minReady":     fmt.Sprintf("%d", required),
+	}
+
+	if ds.Status.ObservedGeneration < ds.Generation {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("DaemonSet %s has not observed generation %d yet", ds.Name, ds.Generation),
+			Details: details,
+		}, nil
+	}
+	if ds.Status.NumberReady < required {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("only %d/%d DaemonSet %s pods ready, need at least %d", ds.Status.NumberReady, desired, ds.Name, required),
+			Details: details,
+		}, nil
+	}
+	return checks.Result{
+		Ready:   true,
+		Message: fmt.Sprintf("%d/%d DaemonSet %s pods ready", ds.Status.NumberReady, desired, ds.Name),
 		Details: details,
 	}, nil
 }
 
+// workloadReplicas returns the desired replicas of the workload ref in namespace.
+func (e *Executor) workloadReplicas(ctx context.Context, namespace string, ref *clustergatev1alpha1.WorkloadReference) (int64, error) {
+	apiVersion := ref.APIVersion
+	if apiVersion == "" {
+		apiVersion = "apps/v1"
+	}
+	workload := &unstructured.Unstructured{}
+	workload.SetAPIVersion(apiVersion)
+	workload.SetKind(ref.Kind)
+	workload.SetNamespace(namespace)
+	workload.SetName(ref.Name)
+	return e.desiredReplicas(ctx, workload)
+}
+
+// checkPodSpread counts the ready pods in each failure domain of
+// spec.SpreadByTopologyKey, records the counts in details and returns a
+// failure message naming the domains below spec.MinReadyPerZone.
+func (e *Executor) checkPodSpread(ctx context.Context, spec *clustergatev1alpha1.PodCheckSpec, pods []corev1.Pod, details map[string]string, now time.Time) (string, error) {
+	key := spec.SpreadByTopologyKey
+	if key == "" {
+		key = corev1.LabelTopologyZone
+	}
+	var nodes corev1.NodeList
+	if err := e.client.List(ctx, &nodes, client.HasLabels{key}); err != nil {
+		return "", err
+	}
+	nodeDomain := make(map[string]string, len(nodes.Items))
+	perDomain := make(map[string]int32)
+	for _, node := range nodes.Items {
+		domain := node.Labels[key]
+		nodeDomain[node.Name] = domain
+		if _, ok := perDomain[domain]; !ok {
+			perDomain[domain] = 0
+		}
+	}
+	if len(perDomain) == 0 {
+		return fmt.Sprintf("no nodes labeled %s", key), nil
+	}
+	for _, pod := range pods {
+		domain, ok := nodeDomain[pod.Spec.NodeName]
+		if ok && podAvailable(&pod, spec.MinReadySeconds, now) {
+			perDomain[domain]++
+		}
+	}
+
+	domains := make([]string, 0, len(perDomain))
+	for domain := range perDomain {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	counts := make([]string, 0, len(domains))
+	var short []string
+	for _, domain := range domains {
+		counts = append(counts, fmt.Sprintf("%s=%d", domain, perDomain[domain]))
+		if perDomain[domain] < spec.MinReadyPerZone {
+			short = append(short, fmt.Sprintf("%s (%d)", domain, perDomain[domain]))
+		}
+	}
+	details["readyPerZone"] = strings.Join(counts, ",")
+	if len(short) == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("fewer than %d ready pods per %s in %s", spec.MinReadyPerZone, key, strings.Join(short, ", ")), nil
+}
+
+// checkPodRestarts records the worst restarting pod in details and returns a
+// failure message when any pod exceeds spec.MaxRestarts, or "" otherwise.
+func (e *Executor) checkPodRestarts(spec *clustergatev1alpha1.PodCheckSpec, pods []corev1.Pod, details map[string]string) string {
+	now := time.Now()
+	var (
+		offenders    int
+		worst        string
+		worstRestart int32 = -1
+	)
+	for _, pod := range pods {
+		var count int32
+		for _, cs := range pod.Status.ContainerStatuses {
+			count += cs.RestartCount
+		}
+		restarts := count
+		if spec.RestartWindow != nil {
+			restarts = e.restarts.observe(pod.UID, pod.CreationTimestamp.Time, count, spec.RestartWindow.Duration, now)
+		}
+		if restarts > *spec.MaxRestarts {
+			offenders++
+		}
+		if restarts > worstRestart {
+			worst, worstRestart = pod.Name, restarts
+		}
+	}
+	if worst == "" {
+		return ""
+	}
+
+	details["worstPod"] = worst
+	details["worstPodRestarts"] = fmt.Sprintf("%d", worstRestart)
+	if offenders == 0 {
+		return ""
+	}
+	within := ""
+	if spec.RestartWindow != nil {
+		within = " within " + spec.RestartWindow.Duration.String()
+	}
+	return fmt.Sprintf("%d pods restarted more than %d times%s, worst %s with %d restarts",
+		offenders, *spec.MaxRestarts, within, worst, worstRestart)
+}
+
+// podRunsImage reports whether any of pod's containers runs image (when set)
+// and matches imageRegex (when set), judged by the container statuses.
+func podRunsImage(pod *corev1.Pod, image string, imageRegex *regexp.Regexp) bool {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if image != "" && !imageMatches(cs, image) {
+			continue
+		}
+		if imageRegex != nil && !imageRegex.MatchString(cs.Image) && !imageRegex.MatchString(cs.ImageID) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// imageMatches compares a container status with an expected image reference,
+// by digest when the reference is pinned to one.
+func imageMatches(cs corev1.ContainerStatus, image string) bool {
+	if _, digest, ok := strings.Cut(image, "@"); ok {
+		return strings.HasSuffix(cs.ImageID, "@"+digest)
+	}
+	return normalizeImage(cs.Image) == normalizeImage(image)
+}
+
+// normalizeImage expands an image reference to the fully qualified form
+// container runtimes report, e.g. nginx:1.25 to docker.io/library/nginx:1.25.
+func normalizeImage(image string) string {
+	domain, _, ok := strings.Cut(image, "/")
+	if !ok || (!strings.ContainsAny(domain, ".:") && domain != "localhost") {
+		if !ok {
+			image = "library/" + image
+		}
+		image = "docker.io/" + image
+	}
+	return image
+}
+
+// initContainerStatus returns the status of pod's first failing init
+// container in kubectl's notation (e.g. Init:CrashLoopBackOff), or "" when
+// its init containers completed or are still running.
+func initContainerStatus(pod *corev1.Pod) string {
+	for _, cs := range pod.Status.InitContainerStatuses {
+		switch {
+		case cs.State.Terminated != nil && cs.State.Terminated.ExitCode == 0:
+			continue
+		case cs.State.Waiting != nil && cs.State.Waiting.Reason != "" && cs.State.Waiting.Reason != "PodInitializing":
+			return "Init:" + cs.State.Waiting.Reason
+		case cs.State.Terminated != nil:
+			return "Init:Error"
+		}
+	}
+	return ""
+}
+
+// ephemeralContainerFailures describes pod's ephemeral debug containers that
+// exited with an error or cannot start.
+func ephemeralContainerFailures(pod *corev1.Pod) []string {
+	var failures []string
+	for _, cs := range pod.Status.EphemeralContainerStatuses {
+		switch {
+		case cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0:
+			failures = append(failures, fmt.Sprintf("%s: exit code %d", cs.Name, cs.State.Terminated.ExitCode))
+		case cs.State.Waiting != nil && strings.HasSuffix(cs.State.Waiting.Reason, "BackOff"):
+			failures = append(failures, fmt.Sprintf("%s: %s", cs.Name, cs.State.Waiting.Reason))
+		}
+	}
+	return failures
+}
+
+// podAvailable reports whether pod is running and has been Ready for at least
+// minReadySeconds at now.
+func podAvailable(pod *corev1.Pod, minReadySeconds int32, now time.Time) bool {
+	if pod.Status.Phase != corev1.PodRunning || !isPodReady(pod) {
+		return false
+	}
+	if minReadySeconds <= 0 {
+		return true
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return !cond.LastTransitionTime.IsZero() &&
+				now.Sub(cond.LastTransitionTime.Time) >= time.Duration(minReadySeconds)*time.Second
+		}
+	}
+	return false
+}
+
 func isPodReady(pod *corev1.Pod) bool {
 	for _, cond := range pod.Status.Conditions {
 		if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
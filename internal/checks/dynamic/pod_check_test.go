@@ -2,17 +2,24 @@ package dynamic
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
 )
@@ -188,3 +195,394 @@ func TestPodCheck_NilLabelSelector(t *testing.T) {
 		t.Errorf("expected ready=true with nil selector matching all pods: %s", result.Message)
 	}
 }
+
+func TestPodCheck_MaxRestarts(t *testing.T) {
+	labels := map[string]string{"app": "api"}
+	restarting := func(name string, restarts ...int32) *corev1.Pod {
+		pod := readyPod(name, "apps", labels)
+		for i, n := range restarts {
+			pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+				Name: fmt.Sprintf("c%d", i), RestartCount: n,
+			})
+		}
+		return pod
+	}
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).
+		WithObjects(restarting("api-1", 0), restarting("api-2", 4, 3), restarting("api-3", 6)).Build()
+	maxRestarts := func(n int32) *int32 { return &n }
+
+	tests := []struct {
+		name        string
+		maxRestarts int32
+		wantReady   bool
+		wantMessage string
+	}{
+		{name: "under threshold", maxRestarts: 7, wantReady: true},
+		{name: "over threshold", maxRestarts: 5, wantMessage: "2 pods restarted more than 5 times, worst api-2 with 7 restarts"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				PodCheck: &clustergatev1alpha1.PodCheckSpec{
+					Namespace:     "apps",
+					LabelSelector: &metav1.LabelSelector{MatchLabels: labels},
+					MinReady:      3,
+					MaxRestarts:   maxRestarts(tt.maxRestarts),
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady || (tt.wantMessage != "" && result.Message != tt.wantMessage) {
+				t.Errorf("result = %v %q, want %v %q", result.Ready, result.Message, tt.wantReady, tt.wantMessage)
+			}
+			if result.Details["worstPod"] != "api-2" || result.Details["worstPodRestarts"] != "7" {
+				t.Errorf("details = %v, want worst pod api-2 with 7 restarts", result.Details)
+			}
+		})
+	}
+}
+
+func TestRestartTracker(t *testing.T) {
+	var tracker restartTracker
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := time.Hour
+
+	// A long-running pod's history before its first observation is ignored.
+	if got := tracker.observe("old", start.Add(-48*time.Hour), 40, window, start); got != 0 {
+		t.Errorf("first observation of an old pod = %d, want 0", got)
+	}
+	if got := tracker.observe("old", time.Time{}, 42, window, start.Add(30*time.Minute)); got != 2 {
+		t.Errorf("restarts after 30m = %d, want 2", got)
+	}
+	if got := tracker.observe("old", time.Time{}, 43, window, start.Add(50*time.Minute)); got != 3 {
+		t.Errorf("restarts after 50m = %d, want 3", got)
+	}
+	// Restarts drop out of the window as it slides past them.
+	if got := tracker.observe("old", time.Time{}, 43, window, start.Add(100*time.Minute)); got != 1 {
+		t.Errorf("restarts after 100m = %d, want 1", got)
+	}
+	if got := tracker.observe("old", time.Time{}, 43, window, start.Add(3*time.Hour)); got != 0 {
+		t.Errorf("restarts after 3h = %d, want 0", got)
+	}
+
+	// A pod created within the window counts its full history.
+	if got := tracker.observe("new", start.Add(-10*time.Minute), 5, window, start); got != 5 {
+		t.Errorf("first observation of a new pod = %d, want 5", got)
+	}
+
+	// Pods not seen within the retention are forgotten.
+	tracker.observe("other", time.Time{}, 0, window, start.Add(restartHistoryRetention+4*time.Hour))
+	if _, ok := tracker.pods["old"]; ok {
+		t.Error("pod unseen for longer than the retention was not pruned")
+	}
+}
+
+func TestPodCheck_MinReadyPerZone(t *testing.T) {
+	labels := map[string]string{"app": "etcd"}
+	node := func(name, zone string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{corev1.LabelTopologyZone: zone}}}
+	}
+	scheduled := func(pod *corev1.Pod, nodeName string) *corev1.Pod {
+		pod.Spec.NodeName = nodeName
+		return pod
+	}
+	objs := []client.Object{
+		node("node-a", "zone-a"), node("node-b", "zone-b"), node("node-c", "zone-c"),
+		scheduled(readyPod("etcd-0", "kube-system", labels), "node-a"),
+		scheduled(readyPod("etcd-1", "kube-system", labels), "node-b"),
+		scheduled(runningButNotReadyPod("etcd-2", "kube-system", labels), "node-c"),
+	}
+
+	tests := []struct {
+		name        string
+		objs        []client.Object
+		wantReady   bool
+		wantMessage string
+	}{
+		{
+			name:        "zone without ready pods",
+			objs:        objs,
+			wantMessage: "fewer than 1 ready pods per topology.kubernetes.io/zone in zone-c (0)",
+		},
+		{
+			name:      "every zone covered",
+			objs:      append(objs[:len(objs)-1:len(objs)-1], scheduled(readyPod("etcd-2", "kube-system", labels), "node-c")),
+			wantReady: true,
+		},
+		{
+			name:        "no labeled nodes",
+			objs:        objs[3:],
+			wantMessage: "no nodes labeled topology.kubernetes.io/zone",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(tt.objs...).Build()
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				PodCheck: &clustergatev1alpha1.PodCheckSpec{
+					Namespace:       "kube-system",
+					LabelSelector:   &metav1.LabelSelector{MatchLabels: labels},
+					MinReady:        2,
+					MinReadyPerZone: 1,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady || (tt.wantMessage != "" && result.Message != tt.wantMessage) {
+				t.Errorf("result = %v %q, want %v %q", result.Ready, result.Message, tt.wantReady, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestPodCheck_ExpectedImage(t *testing.T) {
+	labels := map[string]string{"app": "api"}
+	withImage := func(name, image, imageID string) *corev1.Pod {
+		pod := readyPod(name, "apps", labels)
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+			{Name: "istio-proxy", Image: "docker.io/istio/proxyv2:1.22.0"},
+			{Name: "api", Image: image, ImageID: imageID},
+		}
+		return pod
+	}
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(
+		withImage("api-1", "docker.io/library/api:v2", "docker.io/library/api@sha256:bbb"),
+		withImage("api-2", "docker.io/library/api:v1", "docker.io/library/api@sha256:aaa"),
+	).Build()
+
+	tests := []struct {
+		name        string
+		image       string
+		regex       string
+		wantReady   bool
+		wantMessage string
+	}{
+		{name: "stalled rollout", image: "api:v2", wantMessage: "1/2 pods not running the expected image: api-2"},
+		{name: "digest", image: "registry.example.com/api@sha256:aaa", wantMessage: "1/2 pods not running the expected image: api-1"},
+		{name: "regex", regex: `/api:v[12]$`, wantReady: true},
+		{name: "invalid regex", regex: `(`, wantMessage: "invalid expectedImageRegex"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				PodCheck: &clustergatev1alpha1.PodCheckSpec{
+					Namespace:          "apps",
+					LabelSelector:      &metav1.LabelSelector{MatchLabels: labels},
+					MinReady:           2,
+					ExpectedImage:      tt.image,
+					ExpectedImageRegex: tt.regex,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady || !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("result = %v %q, want %v %q", result.Ready, result.Message, tt.wantReady, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestNormalizeImage(t *testing.T) {
+	for image, want := range map[string]string{
+		"nginx:1.25":                    "docker.io/library/nginx:1.25",
+		"bitnami/redis:7":               "docker.io/bitnami/redis:7",
+		"quay.io/jetstack/cert-manager": "quay.io/jetstack/cert-manager",
+		"localhost/dev:latest":          "localhost/dev:latest",
+		"registry:5000/team/app:1":      "registry:5000/team/app:1",
+		"docker.io/library/nginx:1.25":  "docker.io/library/nginx:1.25",
+	} {
+		if got := normalizeImage(image); got != want {
+			t.Errorf("normalizeImage(%q) = %q, want %q", image, got, want)
+		}
+	}
+}
+
+func TestPodCheck_MinReadyPercent(t *testing.T) {
+	labels := map[string]string{"app": "web"}
+	objs := []client.Object{
+		readyPod("web-1", "shop", labels),
+		readyPod("web-2", "shop", labels),
+		runningButNotReadyPod("web-3", "shop", labels),
+		runningButNotReadyPod("web-4", "shop", labels),
+	}
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(objs...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceGet: func(_ context.Context, _ client.Client, _ string, obj client.Object, scale client.Object, _ ...client.SubResourceGetOption) error {
+				if obj.GetName() != "web" || obj.GetObjectKind().GroupVersionKind().Kind != "Deployment" {
+					return apierrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, obj.GetName())
+				}
+				return unstructured.SetNestedField(scale.(*unstructured.Unstructured).Object, int64(3), "spec", "replicas")
+			},
+		}).Build()
+	percent := func(n int32) *int32 { return &n }
+
+	tests := []struct {
+		name        string
+		percent     int32
+		percentOf   *clustergatev1alpha1.WorkloadReference
+		wantReady   bool
+		wantMessage string
+	}{
+		{name: "half of matching pods", percent: 50, wantReady: true, wantMessage: "2/4 pods ready (minimum 2)"},
+		{name: "most of matching pods", percent: 75, wantMessage: "only 2/4 pods ready, need at least 3"},
+		{
+			name:        "rounded up against desired replicas",
+			percent:     50,
+			percentOf:   &clustergatev1alpha1.WorkloadReference{Kind: "Deployment", Name: "web"},
+			wantReady:   true,
+			wantMessage: "2/4 pods ready (minimum 2)",
+		},
+		{
+			name:        "all desired replicas",
+			percent:     100,
+			percentOf:   &clustergatev1alpha1.WorkloadReference{Kind: "Deployment", Name: "web"},
+			wantMessage: "only 2/4 pods ready, need at least 3",
+		},
+		{
+			name:        "missing workload",
+			percent:     100,
+			percentOf:   &clustergatev1alpha1.WorkloadReference{Kind: "StatefulSet", Name: "web"},
+			wantMessage: "failed to read desired replicas of StatefulSet/web",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				PodCheck: &clustergatev1alpha1.PodCheckSpec{
+					Namespace:       "shop",
+					LabelSelector:   &metav1.LabelSelector{MatchLabels: labels},
+					MinReady:        1,
+					MinReadyPercent: percent(tt.percent),
+					PercentOf:       tt.percentOf,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady || !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("result = %v %q, want %v %q", result.Ready, result.Message, tt.wantReady, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestPodCheck_MinReadySeconds(t *testing.T) {
+	labels := map[string]string{"app": "api"}
+	readySince := func(name string, age time.Duration) *corev1.Pod {
+		pod := readyPod(name, "apps", labels)
+		pod.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now().Add(-age))
+		return pod
+	}
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(
+		readySince("api-1", time.Hour),
+		readySince("api-2", 10*time.Second),
+		readyPod("api-3", "apps", labels),
+	).Build()
+
+	result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+		PodCheck: &clustergatev1alpha1.PodCheckSpec{
+			Namespace:       "apps",
+			LabelSelector:   &metav1.LabelSelector{MatchLabels: labels},
+			MinReady:        2,
+			MinReadySeconds: 60,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Ready || result.Message != "only 1/3 pods ready, need at least 2" {
+		t.Errorf("result = %v %q, want only the pod ready for an hour counted", result.Ready, result.Message)
+	}
+}
+
+func TestPodCheck_InitAndEphemeralContainers(t *testing.T) {
+	labels := map[string]string{"app": "db"}
+	initLooping := runningButNotReadyPod("db-0", "data", labels)
+	initLooping.Status.Phase = corev1.PodPending
+	initLooping.Status.InitContainerStatuses = []corev1.ContainerStatus{
+		{Name: "migrate", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}},
+		{Name: "wait-for-schema", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+	}
+	debugged := readyPod("db-1", "data", labels)
+	debugged.Status.EphemeralContainerStatuses = []corev1.ContainerStatus{
+		{Name: "debugger", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137}}},
+	}
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(initLooping, debugged).Build()
+
+	result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+		PodCheck: &clustergatev1alpha1.PodCheckSpec{
+			Namespace:     "data",
+			LabelSelector: &metav1.LabelSelector{MatchLabels: labels},
+			MinReady:      2,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantMessage := "only 1/2 pods ready, need at least 2; 1 blocked in init containers: db-0 (Init:CrashLoopBackOff)"
+	if result.Ready || result.Message != wantMessage {
+		t.Errorf("result = %v %q, want %q", result.Ready, result.Message, wantMessage)
+	}
+	if got := result.Details["initBlockedPods"]; got != "db-0 (Init:CrashLoopBackOff)" {
+		t.Errorf("initBlockedPods = %q", got)
+	}
+	if got := result.Details["failedEphemeralContainers"]; got != "db-1 (debugger: exit code 137)" {
+		t.Errorf("failedEphemeralContainers = %q", got)
+	}
+}
+
+func TestPodCheck_DaemonSetRef(t *testing.T) {
+	daemonSet := func(name string, generation, observed int64, desired, ready int32) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kube-system", Generation: generation},
+			Status: appsv1.DaemonSetStatus{
+				ObservedGeneration:     observed,
+				DesiredNumberScheduled: desired,
+				NumberReady:            ready,
+				UpdatedNumberScheduled: ready,
+				NumberAvailable:        ready,
+			},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(
+		daemonSet("kube-proxy", 1, 1, 10, 10),
+		daemonSet("cilium", 1, 1, 10, 8),
+		daemonSet("node-exporter", 3, 2, 10, 10),
+	).Build()
+	percent := int32(80)
+
+	tests := []struct {
+		name        string
+		daemonSet   string
+		percent     *int32
+		wantReady   bool
+		wantMessage string
+	}{
+		{name: "fully covered", daemonSet: "kube-proxy", wantReady: true, wantMessage: "10/10 DaemonSet kube-proxy pods ready"},
+		{name: "missing nodes", daemonSet: "cilium", wantMessage: "only 8/10 DaemonSet cilium pods ready, need at least 10"},
+		{name: "percentage", daemonSet: "cilium", percent: &percent, wantReady: true, wantMessage: "8/10 DaemonSet cilium pods ready"},
+		{name: "generation not observed", daemonSet: "node-exporter", wantMessage: "DaemonSet node-exporter has not observed generation 3 yet"},
+		{name: "not found", daemonSet: "calico-node", wantMessage: "failed to get DaemonSet kube-system/calico-node"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				PodCheck: &clustergatev1alpha1.PodCheckSpec{
+					Namespace:       "kube-system",
+					DaemonSetRef:    tt.daemonSet,
+					MinReady:        1,
+					MinReadyPercent: tt.percent,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady || !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("result = %v %q, want %v %q", result.Ready, result.Message, tt.wantReady, tt.wantMessage)
+			}
+		})
+	}
+}
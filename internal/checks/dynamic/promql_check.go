@@ -7,9 +7,16 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"text/template"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
 	"github.com/clustergate/clustergate/internal/checks"
 )
@@ -37,19 +44,43 @@ func (e *Executor) executePromQLCheck(ctx context.Context, spec *clustergatev1al
 		timeout = time.Duration(*spec.TimeoutSeconds) * time.Second
 	}
 
-	httpClient := httpClientForSpec(false, timeout)
+	tlsConfig, err := e.tlsConfig(ctx, spec.InsecureSkipTLSVerify, spec.TLS)
+	if err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("invalid TLS configuration: %v", err),
+		}, nil
+	}
+	httpClient := httpClientWithTLS(tlsConfig, timeout)
+
+	endpoint, err := e.promQLEndpoint(ctx, spec)
+	if err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("failed to resolve Prometheus endpoint: %v", err),
+		}, nil
+	}
+
+	query, err := e.renderPromQLQuery(ctx, spec)
+	if err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("failed to render query: %v", err),
+		}, nil
+	}
 
 	// Build Prometheus query URL
-	queryURL, err := url.Parse(spec.Endpoint)
+	queryURL, err := url.Parse(endpoint)
 	if err != nil {
 		return checks.Result{
 			Ready:   false,
 			Message: fmt.Sprintf("invalid Prometheus endpoint URL: %v", err),
 		}, nil
 	}
-	queryURL.Path = "/api/v1/query"
+	// Keep any path prefix, e.g. Mimir's "/prometheus".
+	queryURL.Path = strings.TrimSuffix(queryURL.Path, "/") + "/api/v1/query"
 	params := url.Values{}
-	params.Set("query", spec.Query)
+	params.Set("query", query)
 	queryURL.RawQuery = params.Encode()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL.String(), nil)
@@ -60,14 +91,27 @@ func (e *Executor) executePromQLCheck(ctx context.Context, spec *clustergatev1al
 		}, nil
 	}
 
+	if err := e.applyCredentials(ctx, spec.BearerTokenSecretRef, spec.BasicAuthSecretRef, req); err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("failed to resolve credentials: %v", err),
+		}, nil
+	}
+	if err := e.applyPromQLTenant(ctx, spec.Tenant, req); err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("failed to resolve tenant: %v", err),
+		}, nil
+	}
+
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return checks.Result{
 			Ready:   false,
 			Message: fmt.Sprintf("Prometheus query failed: %v", err),
 			Details: map[string]string{
-				"endpoint": spec.Endpoint,
-				"query":    spec.Query,
+				"endpoint": endpoint,
+				"query":    query,
 			},
 		}, nil
 	}
@@ -86,8 +130,8 @@ func (e *Executor) executePromQLCheck(ctx context.Context, spec *clustergatev1al
 			Ready:   false,
 			Message: fmt.Sprintf("Prometheus returned HTTP %d: %s", resp.StatusCode, string(body)),
 			Details: map[string]string{
-				"endpoint":   spec.Endpoint,
-				"query":      spec.Query,
+				"endpoint":   endpoint,
+				"query":      query,
 				"statusCode": fmt.Sprintf("%d", resp.StatusCode),
 			},
 		}, nil
@@ -106,8 +150,8 @@ func (e *Executor) executePromQLCheck(ctx context.Context, spec *clustergatev1al
 			Ready:   false,
 			Message: fmt.Sprintf("Prometheus query error: %s (%s)", promResp.Error, promResp.ErrorType),
 			Details: map[string]string{
-				"endpoint": spec.Endpoint,
-				"query":    spec.Query,
+				"endpoint": endpoint,
+				"query":    query,
 			},
 		}, nil
 	}
@@ -115,78 +159,352 @@ func (e *Executor) executePromQLCheck(ctx context.Context, spec *clustergatev1al
 	resultCount := len(promResp.Data.Result)
 
 	details := map[string]string{
-		"endpoint":    spec.Endpoint,
-		"query":       spec.Query,
+		"endpoint":    endpoint,
+		"query":       query,
 		"resultCount": fmt.Sprintf("%d", resultCount),
 		"resultType":  promResp.Data.ResultType,
 	}
 
-	switch spec.Condition.Type {
-	case "resultCount":
-		pass := compareFloat64(float64(resultCount), spec.Condition.Operator, spec.Condition.Threshold)
+	conditions, err := promQLConditions(spec)
+	if err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: err.Error(),
+		}, nil
+	}
+	value := promQLValue(conditions, promResp.Data.Result)
+	if len(conditions) == 1 {
+		pass, message, failingSeries := evaluatePromQLCondition(conditions[0], promResp.Data.Result)
+		if failingSeries != "" {
+			details["failingSeries"] = failingSeries
+		}
+		return checks.Result{Ready: pass, Message: message, Details: details, Value: value}, nil
+	}
+
+	var passed, failed []string
+	for i, cond := range conditions {
+		pass, message, failingSeries := evaluatePromQLCondition(cond, promResp.Data.Result)
+		if failingSeries != "" {
+			details[fmt.Sprintf("conditions[%d].failingSeries", i)] = failingSeries
+		}
 		if pass {
+			passed = append(passed, message)
+		} else {
+			failed = append(failed, message)
+		}
+	}
+
+	if spec.ConditionsMatch == "any" {
+		if len(passed) > 0 {
 			return checks.Result{
 				Ready:   true,
-				Message: fmt.Sprintf("query returned %d results (%s %s %.0f)", resultCount, "resultCount", spec.Condition.Operator, spec.Condition.Threshold),
+				Message: fmt.Sprintf("%d of %d conditions met: %s", len(passed), len(conditions), strings.Join(passed, "; ")),
 				Details: details,
+				Value:   value,
 			}, nil
 		}
 		return checks.Result{
 			Ready:   false,
-			Message: fmt.Sprintf("query returned %d results, expected %s %.0f", resultCount, spec.Condition.Operator, spec.Condition.Threshold),
+			Message: fmt.Sprintf("no condition met: %s", strings.Join(failed, "; ")),
 			Details: details,
+			Value:   value,
 		}, nil
+	}
+
+	if len(failed) == 0 {
+		return checks.Result{
+			Ready:   true,
+			Message: fmt.Sprintf("all %d conditions met: %s", len(conditions), strings.Join(passed, "; ")),
+			Details: details,
+			Value:   value,
+		}, nil
+	}
+	return checks.Result{
+		Ready:   false,
+		Message: fmt.Sprintf("%d of %d conditions failed: %s", len(failed), len(conditions), strings.Join(failed, "; ")),
+		Details: details,
+		Value:   value,
+	}, nil
+}
+
+// promQLTemplateData is the data available to PromQL query templates.
+type promQLTemplateData struct {
+	ClusterName string
+	Values      map[string]string
+}
+
+// renderPromQLQuery expands the query template with the cluster name and the
+// VariablesFrom ConfigMap. Queries without template actions are returned as is.
+func (e *Executor) renderPromQLQuery(ctx context.Context, spec *clustergatev1alpha1.PromQLCheckSpec) (string, error) {
+	if !strings.Contains(spec.Query, "{{") {
+		return spec.Query, nil
+	}
+
+	data := promQLTemplateData{ClusterName: e.clusterName, Values: map[string]string{}}
+	if ref := spec.VariablesFrom; ref != nil {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = e.namespace
+		}
+		var cm corev1.ConfigMap
+		if err := e.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &cm); err != nil {
+			return "", fmt.Errorf("failed to get ConfigMap %s/%s: %w", namespace, ref.Name, err)
+		}
+		data.Values = cm.Data
+	}
+
+	tmpl, err := template.New("query").Option("missingkey=error").Parse(spec.Query)
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// prometheusOperatedService is the Service the Prometheus Operator creates in
+// front of every Prometheus instance in a namespace.
+const prometheusOperatedService = "prometheus-operated"
+
+// promQLEndpoint returns the Prometheus URL to query: spec.Endpoint, the URL of
+// spec.ServiceRef, or the discovered Prometheus Operator Service.
+func (e *Executor) promQLEndpoint(ctx context.Context, spec *clustergatev1alpha1.PromQLCheckSpec) (string, error) {
+	switch {
+	case spec.Endpoint != "" && spec.ServiceRef != nil:
+		return "", fmt.Errorf("endpoint and serviceRef are mutually exclusive")
+	case spec.Endpoint != "":
+		return spec.Endpoint, nil
+	case spec.ServiceRef != nil:
+		return e.serviceURL(ctx, spec.ServiceRef)
+	case e.prometheusNamespace == "":
+		return "", fmt.Errorf("one of endpoint or serviceRef must be set when Prometheus discovery is disabled")
+	default:
+		return e.serviceURL(ctx, &clustergatev1alpha1.HTTPServiceReference{
+			Namespace: e.prometheusNamespace,
+			Name:      prometheusOperatedService,
+			Port:      intstr.FromString("web"),
+		})
+	}
+}
+
+// promQLConditions returns the conditions to evaluate: the single Condition or
+// the Conditions list.
+func promQLConditions(spec *clustergatev1alpha1.PromQLCheckSpec) ([]clustergatev1alpha1.PromQLCondition, error) {
+	switch {
+	case spec.Condition != nil && len(spec.Conditions) > 0:
+		return nil, fmt.Errorf("condition and conditions are mutually exclusive")
+	case spec.Condition != nil:
+		return []clustergatev1alpha1.PromQLCondition{*spec.Condition}, nil
+	case len(spec.Conditions) > 0:
+		return spec.Conditions, nil
+	default:
+		return nil, fmt.Errorf("one of condition or conditions must be specified")
+	}
+}
+
+// evaluatePromQLCondition evaluates one condition against the query result and
+// describes the outcome. For value and mustBeEmpty conditions it also returns
+// the series that failed, capped to maxFailingSeries, or "" when none did.
+func evaluatePromQLCondition(cond clustergatev1alpha1.PromQLCondition, result []json.RawMessage) (bool, string, string) {
+	resultCount := len(result)
+
+	switch cond.Type {
+	case "resultCount":
+		if compareFloat64(float64(resultCount), cond.Operator, cond.Threshold) {
+			return true, fmt.Sprintf("query returned %d results (%s %s %.0f)", resultCount, "resultCount", cond.Operator, cond.Threshold), ""
+		}
+		return false, fmt.Sprintf("query returned %d results, expected %s %.0f", resultCount, cond.Operator, cond.Threshold), ""
 
 	case "value":
 		if resultCount == 0 {
-			return checks.Result{
-				Ready:   false,
-				Message: "query returned no results to evaluate",
-				Details: details,
-			}, nil
+			return false, "query returned no results to evaluate", ""
 		}
 
 		// Parse sample values
-		var failedValues []string
-		allPass := true
-		for _, raw := range promResp.Data.Result {
+		var failedSeries []string
+		for _, raw := range result {
+			metric, val, ok := parseSample(raw)
+			if !ok {
+				continue
+			}
+			if !compareFloat64(val, cond.Operator, cond.Threshold) {
+				failedSeries = append(failedSeries, fmt.Sprintf("%s=%.4f", formatSeries(metric), val))
+			}
+		}
+
+		if len(failedSeries) == 0 {
+			return true, fmt.Sprintf("all %d sample values satisfy %s %.4f", resultCount, cond.Operator, cond.Threshold), ""
+		}
+		series := capSeries(failedSeries)
+		return false, fmt.Sprintf("%d values failed condition %s %.4f: %s", len(failedSeries), cond.Operator, cond.Threshold, series), series
+
+	case "mustBeEmpty":
+		if resultCount == 0 {
+			return true, "query returned no results", ""
+		}
+		var series []string
+		for _, raw := range result {
 			var sample promQLSample
 			if err := json.Unmarshal(raw, &sample); err != nil {
 				continue
 			}
-			valStr, ok := sample.Value[1].(string)
+			series = append(series, formatSeries(sample.Metric))
+		}
+		found := capSeries(series)
+		return false, fmt.Sprintf("query returned %d results, expected none: %s", resultCount, found), found
+
+	default:
+		return false, fmt.Sprintf("unknown condition type: %s", cond.Type), ""
+	}
+}
+
+// parseSample returns the labels and value of a vector sample.
+func parseSample(raw json.RawMessage) (map[string]string, float64, bool) {
+	var sample promQLSample
+	if err := json.Unmarshal(raw, &sample); err != nil {
+		return nil, 0, false
+	}
+	valStr, ok := sample.Value[1].(string)
+	if !ok {
+		return nil, 0, false
+	}
+	val, err := strconv.ParseFloat(valStr, 64)
+	if err != nil {
+		return nil, 0, false
+	}
+	return sample.Metric, val, true
+}
+
+// promQLValue returns the value tracked by the first value condition: the
+// sample furthest from passing (the lowest for gte and gt, the highest for lte
+// and lt, the first mismatch for eq), or nil without a value condition or samples.
+func promQLValue(conditions []clustergatev1alpha1.PromQLCondition, result []json.RawMessage) *float64 {
+	for _, cond := range conditions {
+		if cond.Type != "value" {
+			continue
+		}
+		var value *float64
+		for _, raw := range result {
+			_, val, ok := parseSample(raw)
 			if !ok {
 				continue
 			}
-			val, err := strconv.ParseFloat(valStr, 64)
-			if err != nil {
-				continue
-			}
-			if !compareFloat64(val, spec.Condition.Operator, spec.Condition.Threshold) {
-				allPass = false
-				failedValues = append(failedValues, fmt.Sprintf("%.4f", val))
+			if value == nil || worseValue(val, *value, cond) {
+				v := val
+				value = &v
 			}
 		}
+		return value
+	}
+	return nil
+}
 
-		if allPass {
-			return checks.Result{
-				Ready:   true,
-				Message: fmt.Sprintf("all %d sample values satisfy %s %.4f", resultCount, spec.Condition.Operator, spec.Condition.Threshold),
-				Details: details,
-			}, nil
+// worseValue reports whether val is further from satisfying cond than current.
+func worseValue(val, current float64, cond clustergatev1alpha1.PromQLCondition) bool {
+	switch cond.Operator {
+	case "gte", "gt":
+		return val < current
+	case "lte", "lt":
+		return val > current
+	default:
+		return current == cond.Threshold && val != cond.Threshold
+	}
+}
+
+// maxFailingSeries caps the number of failing series named in a result.
+const maxFailingSeries = 5
+
+// formatSeries renders a series in PromQL notation, e.g.
+// etcd_server_has_leader{instance="10.0.0.1:2379"}.
+func formatSeries(metric map[string]string) string {
+	labels := make([]string, 0, len(metric))
+	for name, value := range metric {
+		if name != "__name__" {
+			labels = append(labels, fmt.Sprintf("%s=%q", name, value))
 		}
-		return checks.Result{
-			Ready:   false,
-			Message: fmt.Sprintf("%d values failed condition %s %.4f", len(failedValues), spec.Condition.Operator, spec.Condition.Threshold),
-			Details: details,
-		}, nil
+	}
+	sort.Strings(labels)
+	return metric["__name__"] + "{" + strings.Join(labels, ",") + "}"
+}
 
-	default:
-		return checks.Result{
-			Ready:   false,
-			Message: fmt.Sprintf("unknown condition type: %s", spec.Condition.Type),
-		}, nil
+// capSeries joins at most maxFailingSeries series, noting how many were left out.
+func capSeries(series []string) string {
+	if len(series) <= maxFailingSeries {
+		return strings.Join(series, ", ")
+	}
+	return strings.Join(series[:maxFailingSeries], ", ") + fmt.Sprintf(" (+%d more)", len(series)-maxFailingSeries)
+}
+
+// applyCredentials sets the bearer token or basic auth credentials that a
+// PromQL or Alertmanager check sources from Secrets.
+func (e *Executor) applyCredentials(ctx context.Context, bearerTokenRef, basicAuthRef *clustergatev1alpha1.SecretReference, req *http.Request) error {
+	if bearerTokenRef != nil && basicAuthRef != nil {
+		return fmt.Errorf("bearerTokenSecretRef and basicAuthSecretRef are mutually exclusive")
+	}
+
+	if ref := bearerTokenRef; ref != nil {
+		secret, err := e.getSecret(ctx, ref)
+		if err != nil {
+			return err
+		}
+		token, ok := secret.Data["token"]
+		if !ok {
+			return fmt.Errorf("Secret %s has no key %q", ref.Name, "token")
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	if ref := basicAuthRef; ref != nil {
+		secret, err := e.getSecret(ctx, ref)
+		if err != nil {
+			return err
+		}
+		username, ok := secret.Data[corev1.BasicAuthUsernameKey]
+		if !ok {
+			return fmt.Errorf("Secret %s has no key %q", ref.Name, corev1.BasicAuthUsernameKey)
+		}
+		req.SetBasicAuth(string(username), string(secret.Data[corev1.BasicAuthPasswordKey]))
+	}
+	return nil
+}
+
+// defaultTenantHeader is the tenant header of Cortex and Mimir.
+const defaultTenantHeader = "X-Scope-OrgID"
+
+// applyPromQLTenant sets the tenant header from the literal ID or the Secret key.
+func (e *Executor) applyPromQLTenant(ctx context.Context, tenant *clustergatev1alpha1.PromQLTenant, req *http.Request) error {
+	if tenant == nil {
+		return nil
+	}
+	if (tenant.ID == "") == (tenant.SecretRef == nil) {
+		return fmt.Errorf("exactly one of tenant.id or tenant.secretRef must be set")
+	}
+
+	id := tenant.ID
+	if ref := tenant.SecretRef; ref != nil {
+		secret, err := e.getSecret(ctx, ref)
+		if err != nil {
+			return err
+		}
+		key := tenant.Key
+		if key == "" {
+			key = "tenant"
+		}
+		value, ok := secret.Data[key]
+		if !ok {
+			return fmt.Errorf("Secret %s has no key %q", ref.Name, key)
+		}
+		id = strings.TrimSpace(string(value))
+	}
+
+	header := tenant.Header
+	if header == "" {
+		header = defaultTenantHeader
 	}
+	req.Header.Set(header, id)
+	return nil
 }
 
 // compareFloat64 evaluates a comparison between two float64 values.
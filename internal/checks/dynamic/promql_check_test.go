@@ -1,6 +1,22 @@
 package dynamic
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
 
 func TestCompareFloat64(t *testing.T) {
 	tests := []struct {
@@ -43,3 +59,600 @@ func TestCompareFloat64(t *testing.T) {
 		})
 	}
 }
+
+func TestPromQLCheck_Authentication(t *testing.T) {
+	var received *http.Request
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		if r.URL.Path != "/prometheus/api/v1/query" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "success",
+			"data":   map[string]interface{}{"resultType": "vector", "result": []interface{}{}},
+		})
+	}))
+	defer srv.Close()
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "prom-ca", Namespace: "monitoring"},
+			Data:       map[string][]byte{"ca.crt": caPEM},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "prom-token", Namespace: "monitoring"},
+			Data:       map[string][]byte{"token": []byte("s3cret\n")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "prom-basic", Namespace: "monitoring"},
+			Type:       corev1.SecretTypeBasicAuth,
+			Data: map[string][]byte{
+				corev1.BasicAuthUsernameKey: []byte("grafana"),
+				corev1.BasicAuthPasswordKey: []byte("hunter2"),
+			},
+		},
+	).Build()
+	ref := func(name string) *clustergatev1alpha1.SecretReference {
+		return &clustergatev1alpha1.SecretReference{Name: name, Namespace: "monitoring"}
+	}
+	tlsConfig := &clustergatev1alpha1.HTTPTLSConfig{CABundleSecretRef: ref("prom-ca")}
+
+	tests := []struct {
+		name        string
+		bearer      *clustergatev1alpha1.SecretReference
+		basicAuth   *clustergatev1alpha1.SecretReference
+		tls         *clustergatev1alpha1.HTTPTLSConfig
+		wantAuth    string
+		wantMessage string
+	}{
+		{
+			name:     "bearer token",
+			bearer:   ref("prom-token"),
+			tls:      tlsConfig,
+			wantAuth: "Bearer s3cret",
+		},
+		{
+			name:      "basic auth",
+			basicAuth: ref("prom-basic"),
+			tls:       tlsConfig,
+			wantAuth:  "Basic Z3JhZmFuYTpodW50ZXIy",
+		},
+		{
+			name:        "untrusted certificate",
+			bearer:      ref("prom-token"),
+			wantMessage: "Prometheus query failed",
+		},
+		{
+			name:        "bearer and basic auth are exclusive",
+			bearer:      ref("prom-token"),
+			basicAuth:   ref("prom-basic"),
+			tls:         tlsConfig,
+			wantMessage: "mutually exclusive",
+		},
+		{
+			name:        "missing token key",
+			bearer:      ref("prom-basic"),
+			tls:         tlsConfig,
+			wantMessage: `has no key "token"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
+					Endpoint:             srv.URL + "/prometheus/",
+					Query:                "up",
+					Condition:            &clustergatev1alpha1.PromQLCondition{Type: "resultCount", Operator: "eq", Threshold: 0},
+					BearerTokenSecretRef: tt.bearer,
+					BasicAuthSecretRef:   tt.basicAuth,
+					TLS:                  tt.tls,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantMessage != "" {
+				if result.Ready || !strings.Contains(result.Message, tt.wantMessage) {
+					t.Errorf("result = %v %q, want failure containing %q", result.Ready, result.Message, tt.wantMessage)
+				}
+				return
+			}
+			if !result.Ready {
+				t.Fatalf("expected ready=true: %s", result.Message)
+			}
+			if got := received.Header.Get("Authorization"); got != tt.wantAuth {
+				t.Errorf("Authorization = %q, want %q", got, tt.wantAuth)
+			}
+		})
+	}
+}
+
+func TestPromQLCheck_MultipleConditions(t *testing.T) {
+	srv := promQLServer(t, http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data": map[string]interface{}{
+			"resultType": "vector",
+			"result": []interface{}{
+				map[string]interface{}{"metric": map[string]string{"instance": "a"}, "value": []interface{}{1.0, "1"}},
+				map[string]interface{}{"metric": map[string]string{"instance": "b"}, "value": []interface{}{1.0, "1"}},
+				map[string]interface{}{"metric": map[string]string{"instance": "c"}, "value": []interface{}{1.0, "0"}},
+			},
+		},
+	})
+	defer srv.Close()
+
+	atLeastThree := clustergatev1alpha1.PromQLCondition{Type: "resultCount", Operator: "gte", Threshold: 3}
+	allOne := clustergatev1alpha1.PromQLCondition{Type: "value", Operator: "eq", Threshold: 1}
+	atLeastTwo := clustergatev1alpha1.PromQLCondition{Type: "resultCount", Operator: "gte", Threshold: 2}
+
+	tests := []struct {
+		name        string
+		condition   *clustergatev1alpha1.PromQLCondition
+		conditions  []clustergatev1alpha1.PromQLCondition
+		match       string
+		wantReady   bool
+		wantMessage string
+	}{
+		{
+			name:        "all fails when one condition fails",
+			conditions:  []clustergatev1alpha1.PromQLCondition{atLeastThree, allOne},
+			wantMessage: "1 of 2 conditions failed: 1 values failed condition eq 1.0000",
+		},
+		{
+			name:        "all passes when every condition passes",
+			conditions:  []clustergatev1alpha1.PromQLCondition{atLeastThree, atLeastTwo},
+			wantReady:   true,
+			wantMessage: "all 2 conditions met",
+		},
+		{
+			name:        "any passes when one condition passes",
+			conditions:  []clustergatev1alpha1.PromQLCondition{allOne, atLeastThree},
+			match:       "any",
+			wantReady:   true,
+			wantMessage: "1 of 2 conditions met: query returned 3 results",
+		},
+		{
+			name:        "any fails when no condition passes",
+			conditions:  []clustergatev1alpha1.PromQLCondition{allOne},
+			match:       "any",
+			wantMessage: "1 values failed condition",
+		},
+		{
+			name:        "condition and conditions are exclusive",
+			condition:   &atLeastThree,
+			conditions:  []clustergatev1alpha1.PromQLCondition{allOne},
+			wantMessage: "mutually exclusive",
+		},
+		{
+			name:        "no condition",
+			wantMessage: "one of condition or conditions must be specified",
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).Build()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
+					Endpoint:        srv.URL,
+					Query:           "up",
+					Condition:       tt.condition,
+					Conditions:      tt.conditions,
+					ConditionsMatch: tt.match,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady {
+				t.Errorf("ready = %v, want %v: %s", result.Ready, tt.wantReady, result.Message)
+			}
+			if !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("message %q does not contain %q", result.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestPromQLCheck_FailingSeries(t *testing.T) {
+	var result []interface{}
+	for i := 1; i <= 7; i++ {
+		value := "0"
+		if i == 1 {
+			value = "1"
+		}
+		result = append(result, map[string]interface{}{
+			"metric": map[string]string{"__name__": "etcd_server_has_leader", "instance": fmt.Sprintf("etcd-%d", i), "job": "etcd"},
+			"value":  []interface{}{1.0, value},
+		})
+	}
+	srv := promQLServer(t, http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   map[string]interface{}{"resultType": "vector", "result": result},
+	})
+	defer srv.Close()
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).Build()
+	res, err := newTestExecutor(c).Execute(context.Background(), "etcd-leader", clustergatev1alpha1.GateCheckSpec{
+		PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
+			Endpoint:  srv.URL,
+			Query:     "etcd_server_has_leader",
+			Condition: &clustergatev1alpha1.PromQLCondition{Type: "value", Operator: "eq", Threshold: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Ready {
+		t.Fatal("expected ready=false")
+	}
+
+	wantSeries := `etcd_server_has_leader{instance="etcd-2",job="etcd"}=0.0000, ` +
+		`etcd_server_has_leader{instance="etcd-3",job="etcd"}=0.0000, ` +
+		`etcd_server_has_leader{instance="etcd-4",job="etcd"}=0.0000, ` +
+		`etcd_server_has_leader{instance="etcd-5",job="etcd"}=0.0000, ` +
+		`etcd_server_has_leader{instance="etcd-6",job="etcd"}=0.0000 (+1 more)`
+	if got := res.Details["failingSeries"]; got != wantSeries {
+		t.Errorf("failingSeries = %q, want %q", got, wantSeries)
+	}
+	if want := "6 values failed condition eq 1.0000: " + wantSeries; res.Message != want {
+		t.Errorf("message = %q, want %q", res.Message, want)
+	}
+}
+
+func TestPromQLEndpoint(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "prometheus-operated", Namespace: "monitoring"},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: corev1.ClusterIPNone,
+				Ports:     []corev1.ServicePort{{Name: "web", Port: 9090, TargetPort: intstr.FromString("web")}},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "thanos-query", Namespace: "thanos"},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: "10.0.0.20",
+				Ports:     []corev1.ServicePort{{Name: "http", Port: 10902}},
+			},
+		},
+	).Build()
+
+	tests := []struct {
+		name                string
+		spec                clustergatev1alpha1.PromQLCheckSpec
+		prometheusNamespace string
+		want                string
+		wantErr             string
+	}{
+		{
+			name: "endpoint",
+			spec: clustergatev1alpha1.PromQLCheckSpec{Endpoint: "http://prometheus:9090"},
+			want: "http://prometheus:9090",
+		},
+		{
+			name: "service reference",
+			spec: clustergatev1alpha1.PromQLCheckSpec{ServiceRef: &clustergatev1alpha1.HTTPServiceReference{
+				Namespace: "thanos", Name: "thanos-query", Port: intstr.FromString("http"),
+			}},
+			want: "http://thanos-query.thanos.svc:10902/",
+		},
+		{
+			name:                "discovered prometheus operator service",
+			prometheusNamespace: "monitoring",
+			want:                "http://prometheus-operated.monitoring.svc:9090/",
+		},
+		{
+			name:                "no prometheus in the discovery namespace",
+			prometheusNamespace: "observability",
+			wantErr:             "failed to get Service observability/prometheus-operated",
+		},
+		{
+			name:    "discovery disabled",
+			wantErr: "one of endpoint or serviceRef must be set",
+		},
+		{
+			name: "endpoint and serviceRef",
+			spec: clustergatev1alpha1.PromQLCheckSpec{
+				Endpoint:   "http://prometheus:9090",
+				ServiceRef: &clustergatev1alpha1.HTTPServiceReference{Namespace: "thanos", Name: "thanos-query", Port: intstr.FromString("http")},
+			},
+			wantErr: "mutually exclusive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := newTestExecutor(c)
+			WithPrometheusNamespace(tt.prometheusNamespace)(executor)
+			got, err := executor.promQLEndpoint(context.Background(), &tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("endpoint = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromQLCheck_MustBeEmpty(t *testing.T) {
+	tests := []struct {
+		name        string
+		result      []interface{}
+		wantReady   bool
+		wantMessage string
+	}{
+		{
+			name:        "no series",
+			result:      []interface{}{},
+			wantReady:   true,
+			wantMessage: "query returned no results",
+		},
+		{
+			name: "firing alerts",
+			result: []interface{}{
+				map[string]interface{}{
+					"metric": map[string]string{"__name__": "ALERTS", "alertname": "EtcdNoLeader", "severity": "critical"},
+					"value":  []interface{}{1.0, "1"},
+				},
+			},
+			wantMessage: `query returned 1 results, expected none: ALERTS{alertname="EtcdNoLeader",severity="critical"}`,
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).Build()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := promQLServer(t, http.StatusOK, map[string]interface{}{
+				"status": "success",
+				"data":   map[string]interface{}{"resultType": "vector", "result": tt.result},
+			})
+			defer srv.Close()
+
+			result, err := newTestExecutor(c).Execute(context.Background(), "no-critical-alerts", clustergatev1alpha1.GateCheckSpec{
+				PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
+					Endpoint:  srv.URL,
+					Query:     `ALERTS{severity="critical",alertstate="firing"}`,
+					Condition: &clustergatev1alpha1.PromQLCondition{Type: "mustBeEmpty"},
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady || result.Message != tt.wantMessage {
+				t.Errorf("result = %v %q, want %v %q", result.Ready, result.Message, tt.wantReady, tt.wantMessage)
+			}
+			if !tt.wantReady && result.Details["failingSeries"] == "" {
+				t.Error("expected the returned series in failingSeries")
+			}
+		})
+	}
+}
+
+func TestPromQLCheck_Tenant(t *testing.T) {
+	var received *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "success",
+			"data":   map[string]interface{}{"resultType": "vector", "result": []interface{}{}},
+		})
+	}))
+	defer srv.Close()
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "mimir-tenant", Namespace: "monitoring"},
+			Data:       map[string][]byte{"tenant": []byte("team-a\n"), "org": []byte("org-7")},
+		},
+	).Build()
+	secretRef := &clustergatev1alpha1.SecretReference{Name: "mimir-tenant", Namespace: "monitoring"}
+
+	tests := []struct {
+		name        string
+		tenant      *clustergatev1alpha1.PromQLTenant
+		wantHeader  string
+		wantValue   string
+		wantMessage string
+	}{
+		{
+			name:       "literal id",
+			tenant:     &clustergatev1alpha1.PromQLTenant{ID: "fleet-eu"},
+			wantHeader: "X-Scope-OrgID",
+			wantValue:  "fleet-eu",
+		},
+		{
+			name:       "id from secret",
+			tenant:     &clustergatev1alpha1.PromQLTenant{SecretRef: secretRef},
+			wantHeader: "X-Scope-OrgID",
+			wantValue:  "team-a",
+		},
+		{
+			name:       "custom key and header",
+			tenant:     &clustergatev1alpha1.PromQLTenant{SecretRef: secretRef, Key: "org", Header: "THANOS-TENANT"},
+			wantHeader: "THANOS-TENANT",
+			wantValue:  "org-7",
+		},
+		{
+			name:        "id and secretRef are exclusive",
+			tenant:      &clustergatev1alpha1.PromQLTenant{ID: "fleet-eu", SecretRef: secretRef},
+			wantMessage: "exactly one of tenant.id or tenant.secretRef",
+		},
+		{
+			name:        "missing key",
+			tenant:      &clustergatev1alpha1.PromQLTenant{SecretRef: secretRef, Key: "absent"},
+			wantMessage: `has no key "absent"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
+					Endpoint:  srv.URL,
+					Query:     "up",
+					Condition: &clustergatev1alpha1.PromQLCondition{Type: "resultCount", Operator: "eq", Threshold: 0},
+					Tenant:    tt.tenant,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantMessage != "" {
+				if result.Ready || !strings.Contains(result.Message, tt.wantMessage) {
+					t.Errorf("result = %v %q, want failure containing %q", result.Ready, result.Message, tt.wantMessage)
+				}
+				if received != nil {
+					t.Error("expected no request to be sent")
+				}
+				return
+			}
+			if !result.Ready {
+				t.Fatalf("expected ready=true: %s", result.Message)
+			}
+			if got := received.Header.Get(tt.wantHeader); got != tt.wantValue {
+				t.Errorf("%s = %q, want %q", tt.wantHeader, got, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestPromQLCheck_QueryTemplate(t *testing.T) {
+	var received string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.URL.Query().Get("query")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "success",
+			"data":   map[string]interface{}{"resultType": "vector", "result": []interface{}{}},
+		})
+	}))
+	defer srv.Close()
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "fleet-vars", Namespace: "clustergate-system"},
+			Data:       map[string]string{"region": "eu-west-1"},
+		},
+	).Build()
+
+	tests := []struct {
+		name          string
+		query         string
+		variablesFrom *clustergatev1alpha1.ConfigMapReference
+		wantQuery     string
+		wantMessage   string
+	}{
+		{
+			name:      "cluster name",
+			query:     `up{job="etcd",cluster="{{ .ClusterName }}"}`,
+			wantQuery: `up{job="etcd",cluster="prod-eu-1"}`,
+		},
+		{
+			name:          "configmap values",
+			query:         `up{cluster="{{ .ClusterName }}",region="{{ .Values.region }}"}`,
+			variablesFrom: &clustergatev1alpha1.ConfigMapReference{Name: "fleet-vars"},
+			wantQuery:     `up{cluster="prod-eu-1",region="eu-west-1"}`,
+		},
+		{
+			name:      "no template actions",
+			query:     `up{job=~"etcd|apiserver"}`,
+			wantQuery: `up{job=~"etcd|apiserver"}`,
+		},
+		{
+			name:          "unknown value",
+			query:         `up{zone="{{ .Values.zone }}"}`,
+			variablesFrom: &clustergatev1alpha1.ConfigMapReference{Name: "fleet-vars"},
+			wantMessage:   `map has no entry for key "zone"`,
+		},
+		{
+			name:          "missing configmap",
+			query:         `up{region="{{ .Values.region }}"}`,
+			variablesFrom: &clustergatev1alpha1.ConfigMapReference{Name: "absent", Namespace: "fleet"},
+			wantMessage:   "failed to get ConfigMap fleet/absent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = ""
+			executor := newTestExecutor(c)
+			executor.namespace = "clustergate-system"
+			WithClusterName("prod-eu-1")(executor)
+			result, err := executor.Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
+					Endpoint:      srv.URL,
+					Query:         tt.query,
+					VariablesFrom: tt.variablesFrom,
+					Condition:     &clustergatev1alpha1.PromQLCondition{Type: "resultCount", Operator: "eq", Threshold: 0},
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantMessage != "" {
+				if result.Ready || !strings.Contains(result.Message, tt.wantMessage) {
+					t.Errorf("result = %v %q, want failure containing %q", result.Ready, result.Message, tt.wantMessage)
+				}
+				return
+			}
+			if received != tt.wantQuery {
+				t.Errorf("query = %q, want %q", received, tt.wantQuery)
+			}
+			if result.Details["query"] != tt.wantQuery {
+				t.Errorf("query detail = %q, want the rendered query", result.Details["query"])
+			}
+		})
+	}
+}
+
+func TestPromQLValue(t *testing.T) {
+	samples := func(values ...string) []json.RawMessage {
+		var result []json.RawMessage
+		for _, v := range values {
+			result = append(result, json.RawMessage(`{"metric":{},"value":[1,"`+v+`"]}`))
+		}
+		return result
+	}
+	float := func(v float64) *float64 { return &v }
+	value := func(op string, threshold float64) []clustergatev1alpha1.PromQLCondition {
+		return []clustergatev1alpha1.PromQLCondition{{Type: "value", Operator: op, Threshold: threshold}}
+	}
+	tests := []struct {
+		name       string
+		conditions []clustergatev1alpha1.PromQLCondition
+		result     []json.RawMessage
+		want       *float64
+	}{
+		{name: "gte reports the lowest sample", conditions: value("gte", 0.9), result: samples("0.95", "0.5", "0.99"), want: float(0.5)},
+		{name: "lte reports the highest sample", conditions: value("lte", 5), result: samples("1", "7", "3"), want: float(7)},
+		{name: "eq reports the first mismatch", conditions: value("eq", 1), result: samples("1", "0", "2"), want: float(0)},
+		{name: "eq all matching", conditions: value("eq", 1), result: samples("1", "1"), want: float(1)},
+		{name: "no samples", conditions: value("gte", 1), result: nil, want: nil},
+		{
+			name:       "no value condition",
+			conditions: []clustergatev1alpha1.PromQLCondition{{Type: "resultCount", Operator: "gte", Threshold: 1}},
+			result:     samples("1"),
+			want:       nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := promQLValue(tt.conditions, tt.result)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("promQLValue = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,13 +2,20 @@ package dynamic
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/google/cel-go/cel"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
@@ -25,6 +32,33 @@ func (e *Executor) executeResourceCheck(ctx context.Context, spec *clustergatev1
 	}
 	gvk := gv.WithKind(spec.Kind)
 
+	if spec.Mode == clustergatev1alpha1.ResourceDryRun {
+		return e.executeResourceDryRun(ctx, spec)
+	}
+
+	mustNotExist := spec.Mode == clustergatev1alpha1.ResourceMustNotExist
+	countBounded := spec.MinCount != nil || spec.MaxCount != nil
+	if countBounded && mustNotExist {
+		return checks.Result{
+			Ready:   false,
+			Message: "minCount and maxCount cannot be used with mode MustNotExist",
+		}, nil
+	}
+	if countBounded && spec.LabelSelector == nil {
+		return checks.Result{
+			Ready:   false,
+			Message: "minCount and maxCount require labelSelector",
+		}, nil
+	}
+
+	namespaceFiltered := spec.NamespaceSelector != nil || len(spec.ExcludeNamespaces) > 0
+	if namespaceFiltered && (spec.LabelSelector == nil || spec.Namespace != "") {
+		return checks.Result{
+			Ready:   false,
+			Message: "namespaceSelector and excludeNamespaces require labelSelector and no namespace",
+		}, nil
+	}
+
 	var resources []unstructured.Unstructured
 
 	if spec.Name != "" {
@@ -35,7 +69,14 @@ func (e *Executor) executeResourceCheck(ctx context.Context, spec *clustergatev1
 			Namespace: spec.Namespace,
 			Name:      spec.Name,
 		}
-		if err := e.client.Get(ctx, key, obj); err != nil {
+		err := e.client.Get(ctx, key, obj)
+		if mustNotExist && apierrors.IsNotFound(err) {
+			return checks.Result{
+				Ready:   true,
+				Message: fmt.Sprintf("resource %s/%s does not exist", spec.Kind, spec.Name),
+			}, nil
+		}
+		if err != nil {
 			return checks.Result{
 				Ready:   false,
 				Message: fmt.Sprintf("resource %s/%s not found: %v", spec.Kind, spec.Name, err),
@@ -70,6 +111,15 @@ func (e *Executor) executeResourceCheck(ctx context.Context, spec *clustergatev1
 			}, nil
 		}
 		resources = list.Items
+		if namespaceFiltered {
+			resources, err = e.filterResourceNamespaces(ctx, spec, resources)
+			if err != nil {
+				return checks.Result{
+					Ready:   false,
+					Message: fmt.Sprintf("failed to select namespaces: %v", err),
+				}, nil
+			}
+		}
 	} else {
 		return checks.Result{
 			Ready:   false,
@@ -77,7 +127,20 @@ func (e *Executor) executeResourceCheck(ctx context.Context, spec *clustergatev1
 		}, nil
 	}
 
-	if len(resources) == 0 {
+	if countBounded {
+		if msg := checkResourceCount(len(resources), spec); msg != "" {
+			return checks.Result{
+				Ready:   false,
+				Message: msg,
+				Details: map[string]string{
+					"apiVersion":    spec.APIVersion,
+					"kind":          spec.Kind,
+					"namespace":     spec.Namespace,
+					"resourceCount": fmt.Sprintf("%d", len(resources)),
+				},
+			}, nil
+		}
+	} else if len(resources) == 0 && !mustNotExist {
 		return checks.Result{
 			Ready:   false,
 			Message: fmt.Sprintf("no %s resources found", spec.Kind),
@@ -89,54 +152,379 @@ func (e *Executor) executeResourceCheck(ctx context.Context, spec *clustergatev1
 		}, nil
 	}
 
-	// Check conditions on each resource
-	var failMessages []string
+	programs, err := compileResourceExpressions(spec.CELExpressions)
+	if err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	details := map[string]string{
+		"apiVersion":    spec.APIVersion,
+		"kind":          spec.Kind,
+		"namespace":     spec.Namespace,
+		"resourceCount": fmt.Sprintf("%d", len(resources)),
+	}
+
+	// Check conditions and expressions on each resource. In MustNotExist
+	// mode they select the resources that must not exist instead.
+	var failMessages, present []string
+	now := time.Now()
 	for _, res := range resources {
 		resName := res.GetName()
-		conditions, found, err := unstructured.NestedSlice(res.Object, "status", "conditions")
-		if err != nil || !found {
-			failMessages = append(failMessages, fmt.Sprintf("%s: no conditions found", resName))
-			continue
+		if spec.Namespace == "" && res.GetNamespace() != "" {
+			resName = res.GetNamespace() + "/" + resName
 		}
-
-		for _, expectedCond := range spec.Conditions {
-			matched := false
-			for _, c := range conditions {
-				cond, ok := c.(map[string]interface{})
-				if !ok {
-					continue
-				}
-				condType, _ := cond["type"].(string)
-				condStatus, _ := cond["status"].(string)
-				if condType == expectedCond.Type && condStatus == expectedCond.Status {
-					matched = true
-					break
-				}
+		msgs := checkResource(ctx, spec, programs, res, now)
+		if spec.Replicas != nil {
+			if msg := e.checkReplicas(ctx, spec.Replicas, &res); msg != "" {
+				msgs = append(msgs, msg)
 			}
-			if !matched {
-				failMessages = append(failMessages, fmt.Sprintf("%s: condition %s != %s", resName, expectedCond.Type, expectedCond.Status))
+		}
+		if mustNotExist {
+			if len(msgs) == 0 {
+				present = append(present, resName)
 			}
+			continue
+		}
+		for _, msg := range msgs {
+			failMessages = append(failMessages, fmt.Sprintf("%s: %s", resName, msg))
 		}
 	}
 
-	details := map[string]string{
-		"apiVersion":    spec.APIVersion,
-		"kind":          spec.Kind,
-		"namespace":     spec.Namespace,
-		"resourceCount": fmt.Sprintf("%d", len(resources)),
+	if mustNotExist {
+		if len(present) > 0 {
+			details["present"] = capSeries(present)
+			return checks.Result{
+				Ready:   false,
+				Message: fmt.Sprintf("%d %s resources must not exist: %s", len(present), spec.Kind, details["present"]),
+				Details: details,
+			}, nil
+		}
+		return checks.Result{
+			Ready:   true,
+			Message: fmt.Sprintf("no matching %s resources exist", spec.Kind),
+			Details: details,
+		}, nil
 	}
 
 	if len(failMessages) > 0 {
 		return checks.Result{
 			Ready:   false,
-			Message: fmt.Sprintf("condition check failed: %s", strings.Join(failMessages, "; ")),
+			Message: fmt.Sprintf("resource check failed: %s", strings.Join(failMessages, "; ")),
 			Details: details,
 		}, nil
 	}
 
 	return checks.Result{
 		Ready:   true,
-		Message: fmt.Sprintf("all %d %s resources have expected conditions", len(resources), spec.Kind),
+		Message: fmt.Sprintf("all %d %s resources satisfy all assertions", len(resources), spec.Kind),
+		Details: details,
+	}, nil
+}
+
+// checkResource evaluates spec's assertions against res and returns a message
+// for each one that fails.
+func checkResource(ctx context.Context, spec *clustergatev1alpha1.ResourceCheckSpec, programs []cel.Program, res unstructured.Unstructured, now time.Time) []string {
+	var msgs []string
+	if spec.RequireObservedGeneration {
+		if msg := checkObservedGeneration(res); msg != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+	for i, prg := range programs {
+		ok, err := evalCELExpression(ctx, prg, res.Object)
+		switch {
+		case err != nil:
+			msgs = append(msgs, fmt.Sprintf("expression %q: %v", spec.CELExpressions[i], err))
+		case !ok:
+			msgs = append(msgs, fmt.Sprintf("expression %q is false", spec.CELExpressions[i]))
+		}
+	}
+	for _, field := range spec.Fields {
+		if msg := checkResourceField(res.Object, field); msg != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	if len(spec.Conditions) == 0 {
+		return msgs
+	}
+	conditions, found, err := unstructured.NestedSlice(res.Object, "status", "conditions")
+	if err != nil || !found {
+		return append(msgs, "no conditions found")
+	}
+	for _, expectedCond := range spec.Conditions {
+		if msg := checkResourceCondition(conditions, expectedCond, now); msg != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
+
+// checkReplicas returns a failure message when res's replica counts fall
+// short of the desired count of its scale subresource, or "" when they meet it.
+func (e *Executor) checkReplicas(ctx context.Context, assertion *clustergatev1alpha1.ReplicasAssertion, res *unstructured.Unstructured) string {
+	desired, err := e.desiredReplicas(ctx, res)
+	if err != nil {
+		return fmt.Sprintf("failed to read scale subresource: %v", err)
+	}
+
+	var short []string
+	for _, count := range []struct {
+		field    string
+		required *bool
+	}{
+		{"readyReplicas", assertion.Ready},
+		{"updatedReplicas", assertion.Updated},
+		{"availableReplicas", assertion.Available},
+	} {
+		if count.required != nil && !*count.required {
+			continue
+		}
+		actual, _, _ := unstructured.NestedInt64(res.Object, "status", count.field)
+		if actual < desired {
+			short = append(short, fmt.Sprintf("%s %d/%d", count.field, actual, desired))
+		}
+	}
+	if len(short) > 0 {
+		return strings.Join(short, ", ")
+	}
+	return ""
+}
+
+// desiredReplicas returns the desired replica count from obj's scale subresource.
+func (e *Executor) desiredReplicas(ctx context.Context, obj *unstructured.Unstructured) (int64, error) {
+	scale := &unstructured.Unstructured{}
+	scale.SetGroupVersionKind(schema.GroupVersionKind{Group: "autoscaling", Version: "v1", Kind: "Scale"})
+	if err := e.client.SubResource("scale").Get(ctx, obj, scale); err != nil {
+		return 0, err
+	}
+	desired, _, err := unstructured.NestedInt64(scale.Object, "spec", "replicas")
+	return desired, err
+}
+
+// filterResourceNamespaces drops resources outside spec's NamespaceSelector
+// or inside its ExcludeNamespaces.
+func (e *Executor) filterResourceNamespaces(ctx context.Context, spec *clustergatev1alpha1.ResourceCheckSpec, resources []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	var selected map[string]bool
+	if spec.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(spec.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace selector: %w", err)
+		}
+		var namespaces corev1.NamespaceList
+		if err := e.client.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		selected = make(map[string]bool, len(namespaces.Items))
+		for _, ns := range namespaces.Items {
+			selected[ns.Name] = true
+		}
+	}
+	excluded := make(map[string]bool, len(spec.ExcludeNamespaces))
+	for _, ns := range spec.ExcludeNamespaces {
+		excluded[ns] = true
+	}
+
+	var filtered []unstructured.Unstructured
+	for _, res := range resources {
+		ns := res.GetNamespace()
+		if excluded[ns] || (selected != nil && !selected[ns]) {
+			continue
+		}
+		filtered = append(filtered, res)
+	}
+	return filtered, nil
+}
+
+// checkResourceCondition returns a failure message when conditions does not
+// satisfy expected at now, or "" when it does.
+func checkResourceCondition(conditions []interface{}, expected clustergatev1alpha1.ResourceConditionCheck, now time.Time) string {
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condType, _ := cond["type"].(string)
+		if condType != expected.Type {
+			continue
+		}
+		condStatus, _ := cond["status"].(string)
+		if condStatus != expected.Status {
+			break
+		}
+		if reason, _ := cond["reason"].(string); expected.Reason != "" && reason != expected.Reason {
+			return fmt.Sprintf("condition %s reason %q != %q", expected.Type, reason, expected.Reason)
+		}
+		if expected.MinAgeSeconds == nil && expected.MaxLastTransitionAgeSeconds == nil {
+			return ""
+		}
+		raw, _ := cond["lastTransitionTime"].(string)
+		transitioned, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return fmt.Sprintf("condition %s has no valid lastTransitionTime", expected.Type)
+		}
+		age := now.Sub(transitioned).Truncate(time.Second)
+		if minAge := expected.MinAgeSeconds; minAge != nil && age < time.Duration(*minAge)*time.Second {
+			return fmt.Sprintf("condition %s transitioned %s ago, expected at least %ds", expected.Type, age, *minAge)
+		}
+		if maxAge := expected.MaxLastTransitionAgeSeconds; maxAge != nil && age > time.Duration(*maxAge)*time.Second {
+			return fmt.Sprintf("condition %s transitioned %s ago, expected at most %ds", expected.Type, age, *maxAge)
+		}
+		return ""
+	}
+	return fmt.Sprintf("condition %s != %s", expected.Type, expected.Status)
+}
+
+// checkObservedGeneration returns a failure message when res's controller has
+// not observed its latest generation, or "" when it has.
+func checkObservedGeneration(res unstructured.Unstructured) string {
+	observed, found, err := unstructured.NestedInt64(res.Object, "status", "observedGeneration")
+	if err != nil || !found {
+		return "status.observedGeneration not reported"
+	}
+	if observed < res.GetGeneration() {
+		return fmt.Sprintf("observedGeneration %d is behind generation %d", observed, res.GetGeneration())
+	}
+	return ""
+}
+
+// checkResourceCount returns a failure message when count is outside the
+// bounds of spec, or "" when it is within them.
+func checkResourceCount(count int, spec *clustergatev1alpha1.ResourceCheckSpec) string {
+	if spec.MinCount != nil && count < int(*spec.MinCount) {
+		return fmt.Sprintf("found %d %s resources, expected at least %d", count, spec.Kind, *spec.MinCount)
+	}
+	if spec.MaxCount != nil && count > int(*spec.MaxCount) {
+		return fmt.Sprintf("found %d %s resources, expected at most %d", count, spec.Kind, *spec.MaxCount)
+	}
+	return ""
+}
+
+// compileResourceExpressions compiles a resource check's CEL expressions.
+func compileResourceExpressions(exprs []string) ([]cel.Program, error) {
+	if len(exprs) == 0 {
+		return nil, nil
+	}
+	env, err := celEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	programs := make([]cel.Program, 0, len(exprs))
+	for _, expr := range exprs {
+		prg, err := compileCELExpression(env, expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CEL expression %q: %w", expr, err)
+		}
+		programs = append(programs, prg)
+	}
+	return programs, nil
+}
+
+// checkResourceField evaluates field against obj and returns a failure
+// message, or "" when the assertion holds for every value at the path.
+func checkResourceField(obj map[string]interface{}, field clustergatev1alpha1.ResourceFieldCheck) string {
+	path := field.Path
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+	jp := jsonpath.New("field").AllowMissingKeys(true)
+	if err := jp.Parse(path); err != nil {
+		return fmt.Sprintf("invalid field path %q: %v", field.Path, err)
+	}
+	results, err := jp.FindResults(obj)
+	if err != nil {
+		return fmt.Sprintf("field %s: %v", field.Path, err)
+	}
+	var values []string
+	for _, result := range results {
+		for _, v := range result {
+			if v.IsValid() && v.CanInterface() && v.Interface() != nil {
+				values = append(values, fmt.Sprint(v.Interface()))
+			}
+		}
+	}
+	if len(values) == 0 {
+		return fmt.Sprintf("field %s not found", field.Path)
+	}
+
+	operator := field.Operator
+	if operator == "" {
+		operator = "eq"
+	}
+	for _, actual := range values {
+		switch operator {
+		case "exists":
+		case "eq":
+			if actual != field.Value {
+				return fmt.Sprintf("field %s is %q, expected %q", field.Path, actual, field.Value)
+			}
+		case "ne":
+			if actual == field.Value {
+				return fmt.Sprintf("field %s is %q, expected not %q", field.Path, actual, field.Value)
+			}
+		default:
+			a, errA := strconv.ParseFloat(actual, 64)
+			b, errB := strconv.ParseFloat(field.Value, 64)
+			if errA != nil || errB != nil {
+				return fmt.Sprintf("field %s: cannot compare %q %s %q numerically", field.Path, actual, operator, field.Value)
+			}
+			if !compareFloat64(a, operator, b) {
+				return fmt.Sprintf("field %s is %s, expected %s %s", field.Path, actual, operator, field.Value)
+			}
+		}
+	}
+	return ""
+}
+
+// executeResourceDryRun creates spec.Manifest with a server-side dry run. The
+// API server runs admission, quota and authorization as for a real create
+// but persists nothing.
+func (e *Executor) executeResourceDryRun(ctx context.Context, spec *clustergatev1alpha1.ResourceCheckSpec) (checks.Result, error) {
+	if spec.Manifest == nil || len(spec.Manifest.Raw) == 0 {
+		return checks.Result{
+			Ready:   false,
+			Message: "mode DryRun requires manifest",
+		}, nil
+	}
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(spec.Manifest.Raw, &obj.Object); err != nil {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("invalid manifest: %v", err),
+		}, nil
+	}
+	if obj.GetAPIVersion() == "" {
+		obj.SetAPIVersion(spec.APIVersion)
+	}
+	if obj.GetKind() == "" {
+		obj.SetKind(spec.Kind)
+	}
+	if spec.Namespace != "" {
+		obj.SetNamespace(spec.Namespace)
+	}
+	if obj.GetName() == "" && obj.GetGenerateName() == "" {
+		obj.SetGenerateName("clustergate-dry-run-")
+	}
+
+	details := map[string]string{
+		"apiVersion": obj.GetAPIVersion(),
+		"kind":       obj.GetKind(),
+		"namespace":  obj.GetNamespace(),
+	}
+	if err := e.client.Create(ctx, obj, client.DryRunAll); err != nil {
+		details["reason"] = string(apierrors.ReasonForError(err))
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("dry-run create of %s rejected: %v", obj.GetKind(), err),
+			Details: details,
+		}, nil
+	}
+	return checks.Result{
+		Ready:   true,
+		Message: fmt.Sprintf("dry-run create of %s accepted", obj.GetKind()),
 		Details: details,
 	}, nil
 }
@@ -2,12 +2,20 @@ package dynamic
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
 )
@@ -206,3 +214,580 @@ func TestResourceCheck_LabelSelectorMultiple(t *testing.T) {
 		t.Errorf("expected ready=true for multiple matching resources: %s", result.Message)
 	}
 }
+
+func TestResourceCheck_CELExpressions(t *testing.T) {
+	deploy := deploymentWithConditions("api", "apps", []interface{}{
+		map[string]interface{}{"type": "Available", "status": "True"},
+	})
+	deploy.Object["spec"] = map[string]interface{}{"replicas": int64(3)}
+	deploy.Object["status"].(map[string]interface{})["readyReplicas"] = int64(2)
+
+	tests := []struct {
+		name        string
+		exprs       []string
+		conditions  []clustergatev1alpha1.ResourceConditionCheck
+		wantReady   bool
+		wantMessage string
+	}{
+		{
+			name:      "expression true",
+			exprs:     []string{"object.status.readyReplicas >= 2", "object.metadata.name == 'api'"},
+			wantReady: true,
+		},
+		{
+			name:        "expression false",
+			exprs:       []string{"object.status.readyReplicas >= object.spec.replicas"},
+			wantMessage: `api: expression "object.status.readyReplicas >= object.spec.replicas" is false`,
+		},
+		{
+			name:        "missing field",
+			exprs:       []string{"object.status.updatedReplicas == 3"},
+			wantMessage: "no such key: updatedReplicas",
+		},
+		{
+			name:      "has guards a missing field",
+			exprs:     []string{"!has(object.status.updatedReplicas) || object.status.updatedReplicas == 3"},
+			wantReady: true,
+		},
+		{
+			name:        "combined with conditions",
+			exprs:       []string{"object.status.readyReplicas >= 2"},
+			conditions:  []clustergatev1alpha1.ResourceConditionCheck{{Type: "Progressing", Status: "True"}},
+			wantMessage: "api: condition Progressing != True",
+		},
+		{
+			name:        "invalid expression",
+			exprs:       []string{"object.status.readyReplicas >="},
+			wantMessage: "invalid CEL expression",
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(deploy).Build()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{
+					APIVersion:     "apps/v1",
+					Kind:           "Deployment",
+					Namespace:      "apps",
+					Name:           "api",
+					Conditions:     tt.conditions,
+					CELExpressions: tt.exprs,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady || !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("result = %v %q, want %v %q", result.Ready, result.Message, tt.wantReady, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestResourceCheck_Fields(t *testing.T) {
+	pvc := &unstructured.Unstructured{}
+	pvc.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "PersistentVolumeClaim"})
+	pvc.SetName("data")
+	pvc.SetNamespace("db")
+	pvc.Object["status"] = map[string]interface{}{"phase": "Pending"}
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(pvc).Build()
+	result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+		ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{
+			APIVersion: "v1",
+			Kind:       "PersistentVolumeClaim",
+			Namespace:  "db",
+			Name:       "data",
+			Fields:     []clustergatev1alpha1.ResourceFieldCheck{{Path: ".status.phase", Value: "Bound"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `data: field .status.phase is "Pending", expected "Bound"`
+	if result.Ready || !strings.Contains(result.Message, want) {
+		t.Errorf("result = %v %q, want failure %q", result.Ready, result.Message, want)
+	}
+}
+
+func TestCheckResourceField(t *testing.T) {
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{"volumeName": "pv-1", "replicas": int64(3)},
+		"status": map[string]interface{}{
+			"phase":             "Bound",
+			"containerStatuses": []interface{}{map[string]interface{}{"ready": true}, map[string]interface{}{"ready": false}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		field       clustergatev1alpha1.ResourceFieldCheck
+		wantMessage string
+	}{
+		{name: "eq by default", field: clustergatev1alpha1.ResourceFieldCheck{Path: ".status.phase", Value: "Bound"}},
+		{
+			name:        "eq mismatch",
+			field:       clustergatev1alpha1.ResourceFieldCheck{Path: ".status.phase", Operator: "eq", Value: "Pending"},
+			wantMessage: `field .status.phase is "Bound", expected "Pending"`,
+		},
+		{name: "ne", field: clustergatev1alpha1.ResourceFieldCheck{Path: ".status.phase", Operator: "ne", Value: "Lost"}},
+		{name: "exists", field: clustergatev1alpha1.ResourceFieldCheck{Path: ".spec.volumeName", Operator: "exists"}},
+		{name: "numeric", field: clustergatev1alpha1.ResourceFieldCheck{Path: ".spec.replicas", Operator: "gte", Value: "2"}},
+		{
+			name:        "numeric mismatch",
+			field:       clustergatev1alpha1.ResourceFieldCheck{Path: ".spec.replicas", Operator: "gt", Value: "3"},
+			wantMessage: "field .spec.replicas is 3, expected gt 3",
+		},
+		{
+			name:        "every value must match",
+			field:       clustergatev1alpha1.ResourceFieldCheck{Path: "{.status.containerStatuses[*].ready}", Value: "true"},
+			wantMessage: `is "false", expected "true"`,
+		},
+		{
+			name:        "not numeric",
+			field:       clustergatev1alpha1.ResourceFieldCheck{Path: ".status.phase", Operator: "lt", Value: "1"},
+			wantMessage: "cannot compare",
+		},
+		{
+			name:        "missing field",
+			field:       clustergatev1alpha1.ResourceFieldCheck{Path: ".status.resizeStatus", Operator: "exists"},
+			wantMessage: "field .status.resizeStatus not found",
+		},
+		{
+			name:        "invalid path",
+			field:       clustergatev1alpha1.ResourceFieldCheck{Path: ".status[", Value: "x"},
+			wantMessage: "invalid field path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkResourceField(obj, tt.field)
+			if (tt.wantMessage == "") != (got == "") || !strings.Contains(got, tt.wantMessage) {
+				t.Errorf("checkResourceField = %q, want %q", got, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestResourceCheck_Count(t *testing.T) {
+	var objs []client.Object
+	for _, name := range []string{"rules-a", "rules-b"} {
+		d := deploymentWithConditions(name, "monitoring", nil)
+		d.SetLabels(map[string]string{"role": "alert-rules"})
+		objs = append(objs, d)
+	}
+	count := func(n int32) *int32 { return &n }
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"role": "alert-rules"}}
+
+	tests := []struct {
+		name        string
+		selector    *metav1.LabelSelector
+		resName     string
+		min, max    *int32
+		wantReady   bool
+		wantMessage string
+	}{
+		{name: "within bounds", selector: selector, min: count(2), max: count(5), wantReady: true},
+		{name: "too few", selector: selector, min: count(3), wantMessage: "found 2 Deployment resources, expected at least 3"},
+		{name: "too many", selector: selector, max: count(1), wantMessage: "found 2 Deployment resources, expected at most 1"},
+		{
+			name:      "none allowed",
+			selector:  &metav1.LabelSelector{MatchLabels: map[string]string{"role": "deprecated"}},
+			max:       count(0),
+			wantReady: true,
+		},
+		{name: "requires labelSelector", resName: "rules-a", min: count(1), wantMessage: "minCount and maxCount require labelSelector"},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(objs...).Build()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{
+					APIVersion:    "apps/v1",
+					Kind:          "Deployment",
+					Namespace:     "monitoring",
+					Name:          tt.resName,
+					LabelSelector: tt.selector,
+					MinCount:      tt.min,
+					MaxCount:      tt.max,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady || !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("result = %v %q, want %v %q", result.Ready, result.Message, tt.wantReady, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestCheckObservedGeneration(t *testing.T) {
+	tests := []struct {
+		name        string
+		generation  int64
+		status      map[string]interface{}
+		wantMessage string
+	}{
+		{name: "up to date", generation: 4, status: map[string]interface{}{"observedGeneration": int64(4)}},
+		{
+			name:        "behind",
+			generation:  5,
+			status:      map[string]interface{}{"observedGeneration": int64(4)},
+			wantMessage: "observedGeneration 4 is behind generation 5",
+		},
+		{name: "not reported", generation: 1, status: map[string]interface{}{}, wantMessage: "status.observedGeneration not reported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := deploymentWithConditions("api", "apps", []interface{}{
+				map[string]interface{}{"type": "Available", "status": "True"},
+			})
+			res.SetGeneration(tt.generation)
+			res.Object["status"] = tt.status
+			if got := checkObservedGeneration(*res); got != tt.wantMessage {
+				t.Errorf("checkObservedGeneration = %q, want %q", got, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestResourceCheck_RequireObservedGeneration(t *testing.T) {
+	deploy := deploymentWithConditions("api", "apps", []interface{}{
+		map[string]interface{}{"type": "Available", "status": "True"},
+	})
+	deploy.Object["status"].(map[string]interface{})["observedGeneration"] = int64(1)
+	deploy.SetGeneration(2)
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(deploy).Build()
+	result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+		ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{
+			APIVersion:                "apps/v1",
+			Kind:                      "Deployment",
+			Namespace:                 "apps",
+			Name:                      "api",
+			RequireObservedGeneration: true,
+			Conditions:                []clustergatev1alpha1.ResourceConditionCheck{{Type: "Available", Status: "True"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Ready || !strings.Contains(result.Message, "is behind generation") {
+		t.Errorf("result = %v %q, want failure for a stale observedGeneration", result.Ready, result.Message)
+	}
+}
+
+func TestCheckResourceCondition(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	conditions := []interface{}{
+		map[string]interface{}{
+			"type":               "Ready",
+			"status":             "True",
+			"reason":             "ReconciliationSucceeded",
+			"lastTransitionTime": now.Add(-2 * time.Minute).Format(time.RFC3339),
+		},
+		map[string]interface{}{"type": "Stalled", "status": "False"},
+	}
+	seconds := func(n int32) *int32 { return &n }
+
+	tests := []struct {
+		name        string
+		expected    clustergatev1alpha1.ResourceConditionCheck
+		wantMessage string
+	}{
+		{name: "status", expected: clustergatev1alpha1.ResourceConditionCheck{Type: "Ready", Status: "True"}},
+		{
+			name:        "status mismatch",
+			expected:    clustergatev1alpha1.ResourceConditionCheck{Type: "Stalled", Status: "True"},
+			wantMessage: "condition Stalled != True",
+		},
+		{
+			name:        "missing",
+			expected:    clustergatev1alpha1.ResourceConditionCheck{Type: "Healthy", Status: "True"},
+			wantMessage: "condition Healthy != True",
+		},
+		{name: "reason", expected: clustergatev1alpha1.ResourceConditionCheck{Type: "Ready", Status: "True", Reason: "ReconciliationSucceeded"}},
+		{
+			name:        "reason mismatch",
+			expected:    clustergatev1alpha1.ResourceConditionCheck{Type: "Ready", Status: "True", Reason: "Succeeded"},
+			wantMessage: `condition Ready reason "ReconciliationSucceeded" != "Succeeded"`,
+		},
+		{name: "old enough", expected: clustergatev1alpha1.ResourceConditionCheck{Type: "Ready", Status: "True", MinAgeSeconds: seconds(60)}},
+		{
+			name:        "too fresh",
+			expected:    clustergatev1alpha1.ResourceConditionCheck{Type: "Ready", Status: "True", MinAgeSeconds: seconds(300)},
+			wantMessage: "condition Ready transitioned 2m0s ago, expected at least 300s",
+		},
+		{name: "recent enough", expected: clustergatev1alpha1.ResourceConditionCheck{Type: "Ready", Status: "True", MaxLastTransitionAgeSeconds: seconds(600)}},
+		{
+			name:        "too old",
+			expected:    clustergatev1alpha1.ResourceConditionCheck{Type: "Ready", Status: "True", MaxLastTransitionAgeSeconds: seconds(60)},
+			wantMessage: "condition Ready transitioned 2m0s ago, expected at most 60s",
+		},
+		{
+			name:        "no lastTransitionTime",
+			expected:    clustergatev1alpha1.ResourceConditionCheck{Type: "Stalled", Status: "False", MinAgeSeconds: seconds(60)},
+			wantMessage: "condition Stalled has no valid lastTransitionTime",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkResourceCondition(conditions, tt.expected, now); got != tt.wantMessage {
+				t.Errorf("checkResourceCondition = %q, want %q", got, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestResourceCheck_NamespaceSelection(t *testing.T) {
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	objs := []client.Object{
+		namespace("team-a", map[string]string{"tenant": "team"}),
+		namespace("team-b", map[string]string{"tenant": "team"}),
+		namespace("sandbox", nil),
+	}
+	for _, ns := range []string{"team-a", "team-b", "sandbox"} {
+		status := "True"
+		if ns != "team-a" {
+			status = "False"
+		}
+		d := deploymentWithConditions("gateway", ns, []interface{}{
+			map[string]interface{}{"type": "Available", "status": status},
+		})
+		d.SetLabels(map[string]string{"tier": "platform"})
+		objs = append(objs, d)
+	}
+
+	tests := []struct {
+		name        string
+		spec        clustergatev1alpha1.ResourceCheckSpec
+		wantReady   bool
+		wantMessage string
+	}{
+		{
+			name:        "all namespaces",
+			wantMessage: "sandbox/gateway: condition Available != True; team-b/gateway: condition Available != True",
+		},
+		{
+			name:        "namespace selector",
+			spec:        clustergatev1alpha1.ResourceCheckSpec{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "team"}}},
+			wantMessage: "team-b/gateway: condition Available != True",
+		},
+		{
+			name:      "excluded namespaces",
+			spec:      clustergatev1alpha1.ResourceCheckSpec{ExcludeNamespaces: []string{"team-b", "sandbox"}},
+			wantReady: true,
+		},
+		{
+			name:        "namespace and selector",
+			spec:        clustergatev1alpha1.ResourceCheckSpec{Namespace: "team-a", ExcludeNamespaces: []string{"team-b"}},
+			wantMessage: "namespaceSelector and excludeNamespaces require labelSelector and no namespace",
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(objs...).Build()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := tt.spec
+			spec.APIVersion = "apps/v1"
+			spec.Kind = "Deployment"
+			spec.LabelSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "platform"}}
+			spec.Conditions = []clustergatev1alpha1.ResourceConditionCheck{{Type: "Available", Status: "True"}}
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{ResourceCheck: &spec})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady || !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("result = %v %q, want %v %q", result.Ready, result.Message, tt.wantReady, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestResourceCheck_MustNotExist(t *testing.T) {
+	node := func(name string, taints []interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(schema.GroupVersionKind{Version: "v1", Kind: "Node"})
+		obj.SetName(name)
+		obj.SetLabels(map[string]string{"pool": "workers"})
+		if taints != nil {
+			obj.Object["spec"] = map[string]interface{}{"taints": taints}
+		}
+		return obj
+	}
+	draining := []interface{}{map[string]interface{}{"key": "node.kubernetes.io/unschedulable", "effect": "NoSchedule"}}
+	workers := &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "workers"}}
+	tainted := []string{"has(object.spec.taints) && object.spec.taints.exists(t, t.key == 'node.kubernetes.io/unschedulable')"}
+
+	tests := []struct {
+		name        string
+		spec        clustergatev1alpha1.ResourceCheckSpec
+		wantReady   bool
+		wantMessage string
+	}{
+		{
+			name:        "matching resources present",
+			spec:        clustergatev1alpha1.ResourceCheckSpec{LabelSelector: workers, CELExpressions: tainted},
+			wantMessage: "1 Node resources must not exist: node-b",
+		},
+		{
+			name:        "named resource present",
+			spec:        clustergatev1alpha1.ResourceCheckSpec{Name: "node-a"},
+			wantMessage: "1 Node resources must not exist: node-a",
+		},
+		{
+			name:        "named resource absent",
+			spec:        clustergatev1alpha1.ResourceCheckSpec{Name: "node-z"},
+			wantReady:   true,
+			wantMessage: "resource Node/node-z does not exist",
+		},
+		{
+			name:        "no matches",
+			spec:        clustergatev1alpha1.ResourceCheckSpec{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "gpu"}}},
+			wantReady:   true,
+			wantMessage: "no matching Node resources exist",
+		},
+		{
+			name:        "count bounds rejected",
+			spec:        clustergatev1alpha1.ResourceCheckSpec{LabelSelector: workers, MaxCount: new(int32)},
+			wantMessage: "minCount and maxCount cannot be used with mode MustNotExist",
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(node("node-a", nil), node("node-b", draining)).Build()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := tt.spec
+			spec.APIVersion = "v1"
+			spec.Kind = "Node"
+			spec.Mode = clustergatev1alpha1.ResourceMustNotExist
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{ResourceCheck: &spec})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady || !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("result = %v %q, want %v %q", result.Ready, result.Message, tt.wantReady, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestResourceCheck_Replicas(t *testing.T) {
+	deploy := deploymentWithConditions("api", "apps", nil)
+	deploy.Object["status"] = map[string]interface{}{
+		"readyReplicas": int64(3), "updatedReplicas": int64(2), "availableReplicas": int64(3),
+	}
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(deploy).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceGet: func(_ context.Context, _ client.Client, subResource string, _ client.Object, obj client.Object, _ ...client.SubResourceGetOption) error {
+				if subResource != "scale" {
+					return fmt.Errorf("unexpected subresource %s", subResource)
+				}
+				return unstructured.SetNestedField(obj.(*unstructured.Unstructured).Object, int64(3), "spec", "replicas")
+			},
+		}).Build()
+	disabled := false
+
+	tests := []struct {
+		name        string
+		assertion   clustergatev1alpha1.ReplicasAssertion
+		wantReady   bool
+		wantMessage string
+	}{
+		{name: "rollout in progress", wantMessage: "api: updatedReplicas 2/3"},
+		{name: "updated not required", assertion: clustergatev1alpha1.ReplicasAssertion{Updated: &disabled}, wantReady: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertion := tt.assertion
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Namespace:  "apps",
+					Name:       "api",
+					Replicas:   &assertion,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady || !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("result = %v %q, want %v %q", result.Ready, result.Message, tt.wantReady, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestResourceCheck_DryRun(t *testing.T) {
+	manifest := func(raw string) *apiextensionsv1.JSON { return &apiextensionsv1.JSON{Raw: []byte(raw)} }
+
+	tests := []struct {
+		name        string
+		manifest    *apiextensionsv1.JSON
+		deny        bool
+		wantReady   bool
+		wantMessage string
+	}{
+		{
+			name:        "accepted",
+			manifest:    manifest(`{"spec":{"replicas":1}}`),
+			wantReady:   true,
+			wantMessage: "dry-run create of Deployment accepted",
+		},
+		{
+			name:        "rejected by admission",
+			manifest:    manifest(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"probe"}}`),
+			deny:        true,
+			wantMessage: `is forbidden: admission webhook "policy.example.com" denied the request`,
+		},
+		{name: "missing manifest", wantMessage: "mode DryRun requires manifest"},
+		{name: "invalid manifest", manifest: manifest(`[1]`), wantMessage: "invalid manifest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *unstructured.Unstructured
+			c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					createOpts := &client.CreateOptions{}
+					createOpts.ApplyOptions(opts)
+					if len(createOpts.DryRun) != 1 || createOpts.DryRun[0] != metav1.DryRunAll {
+						t.Errorf("create without dry run: %+v", createOpts)
+					}
+					created = obj.(*unstructured.Unstructured)
+					if tt.deny {
+						return apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "probe",
+							fmt.Errorf(`admission webhook "policy.example.com" denied the request`))
+					}
+					return nil
+				},
+			}).Build()
+
+			result, err := newTestExecutor(c).Execute(context.Background(), "test", clustergatev1alpha1.GateCheckSpec{
+				ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Namespace:  "team-a",
+					Mode:       clustergatev1alpha1.ResourceDryRun,
+					Manifest:   tt.manifest,
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady || !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("result = %v %q, want %v %q", result.Ready, result.Message, tt.wantReady, tt.wantMessage)
+			}
+			if tt.name == "accepted" && (created.GetNamespace() != "team-a" || created.GetGenerateName() == "" || created.GetKind() != "Deployment") {
+				t.Errorf("created %+v, want a generated Deployment in team-a", created.Object)
+			}
+		})
+	}
+}
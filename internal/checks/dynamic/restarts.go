@@ -0,0 +1,93 @@
+package dynamic
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// restartHistoryRetention bounds how long restart counts are remembered, and
+// so the longest usable restartWindow.
+const restartHistoryRetention = 24 * time.Hour
+
+// restartSample is a pod's total container restart count observed at a time.
+type restartSample struct {
+	at    time.Time
+	count int32
+}
+
+// restartTracker remembers the restart counts observed for each pod so that
+// restarts within a window can be derived from the cumulative counts the API
+// reports. Only changes are stored. The zero value is ready to use.
+type restartTracker struct {
+	mu   sync.Mutex
+	pods map[types.UID]*podRestarts
+}
+
+type podRestarts struct {
+	samples  []restartSample
+	lastSeen time.Time
+}
+
+// observe records count for pod at now and returns the restarts within the
+// window before now. Restarts before the pod was first observed are not
+// counted, except that a pod's first observation counts its full history when
+// it was created within the window.
+func (t *restartTracker) observe(pod types.UID, created time.Time, count int32, window time.Duration, now time.Time) int32 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if window > restartHistoryRetention {
+		window = restartHistoryRetention
+	}
+	if t.pods == nil {
+		t.pods = make(map[types.UID]*podRestarts)
+	}
+	t.prune(now)
+
+	p := t.pods[pod]
+	if p == nil {
+		p = &podRestarts{}
+		if !created.IsZero() && now.Sub(created) <= window {
+			p.samples = append(p.samples, restartSample{at: created, count: 0})
+		}
+		t.pods[pod] = p
+	}
+	p.lastSeen = now
+	if n := len(p.samples); n == 0 || p.samples[n-1].count != count {
+		p.samples = append(p.samples, restartSample{at: now, count: count})
+	}
+
+	// The count at the start of the window is the last sample at or before
+	// it, or the earliest sample when history starts inside the window.
+	start := now.Add(-window)
+	baseline := p.samples[0].count
+	for _, s := range p.samples {
+		if s.at.After(start) {
+			break
+		}
+		baseline = s.count
+	}
+	if count < baseline {
+		return 0
+	}
+	return count - baseline
+}
+
+// prune forgets pods not seen within the retention and samples older than it,
+// keeping the last sample before the retention as the baseline.
+func (t *restartTracker) prune(now time.Time) {
+	cutoff := now.Add(-restartHistoryRetention)
+	for uid, p := range t.pods {
+		if p.lastSeen.Before(cutoff) {
+			delete(t.pods, uid)
+			continue
+		}
+		i := 0
+		for i+1 < len(p.samples) && !p.samples[i+1].at.After(cutoff) {
+			i++
+		}
+		p.samples = p.samples[i:]
+	}
+}
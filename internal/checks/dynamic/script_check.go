@@ -3,14 +3,26 @@ package dynamic
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"path"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
 	"github.com/clustergate/clustergate/internal/checks"
@@ -18,20 +30,66 @@ import (
 
 const (
 	defaultScriptTimeout = 30
-	scriptPollInterval   = 2 * time.Second
 	labelManagedBy       = "app.kubernetes.io/managed-by"
 	labelManagedByValue  = "clustergate"
 	labelCheckName       = "clustergate.io/check"
+	labelNodeArch        = "kubernetes.io/arch"
+	scriptContainerName  = "script"
+	scriptVolumeName     = "clustergate-script"
+	scriptMountPath      = "/clustergate/script"
+	scriptLogTailLines   = 20
+	scriptLogTailBytes   = 2048
 )
 
+// ScriptJobLabels are set on every script check Job and its pods. The manager
+// restricts its Job cache to them.
+var ScriptJobLabels = map[string]string{labelManagedBy: labelManagedByValue}
+
 // executeScriptCheck deploys a Kubernetes Job, waits for completion, reads
 // the pod logs, and interprets the exit code.  Exit 0 → Ready, non-zero → not Ready.
-func executeScriptCheck(ctx context.Context, clientset kubernetes.Interface, namespace string, checkName string, spec *clustergatev1alpha1.ScriptCheckSpec) (checks.Result, error) {
+// With resultFormat json, a JSON document from the script decides the result.
+// The Job is created, watched and its logs streamed through clientset; its
+// pods are read through reader, normally the manager's cache.
+func executeScriptCheck(ctx context.Context, clientset kubernetes.Interface, reader client.Reader, namespace string, checkName string, spec *clustergatev1alpha1.ScriptCheckSpec) (checks.Result, error) {
+	job := buildScriptJob(namespace, checkName, spec)
+	ttlAfterFinished := scriptJobTTLAfterFinished
+	job.Spec.TTLSecondsAfterFinished = &ttlAfterFinished
+
+	// Create the Job.
+	created, err := clientset.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return checks.Result{}, fmt.Errorf("failed to create script check job: %w", err)
+	}
+
+	jobName := created.Name
+
+	// Ensure cleanup regardless of outcome.
+	defer func() {
+		propagation := metav1.DeletePropagationBackground
+		_ = clientset.BatchV1().Jobs(namespace).Delete(context.Background(), jobName, metav1.DeleteOptions{
+			PropagationPolicy: &propagation,
+		})
+	}()
+
+	// Watch until the Job completes or the timeout passes.
+	timeout := time.Duration(*job.Spec.ActiveDeadlineSeconds) * time.Second
+	result, err := waitForJobCompletion(ctx, clientset, namespace, jobName, timeout)
+	if err != nil {
+		return checks.Result{}, err
+	}
+
+	return scriptJobResult(ctx, clientset, reader, namespace, jobName, spec, result), nil
+}
+
+// buildScriptJob returns the Job that runs spec's script for checkName.
+func buildScriptJob(namespace, checkName string, spec *clustergatev1alpha1.ScriptCheckSpec) *batchv1.Job {
 	timeout := int64(defaultScriptTimeout)
 	if spec.TimeoutSeconds != nil {
 		timeout = int64(*spec.TimeoutSeconds)
 	}
 
+	image, archs := resolveScriptImage(spec, runtime.GOARCH)
+
 	var backoffLimit int32 = 0
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
@@ -55,60 +113,350 @@ func executeScriptCheck(ctx context.Context, clientset kubernetes.Interface, nam
 				Spec: corev1.PodSpec{
 					RestartPolicy:      corev1.RestartPolicyNever,
 					ServiceAccountName: spec.ServiceAccountName,
+					ImagePullSecrets:   spec.ImagePullSecrets,
+					NodeSelector:       spec.NodeSelector,
+					Tolerations:        spec.Tolerations,
+					Affinity:           archAffinity(spec.Affinity, archs),
+					SecurityContext:    scriptPodSecurityContext(spec),
 					Containers: []corev1.Container{
 						{
-							Name:    "script",
-							Image:   spec.Image,
-							Command: spec.Command,
-							Args:    spec.Args,
-							Env:     spec.Env,
+							Name:            scriptContainerName,
+							Image:           image,
+							Command:         spec.Command,
+							Args:            spec.Args,
+							Env:             spec.Env,
+							Resources:       scriptResources(spec),
+							SecurityContext: scriptSecurityContext(spec),
 						},
 					},
 				},
 			},
 		},
 	}
+	mountScriptSource(&job.Spec.Template.Spec, spec)
+	return job
+}
 
-	// Create the Job.
-	created, err := clientset.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
-	if err != nil {
-		return checks.Result{}, fmt.Errorf("failed to create script check job: %w", err)
+// scriptJobResult interprets the finished Job jobName from its outcome and
+// its pod's logs.
+func scriptJobResult(ctx context.Context, clientset kubernetes.Interface, reader client.Reader, namespace, jobName string, spec *clustergatev1alpha1.ScriptCheckSpec, result jobResult) checks.Result {
+	// Read logs from the Job's pod.
+	pod, podErr := getJobPod(ctx, reader, namespace, jobName)
+	var logOutput string
+	var logErr error
+	if podErr != nil {
+		logErr = podErr
+	} else {
+		logOutput, logErr = getPodLogs(ctx, clientset, pod)
+	}
+	if logErr != nil {
+		// Non-fatal: include error in message but still return the check result.
+		logOutput = fmt.Sprintf("(failed to read logs: %v)", logErr)
 	}
 
-	jobName := created.Name
+	var res checks.Result
+	switch {
+	case spec.ResultFormat == clustergatev1alpha1.ScriptResultFormatJSON:
+		res = scriptJSONResult(result, terminationMessage(pod), logOutput)
+	case result.ready:
+		res = checks.Result{
+			Ready:   true,
+			Message: fmt.Sprintf("script completed successfully: %s", truncateLog(logOutput, 500)),
+		}
+	default:
+		res = checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("script failed (reason: %s): %s", result.reason, truncateLog(logOutput, 500)),
+		}
+	}
+	if logErr == nil {
+		addScriptDiagnostics(&res, pod, logOutput)
+	}
+	return res
+}
 
-	// Ensure cleanup regardless of outcome.
-	defer func() {
-		propagation := metav1.DeletePropagationBackground
-		_ = clientset.BatchV1().Jobs(namespace).Delete(context.Background(), jobName, metav1.DeleteOptions{
-			PropagationPolicy: &propagation,
-		})
-	}()
+// addScriptDiagnostics records the pod name, the script container's exit code
+// and the last scriptLogTailLines lines of its log in res.Details, without
+// overwriting details the script reported itself.
+func addScriptDiagnostics(res *checks.Result, pod *corev1.Pod, logOutput string) {
+	diagnostics := map[string]string{
+		"podName": pod.Name,
+		"logTail": logTail(logOutput, scriptLogTailLines, scriptLogTailBytes),
+	}
+	if terminated := scriptContainerTerminated(pod); terminated != nil {
+		diagnostics["exitCode"] = strconv.Itoa(int(terminated.ExitCode))
+	}
+	if res.Details == nil {
+		res.Details = make(map[string]string, len(diagnostics))
+	}
+	for k, v := range diagnostics {
+		if _, exists := res.Details[k]; !exists {
+			res.Details[k] = v
+		}
+	}
+}
+
+// logTail returns the last n lines of s, keeping at most maxBytes from the end.
+func logTail(s string, n, maxBytes int) string {
+	s = strings.TrimRight(s, "\n")
+	lines := strings.Split(s, "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	tail := strings.Join(lines, "\n")
+	if len(tail) > maxBytes {
+		tail = "(truncated)..." + tail[len(tail)-maxBytes:]
+	}
+	return tail
+}
+
+// scriptOutput is the document a script writes when resultFormat is json.
+type scriptOutput struct {
+	Ready   *bool                  `json:"ready"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details"`
+	Value   *float64               `json:"value"`
+}
 
-	// Poll until Job completes or context times out.
-	result, err := pollJobCompletion(ctx, clientset, namespace, jobName, time.Duration(timeout)*time.Second)
+// scriptJSONResult builds the check result from the JSON document in the
+// termination message, or in stdout when the termination message is empty.
+// A failed Job is never ready, but its document still supplies the message.
+func scriptJSONResult(job jobResult, termination, logOutput string) checks.Result {
+	source := termination
+	if strings.TrimSpace(source) == "" {
+		source = logOutput
+	}
+	out, err := parseScriptOutput(source)
 	if err != nil {
-		return checks.Result{}, err
+		if job.ready {
+			return checks.Result{
+				Ready:   false,
+				Message: fmt.Sprintf("script result is not valid JSON: %v: %s", err, truncateLog(source, 500)),
+			}
+		}
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("script failed (reason: %s): %s", job.reason, truncateLog(logOutput, 500)),
+		}
 	}
 
-	// Read logs from the Job's pod.
-	logOutput, logErr := getJobPodLogs(ctx, clientset, namespace, jobName)
-	if logErr != nil {
-		// Non-fatal: include error in message but still return the check result.
-		logOutput = fmt.Sprintf("(failed to read logs: %v)", logErr)
+	res := checks.Result{
+		Ready:   job.ready && *out.Ready,
+		Message: out.Message,
+		Value:   out.Value,
+	}
+	if !job.ready {
+		res.Message = fmt.Sprintf("script failed (reason: %s): %s", job.reason, out.Message)
+	}
+	if len(out.Details) > 0 {
+		res.Details = make(map[string]string, len(out.Details))
+		for k, v := range out.Details {
+			if str, ok := v.(string); ok {
+				res.Details[k] = str
+				continue
+			}
+			raw, _ := json.Marshal(v)
+			res.Details[k] = string(raw)
+		}
 	}
+	return res
+}
 
-	if result.ready {
-		return checks.Result{
-			Ready:   true,
-			Message: fmt.Sprintf("script completed successfully: %s", truncateLog(logOutput, 500)),
-		}, nil
+// parseScriptOutput decodes a script result document. Output that is not a
+// single JSON document is retried with its last non-empty line, so scripts
+// may log before printing the result.
+func parseScriptOutput(output string) (scriptOutput, error) {
+	output = strings.TrimSpace(output)
+	out, err := decodeScriptOutput(output)
+	if err == nil {
+		return out, nil
+	}
+	if i := strings.LastIndexByte(output, '\n'); i >= 0 {
+		if lastOut, lastErr := decodeScriptOutput(strings.TrimSpace(output[i+1:])); lastErr == nil {
+			return lastOut, nil
+		}
+	}
+	return scriptOutput{}, err
+}
+
+func decodeScriptOutput(s string) (scriptOutput, error) {
+	var out scriptOutput
+	if err := json.Unmarshal([]byte(s), &out); err != nil {
+		return scriptOutput{}, err
+	}
+	if out.Ready == nil {
+		return scriptOutput{}, fmt.Errorf("missing required field \"ready\"")
+	}
+	return out, nil
+}
+
+// terminationMessage returns the script container's termination message, or
+// "" when pod is nil or the container has not terminated.
+func terminationMessage(pod *corev1.Pod) string {
+	if terminated := scriptContainerTerminated(pod); terminated != nil {
+		return terminated.Message
+	}
+	return ""
+}
+
+// scriptContainerTerminated returns the script container's terminated state,
+// or nil when pod is nil or the container has not terminated.
+func scriptContainerTerminated(pod *corev1.Pod) *corev1.ContainerStateTerminated {
+	if pod == nil {
+		return nil
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == scriptContainerName {
+			return cs.State.Terminated
+		}
+	}
+	return nil
+}
+
+// resolveScriptImage picks the image to run and the node architectures the Job
+// must be restricted to. With ImagePerArch set, hostArch is preferred when listed,
+// otherwise the lexically first architecture is used so the choice is stable.
+func resolveScriptImage(spec *clustergatev1alpha1.ScriptCheckSpec, hostArch string) (string, []string) {
+	if len(spec.ImagePerArch) == 0 {
+		return spec.Image, spec.Architectures
+	}
+	if image, ok := spec.ImagePerArch[hostArch]; ok && image != "" {
+		return image, []string{hostArch}
+	}
+
+	archs := make([]string, 0, len(spec.ImagePerArch))
+	for arch := range spec.ImagePerArch {
+		archs = append(archs, arch)
+	}
+	sort.Strings(archs)
+	for _, arch := range archs {
+		if image := spec.ImagePerArch[arch]; image != "" {
+			return image, []string{arch}
+		}
+	}
+	return spec.Image, spec.Architectures
+}
+
+// archAffinity returns base with a required node affinity on kubernetes.io/arch
+// added to each node selector term, or base unchanged when no architectures
+// are given.
+func archAffinity(base *corev1.Affinity, archs []string) *corev1.Affinity {
+	if len(archs) == 0 {
+		return base
+	}
+	return requireNodeTerm(base, corev1.NodeSelectorTerm{
+		MatchExpressions: []corev1.NodeSelectorRequirement{
+			{
+				Key:      labelNodeArch,
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   archs,
+			},
+		},
+	})
+}
+
+// requireNodeTerm returns a copy of base whose required node affinity also
+// demands the expressions and fields of term.
+func requireNodeTerm(base *corev1.Affinity, term corev1.NodeSelectorTerm) *corev1.Affinity {
+	affinity := &corev1.Affinity{}
+	if base != nil {
+		affinity = base.DeepCopy()
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil || len(required.NodeSelectorTerms) == 0 {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{term},
+		}
+		return affinity
 	}
+	// Terms are ORed, so the requirements must be added to each of them.
+	for i := range required.NodeSelectorTerms {
+		t := &required.NodeSelectorTerms[i]
+		t.MatchExpressions = append(t.MatchExpressions, term.MatchExpressions...)
+		t.MatchFields = append(t.MatchFields, term.MatchFields...)
+	}
+	return affinity
+}
+
+// mountScriptSource mounts the ConfigMap script selected by spec.ScriptFrom
+// into the script container and points its command at it. The script is run
+// directly when spec.Command is empty, and passed as the first argument to
+// spec.Command otherwise.
+func mountScriptSource(podSpec *corev1.PodSpec, spec *clustergatev1alpha1.ScriptCheckSpec) {
+	if spec.ScriptFrom == nil {
+		return
+	}
+	ref := spec.ScriptFrom.ConfigMapKeyRef
+	mode := int32(0o555)
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: scriptVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: ref.LocalObjectReference,
+				Items:                []corev1.KeyToPath{{Key: ref.Key, Path: ref.Key}},
+				DefaultMode:          &mode,
+				Optional:             ref.Optional,
+			},
+		},
+	})
+
+	container := &podSpec.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      scriptVolumeName,
+		MountPath: scriptMountPath,
+		ReadOnly:  true,
+	})
+	scriptPath := path.Join(scriptMountPath, ref.Key)
+	if len(spec.Command) == 0 {
+		container.Command = []string{scriptPath}
+		return
+	}
+	container.Args = append([]string{scriptPath}, spec.Args...)
+}
+
+// scriptResources returns the container resources requested by spec.
+func scriptResources(spec *clustergatev1alpha1.ScriptCheckSpec) corev1.ResourceRequirements {
+	if spec.Resources == nil {
+		return corev1.ResourceRequirements{}
+	}
+	return *spec.Resources.DeepCopy()
+}
+
+// scriptSecurityContext returns the container security context from spec with
+// unset fields defaulted to the PodSecurity "restricted" profile.
+func scriptSecurityContext(spec *clustergatev1alpha1.ScriptCheckSpec) *corev1.SecurityContext {
+	sc := &corev1.SecurityContext{}
+	if spec.SecurityContext != nil {
+		sc = spec.SecurityContext.DeepCopy()
+	}
+	if sc.AllowPrivilegeEscalation == nil {
+		allow := false
+		sc.AllowPrivilegeEscalation = &allow
+	}
+	if sc.Capabilities == nil {
+		sc.Capabilities = &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}}
+	}
+	return sc
+}
 
-	return checks.Result{
-		Ready:   false,
-		Message: fmt.Sprintf("script failed (reason: %s): %s", result.reason, truncateLog(logOutput, 500)),
-	}, nil
+// scriptPodSecurityContext returns the pod security context from spec with
+// unset fields defaulted to the PodSecurity "restricted" profile.
+func scriptPodSecurityContext(spec *clustergatev1alpha1.ScriptCheckSpec) *corev1.PodSecurityContext {
+	psc := &corev1.PodSecurityContext{}
+	if spec.PodSecurityContext != nil {
+		psc = spec.PodSecurityContext.DeepCopy()
+	}
+	if psc.RunAsNonRoot == nil {
+		nonRoot := true
+		psc.RunAsNonRoot = &nonRoot
+	}
+	if psc.SeccompProfile == nil {
+		psc.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	}
+	return psc
 }
 
 // jobResult holds the outcome of a completed Job.
@@ -117,52 +465,84 @@ type jobResult struct {
 	reason string
 }
 
-// pollJobCompletion waits for a Job to reach a terminal state.
-func pollJobCompletion(ctx context.Context, clientset kubernetes.Interface, namespace, jobName string, timeout time.Duration) (jobResult, error) {
-	deadline := time.After(timeout)
-	ticker := time.NewTicker(scriptPollInterval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return jobResult{ready: false, reason: "context cancelled"}, ctx.Err()
-		case <-deadline:
-			return jobResult{ready: false, reason: "timeout"}, nil
-		case <-ticker.C:
-			job, err := clientset.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
-			if err != nil {
-				return jobResult{}, fmt.Errorf("failed to get job %s: %w", jobName, err)
-			}
+// waitForJobCompletion watches a Job until it reaches a terminal state or
+// timeout passes. The watch is preceded by a list, so a Job that finished
+// before the watch started is still seen.
+func waitForJobCompletion(ctx context.Context, clientset kubernetes.Interface, namespace, jobName string, timeout time.Duration) (jobResult, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-			for _, cond := range job.Status.Conditions {
-				if cond.Type == batchv1.JobComplete && cond.Status == corev1.ConditionTrue {
-					return jobResult{ready: true}, nil
-				}
-				if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
-					return jobResult{ready: false, reason: cond.Reason}, nil
-				}
-			}
+	jobs := clientset.BatchV1().Jobs(namespace)
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", jobName).String()
+	lw := &cache.ListWatch{
+		ListWithContextFunc: func(ctx context.Context, options metav1.ListOptions) (k8sruntime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return jobs.List(ctx, options)
+		},
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return jobs.Watch(ctx, options)
+		},
+	}
+
+	var result jobResult
+	// Wrapping with the clientset lets the informer fall back from streaming
+	// lists for clients that do not support them, such as the fake clientset.
+	lister := cache.ToListWatcherWithWatchListSemantics(lw, clientset)
+	_, err := watchtools.UntilWithSync(waitCtx, lister, &batchv1.Job{}, nil, func(event watch.Event) (bool, error) {
+		job, ok := event.Object.(*batchv1.Job)
+		if !ok || job.Name != jobName {
+			return false, nil
+		}
+		if event.Type == watch.Deleted {
+			return false, fmt.Errorf("job %s was deleted before it finished", jobName)
 		}
+		var done bool
+		result, done = jobTerminalState(job)
+		return done, nil
+	})
+	switch {
+	case err == nil:
+		return result, nil
+	case ctx.Err() != nil:
+		return jobResult{ready: false, reason: "context cancelled"}, ctx.Err()
+	case waitCtx.Err() != nil:
+		return jobResult{ready: false, reason: "timeout"}, nil
+	default:
+		return jobResult{}, fmt.Errorf("failed to watch job %s: %w", jobName, err)
 	}
 }
 
-// getJobPodLogs finds the pod created by the Job and returns its logs.
-func getJobPodLogs(ctx context.Context, clientset kubernetes.Interface, namespace, jobName string) (string, error) {
-	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: fmt.Sprintf("job-name=%s", jobName),
-	})
-	if err != nil {
-		return "", fmt.Errorf("failed to list pods for job %s: %w", jobName, err)
+// jobTerminalState reports whether job has completed or failed, and how.
+func jobTerminalState(job *batchv1.Job) (jobResult, bool) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobComplete && cond.Status == corev1.ConditionTrue {
+			return jobResult{ready: true}, true
+		}
+		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+			return jobResult{ready: false, reason: cond.Reason}, true
+		}
+	}
+	return jobResult{}, false
+}
+
+// getJobPod finds the pod created by the Job.
+func getJobPod(ctx context.Context, reader client.Reader, namespace, jobName string) (*corev1.Pod, error) {
+	var pods corev1.PodList
+	if err := reader.List(ctx, &pods, client.InNamespace(namespace), client.MatchingLabels{"job-name": jobName}); err != nil {
+		return nil, fmt.Errorf("failed to list pods for job %s: %w", jobName, err)
 	}
 	if len(pods.Items) == 0 {
-		return "", fmt.Errorf("no pods found for job %s", jobName)
+		return nil, fmt.Errorf("no pods found for job %s", jobName)
 	}
+	return &pods.Items[0], nil
+}
 
-	podName := pods.Items[0].Name
-	logStream, err := clientset.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{}).Stream(ctx)
+// getPodLogs returns the logs of pod.
+func getPodLogs(ctx context.Context, clientset kubernetes.Interface, pod *corev1.Pod) (string, error) {
+	logStream, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{}).Stream(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to get logs for pod %s: %w", podName, err)
+		return "", fmt.Errorf("failed to get logs for pod %s: %w", pod.Name, err)
 	}
 	defer logStream.Close()
 
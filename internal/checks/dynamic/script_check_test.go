@@ -2,19 +2,32 @@ package dynamic
 
 import (
 	"context"
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 	k8stesting "k8s.io/client-go/testing"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
 )
 
+// emptyReader returns a cache stand-in that has not observed any Job yet.
+func emptyReader() client.Reader {
+	return fake.NewClientBuilder().Build()
+}
+
 func TestTruncateLog(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -94,7 +107,7 @@ func TestExecuteScriptCheck_JobCreation(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	result, _ := executeScriptCheck(ctx, cs, "test-ns", "my-check", spec)
+	result, _ := executeScriptCheck(ctx, cs, emptyReader(), "test-ns", "my-check", spec)
 
 	if !jobCreated {
 		t.Fatal("expected Job to be created")
@@ -128,7 +141,7 @@ func TestExecuteScriptCheck_CustomTimeout(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	executeScriptCheck(ctx, cs, "test-ns", "test", spec)
+	executeScriptCheck(ctx, cs, emptyReader(), "test-ns", "test", spec)
 
 	if capturedDeadline != 120 {
 		t.Errorf("expected activeDeadlineSeconds 120, got %d", capturedDeadline)
@@ -156,7 +169,7 @@ func TestExecuteScriptCheck_DefaultTimeout(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	executeScriptCheck(ctx, cs, "test-ns", "test", spec)
+	executeScriptCheck(ctx, cs, emptyReader(), "test-ns", "test", spec)
 
 	if capturedDeadline != int64(defaultScriptTimeout) {
 		t.Errorf("expected activeDeadlineSeconds %d, got %d", defaultScriptTimeout, capturedDeadline)
@@ -186,13 +199,42 @@ func TestExecuteScriptCheck_ServiceAccountName(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	executeScriptCheck(ctx, cs, "test-ns", "test", spec)
+	executeScriptCheck(ctx, cs, emptyReader(), "test-ns", "test", spec)
 
 	if capturedSA != "my-sa" {
 		t.Errorf("expected serviceAccountName my-sa, got %s", capturedSA)
 	}
 }
 
+func TestExecuteScriptCheck_ImagePullSecrets(t *testing.T) {
+	cs := kubefake.NewSimpleClientset()
+
+	var captured []corev1.LocalObjectReference
+	cs.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		createAction := action.(k8stesting.CreateAction)
+		job := createAction.GetObject().(*batchv1.Job)
+		captured = job.Spec.Template.Spec.ImagePullSecrets
+		job.Name = "clustergate-test-abc"
+		return false, nil, nil
+	})
+
+	timeoutSec := int32(1)
+	spec := &clustergatev1alpha1.ScriptCheckSpec{
+		Image:            "registry.example.com/probes/dns:1.0",
+		TimeoutSeconds:   &timeoutSec,
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-credentials"}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	executeScriptCheck(ctx, cs, emptyReader(), "test-ns", "test", spec)
+
+	if len(captured) != 1 || captured[0].Name != "registry-credentials" {
+		t.Errorf("expected imagePullSecrets [registry-credentials], got %+v", captured)
+	}
+}
+
 func TestExecuteScriptCheck_EnvVars(t *testing.T) {
 	cs := kubefake.NewSimpleClientset()
 
@@ -219,7 +261,7 @@ func TestExecuteScriptCheck_EnvVars(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	executeScriptCheck(ctx, cs, "test-ns", "test", spec)
+	executeScriptCheck(ctx, cs, emptyReader(), "test-ns", "test", spec)
 
 	if len(capturedEnv) != 2 {
 		t.Fatalf("expected 2 env vars, got %d", len(capturedEnv))
@@ -229,7 +271,169 @@ func TestExecuteScriptCheck_EnvVars(t *testing.T) {
 	}
 }
 
-func TestPollJobCompletion_Success(t *testing.T) {
+func TestResolveScriptImage(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      clustergatev1alpha1.ScriptCheckSpec
+		hostArch  string
+		wantImage string
+		wantArchs []string
+	}{
+		{
+			name:      "plain image",
+			spec:      clustergatev1alpha1.ScriptCheckSpec{Image: "busybox"},
+			hostArch:  "amd64",
+			wantImage: "busybox",
+		},
+		{
+			name:      "architectures constraint",
+			spec:      clustergatev1alpha1.ScriptCheckSpec{Image: "busybox", Architectures: []string{"amd64"}},
+			hostArch:  "arm64",
+			wantImage: "busybox",
+			wantArchs: []string{"amd64"},
+		},
+		{
+			name: "per-arch prefers host arch",
+			spec: clustergatev1alpha1.ScriptCheckSpec{
+				Image:        "busybox",
+				ImagePerArch: map[string]string{"amd64": "probe:amd64", "arm64": "probe:arm64"},
+			},
+			hostArch:  "arm64",
+			wantImage: "probe:arm64",
+			wantArchs: []string{"arm64"},
+		},
+		{
+			name: "per-arch falls back to first arch",
+			spec: clustergatev1alpha1.ScriptCheckSpec{
+				Image:        "busybox",
+				ImagePerArch: map[string]string{"s390x": "probe:s390x", "arm64": "probe:arm64"},
+			},
+			hostArch:  "amd64",
+			wantImage: "probe:arm64",
+			wantArchs: []string{"arm64"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image, archs := resolveScriptImage(&tt.spec, tt.hostArch)
+			if image != tt.wantImage {
+				t.Errorf("image = %q, want %q", image, tt.wantImage)
+			}
+			if len(archs) != len(tt.wantArchs) {
+				t.Fatalf("archs = %v, want %v", archs, tt.wantArchs)
+			}
+			for i := range archs {
+				if archs[i] != tt.wantArchs[i] {
+					t.Errorf("archs = %v, want %v", archs, tt.wantArchs)
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteScriptCheck_ArchAffinity(t *testing.T) {
+	cs := kubefake.NewSimpleClientset()
+
+	var capturedAffinity *corev1.Affinity
+	cs.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		createAction := action.(k8stesting.CreateAction)
+		job := createAction.GetObject().(*batchv1.Job)
+		capturedAffinity = job.Spec.Template.Spec.Affinity
+		job.Name = "clustergate-test-abc"
+		return false, nil, nil
+	})
+
+	timeoutSec := int32(1)
+	spec := &clustergatev1alpha1.ScriptCheckSpec{
+		Image:          "alpine:latest",
+		Architectures:  []string{"amd64", "arm64"},
+		TimeoutSeconds: &timeoutSec,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	executeScriptCheck(ctx, cs, emptyReader(), "test-ns", "test", spec)
+
+	if capturedAffinity == nil || capturedAffinity.NodeAffinity == nil {
+		t.Fatal("expected node affinity to be set")
+	}
+	expr := capturedAffinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0]
+	if expr.Key != labelNodeArch || len(expr.Values) != 2 {
+		t.Errorf("unexpected arch requirement: %+v", expr)
+	}
+}
+
+func TestArchAffinity_MergesUserAffinity(t *testing.T) {
+	base := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"gpu"}}}},
+					{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"gpu-spot"}}}},
+				},
+			},
+		},
+	}
+
+	got := archAffinity(base, []string{"amd64"})
+	terms := got.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	for i, term := range terms {
+		if len(term.MatchExpressions) != 2 || term.MatchExpressions[1].Key != labelNodeArch {
+			t.Errorf("term %d: expected pool and arch requirements, got %+v", i, term.MatchExpressions)
+		}
+	}
+	if n := len(base.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions); n != 1 {
+		t.Errorf("expected base affinity not to be modified, got %d expressions", n)
+	}
+
+	if archAffinity(base, nil) != base {
+		t.Error("expected base affinity to be returned unchanged without architectures")
+	}
+	if archAffinity(nil, nil) != nil {
+		t.Error("expected nil affinity without base or architectures")
+	}
+}
+
+func TestExecuteScriptCheck_NodePlacement(t *testing.T) {
+	cs := kubefake.NewSimpleClientset()
+
+	var captured corev1.PodSpec
+	cs.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		createAction := action.(k8stesting.CreateAction)
+		job := createAction.GetObject().(*batchv1.Job)
+		captured = job.Spec.Template.Spec
+		job.Name = "clustergate-test-abc"
+		return false, nil, nil
+	})
+
+	timeoutSec := int32(1)
+	spec := &clustergatev1alpha1.ScriptCheckSpec{
+		Image:          "alpine:latest",
+		TimeoutSeconds: &timeoutSec,
+		NodeSelector:   map[string]string{"nvidia.com/gpu.present": "true"},
+		Tolerations: []corev1.Toleration{
+			{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	executeScriptCheck(ctx, cs, emptyReader(), "test-ns", "test", spec)
+
+	if captured.NodeSelector["nvidia.com/gpu.present"] != "true" {
+		t.Errorf("expected node selector to be set, got %v", captured.NodeSelector)
+	}
+	if len(captured.Tolerations) != 1 || captured.Tolerations[0].Key != "nvidia.com/gpu" {
+		t.Errorf("expected gpu toleration, got %+v", captured.Tolerations)
+	}
+	if captured.Affinity != nil {
+		t.Errorf("expected no affinity, got %+v", captured.Affinity)
+	}
+}
+
+func TestWaitForJobCompletion_Success(t *testing.T) {
 	completedJob := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "test-job",
@@ -248,7 +452,7 @@ func TestPollJobCompletion_Success(t *testing.T) {
 	cs := kubefake.NewSimpleClientset(completedJob)
 	ctx := context.Background()
 
-	result, err := pollJobCompletion(ctx, cs, "test-ns", "test-job", 5*time.Second)
+	result, err := waitForJobCompletion(ctx, cs, "test-ns", "test-job", 5*time.Second)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -257,7 +461,7 @@ func TestPollJobCompletion_Success(t *testing.T) {
 	}
 }
 
-func TestPollJobCompletion_Failed(t *testing.T) {
+func TestWaitForJobCompletion_Failed(t *testing.T) {
 	failedJob := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "test-job",
@@ -277,7 +481,7 @@ func TestPollJobCompletion_Failed(t *testing.T) {
 	cs := kubefake.NewSimpleClientset(failedJob)
 	ctx := context.Background()
 
-	result, err := pollJobCompletion(ctx, cs, "test-ns", "test-job", 5*time.Second)
+	result, err := waitForJobCompletion(ctx, cs, "test-ns", "test-job", 5*time.Second)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -289,7 +493,45 @@ func TestPollJobCompletion_Failed(t *testing.T) {
 	}
 }
 
-func TestPollJobCompletion_ContextCancelled(t *testing.T) {
+func TestWaitForJobCompletion_WatchesUpdates(t *testing.T) {
+	pendingJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-job",
+			Namespace: "test-ns",
+		},
+	}
+	otherJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-job",
+			Namespace: "test-ns",
+		},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}},
+		},
+	}
+	cs := kubefake.NewSimpleClientset(pendingJob, otherJob)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		completed := pendingJob.DeepCopy()
+		completed.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		_, _ = cs.BatchV1().Jobs("test-ns").UpdateStatus(context.Background(), completed, metav1.UpdateOptions{})
+	}()
+
+	start := time.Now()
+	result, err := waitForJobCompletion(context.Background(), cs, "test-ns", "test-job", 5*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.ready {
+		t.Errorf("expected ready=true once the job completes, got %+v", result)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected completion to be reported promptly, took %s", elapsed)
+	}
+}
+
+func TestWaitForJobCompletion_ContextCancelled(t *testing.T) {
 	// Job that never completes — no conditions
 	pendingJob := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
@@ -302,7 +544,7 @@ func TestPollJobCompletion_ContextCancelled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // cancel immediately
 
-	result, err := pollJobCompletion(ctx, cs, "test-ns", "test-job", 30*time.Second)
+	result, err := waitForJobCompletion(ctx, cs, "test-ns", "test-job", 30*time.Second)
 	if err == nil {
 		t.Error("expected error for cancelled context")
 	}
@@ -310,3 +552,496 @@ func TestPollJobCompletion_ContextCancelled(t *testing.T) {
 		t.Error("expected ready=false for cancelled context")
 	}
 }
+
+func TestWaitForJobCompletion_Timeout(t *testing.T) {
+	cs := kubefake.NewSimpleClientset()
+
+	result, err := waitForJobCompletion(context.Background(), cs, "test-ns", "test-job", 200*time.Millisecond)
+	if err != nil {
+		t.Fatalf("a Job that does not finish in time must not fail the check: %v", err)
+	}
+	if result.reason != "timeout" {
+		t.Errorf("reason = %q, want timeout", result.reason)
+	}
+}
+
+func TestMountScriptSource(t *testing.T) {
+	ref := corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "readiness-scripts"},
+		Key:                  "check.sh",
+	}
+	tests := []struct {
+		name        string
+		command     []string
+		args        []string
+		wantCommand []string
+		wantArgs    []string
+	}{
+		{
+			name:        "executed directly",
+			args:        []string{"--verbose"},
+			wantCommand: []string{"/clustergate/script/check.sh"},
+			wantArgs:    []string{"--verbose"},
+		},
+		{
+			name:        "passed to interpreter",
+			command:     []string{"sh"},
+			args:        []string{"--verbose"},
+			wantCommand: []string{"sh"},
+			wantArgs:    []string{"/clustergate/script/check.sh", "--verbose"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &clustergatev1alpha1.ScriptCheckSpec{
+				Image:      "alpine:latest",
+				Command:    tt.command,
+				Args:       tt.args,
+				ScriptFrom: &clustergatev1alpha1.ScriptSource{ConfigMapKeyRef: ref},
+			}
+			podSpec := corev1.PodSpec{
+				Containers: []corev1.Container{{Name: scriptContainerName, Command: spec.Command, Args: spec.Args}},
+			}
+
+			mountScriptSource(&podSpec, spec)
+
+			if len(podSpec.Volumes) != 1 || podSpec.Volumes[0].ConfigMap == nil || podSpec.Volumes[0].ConfigMap.Name != "readiness-scripts" {
+				t.Fatalf("expected readiness-scripts ConfigMap volume, got %+v", podSpec.Volumes)
+			}
+			if mode := podSpec.Volumes[0].ConfigMap.DefaultMode; mode == nil || *mode&0o111 == 0 {
+				t.Errorf("expected executable default mode, got %v", mode)
+			}
+			container := podSpec.Containers[0]
+			if len(container.VolumeMounts) != 1 || container.VolumeMounts[0].MountPath != scriptMountPath {
+				t.Errorf("expected volume mount at %s, got %+v", scriptMountPath, container.VolumeMounts)
+			}
+			if !reflect.DeepEqual(container.Command, tt.wantCommand) {
+				t.Errorf("Command = %v, want %v", container.Command, tt.wantCommand)
+			}
+			if !reflect.DeepEqual(container.Args, tt.wantArgs) {
+				t.Errorf("Args = %v, want %v", container.Args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestScriptSecurityContext_RestrictedDefaults(t *testing.T) {
+	spec := &clustergatev1alpha1.ScriptCheckSpec{Image: "alpine:latest"}
+
+	sc := scriptSecurityContext(spec)
+	if sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+		t.Errorf("expected allowPrivilegeEscalation false, got %v", sc.AllowPrivilegeEscalation)
+	}
+	if sc.Capabilities == nil || len(sc.Capabilities.Drop) != 1 || sc.Capabilities.Drop[0] != "ALL" {
+		t.Errorf("expected capabilities to drop ALL, got %+v", sc.Capabilities)
+	}
+
+	psc := scriptPodSecurityContext(spec)
+	if psc.RunAsNonRoot == nil || !*psc.RunAsNonRoot {
+		t.Errorf("expected runAsNonRoot true, got %v", psc.RunAsNonRoot)
+	}
+	if psc.SeccompProfile == nil || psc.SeccompProfile.Type != corev1.SeccompProfileTypeRuntimeDefault {
+		t.Errorf("expected RuntimeDefault seccomp profile, got %+v", psc.SeccompProfile)
+	}
+}
+
+func TestScriptSecurityContext_Overrides(t *testing.T) {
+	allow := true
+	nonRoot := false
+	uid := int64(1000)
+	spec := &clustergatev1alpha1.ScriptCheckSpec{
+		Image: "alpine:latest",
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &allow,
+		},
+		PodSecurityContext: &corev1.PodSecurityContext{
+			RunAsNonRoot: &nonRoot,
+			RunAsUser:    &uid,
+		},
+	}
+
+	sc := scriptSecurityContext(spec)
+	if !*sc.AllowPrivilegeEscalation {
+		t.Error("expected explicit allowPrivilegeEscalation to be kept")
+	}
+	if sc.Capabilities == nil || sc.Capabilities.Drop[0] != "ALL" {
+		t.Errorf("expected unset capabilities to default to drop ALL, got %+v", sc.Capabilities)
+	}
+	if spec.SecurityContext.Capabilities != nil {
+		t.Error("expected spec security context not to be modified")
+	}
+
+	psc := scriptPodSecurityContext(spec)
+	if *psc.RunAsNonRoot {
+		t.Error("expected explicit runAsNonRoot to be kept")
+	}
+	if psc.RunAsUser == nil || *psc.RunAsUser != 1000 {
+		t.Errorf("expected runAsUser 1000, got %v", psc.RunAsUser)
+	}
+	if psc.SeccompProfile == nil {
+		t.Error("expected unset seccomp profile to be defaulted")
+	}
+}
+
+func TestExecuteScriptCheck_ResourcesAndSecurityContext(t *testing.T) {
+	cs := kubefake.NewSimpleClientset()
+
+	var captured corev1.PodSpec
+	cs.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		createAction := action.(k8stesting.CreateAction)
+		job := createAction.GetObject().(*batchv1.Job)
+		captured = job.Spec.Template.Spec
+		job.Name = "clustergate-test-abc"
+		return false, nil, nil
+	})
+
+	timeoutSec := int32(1)
+	spec := &clustergatev1alpha1.ScriptCheckSpec{
+		Image:          "alpine:latest",
+		Command:        []string{"true"},
+		TimeoutSeconds: &timeoutSec,
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	executeScriptCheck(ctx, cs, emptyReader(), "test-ns", "test", spec)
+
+	if captured.SecurityContext == nil || captured.SecurityContext.RunAsNonRoot == nil || !*captured.SecurityContext.RunAsNonRoot {
+		t.Errorf("expected pod runAsNonRoot true, got %+v", captured.SecurityContext)
+	}
+	if len(captured.Containers) != 1 {
+		t.Fatalf("expected 1 container, got %d", len(captured.Containers))
+	}
+	container := captured.Containers[0]
+	if container.SecurityContext == nil || container.SecurityContext.AllowPrivilegeEscalation == nil || *container.SecurityContext.AllowPrivilegeEscalation {
+		t.Errorf("expected allowPrivilegeEscalation false, got %+v", container.SecurityContext)
+	}
+	if got := container.Resources.Limits.Memory().String(); got != "64Mi" {
+		t.Errorf("expected memory limit 64Mi, got %s", got)
+	}
+}
+
+func TestScriptJSONResult(t *testing.T) {
+	tests := []struct {
+		name        string
+		job         jobResult
+		termination string
+		logs        string
+		wantReady   bool
+		wantMessage string
+		wantDetails map[string]string
+	}{
+		{
+			name:        "stdout document",
+			job:         jobResult{ready: true},
+			logs:        `{"ready": true, "message": "3/3 endpoints reachable", "details": {"endpoints": 3, "zone": "a"}}`,
+			wantReady:   true,
+			wantMessage: "3/3 endpoints reachable",
+			wantDetails: map[string]string{"endpoints": "3", "zone": "a"},
+		},
+		{
+			name:        "last line after log noise",
+			job:         jobResult{ready: true},
+			logs:        "probing...\n{\"ready\": false, \"message\": \"1/3 endpoints reachable\"}\n",
+			wantReady:   false,
+			wantMessage: "1/3 endpoints reachable",
+		},
+		{
+			name:        "termination message preferred",
+			job:         jobResult{ready: true},
+			termination: `{"ready": true, "message": "from termination log"}`,
+			logs:        `{"ready": false, "message": "from stdout"}`,
+			wantReady:   true,
+			wantMessage: "from termination log",
+		},
+		{
+			name:        "failed job is never ready",
+			job:         jobResult{ready: false, reason: "BackoffLimitExceeded"},
+			logs:        `{"ready": true, "message": "claims ready"}`,
+			wantReady:   false,
+			wantMessage: "script failed (reason: BackoffLimitExceeded): claims ready",
+		},
+		{
+			name:        "invalid document",
+			job:         jobResult{ready: true},
+			logs:        "DNS OK",
+			wantReady:   false,
+			wantMessage: "script result is not valid JSON",
+		},
+		{
+			name:        "missing ready",
+			job:         jobResult{ready: true},
+			logs:        `{"message": "no verdict"}`,
+			wantReady:   false,
+			wantMessage: `missing required field "ready"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scriptJSONResult(tt.job, tt.termination, tt.logs)
+			if got.Ready != tt.wantReady {
+				t.Errorf("Ready = %v, want %v", got.Ready, tt.wantReady)
+			}
+			if !strings.Contains(got.Message, tt.wantMessage) {
+				t.Errorf("Message = %q, want it to contain %q", got.Message, tt.wantMessage)
+			}
+			for k, v := range tt.wantDetails {
+				if got.Details[k] != v {
+					t.Errorf("Details[%q] = %q, want %q", k, got.Details[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestTerminationMessage(t *testing.T) {
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: scriptContainerName,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Message: `{"ready": true}`},
+					},
+				},
+			},
+		},
+	}
+	if got := terminationMessage(pod); got != `{"ready": true}` {
+		t.Errorf("terminationMessage() = %q", got)
+	}
+	if got := terminationMessage(nil); got != "" {
+		t.Errorf("terminationMessage(nil) = %q, want empty", got)
+	}
+}
+
+func TestScriptResultCache(t *testing.T) {
+	var cache scriptResultCache
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if _, _, ok := cache.get("dns/1", now); ok {
+		t.Fatal("expected empty cache to miss")
+	}
+
+	cache.put("dns/1", checks.Result{Ready: true, Message: "DNS OK"}, time.Minute, now)
+
+	got, finished, ok := cache.get("dns/1", now.Add(30*time.Second))
+	if !ok || !got.Ready || got.Message != "DNS OK" || !finished.Equal(now) {
+		t.Errorf("expected cached result within TTL, got %+v at %v (ok=%v)", got, finished, ok)
+	}
+	if _, _, ok := cache.get("dns/1", now.Add(time.Minute)); ok {
+		t.Error("expected result to expire after TTL")
+	}
+
+	cache.put("dns/2", checks.Result{}, time.Minute, now.Add(2*time.Minute))
+	if _, exists := cache.entries["dns/1"]; exists {
+		t.Error("expected expired entry to be pruned on put")
+	}
+}
+
+func TestScriptResultKey(t *testing.T) {
+	spec := &clustergatev1alpha1.ScriptCheckSpec{Image: "alpine:latest", Args: []string{"a"}}
+	changed := &clustergatev1alpha1.ScriptCheckSpec{Image: "alpine:latest", Args: []string{"b"}}
+
+	if scriptResultKey("dns", spec) != scriptResultKey("dns", spec.DeepCopy()) {
+		t.Error("expected identical specs to share a key")
+	}
+	if scriptResultKey("dns", spec) == scriptResultKey("dns", changed) {
+		t.Error("expected changed spec to get a new key")
+	}
+	if scriptResultKey("dns", spec) == scriptResultKey("ntp", spec) {
+		t.Error("expected different checks to get different keys")
+	}
+}
+
+func TestCachedScriptResult(t *testing.T) {
+	original := checks.Result{Ready: true, Details: map[string]string{"zone": "a"}}
+	finished := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	got := cachedScriptResult(original, finished)
+
+	if got.Details["cachedAt"] != "2026-01-01T12:00:00Z" || got.Details["zone"] != "a" {
+		t.Errorf("unexpected details: %v", got.Details)
+	}
+	if _, exists := original.Details["cachedAt"]; exists {
+		t.Error("expected cached result details not to be shared with the stored result")
+	}
+}
+
+func TestExecuteQueuedScriptCheck_WaitsForSlot(t *testing.T) {
+	e := &Executor{clientset: kubefake.NewSimpleClientset(), client: fake.NewClientBuilder().Build(), namespace: "test-ns"}
+	WithMaxConcurrentScriptJobs(1)(e)
+	e.scriptSlots <- struct{}{}
+
+	timeoutSec := int32(1)
+	spec := &clustergatev1alpha1.ScriptCheckSpec{Image: "alpine:latest", TimeoutSeconds: &timeoutSec}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := e.executeQueuedScriptCheck(ctx, "test", spec); err == nil || !strings.Contains(err.Error(), "waiting for a script job slot") {
+		t.Fatalf("expected slot wait error, got %v", err)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		<-e.scriptSlots
+	}()
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel2()
+	result, err := e.executeQueuedScriptCheck(ctx2, "test", spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	queued, err := time.ParseDuration(result.Details["queuedFor"])
+	if err != nil || queued < 50*time.Millisecond {
+		t.Errorf("expected queuedFor of at least 50ms, got %q", result.Details["queuedFor"])
+	}
+	if len(e.scriptSlots) != 0 {
+		t.Errorf("expected slot to be released, %d held", len(e.scriptSlots))
+	}
+}
+
+func TestWithMaxConcurrentScriptJobs(t *testing.T) {
+	e := &Executor{}
+	WithMaxConcurrentScriptJobs(3)(e)
+	if cap(e.scriptSlots) != 3 {
+		t.Errorf("expected 3 slots, got %d", cap(e.scriptSlots))
+	}
+	WithMaxConcurrentScriptJobs(0)(e)
+	if e.scriptSlots != nil {
+		t.Error("expected no limit for 0")
+	}
+}
+
+func TestNodeScriptSpec(t *testing.T) {
+	spec := &clustergatev1alpha1.ScriptCheckSpec{
+		Image:         "alpine:latest",
+		RunOnAllNodes: true,
+		Architectures: []string{"amd64"},
+		Env:           []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+	}
+
+	pinned := nodeScriptSpec(spec, "worker-1")
+
+	if pinned.RunOnAllNodes {
+		t.Error("expected pinned spec not to fan out again")
+	}
+	if len(pinned.Env) != 2 || pinned.Env[1].Name != scriptNodeNameEnv || pinned.Env[1].Value != "worker-1" {
+		t.Errorf("expected node name env var, got %+v", pinned.Env)
+	}
+	if len(spec.Env) != 1 || spec.Affinity != nil {
+		t.Error("expected original spec not to be modified")
+	}
+	fields := pinned.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields
+	if len(fields) != 1 || fields[0].Key != "metadata.name" || fields[0].Values[0] != "worker-1" {
+		t.Errorf("expected metadata.name requirement, got %+v", fields)
+	}
+
+	affinity := archAffinity(pinned.Affinity, spec.Architectures)
+	term := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0]
+	if len(term.MatchFields) != 1 || len(term.MatchExpressions) != 1 {
+		t.Errorf("expected node name and arch requirements in one term, got %+v", term)
+	}
+}
+
+func TestAggregateNodeScriptResults(t *testing.T) {
+	results := []nodeScriptResult{
+		{node: "worker-1", result: checks.Result{Ready: true, Message: "ok"}},
+		{node: "worker-2", result: checks.Result{Ready: false, Message: "script failed (reason: BackoffLimitExceeded): nvidia module missing"}},
+		{node: "worker-3", err: errors.New("failed to create script check job: forbidden")},
+	}
+
+	got := aggregateNodeScriptResults(results)
+
+	if got.Ready {
+		t.Error("expected not ready when any node fails")
+	}
+	if !strings.Contains(got.Message, "2 of 3 nodes: worker-2, worker-3") {
+		t.Errorf("unexpected message: %s", got.Message)
+	}
+	if !strings.Contains(got.Details["node.worker-2"], "nvidia module missing") || !strings.Contains(got.Details["node.worker-3"], "forbidden") {
+		t.Errorf("expected per-node failure details, got %v", got.Details)
+	}
+
+	allReady := aggregateNodeScriptResults(results[:1])
+	if !allReady.Ready || allReady.Details["nodes"] != "1" {
+		t.Errorf("expected ready on all nodes, got %+v", allReady)
+	}
+}
+
+func TestExecuteScriptCheckOnNodes_NoMatchingNodes(t *testing.T) {
+	cordoned := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu-1", Labels: map[string]string{"pool": "gpu"}},
+		Spec:       corev1.NodeSpec{Unschedulable: true},
+	}
+	other := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cpu-1", Labels: map[string]string{"pool": "cpu"}}}
+	e := newTestExecutor(fake.NewClientBuilder().WithObjects(cordoned, other).Build())
+
+	spec := &clustergatev1alpha1.ScriptCheckSpec{
+		Image:         "alpine:latest",
+		RunOnAllNodes: true,
+		NodeSelector:  map[string]string{"pool": "gpu"},
+	}
+	got, err := e.executeScriptCheckOnNodes(context.Background(), "gpu-smoke", spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Ready || !strings.Contains(got.Message, "no schedulable nodes") {
+		t.Errorf("expected no schedulable nodes failure, got %+v", got)
+	}
+}
+
+func TestLogTail(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		n        int
+		maxBytes int
+		want     string
+	}{
+		{"fewer lines", "a\nb\n", 3, 100, "a\nb"},
+		{"last lines", "a\nb\nc\nd\n", 2, 100, "c\nd"},
+		{"byte cap", "0123456789", 5, 4, "(truncated)...6789"},
+		{"empty", "", 3, 100, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := logTail(tt.input, tt.n, tt.maxBytes); got != tt.want {
+				t.Errorf("logTail(%q, %d, %d) = %q, want %q", tt.input, tt.n, tt.maxBytes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddScriptDiagnostics(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "clustergate-dns-abc-xyz"},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: scriptContainerName,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{ExitCode: 3},
+					},
+				},
+			},
+		},
+	}
+	res := checks.Result{Details: map[string]string{"podName": "reported-by-script"}}
+
+	addScriptDiagnostics(&res, pod, "resolving...\nNXDOMAIN\n")
+
+	if res.Details["exitCode"] != "3" {
+		t.Errorf("exitCode = %q, want 3", res.Details["exitCode"])
+	}
+	if res.Details["logTail"] != "resolving...\nNXDOMAIN" {
+		t.Errorf("logTail = %q", res.Details["logTail"])
+	}
+	if res.Details["podName"] != "reported-by-script" {
+		t.Errorf("expected script-reported details to be kept, got podName %q", res.Details["podName"])
+	}
+}
@@ -0,0 +1,113 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+)
+
+// maxCronJobNameLength leaves room for the suffix the CronJob controller
+// appends to the names of the Jobs it creates.
+const maxCronJobNameLength = 52
+
+// ScriptCronJobName returns the name of the CronJob that runs the scheduled
+// script check checkName. Names too long for a CronJob are truncated and end
+// in a hash of checkName, so checks sharing a long prefix get distinct
+// CronJobs.
+func ScriptCronJobName(checkName string) string {
+	name := "clustergate-" + checkName
+	if len(name) <= maxCronJobNameLength {
+		return name
+	}
+	h := fnv.New32a()
+	h.Write([]byte(checkName))
+	suffix := fmt.Sprintf("-%08x", h.Sum32())
+	return strings.TrimRight(name[:maxCronJobNameLength-len(suffix)], "-.") + suffix
+}
+
+// ScriptCronJob returns the CronJob that runs spec's script for checkName on
+// spec.Schedule. Only one run is active at a time and only the latest
+// successful and failed Jobs are kept.
+func ScriptCronJob(namespace, checkName string, spec *clustergatev1alpha1.ScriptCheckSpec) *batchv1.CronJob {
+	job := buildScriptJob(namespace, checkName, spec)
+	historyLimit := int32(1)
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ScriptCronJobName(checkName),
+			Namespace: namespace,
+			Labels:    job.Labels,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   spec.Schedule,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: &historyLimit,
+			FailedJobsHistoryLimit:     &historyLimit,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: job.Labels},
+				Spec:       job.Spec,
+			},
+		},
+	}
+}
+
+// isCronJobOwned reports whether job was created by a CronJob.
+func isCronJobOwned(job *batchv1.Job) bool {
+	for _, ref := range job.OwnerReferences {
+		if ref.Kind == "CronJob" {
+			return true
+		}
+	}
+	return false
+}
+
+// readScheduledScriptCheck reports the latest finished run of the CronJob
+// the GateCheck controller materializes for a scheduled script check,
+// without creating a Job.
+func (e *Executor) readScheduledScriptCheck(ctx context.Context, checkName string, spec *clustergatev1alpha1.ScriptCheckSpec) (checks.Result, error) {
+	var jobs batchv1.JobList
+	if err := e.client.List(ctx, &jobs, client.InNamespace(e.namespace), client.MatchingLabels{
+		labelManagedBy: labelManagedByValue,
+		labelCheckName: checkName,
+	}); err != nil {
+		return checks.Result{}, fmt.Errorf("failed to list scheduled script check jobs: %w", err)
+	}
+
+	var latest *batchv1.Job
+	var latestResult jobResult
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if !isCronJobOwned(job) {
+			continue
+		}
+		result, done := jobTerminalState(job)
+		if !done {
+			continue
+		}
+		if latest == nil || job.CreationTimestamp.After(latest.CreationTimestamp.Time) {
+			latest, latestResult = job, result
+		}
+	}
+	if latest == nil {
+		return checks.Result{
+			Ready: false,
+			Message: fmt.Sprintf("no scheduled run of CronJob %s/%s has finished yet",
+				e.namespace, ScriptCronJobName(checkName)),
+		}, nil
+	}
+
+	res := scriptJobResult(ctx, e.clientset, e.client, e.namespace, latest.Name, spec, latestResult)
+	if res.Details == nil {
+		res.Details = make(map[string]string, 1)
+	}
+	res.Details["lastRun"] = latest.CreationTimestamp.UTC().Format(time.RFC3339)
+	return res, nil
+}
@@ -0,0 +1,139 @@
+package dynamic
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+func cronScriptJob(name string, created time.Time, condition batchv1.JobConditionType) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "clustergate-system",
+			Labels:            map[string]string{labelManagedBy: labelManagedByValue, labelCheckName: "disk-bench"},
+			CreationTimestamp: metav1.NewTime(created),
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "batch/v1", Kind: "CronJob", Name: "clustergate-disk-bench", UID: "cron-uid"},
+			},
+		},
+	}
+	if condition != "" {
+		job.Status.Conditions = []batchv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"}}
+	}
+	return job
+}
+
+func TestScriptCronJob(t *testing.T) {
+	spec := &clustergatev1alpha1.ScriptCheckSpec{Image: "my-probe:1.0", Schedule: "*/5 * * * *"}
+
+	cronJob := ScriptCronJob("clustergate-system", "disk-bench", spec)
+
+	if cronJob.Name != "clustergate-disk-bench" || cronJob.Namespace != "clustergate-system" {
+		t.Errorf("unexpected CronJob key %s/%s", cronJob.Namespace, cronJob.Name)
+	}
+	if cronJob.Spec.Schedule != "*/5 * * * *" || cronJob.Spec.ConcurrencyPolicy != batchv1.ForbidConcurrent {
+		t.Errorf("unexpected schedule or concurrency policy: %+v", cronJob.Spec)
+	}
+	if cronJob.Spec.JobTemplate.Labels[labelCheckName] != "disk-bench" || cronJob.Labels[labelManagedBy] != labelManagedByValue {
+		t.Errorf("expected script job labels, got %v and %v", cronJob.Labels, cronJob.Spec.JobTemplate.Labels)
+	}
+	if cronJob.Spec.JobTemplate.Spec.TTLSecondsAfterFinished != nil {
+		t.Error("expected scheduled Jobs to be kept until the history limit removes them")
+	}
+
+	long := ScriptCronJobName(strings.Repeat("a", 63))
+	if len(long) != maxCronJobNameLength {
+		t.Errorf("expected long names to be truncated to %d characters, got %d", maxCronJobNameLength, len(long))
+	}
+}
+
+func TestScriptCronJobName(t *testing.T) {
+	if got := ScriptCronJobName("disk-bench"); got != "clustergate-disk-bench" {
+		t.Errorf("ScriptCronJobName(disk-bench) = %q", got)
+	}
+
+	prefix := "storage-latency-benchmark-for-the-primary-database-"
+	a, b := ScriptCronJobName(prefix+"east"), ScriptCronJobName(prefix+"west")
+	if a == b {
+		t.Errorf("checks sharing a long prefix map to the same CronJob %q", a)
+	}
+	// The cut falls on a "-", which must not end up before the hash.
+	dashed := ScriptCronJobName(strings.Repeat("a", 30) + strings.Repeat("-", 20) + "b")
+	for _, name := range []string{a, b, dashed} {
+		if len(name) > maxCronJobNameLength {
+			t.Errorf("%q is longer than %d characters", name, maxCronJobNameLength)
+		}
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			t.Errorf("%q is not a valid name: %v", name, errs)
+		}
+	}
+	if strings.Contains(dashed, "--") {
+		t.Errorf("%q keeps the dashes cut off at the limit", dashed)
+	}
+}
+
+func TestReadScheduledScriptCheck(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	adHoc := cronScriptJob("clustergate-disk-bench-adhoc", now, batchv1.JobComplete)
+	adHoc.OwnerReferences = nil
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "clustergate-disk-bench-200-abc",
+			Namespace: "clustergate-system",
+			Labels:    map[string]string{"job-name": "clustergate-disk-bench-200"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(
+		cronScriptJob("clustergate-disk-bench-100", now.Add(-10*time.Minute), batchv1.JobComplete),
+		cronScriptJob("clustergate-disk-bench-200", now.Add(-5*time.Minute), batchv1.JobFailed),
+		cronScriptJob("clustergate-disk-bench-300", now.Add(-time.Minute), ""),
+		adHoc,
+		pod,
+	).Build()
+	e := newTestExecutor(c)
+	e.clientset = kubefake.NewSimpleClientset()
+	e.namespace = "clustergate-system"
+
+	spec := &clustergatev1alpha1.ScriptCheckSpec{Image: "my-probe:1.0", Schedule: "*/5 * * * *"}
+	got, err := e.readScheduledScriptCheck(context.Background(), "disk-bench", spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Ready || !strings.Contains(got.Message, "BackoffLimitExceeded") {
+		t.Errorf("expected the latest finished run to fail, got %+v", got)
+	}
+	if got.Details["lastRun"] != now.Add(-5*time.Minute).Format(time.RFC3339) {
+		t.Errorf("lastRun = %q", got.Details["lastRun"])
+	}
+	if got.Details["podName"] != "clustergate-disk-bench-200-abc" {
+		t.Errorf("podName = %q", got.Details["podName"])
+	}
+}
+
+func TestReadScheduledScriptCheck_NoFinishedRun(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(
+		cronScriptJob("clustergate-disk-bench-300", time.Now(), ""),
+	).Build()
+	e := newTestExecutor(c)
+	e.namespace = "clustergate-system"
+
+	spec := &clustergatev1alpha1.ScriptCheckSpec{Image: "my-probe:1.0", Schedule: "*/5 * * * *"}
+	got, err := e.readScheduledScriptCheck(context.Background(), "disk-bench", spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Ready || !strings.Contains(got.Message, "has finished yet") {
+		t.Errorf("expected waiting for first run, got %+v", got)
+	}
+}
@@ -0,0 +1,118 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/clustergate/clustergate/internal/metrics"
+)
+
+const (
+	defaultScriptJobGCInterval = 10 * time.Minute
+	defaultScriptJobTTL        = 10 * time.Minute
+)
+
+// ScriptJobJanitor periodically deletes script check Jobs and pods left
+// behind when the operator exits mid-check and its deferred delete never runs.
+// A Job is orphaned once it is older than its activeDeadlineSeconds plus TTL,
+// so Jobs of checks still running are never collected. Pods are collected
+// when they are older than TTL and their Job no longer exists. Jobs created by
+// scheduled script checks' CronJobs are left to the CronJob's history limits.
+type ScriptJobJanitor struct {
+	Client    client.Client
+	Namespace string
+	Interval  time.Duration
+	TTL       time.Duration
+}
+
+// Start runs the janitor until the context is cancelled. It implements manager.Runnable.
+func (j *ScriptJobJanitor) Start(ctx context.Context) error {
+	interval := j.Interval
+	if interval <= 0 {
+		interval = defaultScriptJobGCInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := j.Collect(ctx, time.Now()); err != nil {
+				log.FromContext(ctx).Error(err, "failed to garbage-collect script check jobs")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection returns true: only the leader runs script checks.
+func (j *ScriptJobJanitor) NeedLeaderElection() bool {
+	return true
+}
+
+// Collect deletes orphaned script check Jobs and pods as of now and returns
+// the names of the deleted objects.
+func (j *ScriptJobJanitor) Collect(ctx context.Context, now time.Time) ([]string, error) {
+	ttl := j.TTL
+	if ttl <= 0 {
+		ttl = defaultScriptJobTTL
+	}
+
+	var jobs batchv1.JobList
+	if err := j.Client.List(ctx, &jobs, client.InNamespace(j.Namespace), client.MatchingLabels(ScriptJobLabels)); err != nil {
+		return nil, fmt.Errorf("failed to list script check jobs: %w", err)
+	}
+	var deleted []string
+	live := make(map[string]bool, len(jobs.Items))
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if isCronJobOwned(job) {
+			// The CronJob's history limits clean these up.
+			live[job.Name] = true
+			continue
+		}
+		maxAge := ttl
+		if job.Spec.ActiveDeadlineSeconds != nil {
+			maxAge += time.Duration(*job.Spec.ActiveDeadlineSeconds) * time.Second
+		}
+		if now.Sub(job.CreationTimestamp.Time) <= maxAge {
+			live[job.Name] = true
+			continue
+		}
+		if err := j.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+			return deleted, fmt.Errorf("failed to delete script check job %s: %w", job.Name, err)
+		}
+		deleted = append(deleted, "job/"+job.Name)
+	}
+
+	var pods corev1.PodList
+	if err := j.Client.List(ctx, &pods, client.InNamespace(j.Namespace), client.MatchingLabels(ScriptJobLabels)); err != nil {
+		return deleted, fmt.Errorf("failed to list script check pods: %w", err)
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if live[pod.Labels["job-name"]] || now.Sub(pod.CreationTimestamp.Time) <= ttl {
+			continue
+		}
+		if err := j.Client.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+			return deleted, fmt.Errorf("failed to delete script check pod %s: %w", pod.Name, err)
+		}
+		deleted = append(deleted, "pod/"+pod.Name)
+	}
+
+	for _, name := range deleted {
+		log.FromContext(ctx).Info("deleted orphaned script check object", "object", name, "namespace", j.Namespace)
+	}
+	metrics.ScriptJobsCollected.Add(float64(len(deleted)))
+	return deleted, nil
+}
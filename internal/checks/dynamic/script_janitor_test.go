@@ -0,0 +1,82 @@
+package dynamic
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func scriptJob(name string, created time.Time, deadline int64) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "clustergate-system",
+			Labels:            map[string]string{labelManagedBy: labelManagedByValue},
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: batchv1.JobSpec{ActiveDeadlineSeconds: &deadline},
+	}
+}
+
+func scriptPod(name, jobName string, created time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "clustergate-system",
+			Labels:            map[string]string{labelManagedBy: labelManagedByValue, "job-name": jobName},
+			CreationTimestamp: metav1.NewTime(created),
+		},
+	}
+}
+
+func TestScriptJobJanitor_Collect(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	unmanaged := scriptJob("user-job", now.Add(-24*time.Hour), 30)
+	unmanaged.Labels = nil
+	scheduled := scriptJob("clustergate-disk-bench-100", now.Add(-24*time.Hour), 30)
+	scheduled.OwnerReferences = []metav1.OwnerReference{{APIVersion: "batch/v1", Kind: "CronJob", Name: "clustergate-disk-bench", UID: "cron-uid"}}
+
+	c := fake.NewClientBuilder().WithScheme(dynamicTestScheme()).WithObjects(
+		// Created 15m ago with a 30s deadline: past deadline + 10m TTL.
+		scriptJob("clustergate-dns-old", now.Add(-15*time.Minute), 30),
+		// Created 15m ago with a 1h deadline: possibly still running.
+		scriptJob("clustergate-slow-running", now.Add(-15*time.Minute), 3600),
+		scriptJob("clustergate-dns-new", now.Add(-time.Minute), 30),
+		unmanaged,
+		scheduled,
+		scriptPod("clustergate-slow-running-abc", "clustergate-slow-running", now.Add(-15*time.Minute)),
+		scriptPod("clustergate-gone-abc", "clustergate-gone", now.Add(-15*time.Minute)),
+		scriptPod("clustergate-gone-new", "clustergate-gone-2", now.Add(-time.Minute)),
+	).Build()
+
+	janitor := &ScriptJobJanitor{Client: c, Namespace: "clustergate-system"}
+	deleted, err := janitor.Collect(context.Background(), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sort.Strings(deleted)
+	want := []string{"job/clustergate-dns-old", "pod/clustergate-gone-abc"}
+	if len(deleted) != len(want) || deleted[0] != want[0] || deleted[1] != want[1] {
+		t.Errorf("deleted = %v, want %v", deleted, want)
+	}
+
+	var job batchv1.Job
+	err = c.Get(context.Background(), types.NamespacedName{Namespace: "clustergate-system", Name: "clustergate-dns-old"}, &job)
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected orphaned job to be deleted, got %v", err)
+	}
+	for _, name := range []string{"clustergate-slow-running", "clustergate-dns-new", "user-job", "clustergate-disk-bench-100"} {
+		if err := c.Get(context.Background(), types.NamespacedName{Namespace: "clustergate-system", Name: name}, &job); err != nil {
+			t.Errorf("expected job %s to be kept, got %v", name, err)
+		}
+	}
+}
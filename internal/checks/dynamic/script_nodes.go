@@ -0,0 +1,111 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+)
+
+// scriptNodeNameEnv is set to the target node's name in runOnAllNodes Jobs.
+const scriptNodeNameEnv = "CLUSTERGATE_NODE_NAME"
+
+// nodeScriptResult is the outcome of a script Job on one node.
+type nodeScriptResult struct {
+	node   string
+	result checks.Result
+	err    error
+}
+
+// executeScriptCheckOnNodes runs the script once on every schedulable node
+// matching spec.NodeSelector and is ready only when every run is ready.
+// Cordoned nodes are skipped. Runs share the executor's Job concurrency limit.
+func (e *Executor) executeScriptCheckOnNodes(ctx context.Context, checkName string, spec *clustergatev1alpha1.ScriptCheckSpec) (checks.Result, error) {
+	var nodes corev1.NodeList
+	if err := e.client.List(ctx, &nodes, client.MatchingLabels(spec.NodeSelector)); err != nil {
+		return checks.Result{}, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	var targets []string
+	for _, node := range nodes.Items {
+		if !node.Spec.Unschedulable {
+			targets = append(targets, node.Name)
+		}
+	}
+	if len(targets) == 0 {
+		return checks.Result{
+			Ready:   false,
+			Message: "no schedulable nodes match the script check's nodeSelector",
+		}, nil
+	}
+	sort.Strings(targets)
+
+	results := make([]nodeScriptResult, len(targets))
+	var wg sync.WaitGroup
+	for i, node := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := e.executeQueuedScriptCheck(ctx, checkName, nodeScriptSpec(spec, node))
+			results[i] = nodeScriptResult{node: node, result: res, err: err}
+		}()
+	}
+	wg.Wait()
+
+	return aggregateNodeScriptResults(results), nil
+}
+
+// nodeScriptSpec returns a copy of spec pinned to node, with the node name
+// in the CLUSTERGATE_NODE_NAME environment variable.
+func nodeScriptSpec(spec *clustergatev1alpha1.ScriptCheckSpec, node string) *clustergatev1alpha1.ScriptCheckSpec {
+	pinned := spec.DeepCopy()
+	pinned.RunOnAllNodes = false
+	pinned.Env = append(pinned.Env, corev1.EnvVar{Name: scriptNodeNameEnv, Value: node})
+	pinned.Affinity = requireNodeTerm(pinned.Affinity, corev1.NodeSelectorTerm{
+		MatchFields: []corev1.NodeSelectorRequirement{
+			{
+				Key:      "metadata.name",
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{node},
+			},
+		},
+	})
+	return pinned
+}
+
+// aggregateNodeScriptResults combines per-node results, which must be sorted
+// by node. Errors running a node's Job count as failures on that node.
+func aggregateNodeScriptResults(results []nodeScriptResult) checks.Result {
+	var failed []string
+	details := map[string]string{}
+	for _, r := range results {
+		ready, message := r.result.Ready, r.result.Message
+		if r.err != nil {
+			ready, message = false, r.err.Error()
+		}
+		if !ready {
+			failed = append(failed, r.node)
+			details["node."+r.node] = truncateLog(message, 200)
+		}
+	}
+	details["nodes"] = fmt.Sprintf("%d", len(results))
+
+	if len(failed) == 0 {
+		return checks.Result{
+			Ready:   true,
+			Message: fmt.Sprintf("script completed successfully on all %d nodes", len(results)),
+			Details: details,
+		}
+	}
+	details["failedNodes"] = capSeries(failed)
+	return checks.Result{
+		Ready:   false,
+		Message: fmt.Sprintf("script failed on %d of %d nodes: %s", len(failed), len(results), capSeries(failed)),
+		Details: details,
+	}
+}
@@ -0,0 +1,147 @@
+package dynamic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+)
+
+// scriptJobTTLAfterFinished lets the Job TTL controller remove a finished
+// script Job whose deferred delete never ran, e.g. because the operator exited.
+const scriptJobTTLAfterFinished = int32(300)
+
+// scriptResultCache remembers script check outcomes so identical checks run
+// within resultTTLSeconds reuse one Job instead of each creating their own.
+// Concurrent runs of the same check share a single Job. The zero value is
+// ready to use.
+type scriptResultCache struct {
+	mu      sync.Mutex
+	entries map[string]scriptResultEntry
+	group   singleflight.Group
+}
+
+type scriptResultEntry struct {
+	result   checks.Result
+	finished time.Time
+	expires  time.Time
+}
+
+// get returns the result stored for key if it has not expired at now.
+func (c *scriptResultCache) get(key string, now time.Time) (checks.Result, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !now.Before(entry.expires) {
+		return checks.Result{}, time.Time{}, false
+	}
+	return entry.result, entry.finished, true
+}
+
+// put stores result for key until now+ttl and forgets expired entries.
+func (c *scriptResultCache) put(key string, result checks.Result, ttl time.Duration, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]scriptResultEntry)
+	}
+	for k, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = scriptResultEntry{result: result, finished: now, expires: now.Add(ttl)}
+}
+
+// scriptResultKey identifies a script check by name and spec, so a changed
+// or namespace-scoped spec never reuses another's result.
+func scriptResultKey(checkName string, spec *clustergatev1alpha1.ScriptCheckSpec) string {
+	h := fnv.New64a()
+	raw, _ := json.Marshal(spec)
+	h.Write(raw)
+	return fmt.Sprintf("%s/%x", checkName, h.Sum64())
+}
+
+// runScriptCheck runs a script check, reusing a result from the last
+// resultTTLSeconds when one exists. Errors are never reused. Scheduled script
+// checks report the latest run of their CronJob instead.
+func (e *Executor) runScriptCheck(ctx context.Context, checkName string, spec *clustergatev1alpha1.ScriptCheckSpec) (checks.Result, error) {
+	if spec.Schedule != "" {
+		return e.readScheduledScriptCheck(ctx, checkName, spec)
+	}
+	run := e.executeQueuedScriptCheck
+	if spec.RunOnAllNodes {
+		run = e.executeScriptCheckOnNodes
+	}
+	if spec.ResultTTLSeconds == nil || *spec.ResultTTLSeconds <= 0 {
+		return run(ctx, checkName, spec)
+	}
+	ttl := time.Duration(*spec.ResultTTLSeconds) * time.Second
+	key := scriptResultKey(checkName, spec)
+
+	if result, finished, ok := e.scripts.get(key, time.Now()); ok {
+		return cachedScriptResult(result, finished), nil
+	}
+	v, err, _ := e.scripts.group.Do(key, func() (interface{}, error) {
+		if result, finished, ok := e.scripts.get(key, time.Now()); ok {
+			return cachedScriptResult(result, finished), nil
+		}
+		result, err := run(ctx, checkName, spec)
+		if err == nil {
+			e.scripts.put(key, result, ttl, time.Now())
+		}
+		return result, err
+	})
+	if err != nil {
+		return checks.Result{}, err
+	}
+	return v.(checks.Result), nil
+}
+
+// executeQueuedScriptCheck runs a script check once a Job slot is free, when
+// the executor limits concurrent Jobs, and records the time spent waiting in
+// the "queuedFor" detail.
+func (e *Executor) executeQueuedScriptCheck(ctx context.Context, checkName string, spec *clustergatev1alpha1.ScriptCheckSpec) (checks.Result, error) {
+	if e.scriptSlots == nil {
+		return executeScriptCheck(ctx, e.clientset, e.client, e.namespace, checkName, spec)
+	}
+
+	queued := time.Now()
+	select {
+	case e.scriptSlots <- struct{}{}:
+	case <-ctx.Done():
+		return checks.Result{}, fmt.Errorf("waiting for a script job slot after %s: %w",
+			time.Since(queued).Round(time.Millisecond), ctx.Err())
+	}
+	defer func() { <-e.scriptSlots }()
+	wait := time.Since(queued)
+
+	result, err := executeScriptCheck(ctx, e.clientset, e.client, e.namespace, checkName, spec)
+	if err != nil {
+		return result, err
+	}
+	if result.Details == nil {
+		result.Details = make(map[string]string, 1)
+	}
+	result.Details["queuedFor"] = wait.Round(time.Millisecond).String()
+	return result, nil
+}
+
+// cachedScriptResult returns a copy of result recording when its Job finished.
+func cachedScriptResult(result checks.Result, finished time.Time) checks.Result {
+	details := make(map[string]string, len(result.Details)+1)
+	maps.Copy(details, result.Details)
+	details["cachedAt"] = finished.UTC().Format(time.RFC3339)
+	result.Details = details
+	return result
+}
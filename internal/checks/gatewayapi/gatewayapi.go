@@ -0,0 +1,311 @@
+package gatewayapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/clustergate/clustergate/internal/checks"
+)
+
+const (
+	CheckName = "gateway-api"
+
+	group = "gateway.networking.k8s.io"
+
+	// maxReportedProblems caps how many problems are listed in the result message.
+	maxReportedProblems = 5
+)
+
+var (
+	gatewayListGVK   = schema.GroupVersionKind{Group: group, Version: "v1", Kind: "GatewayList"}
+	httpRouteListGVK = schema.GroupVersionKind{Group: group, Version: "v1", Kind: "HTTPRouteList"}
+)
+
+// Config holds Gateway API check-specific configuration.
+type Config struct {
+	// Namespace limits the check to Gateways and HTTPRoutes in one namespace.
+	// Defaults to all namespaces.
+	Namespace string `json:"namespace,omitempty"`
+
+	// GatewayClassName limits the check to Gateways of this class and the
+	// HTTPRoutes attached to them.
+	GatewayClassName string `json:"gatewayClassName,omitempty"`
+
+	// RequireAttachedRoutes fails listeners that have no routes attached.
+	// Defaults to true.
+	RequireAttachedRoutes *bool `json:"requireAttachedRoutes,omitempty"`
+}
+
+// GatewayAPICheck verifies that Gateways and HTTPRoutes are accepted and
+// programmed according to the standard Gateway API status conditions.
+type GatewayAPICheck struct {
+	client client.Client
+}
+
+// New creates a new GatewayAPICheck with the given Kubernetes client.
+func New(c client.Client) *GatewayAPICheck {
+	return &GatewayAPICheck{client: c}
+}
+
+func (g *GatewayAPICheck) Name() string {
+	return CheckName
+}
+
+func (g *GatewayAPICheck) DefaultSeverity() string {
+	return "critical"
+}
+
+func (g *GatewayAPICheck) DefaultCategory() string {
+	return "networking"
+}
+
+// The subset of the Gateway API types the check inspects.
+type gateway struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		GatewayClassName string `json:"gatewayClassName"`
+	} `json:"spec"`
+	Status struct {
+		Conditions []metav1.Condition `json:"conditions"`
+		Listeners  []struct {
+			Name           string             `json:"name"`
+			AttachedRoutes int32              `json:"attachedRoutes"`
+			Conditions     []metav1.Condition `json:"conditions"`
+		} `json:"listeners"`
+	} `json:"status"`
+}
+
+type parentReference struct {
+	Group       *string `json:"group"`
+	Kind        *string `json:"kind"`
+	Namespace   *string `json:"namespace"`
+	Name        string  `json:"name"`
+	SectionName *string `json:"sectionName"`
+}
+
+type httpRoute struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		ParentRefs []parentReference `json:"parentRefs"`
+	} `json:"spec"`
+	Status struct {
+		Parents []struct {
+			ParentRef  parentReference    `json:"parentRef"`
+			Conditions []metav1.Condition `json:"conditions"`
+		} `json:"parents"`
+	} `json:"status"`
+}
+
+func (g *GatewayAPICheck) Run(ctx context.Context, rawConfig json.RawMessage) (checks.Result, error) {
+	cfg := Config{}
+	if len(rawConfig) > 0 {
+		if err := json.Unmarshal(rawConfig, &cfg); err != nil {
+			return checks.Result{}, fmt.Errorf("parsing gateway-api check config: %w", err)
+		}
+	}
+	requireAttached := cfg.RequireAttachedRoutes == nil || *cfg.RequireAttachedRoutes
+
+	var opts []client.ListOption
+	if cfg.Namespace != "" {
+		opts = append(opts, client.InNamespace(cfg.Namespace))
+	}
+
+	gateways, err := listAs[gateway](ctx, g.client, gatewayListGVK, opts...)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return checks.Result{
+				Ready:   false,
+				Message: "Gateway API CRDs are not installed",
+			}, nil
+		}
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("failed to list Gateways: %v", err),
+		}, nil
+	}
+
+	selected := make(map[string]bool)
+	var problems []string
+	for _, gw := range gateways {
+		if cfg.GatewayClassName != "" && gw.Spec.GatewayClassName != cfg.GatewayClassName {
+			continue
+		}
+		selected[gw.Namespace+"/"+gw.Name] = true
+		problems = append(problems, gatewayProblems(&gw, requireAttached)...)
+	}
+
+	details := map[string]string{
+		"gateways": fmt.Sprintf("%d", len(selected)),
+	}
+	if len(selected) == 0 {
+		return checks.Result{
+			Ready:   false,
+			Message: "no Gateways found",
+			Details: details,
+		}, nil
+	}
+
+	routes, err := listAs[httpRoute](ctx, g.client, httpRouteListGVK, opts...)
+	if err != nil && !meta.IsNoMatchError(err) {
+		return checks.Result{
+			Ready:   false,
+			Message: fmt.Sprintf("failed to list HTTPRoutes: %v", err),
+			Details: details,
+		}, nil
+	}
+
+	routeCount := 0
+	for _, route := range routes {
+		p, attached := routeProblems(&route, selected)
+		if attached {
+			routeCount++
+		}
+		problems = append(problems, p...)
+	}
+	details["httpRoutes"] = fmt.Sprintf("%d", routeCount)
+	details["problems"] = fmt.Sprintf("%d", len(problems))
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		shown := problems
+		if len(shown) > maxReportedProblems {
+			shown = append(shown[:maxReportedProblems:maxReportedProblems], fmt.Sprintf("and %d more", len(problems)-maxReportedProblems))
+		}
+		return checks.Result{
+			Ready:   false,
+			Message: strings.Join(shown, "; "),
+			Details: details,
+		}, nil
+	}
+
+	return checks.Result{
+		Ready:   true,
+		Message: fmt.Sprintf("%d Gateways and %d HTTPRoutes accepted and programmed", len(selected), routeCount),
+		Details: details,
+	}, nil
+}
+
+// listAs fetches all objects of the given list kind and decodes them as T.
+func listAs[T any](ctx context.Context, c client.Client, gvk schema.GroupVersionKind, opts ...client.ListOption) ([]T, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk)
+	if err := c.List(ctx, list, opts...); err != nil {
+		return nil, err
+	}
+	out := make([]T, len(list.Items))
+	for i := range list.Items {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, &out[i]); err != nil {
+			return nil, fmt.Errorf("decoding %s %s: %w", gvk.Kind, list.Items[i].GetName(), err)
+		}
+	}
+	return out, nil
+}
+
+func gatewayProblems(gw *gateway, requireAttached bool) []string {
+	name := fmt.Sprintf("Gateway %s/%s", gw.Namespace, gw.Name)
+	var problems []string
+	for _, condType := range []string{"Accepted", "Programmed"} {
+		if p := conditionProblem(gw.Status.Conditions, condType, gw.Generation); p != "" {
+			problems = append(problems, name+" "+p)
+		}
+	}
+	for _, l := range gw.Status.Listeners {
+		listener := fmt.Sprintf("%s listener %s", name, l.Name)
+		if p := conditionProblem(l.Conditions, "Programmed", gw.Generation); p != "" {
+			problems = append(problems, listener+" "+p)
+		}
+		if meta.IsStatusConditionTrue(l.Conditions, "Conflicted") {
+			problems = append(problems, listener+" is conflicted")
+		}
+		if requireAttached && l.AttachedRoutes == 0 {
+			problems = append(problems, listener+" has no attached routes")
+		}
+	}
+	return problems
+}
+
+// routeProblems evaluates the route's status for each parent Gateway in selected.
+// It reports whether the route targets any selected Gateway at all.
+func routeProblems(route *httpRoute, selected map[string]bool) ([]string, bool) {
+	name := fmt.Sprintf("HTTPRoute %s/%s", route.Namespace, route.Name)
+	var problems []string
+	attached := false
+	for _, ref := range route.Spec.ParentRefs {
+		key, ok := gatewayKey(ref, route.Namespace)
+		if !ok || !selected[key] {
+			continue
+		}
+		attached = true
+
+		var conditions []metav1.Condition
+		found := false
+		for _, ps := range route.Status.Parents {
+			if k, ok := gatewayKey(ps.ParentRef, route.Namespace); ok && k == key && sectionName(ps.ParentRef) == sectionName(ref) {
+				conditions = ps.Conditions
+				found = true
+				break
+			}
+		}
+		parent := name + " on " + key
+		if s := sectionName(ref); s != "" {
+			parent += "/" + s
+		}
+		if !found {
+			problems = append(problems, parent+" has no status from the Gateway controller")
+			continue
+		}
+		for _, condType := range []string{"Accepted", "ResolvedRefs"} {
+			if p := conditionProblem(conditions, condType, route.Generation); p != "" {
+				problems = append(problems, parent+" "+p)
+			}
+		}
+	}
+	return problems, attached
+}
+
+// gatewayKey returns "namespace/name" if the reference targets a Gateway.
+func gatewayKey(ref parentReference, defaultNamespace string) (string, bool) {
+	if ref.Group != nil && *ref.Group != group {
+		return "", false
+	}
+	if ref.Kind != nil && *ref.Kind != "Gateway" {
+		return "", false
+	}
+	ns := defaultNamespace
+	if ref.Namespace != nil && *ref.Namespace != "" {
+		ns = *ref.Namespace
+	}
+	return ns + "/" + ref.Name, true
+}
+
+func sectionName(ref parentReference) string {
+	if ref.SectionName == nil {
+		return ""
+	}
+	return *ref.SectionName
+}
+
+// conditionProblem describes why the condition is not True for the current
+// generation, or returns "" if it is.
+func conditionProblem(conditions []metav1.Condition, condType string, generation int64) string {
+	c := meta.FindStatusCondition(conditions, condType)
+	switch {
+	case c == nil:
+		return fmt.Sprintf("has no %s condition", condType)
+	case c.Status != metav1.ConditionTrue:
+		return fmt.Sprintf("is not %s (%s: %s)", condType, c.Reason, c.Message)
+	case c.ObservedGeneration != 0 && c.ObservedGeneration < generation:
+		return fmt.Sprintf("%s condition is stale (observed generation %d, current %d)", condType, c.ObservedGeneration, generation)
+	}
+	return ""
+}
@@ -0,0 +1,240 @@
+package gatewayapi
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func gatewayTestScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	return s
+}
+
+// gatewayTestClient builds a fake client whose RESTMapper knows the Gateway API kinds.
+func gatewayTestClient(objs ...client.Object) client.Client {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: group, Version: "v1", Kind: "Gateway"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: group, Version: "v1", Kind: "HTTPRoute"}, meta.RESTScopeNamespace)
+	return fake.NewClientBuilder().
+		WithScheme(gatewayTestScheme()).
+		WithRESTMapper(mapper).
+		WithObjects(objs...).
+		Build()
+}
+
+func condition(condType, status string) interface{} {
+	return map[string]interface{}{
+		"type":               condType,
+		"status":             status,
+		"reason":             condType,
+		"message":            "",
+		"lastTransitionTime": "2026-01-01T00:00:00Z",
+	}
+}
+
+func newGateway(namespace, name, class string, attachedRoutes int64, conds ...interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"gatewayClassName": class,
+		},
+		"status": map[string]interface{}{
+			"conditions": conds,
+			"listeners": []interface{}{
+				map[string]interface{}{
+					"name":           "http",
+					"attachedRoutes": attachedRoutes,
+					"conditions":     []interface{}{condition("Programmed", "True")},
+				},
+			},
+		},
+	}}
+	obj.SetAPIVersion(group + "/v1")
+	obj.SetKind("Gateway")
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func newHTTPRoute(namespace, name, gateway string, parentConds ...interface{}) *unstructured.Unstructured {
+	ref := map[string]interface{}{"name": gateway}
+	status := map[string]interface{}{}
+	if parentConds != nil {
+		status["parents"] = []interface{}{
+			map[string]interface{}{
+				"parentRef":      ref,
+				"controllerName": "example.com/gateway-controller",
+				"conditions":     parentConds,
+			},
+		}
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec":   map[string]interface{}{"parentRefs": []interface{}{ref}},
+		"status": status,
+	}}
+	obj.SetAPIVersion(group + "/v1")
+	obj.SetKind("HTTPRoute")
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func TestGatewayAPICheck_Metadata(t *testing.T) {
+	check := New(gatewayTestClient())
+	if got := check.Name(); got != "gateway-api" {
+		t.Errorf("Name() = %q, want %q", got, "gateway-api")
+	}
+	if got := check.DefaultSeverity(); got != "critical" {
+		t.Errorf("DefaultSeverity() = %q, want %q", got, "critical")
+	}
+	if got := check.DefaultCategory(); got != "networking" {
+		t.Errorf("DefaultCategory() = %q, want %q", got, "networking")
+	}
+}
+
+func TestGatewayAPICheck_InvalidConfig(t *testing.T) {
+	_, err := New(gatewayTestClient()).Run(context.Background(), json.RawMessage(`{invalid json`))
+	if err == nil {
+		t.Error("expected error for invalid JSON config")
+	}
+}
+
+func TestGatewayAPICheck_CRDsNotInstalled(t *testing.T) {
+	c := fake.NewClientBuilder().
+		WithScheme(gatewayTestScheme()).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(_ context.Context, _ client.WithWatch, list client.ObjectList, _ ...client.ListOption) error {
+				gvk := list.GetObjectKind().GroupVersionKind()
+				return &meta.NoKindMatchError{GroupKind: gvk.GroupKind(), SearchedVersions: []string{gvk.Version}}
+			},
+		}).
+		Build()
+	result, err := New(c).Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Ready || !strings.Contains(result.Message, "not installed") {
+		t.Errorf("expected CRDs-not-installed failure, got ready=%v: %s", result.Ready, result.Message)
+	}
+}
+
+func TestGatewayAPICheck_Run(t *testing.T) {
+	accepted := condition("Accepted", "True")
+	programmed := condition("Programmed", "True")
+	resolved := condition("ResolvedRefs", "True")
+
+	tests := []struct {
+		name        string
+		objs        []client.Object
+		config      string
+		wantReady   bool
+		wantMessage string
+	}{
+		{
+			name:        "no gateways",
+			wantReady:   false,
+			wantMessage: "no Gateways found",
+		},
+		{
+			name: "healthy gateway and route",
+			objs: []client.Object{
+				newGateway("infra", "public", "istio", 1, accepted, programmed),
+				newHTTPRoute("infra", "web", "public", accepted, resolved),
+			},
+			wantReady: true,
+		},
+		{
+			name: "gateway not programmed",
+			objs: []client.Object{
+				newGateway("infra", "public", "istio", 1, accepted, condition("Programmed", "False")),
+				newHTTPRoute("infra", "web", "public", accepted, resolved),
+			},
+			wantReady:   false,
+			wantMessage: "Gateway infra/public is not Programmed",
+		},
+		{
+			name: "listener without routes",
+			objs: []client.Object{
+				newGateway("infra", "public", "istio", 0, accepted, programmed),
+			},
+			wantReady:   false,
+			wantMessage: "listener http has no attached routes",
+		},
+		{
+			name: "listener without routes allowed",
+			objs: []client.Object{
+				newGateway("infra", "public", "istio", 0, accepted, programmed),
+			},
+			config:    `{"requireAttachedRoutes": false}`,
+			wantReady: true,
+		},
+		{
+			name: "route with unresolved refs",
+			objs: []client.Object{
+				newGateway("infra", "public", "istio", 1, accepted, programmed),
+				newHTTPRoute("infra", "web", "public", accepted, condition("ResolvedRefs", "False")),
+			},
+			wantReady:   false,
+			wantMessage: "HTTPRoute infra/web on infra/public is not ResolvedRefs",
+		},
+		{
+			name: "route without status",
+			objs: []client.Object{
+				newGateway("infra", "public", "istio", 1, accepted, programmed),
+				newHTTPRoute("infra", "web", "public"),
+			},
+			wantReady:   false,
+			wantMessage: "has no status from the Gateway controller",
+		},
+		{
+			name: "other gateway class ignored",
+			objs: []client.Object{
+				newGateway("infra", "public", "istio", 1, accepted, programmed),
+				newGateway("infra", "legacy", "nginx", 0, condition("Accepted", "False")),
+				newHTTPRoute("infra", "old", "legacy"),
+			},
+			config:    `{"gatewayClassName": "istio"}`,
+			wantReady: true,
+		},
+		{
+			name: "namespace scoped",
+			objs: []client.Object{
+				newGateway("infra", "public", "istio", 1, accepted, programmed),
+				newGateway("team-a", "broken", "istio", 0),
+			},
+			config:    `{"namespace": "infra"}`,
+			wantReady: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var raw json.RawMessage
+			if tt.config != "" {
+				raw = json.RawMessage(tt.config)
+			}
+			result, err := New(gatewayTestClient(tt.objs...)).Run(context.Background(), raw)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Ready != tt.wantReady {
+				t.Fatalf("ready = %v, want %v: %s", result.Ready, tt.wantReady, result.Message)
+			}
+			if tt.wantMessage != "" && !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("message %q does not contain %q", result.Message, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -31,4 +31,8 @@ type Result struct {
 
 	// Details contains additional key-value diagnostic information.
 	Details map[string]string `json:"details,omitempty"`
+
+	// Value is the numeric signal the check evaluated, for checks that have
+	// one (e.g. PromQL value conditions). It is exported as clustergate_check_value.
+	Value *float64 `json:"value,omitempty"`
 }
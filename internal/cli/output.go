@@ -17,6 +17,9 @@ func FormatText(w io.Writer, report *Report) {
 		marker := "[PASS]"
 		if c.Status == "Failing" {
 			marker = "[FAIL]"
+			if c.Severity == "info" {
+				marker = "[INFO]"
+			}
 		}
 		fmt.Fprintf(w, "%s %s (%s/%s)\n", marker, c.Name, c.Category, c.Severity)
 		fmt.Fprintf(w, "       %s\n", c.Message)
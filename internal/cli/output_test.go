@@ -201,3 +201,25 @@ func TestFormatText_CategoryAndSeverity(t *testing.T) {
 		t.Error("expected (networking/critical) in output")
 	}
 }
+
+func TestFormatText_Info(t *testing.T) {
+	report := &Report{
+		State:  "Healthy",
+		Total:  1,
+		Failed: 1,
+		Checks: []CheckResult{
+			{Name: "audit", Category: "security", Severity: "info", Status: "Failing", Message: "audit logging disabled"},
+		},
+	}
+
+	var buf bytes.Buffer
+	FormatText(&buf, report)
+	out := buf.String()
+
+	if !strings.Contains(out, "[INFO] audit") {
+		t.Error("expected [INFO] audit in output")
+	}
+	if strings.Contains(out, "[FAIL]") {
+		t.Error("did not expect [FAIL] in output")
+	}
+}
@@ -62,7 +62,12 @@ func RunChecks(ctx context.Context, checkers []checks.Checker, filter map[string
 				Error: err.Error(),
 			})
 			report.Failed++
-			hasCriticalFailure = true
+			switch c.DefaultSeverity() {
+			case "critical":
+				hasCriticalFailure = true
+			case "warning":
+				hasWarningFailure = true
+			}
 			continue
 		}
 
@@ -87,7 +92,7 @@ func RunChecks(ctx context.Context, checkers []checks.Checker, filter map[string
 		}
 	}
 
-	// Compute the health state
+	// Compute the health state; info checks never affect it.
 	if hasCriticalFailure {
 		report.State = "Unhealthy"
 	} else if hasWarningFailure {
@@ -138,3 +138,20 @@ func TestRunChecks_DeterministicOrder(t *testing.T) {
 			report.Checks[0].Name, report.Checks[1].Name, report.Checks[2].Name)
 	}
 }
+
+func TestRunChecks_Info(t *testing.T) {
+	checkers := []checks.Checker{
+		&stubChecker{name: "a", severity: "critical", category: "cat1", result: checks.Result{Ready: true, Message: "ok"}},
+		&stubChecker{name: "b", severity: "info", category: "cat2", result: checks.Result{Ready: false, Message: "fyi"}},
+		&stubChecker{name: "c", severity: "info", category: "cat2", err: errors.New("boom")},
+	}
+
+	report := RunChecks(context.Background(), checkers, nil)
+
+	if report.State != "Healthy" {
+		t.Fatalf("expected State=Healthy, got %s", report.State)
+	}
+	if report.Failed != 2 {
+		t.Fatalf("expected Failed=2, got %d", report.Failed)
+	}
+}
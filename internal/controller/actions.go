@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+// actionFinalizers returns, for each finalizer guarding an action whose
+// changes must be undone before cr is deleted, whether cr needs it: while
+// the action is configured or has changes left to undo.
+func (r *ClusterReadinessReconciler) actionFinalizers(cr *clustergatev1alpha1.ClusterReadiness) map[string]bool {
+	return map[string]bool{
+		nodeTaintFinalizer:     desiredNodeTaint(cr) != nil || cr.Status.NodeTaint != nil,
+		gitOpsFinalizer:        len(r.gitOpsTargets(cr)) > 0 || len(cr.Status.SuspendedGitOps) > 0,
+		trafficWeightFinalizer: desiredTrafficWeight(cr) != nil || cr.Status.TrafficWeight != nil,
+	}
+}
+
+// syncActionFinalizers adds the action finalizers cr needs and drops the
+// others.
+func (r *ClusterReadinessReconciler) syncActionFinalizers(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness) error {
+	patch := client.MergeFromWithOptions(cr.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	changed := false
+	for finalizer, want := range r.actionFinalizers(cr) {
+		if want {
+			changed = controllerutil.AddFinalizer(cr, finalizer) || changed
+		} else {
+			changed = controllerutil.RemoveFinalizer(cr, finalizer) || changed
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return r.Patch(ctx, cr, patch)
+}
+
+// finalizeActions undoes the changes of cr's actions once it is being
+// deleted, and then releases it.
+func (r *ClusterReadinessReconciler) finalizeActions(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness) error {
+	undo := map[string]func(context.Context, *clustergatev1alpha1.ClusterReadiness) error{
+		nodeTaintFinalizer:     r.removeNodeTaints,
+		gitOpsFinalizer:        r.resumeGitOps,
+		trafficWeightFinalizer: r.releaseTrafficWeight,
+	}
+	patch := client.MergeFromWithOptions(cr.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	changed := false
+	var errs []error
+	for finalizer, fn := range undo {
+		if !controllerutil.ContainsFinalizer(cr, finalizer) {
+			continue
+		}
+		if err := fn(ctx, cr); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		changed = controllerutil.RemoveFinalizer(cr, finalizer) || changed
+	}
+	if changed {
+		errs = append(errs, r.Patch(ctx, cr, patch))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+// SummaryAnnotation holds a compact result summary written onto ClusterReadiness
+// and GateProfile objects when spec.annotateSummary is set.
+const SummaryAnnotation = "clustergate.io/summary"
+
+// maxSummaryFailing caps the number of failing check names listed in the annotation.
+const maxSummaryFailing = 10
+
+// formatSummary renders statuses as "42/45 passing, failing: dns,etcd".
+// Suspended checks are left out.
+func formatSummary(statuses []clustergatev1alpha1.CheckStatus) string {
+	var failing []string
+	total := 0
+	for _, cs := range statuses {
+		if cs.Status == "Suspended" {
+			continue
+		}
+		total++
+		if cs.Status != "Passing" {
+			failing = append(failing, cs.Name)
+		}
+	}
+	summary := fmt.Sprintf("%d/%d passing", total-len(failing), total)
+	if len(failing) == 0 {
+		return summary
+	}
+
+	sort.Strings(failing)
+	more := ""
+	if len(failing) > maxSummaryFailing {
+		more = fmt.Sprintf(" (+%d more)", len(failing)-maxSummaryFailing)
+		failing = failing[:maxSummaryFailing]
+	}
+	return summary + ", failing: " + strings.Join(failing, ",") + more
+}
+
+// annotateSummary writes the summary annotation onto the CR and, prefixed with
+// the CR name, onto each referenced GateProfile for the checks it contributed.
+// Objects are only patched when the annotation changes. When annotateSummary
+// is off, a previously written annotation is removed from the CR.
+func (r *ClusterReadinessReconciler) annotateSummary(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, statuses []clustergatev1alpha1.CheckStatus) error {
+	if !cr.Spec.AnnotateSummary {
+		if _, ok := cr.Annotations[SummaryAnnotation]; !ok {
+			return nil
+		}
+		return r.setSummaryAnnotation(ctx, cr, "")
+	}
+
+	if err := r.setSummaryAnnotation(ctx, cr, formatSummary(statuses)); err != nil {
+		return err
+	}
+
+	for _, ref := range cr.Spec.Profiles {
+		var profileStatuses []clustergatev1alpha1.CheckStatus
+		for _, cs := range statuses {
+			if cs.Source == "profile:"+ref.Name {
+				profileStatuses = append(profileStatuses, cs)
+			}
+		}
+		var profile clustergatev1alpha1.GateProfile
+		if err := r.Get(ctx, types.NamespacedName{Name: ref.Name}, &profile); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if err := r.setSummaryAnnotation(ctx, &profile, cr.Name+": "+formatSummary(profileStatuses)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setSummaryAnnotation patches the summary annotation on obj, removing it when value is empty.
+func (r *ClusterReadinessReconciler) setSummaryAnnotation(ctx context.Context, obj client.Object, value string) error {
+	annotations := obj.GetAnnotations()
+	if current, ok := annotations[SummaryAnnotation]; ok == (value != "") && current == value {
+		return nil
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	if value == "" {
+		delete(annotations, SummaryAnnotation)
+	} else {
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[SummaryAnnotation] = value
+	}
+	obj.SetAnnotations(annotations)
+	return r.Patch(ctx, obj, patch)
+}
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+func TestFormatSummary(t *testing.T) {
+	status := func(name, s string) clustergatev1alpha1.CheckStatus {
+		return clustergatev1alpha1.CheckStatus{Name: name, Status: s}
+	}
+	many := make([]clustergatev1alpha1.CheckStatus, 12)
+	for i := range many {
+		many[i] = status(fmt.Sprintf("check-%02d", i), "Failing")
+	}
+
+	tests := []struct {
+		name     string
+		statuses []clustergatev1alpha1.CheckStatus
+		want     string
+	}{
+		{
+			name: "no checks",
+			want: "0/0 passing",
+		},
+		{
+			name:     "all passing",
+			statuses: []clustergatev1alpha1.CheckStatus{status("dns", "Passing"), status("etcd", "Passing")},
+			want:     "2/2 passing",
+		},
+		{
+			name: "failing names are sorted",
+			statuses: []clustergatev1alpha1.CheckStatus{
+				status("etcd", "Failing"), status("api", "Passing"), status("dns", "Failing"),
+			},
+			want: "1/3 passing, failing: dns,etcd",
+		},
+		{
+			name:     "failing names are capped",
+			statuses: many,
+			want:     "0/12 passing, failing: check-00,check-01,check-02,check-03,check-04,check-05,check-06,check-07,check-08,check-09 (+2 more)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatSummary(tt.statuses); got != tt.want {
+				t.Errorf("formatSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnotateSummary(t *testing.T) {
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			AnnotateSummary: true,
+			Profiles: []clustergatev1alpha1.ProfileRef{
+				{Name: "baseline"},
+				{Name: "deleted"},
+			},
+		},
+	}
+	profile := &clustergatev1alpha1.GateProfile{ObjectMeta: metav1.ObjectMeta{Name: "baseline"}}
+	statuses := []clustergatev1alpha1.CheckStatus{
+		{Name: "dns", Source: "profile:baseline", Status: "Failing"},
+		{Name: "etcd", Source: "profile:baseline", Status: "Passing"},
+		{Name: "vault", Source: "inline", Status: "Passing"},
+	}
+
+	patches := 0
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(cr, profile).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				patches++
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+	r := &ClusterReadinessReconciler{Client: c}
+	ctx := context.Background()
+
+	if err := r.annotateSummary(ctx, cr, statuses); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var gotCR clustergatev1alpha1.ClusterReadiness
+	if err := c.Get(ctx, types.NamespacedName{Name: "prod"}, &gotCR); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := gotCR.Annotations[SummaryAnnotation], "2/3 passing, failing: dns"; got != want {
+		t.Errorf("CR annotation = %q, want %q", got, want)
+	}
+	var gotProfile clustergatev1alpha1.GateProfile
+	if err := c.Get(ctx, types.NamespacedName{Name: "baseline"}, &gotProfile); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := gotProfile.Annotations[SummaryAnnotation], "prod: 1/2 passing, failing: dns"; got != want {
+		t.Errorf("profile annotation = %q, want %q", got, want)
+	}
+
+	// Unchanged results do not patch again.
+	patches = 0
+	if err := r.annotateSummary(ctx, &gotCR, statuses); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if patches != 0 {
+		t.Errorf("expected no patches for unchanged summary, got %d", patches)
+	}
+
+	// Disabling removes the annotation from the CR.
+	gotCR.Spec.AnnotateSummary = false
+	if err := r.annotateSummary(ctx, &gotCR, statuses); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "prod"}, &gotCR); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := gotCR.Annotations[SummaryAnnotation]; ok {
+		t.Error("expected annotation to be removed when annotateSummary is off")
+	}
+}
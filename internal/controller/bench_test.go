@@ -0,0 +1,248 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+	"github.com/clustergate/clustergate/internal/server"
+)
+
+// benchSizes are the synthetic check-set sizes exercised by the benchmarks.
+var benchSizes = []int{100, 300, 1000}
+
+// benchProfileSize is the number of GateChecks per synthetic GateProfile.
+const benchProfileSize = 50
+
+// benchExecutor passes every dynamic check without doing any work, so that the
+// benchmarks measure the reconciler rather than the checks.
+type benchExecutor struct{}
+
+func (benchExecutor) Execute(_ context.Context, name string, _ clustergatev1alpha1.GateCheckSpec) (checks.Result, error) {
+	return checks.Result{Ready: true, Message: name + " ok"}, nil
+}
+
+func (benchExecutor) Ready() error { return nil }
+
+// syntheticCheckSet generates n GateChecks grouped into GateProfiles of
+// benchProfileSize checks, plus a ClusterReadiness that references every
+// profile and one built-in check inline.
+func syntheticCheckSet(n int) (*clustergatev1alpha1.ClusterReadiness, []client.Object) {
+	var objs []client.Object
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "bench"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Interval: metav1.Duration{Duration: time.Minute},
+			Checks: []clustergatev1alpha1.CheckSpec{
+				{Name: "resolver-test-check"},
+			},
+		},
+	}
+
+	var profile *clustergatev1alpha1.GateProfile
+	for i := 0; i < n; i++ {
+		if i%benchProfileSize == 0 {
+			profile = &clustergatev1alpha1.GateProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("bench-profile-%d", i/benchProfileSize)},
+			}
+			objs = append(objs, profile)
+			cr.Spec.Profiles = append(cr.Spec.Profiles, clustergatev1alpha1.ProfileRef{Name: profile.Name})
+		}
+
+		severity := clustergatev1alpha1.SeverityCritical
+		if i%4 == 0 {
+			severity = clustergatev1alpha1.SeverityWarning
+		}
+		gc := &clustergatev1alpha1.GateCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("bench-check-%d", i)},
+			Spec: clustergatev1alpha1.GateCheckSpec{
+				Severity: severity,
+				Category: fmt.Sprintf("category-%d", i%8),
+				HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{
+					URL: fmt.Sprintf("http://bench-%d.example/healthz", i),
+				},
+			},
+		}
+		objs = append(objs, gc)
+		profile.Spec.Checks = append(profile.Spec.Checks, clustergatev1alpha1.ProfileCheckRef{GateCheckRef: gc.Name})
+	}
+
+	return cr, append(objs, cr)
+}
+
+// newBenchClient returns a fake client seeded with objs that counts every API call into calls.
+func newBenchClient(objs []client.Object, calls *atomic.Int64) client.Client {
+	count := func() { calls.Add(1) }
+	return fake.NewClientBuilder().
+		WithScheme(testScheme()).
+		WithObjects(objs...).
+		WithStatusSubresource(&clustergatev1alpha1.ClusterReadiness{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				count()
+				return c.Get(ctx, key, obj, opts...)
+			},
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				count()
+				return c.List(ctx, list, opts...)
+			},
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				count()
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+}
+
+func reportAPICalls(b *testing.B, calls *atomic.Int64) {
+	b.ReportMetric(float64(calls.Load())/float64(b.N), "apicalls/op")
+}
+
+// benchAPICallSlack is the number of API calls a reconcile may make on top of
+// one per check, for the ClusterReadiness itself, its profiles and lists.
+const benchAPICallSlack = 50
+
+// checkAPICalls fails b when a reconcile of n checks made more than about one
+// API call per check, as looking up per check what references it would.
+func checkAPICalls(b *testing.B, calls *atomic.Int64, n int) {
+	if perOp := calls.Load() / int64(b.N); perOp > int64(n+benchAPICallSlack) {
+		b.Errorf("%d API calls per reconcile of %d checks, want at most %d", perOp, n, n+benchAPICallSlack)
+	}
+}
+
+// BenchmarkReconcile_AllDue measures a reconcile in which every check runs,
+// as on the first reconcile of a CR or after an operator restart.
+func BenchmarkReconcile_AllDue(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprintf("checks=%d", n), func(b *testing.B) {
+			cr, objs := syntheticCheckSet(n)
+			var calls atomic.Int64
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: cr.Name}}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				counted := calls.Load()
+				r := &ClusterReadinessReconciler{
+					Client:          newBenchClient(objs, &calls),
+					ReadinessState:  server.NewReadinessState(),
+					DynamicExecutor: benchExecutor{},
+				}
+				calls.Store(counted)
+				b.StartTimer()
+				if _, err := r.Reconcile(context.Background(), req); err != nil {
+					b.Fatal(err)
+				}
+			}
+			reportAPICalls(b, &calls)
+			checkAPICalls(b, &calls, n)
+		})
+	}
+}
+
+// BenchmarkReconcile_Steady measures a reconcile in which no check is due and
+// every result is carried forward, as on a GateCheck or GateProfile event.
+func BenchmarkReconcile_Steady(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprintf("checks=%d", n), func(b *testing.B) {
+			cr, objs := syntheticCheckSet(n)
+			var calls atomic.Int64
+			r := &ClusterReadinessReconciler{
+				Client:          newBenchClient(objs, &calls),
+				ReadinessState:  server.NewReadinessState(),
+				DynamicExecutor: benchExecutor{},
+			}
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: cr.Name}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				b.Fatal(err)
+			}
+			calls.Store(0)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := r.Reconcile(context.Background(), req); err != nil {
+					b.Fatal(err)
+				}
+			}
+			reportAPICalls(b, &calls)
+			checkAPICalls(b, &calls, n)
+		})
+	}
+}
+
+func BenchmarkResolveChecks(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprintf("checks=%d", n), func(b *testing.B) {
+			cr, objs := syntheticCheckSet(n)
+			var calls atomic.Int64
+			c := newBenchClient(objs, &calls)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := ResolveChecks(context.Background(), c, cr.Spec, time.Minute); err != nil {
+					b.Fatal(err)
+				}
+			}
+			reportAPICalls(b, &calls)
+		})
+	}
+}
+
+func BenchmarkCheckSchedule(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprintf("checks=%d", n), func(b *testing.B) {
+			now := time.Now()
+			resolved := make([]ResolvedCheck, n)
+			existing := make([]clustergatev1alpha1.CheckStatus, n)
+			for i := range resolved {
+				name := fmt.Sprintf("bench-check-%d", i)
+				resolved[i] = ResolvedCheck{Identifier: name, GateCheckName: name, Interval: time.Minute}
+				// Half the checks are due, half are carried forward.
+				lastChecked := metav1.NewTime(now.Add(-time.Duration(i%2) * 2 * time.Minute))
+				existing[i] = clustergatev1alpha1.CheckStatus{Name: name, Status: "Passing", LastChecked: &lastChecked}
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				CheckSchedule(resolved, existing, now, 0)
+			}
+		})
+	}
+}
+
+func BenchmarkSplitSuspended(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprintf("checks=%d", n), func(b *testing.B) {
+			// A weekly window of the longest duration, outside it, which is
+			// the most ground to cover looking back for its start.
+			windows := []clustergatev1alpha1.MaintenanceWindow{{
+				Schedule: "0 2 * * 6", Duration: metav1.Duration{Duration: maxMaintenanceWindow}, TimeZone: "Europe/Berlin",
+			}}
+			resolved := make([]ResolvedCheck, n)
+			for i := range resolved {
+				name := fmt.Sprintf("bench-check-%d", i)
+				resolved[i] = ResolvedCheck{Identifier: name, GateCheckName: name, MaintenanceWindows: windows}
+			}
+			// A Saturday just before the window opens.
+			now := time.Date(2025, 1, 4, 0, 59, 0, 0, time.UTC)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, _, _, err := splitSuspended(resolved, now, 24*time.Hour); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -2,24 +2,34 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"sort"
 	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
 	"github.com/clustergate/clustergate/internal/checks"
 	"github.com/clustergate/clustergate/internal/checks/dynamic"
 	"github.com/clustergate/clustergate/internal/metrics"
+	"github.com/clustergate/clustergate/internal/notify"
 	"github.com/clustergate/clustergate/internal/server"
 )
 
@@ -31,15 +41,67 @@ const (
 type ClusterReadinessReconciler struct {
 	client.Client
 	ReadinessState  *server.ReadinessState
-	DynamicExecutor *dynamic.Executor
+	DynamicExecutor DynamicCheckExecutor
+
+	// GateReadinessState, when set, receives the readiness of each of
+	// spec.gates, keyed "<gate>/<name>".
+	GateReadinessState *server.ReadinessState
+
+	// ServerVersion, when set, provides the API server version to runIf
+	// expressions.
+	ServerVersion ServerVersioner
+
+	// StartedAt is when the operator started. Like a ClusterReadiness's
+	// creation, it begins spec.initialDelaySeconds.
+	StartedAt time.Time
+
+	// Recorder, when set, emits events on check and state transitions.
+	Recorder events.EventRecorder
+
+	// Namespace is where Secrets referenced without a namespace are read.
+	Namespace string
+
+	// Notifier delivers state change notifications. Defaults to a sender
+	// using http.DefaultClient.
+	Notifier *notify.Sender
+
+	// notifications tracks deliveries still in flight.
+	notifications sync.WaitGroup
+
+	// throttles hold what each state change notifier last sent, keyed
+	// "<ClusterReadiness>/<kind>/<name>".
+	throttlesMu sync.Mutex
+	throttles   map[string]*notifierThrottle
 }
 
-// +kubebuilder:rbac:groups=clustergate.io,resources=clusterreadinesses,verbs=get;list;watch
+// DynamicCheckExecutor runs the check defined by a GateCheck spec. It is
+// implemented by *dynamic.Executor and substituted with fakes in tests.
+type DynamicCheckExecutor interface {
+	Execute(ctx context.Context, name string, spec clustergatev1alpha1.GateCheckSpec) (checks.Result, error)
+
+	// Ready returns the executor's initialization error, if any.
+	Ready() error
+}
+
+var _ DynamicCheckExecutor = (*dynamic.Executor)(nil)
+
+// +kubebuilder:rbac:groups=clustergate.io,resources=clusterreadinesses,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=clustergate.io,resources=clusterreadinesses/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=clustergate.io,resources=namespacegatechecks,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=nodes;namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=patch
+// +kubebuilder:rbac:groups=kustomize.toolkit.fluxcd.io,resources=kustomizations,verbs=get;patch
+// +kubebuilder:rbac:groups=helm.toolkit.fluxcd.io,resources=helmreleases,verbs=get;patch
+// +kubebuilder:rbac:groups=argoproj.io,resources=appprojects,verbs=get;patch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;patch
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="*",resources="*",verbs=get;list
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
-// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=create;delete;get;list;watch
 // +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
 // +kubebuilder:rbac:urls="/healthz",verbs=get
@@ -57,10 +119,24 @@ func (r *ClusterReadinessReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	if err := r.Get(ctx, req.NamespacedName, &cr); err != nil {
 		// CR deleted — clean up state.
 		r.ReadinessState.Remove(req.Name)
+		r.forgetThrottles(req.Name)
+		if r.GateReadinessState != nil {
+			r.GateReadinessState.RemoveName(req.Name)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	if !cr.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.finalizeActions(ctx, &cr)
+	}
+	if err := r.syncActionFinalizers(ctx, &cr); err != nil {
+		return ctrl.Result{}, err
+	}
+	// base is the object as fetched, which status patches are computed against.
+	base := cr.DeepCopy()
 
-	logger.Info("reconciling ClusterReadiness", "name", cr.Name)
+	logger.V(1).Info("reconciling ClusterReadiness", "name", cr.Name)
+	reconcileStart := time.Now()
+	transitions := newTransitionLogger(logger, cr.Name)
 
 	// Determine default requeue interval.
 	interval := defaultInterval
@@ -68,8 +144,26 @@ func (r *ClusterReadinessReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		interval = cr.Spec.Interval.Duration
 	}
 
+	// A suspended ClusterReadiness runs nothing and keeps its reported state.
+	now := metav1.Now()
+	suspended, suspendedUntil, err := suspension(cr.Spec.Suspend, cr.Spec.MaintenanceWindows, now.Time)
+	if err != nil {
+		logger.Error(err, "ignoring invalid maintenance windows")
+	}
+	if suspended {
+		return r.reconcileSuspended(ctx, &cr, base, now, suspendedUntil)
+	}
+	if meta.IsStatusConditionTrue(cr.Status.Conditions, "Suspended") {
+		meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+			Type:    "Suspended",
+			Status:  metav1.ConditionFalse,
+			Reason:  "Resumed",
+			Message: "checks are running",
+		})
+	}
+
 	// Resolve profiles + inline checks into a flat list.
-	resolvedChecks, err := ResolveChecks(ctx, r.Client, cr.Spec, interval)
+	resolvedChecks, excludedChecks, err := ResolveChecks(ctx, r.Client, cr.Spec, interval)
 	if err != nil {
 		logger.Error(err, "failed to resolve checks")
 		// Set a ProfilesResolved=False condition
@@ -80,12 +174,16 @@ func (r *ClusterReadinessReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			Reason:             "ResolutionFailed",
 			Message:            fmt.Sprintf("failed to resolve profiles: %v", err),
 		})
-		if updateErr := r.Status().Update(ctx, &cr); updateErr != nil {
+		setChecksResolvedCondition(&cr, 0, 0, err)
+		if updateErr := r.patchStatus(ctx, &cr, base); updateErr != nil {
 			logger.Error(updateErr, "failed to update status after resolution failure")
 		}
 		return ctrl.Result{RequeueAfter: interval}, nil
 	}
 
+	cr.Status.ExcludedChecks = excludedChecks
+	setChecksResolvedCondition(&cr, len(resolvedChecks), len(excludedChecks), nil)
+
 	// Set ProfilesResolved condition if profiles are used
 	if len(cr.Spec.Profiles) > 0 {
 		meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
@@ -97,22 +195,29 @@ func (r *ClusterReadinessReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		})
 	}
 
-	// Determine which checks are due for execution based on per-check intervals.
-	now := metav1.Now()
+	if r.DynamicExecutor != nil {
+		meta.SetStatusCondition(&cr.Status.Conditions, executorReadyCondition(r.DynamicExecutor.Ready()))
+	}
 
-	// Flatten existing categories for scheduler lookup.
-	var existingChecks []clustergatev1alpha1.CheckStatus
-	existingCategoryLookup := make(map[string]string)
-	for _, cat := range cr.Status.Categories {
-		for _, c := range cat.Checks {
-			existingChecks = append(existingChecks, c)
-			existingCategoryLookup[c.Name] = cat.Category
-		}
+	// Set aside suspended and skipped checks; they are reported without running.
+	activeChecks, suspendedChecks, suspensionChange, err := splitSuspended(resolvedChecks, now.Time, interval)
+	if err != nil {
+		logger.Error(err, "ignoring invalid maintenance windows")
 	}
+	activeChecks, skippedChecks, runIfErrors := r.splitRunIf(ctx, activeChecks)
+	setRevisions(ctx, r.Client, activeChecks)
 
-	dueChecks, carriedStatuses, nextRequeue := CheckSchedule(resolvedChecks, existingChecks, now.Time)
+	// Determine which checks are due for execution based on per-check intervals.
+	// Flatten existing categories for scheduler lookup.
+	existingChecks, existingCategoryLookup, existingStatusLookup := flattenCategories(cr.Status.Categories)
+
+	dueChecks, carriedStatuses, nextRequeue := CheckSchedule(activeChecks, existingChecks, now.Time, cr.Spec.JitterPercent)
+	if nextRequeue == 0 || suspensionChange < nextRequeue {
+		nextRequeue = suspensionChange
+	}
+	nextRequeue = nextSuspension(cr.Spec.MaintenanceWindows, now.Time, nextRequeue)
 
-	logger.Info("check scheduling",
+	logger.V(1).Info("check scheduling",
 		"total", len(resolvedChecks),
 		"due", len(dueChecks),
 		"carried", len(carriedStatuses),
@@ -128,187 +233,164 @@ func (r *ClusterReadinessReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		go func(idx int, resolved ResolvedCheck) {
 			defer wg.Done()
 
-			// Resolve final severity and category
-			sev, cat := ResolveSeverityAndCategory(resolved, ctx, r.Client)
-
-			if resolved.IsBuiltin {
+			switch {
+			case resolved.IsBuiltin:
+				// Resolve final severity and category
+				sev, cat := ResolveSeverityAndCategory(resolved, ctx, r.Client)
 				r.runBuiltinCheck(ctx, idx, resolved, sev, cat, results)
-			} else {
-				r.runResolvedDynamicCheck(ctx, idx, resolved, sev, cat, results)
+			case resolved.Namespace != "":
+				r.runNamespaceGateCheck(ctx, idx, resolved, results)
+			default:
+				r.runResolvedDynamicCheck(ctx, idx, resolved, results)
 			}
 		}(i, rc)
 	}
 
 	wg.Wait()
+	withHistory(results, dueChecks, existingChecks, cr.Spec.HistoryLimit)
 
-	// Build status from results (newly executed + carried forward).
-	healthChecks := make(map[string]*server.CheckState, len(results)+len(carriedStatuses))
-
-	// Aggregation counters
-	summary := &clustergatev1alpha1.ReadinessSummary{}
-	categoryMap := make(map[string]*categoryAgg)
-
-	// Process newly executed check results
-	for _, res := range results {
-		ready := res.result.Ready
-		message := res.result.Message
-		if res.err != nil {
-			ready = false
-			message = fmt.Sprintf("check error: %v", res.err)
-		}
-
-		status := "Passing"
-		if !ready {
-			status = "Failing"
+	// Report checks that were not run alongside the executed ones. A check
+	// whose runIf cannot be evaluated fails.
+	for _, rc := range resolvedChecks {
+		res := checkResult{
+			name:         rc.Identifier,
+			source:       rc.Source,
+			previous:     previousStatus(existingChecks, rc.Identifier),
+			historyLimit: resolveHistoryLimit(cr.Spec.HistoryLimit),
 		}
-
-		cs := clustergatev1alpha1.CheckStatus{
-			Name:        res.name,
-			Source:      res.source,
-			Status:      status,
-			Severity:    clustergatev1alpha1.Severity(res.severity),
-			Message:     message,
-			LastChecked: &now,
+		if until, ok := suspendedChecks[rc.Identifier]; ok {
+			res.notRun, res.result.Message = "Suspended", suspendedMessage(until)
+		} else if reason, ok := skippedChecks[rc.Identifier]; ok {
+			res.notRun, res.result.Message = "Skipped", reason
+		} else if runIfErr, ok := runIfErrors[rc.Identifier]; ok {
+			res.result.Message = runIfErr.Error()
+		} else {
+			continue
 		}
+		res.severity, res.category = ResolveSeverityAndCategory(rc, ctx, r.Client)
+		results = append(results, res)
+	}
 
-		healthChecks[res.name] = &server.CheckState{
-			Status:   status,
-			Message:  message,
-			Severity: res.severity,
-			Category: res.category,
+	// Record transitions and per-check metrics for newly executed checks,
+	// with the status evaluate reports, so failures held as Initializing
+	// are not logged as transitions on every run.
+	initializing, initialDelayLeft := r.initialDelay(&cr, now.Time)
+	for _, res := range results {
+		status, message, ready := reportedOutcome(res, initializing)
+		transitions.Check(res.name, existingStatusLookup[res.name], status, message, res.duration)
+		if res.notRun != "" {
+			continue
 		}
 
-		// Update metrics.
 		readyVal := float64(0)
 		if ready {
 			readyVal = 1
 		}
 		metrics.CheckReady.WithLabelValues(res.name, req.Name, res.severity, res.category).Set(readyVal)
+		if res.result.Value != nil && res.err == nil {
+			metrics.CheckValue.WithLabelValues(res.name, req.Name, res.severity, res.category).Set(*res.result.Value)
+		} else {
+			metrics.CheckValue.DeleteLabelValues(res.name, req.Name, res.severity, res.category)
+		}
 		metrics.CheckDuration.WithLabelValues(res.name, res.severity, res.category).Observe(res.duration.Seconds())
-
-		aggregateCheck(summary, categoryMap, res.severity, res.category, ready)
-		categoryMap[res.category].checks = append(categoryMap[res.category].checks, cs)
 	}
 
-	// Process carried-forward check statuses
-	for _, cs := range carriedStatuses {
-		cat := existingCategoryLookup[cs.Name]
-
-		healthChecks[cs.Name] = &server.CheckState{
-			Status:   cs.Status,
-			Message:  cs.Message,
-			Severity: string(cs.Severity),
-			Category: cat,
-		}
-
-		ready := cs.Status == "Passing"
-		aggregateCheck(summary, categoryMap, string(cs.Severity), cat, ready)
-		categoryMap[cat].checks = append(categoryMap[cat].checks, cs)
+	// Build status from results (newly executed + carried forward).
+	if initializing && initialDelayLeft < nextRequeue {
+		nextRequeue = initialDelayLeft
 	}
+	eval := evaluate(results, carriedStatuses, existingCategoryLookup, cr.Spec.DegradedThreshold, initializing, &now)
+	eval.requireCategories(cr.Spec.RequiredCategories)
+	eval.applyPolicy(cr.Spec.ReadinessPolicy)
+	if held := holdHealthy(&cr, &eval, now); held > 0 && held < nextRequeue {
+		nextRequeue = held
+	}
+	summary, categories, healthState := eval.summary, eval.categories, eval.state
 
-	// Build categories with nested checks
-	categories := make([]clustergatev1alpha1.CategoryStatus, 0, len(categoryMap))
-	for _, agg := range categoryMap {
-		var catState string
-		if agg.criticalFailing {
-			catState = "Unhealthy"
-		} else if agg.warningFailing {
-			catState = "Degraded"
-		} else {
-			catState = "Healthy"
-		}
-
-		// Sort checks within category for deterministic output
-		sort.Slice(agg.checks, func(i, j int) bool {
-			return agg.checks[i].Name < agg.checks[j].Name
-		})
-
-		categories = append(categories, clustergatev1alpha1.CategoryStatus{
-			Category: agg.category,
-			State:    catState,
-			Checks:   agg.checks,
-			Total:    agg.total,
-			Passing:  agg.passing,
-			Failing:  agg.failing,
-		})
-
-		// Update category metrics
+	// Update category metrics
+	for _, cat := range categories {
 		catReadyVal := float64(0)
-		if !agg.criticalFailing {
+		if cat.State != "Unhealthy" {
 			catReadyVal = 1
 		}
-		metrics.CategoryReady.WithLabelValues(agg.category, req.Name).Set(catReadyVal)
+		metrics.CategoryReady.WithLabelValues(cat.Category, req.Name).Set(catReadyVal)
 	}
-	// Sort for deterministic output
-	sort.Slice(categories, func(i, j int) bool {
-		return categories[i].Category < categories[j].Category
-	})
 
-	// Readiness is determined by critical checks only
-	allCriticalReady := summary.CriticalTotal == summary.CriticalPassing
-
-	// Compute the cluster health state:
-	// Healthy = all checks passing
-	// Degraded = all critical passing but warning checks failing
-	// Unhealthy = any critical check failing
-	var healthState clustergatev1alpha1.ClusterHealthState
-	if !allCriticalReady {
-		healthState = clustergatev1alpha1.ClusterUnhealthy
-	} else if summary.WarningFailing > 0 {
-		healthState = clustergatev1alpha1.ClusterDegraded
-	} else {
-		healthState = clustergatev1alpha1.ClusterHealthy
-	}
-
-	// Update overall metrics.
+	// Update overall metrics. Readiness is determined by critical checks, or
+	// required categories, and the readiness policy.
 	clusterReadyVal := float64(0)
-	if allCriticalReady {
+	if eval.ready() {
 		clusterReadyVal = 1
 	}
 	metrics.ClusterReady.WithLabelValues(req.Name).Set(clusterReadyVal)
 	metrics.ClusterHealthState.WithLabelValues(req.Name, string(healthState)).Set(1)
 	// Reset other state gauges
-	for _, s := range []string{"Healthy", "Degraded", "Unhealthy"} {
+	for _, s := range []string{"Healthy", "Degraded", "Unhealthy", "Initializing"} {
 		if s != string(healthState) {
 			metrics.ClusterHealthState.WithLabelValues(req.Name, s).Set(0)
 		}
 	}
 
-	// Build health server summary view
-	healthSummary := &server.ReadinessSummaryView{
-		Total:           summary.Total,
-		Passing:         summary.Passing,
-		Failing:         summary.Failing,
-		CriticalTotal:   summary.CriticalTotal,
-		CriticalPassing: summary.CriticalPassing,
-		WarningFailing:  summary.WarningFailing,
+	// Update health server state.
+	eval.updateState(r.ReadinessState, req.Name)
+	r.reconcileGates(&cr, eval, now)
+	setStandardConditions(&cr, eval, false)
+	ttl := evaluationTTL(interval, resolvedChecks)
+	if err := r.reconcileReadyMarkers(ctx, &cr, eval.ready(), healthState, ttl, now); err != nil {
+		logger.Error(err, "failed to reconcile ready markers")
 	}
-	healthCategorySummaries := make([]server.CategorySummaryView, len(categories))
-	for i, cs := range categories {
-		healthCategorySummaries[i] = server.CategorySummaryView{
-			Category: cs.Category,
-			State:    cs.State,
-			Total:    cs.Total,
-			Passing:  cs.Passing,
-			Failing:  cs.Failing,
-		}
+	if err := r.reconcileNodeTaints(ctx, &cr, eval.ready()); err != nil {
+		logger.Error(err, "failed to reconcile node taints")
+	}
+	if err := r.reconcileGitOps(ctx, &cr, healthState); err != nil {
+		logger.Error(err, "failed to reconcile GitOps suspension")
+	}
+	if err := r.reconcileTrafficWeight(ctx, &cr, healthState); err != nil {
+		logger.Error(err, "failed to reconcile traffic weight")
 	}
-
-	// Update health server state.
-	r.ReadinessState.Update(req.Name, string(healthState), healthChecks, healthSummary, healthCategorySummaries)
 
 	// Update CR status.
+	previousState := cr.Status.State
 	cr.Status.State = healthState
 	cr.Status.LastChecked = &now
 	cr.Status.Categories = categories
 	cr.Status.Summary = summary
 
-	if err := r.Status().Update(ctx, &cr); err != nil {
+	if err := r.patchStatus(ctx, &cr, base); err != nil {
 		logger.Error(err, "failed to update ClusterReadiness status")
 		return ctrl.Result{}, err
 	}
 
-	logger.Info("reconciliation complete",
+	if err := r.annotateSummary(ctx, &cr, eval.statuses()); err != nil {
+		logger.Error(err, "failed to write summary annotation")
+	}
+	if err := r.recordGateCheckResults(ctx, &cr, dueChecks, results, now); err != nil {
+		logger.Error(err, "failed to record GateCheck results")
+	}
+	r.recordCheckEvents(ctx, &cr, dueChecks, results, existingStatusLookup)
+	r.notifyCheckIncidents(ctx, &cr, dueChecks, results, existingStatusLookup, initializing, now)
+
+	if len(dueChecks) > 0 && shouldRecordGateRun(cr.Spec.GateRuns, previousState, healthState) {
+		if err := r.recordGateRun(ctx, &cr, now); err != nil {
+			logger.Error(err, "failed to record GateRun")
+		}
+	}
+	if err := r.pruneGateRuns(ctx, &cr, now.Time); err != nil {
+		logger.Error(err, "failed to prune GateRuns")
+	}
+
+	stateDetail := fmt.Sprintf("%d/%d critical checks passing, %d warning checks failing",
+		summary.CriticalPassing, summary.CriticalTotal, summary.WarningFailing)
+	transitions.Cluster(string(previousState), string(healthState), stateDetail, time.Since(reconcileStart))
+	r.recordStateEvent(&cr, previousState, healthState, stateDetail)
+	_, categoryLookup, _ := flattenCategories(categories)
+	event := stateChangeEvent(cr.Name, previousState, healthState, stateDetail, eval.statuses(), categoryLookup, now)
+	if wait := r.notifyStateChange(ctx, &cr, event, now.Time); wait > 0 && (nextRequeue == 0 || wait < nextRequeue) {
+		nextRequeue = wait
+	}
+	r.notifyAlertmanagers(ctx, &cr, eval.statuses(), results, existingStatusLookup, categoryLookup, ttl, now)
+
+	logger.V(1).Info("reconciliation complete",
 		"state", healthState,
 		"total", summary.Total,
 		"criticalPassing", summary.CriticalPassing,
@@ -321,28 +403,159 @@ func (r *ClusterReadinessReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	return ctrl.Result{RequeueAfter: nextRequeue}, nil
 }
 
+// Field indexes used to find the objects referencing a GateProfile or
+// GateCheck.
+const (
+	// profileRefIndex indexes ClusterReadiness CRs by referenced GateProfile.
+	profileRefIndex = "spec.profiles.name"
+	// gateCheckRefIndex indexes ClusterReadiness CRs and GateProfiles by the
+	// GateCheck their checks reference.
+	gateCheckRefIndex = "spec.checks.gateCheckRef"
+	// profileSnapshotIndex indexes ClusterReadiness CRs by the
+	// namespace/name of the ConfigMap snapshots their profiles are read from.
+	profileSnapshotIndex = "spec.profiles.snapshotRef"
+)
+
+// clusterReadinessProfileRefs extracts the profileRefIndex values.
+func clusterReadinessProfileRefs(obj client.Object) []string {
+	cr := obj.(*clustergatev1alpha1.ClusterReadiness)
+	var names []string
+	for _, ref := range cr.Spec.Profiles {
+		names = append(names, ref.Name)
+	}
+	return names
+}
+
+// clusterReadinessGateCheckRefs extracts the gateCheckRefIndex values of a
+// ClusterReadiness's inline checks.
+func clusterReadinessGateCheckRefs(obj client.Object) []string {
+	cr := obj.(*clustergatev1alpha1.ClusterReadiness)
+	var names []string
+	for _, cs := range cr.Spec.Checks {
+		if cs.GateCheckRef != "" {
+			names = append(names, cs.GateCheckRef)
+		}
+	}
+	return names
+}
+
+// clusterReadinessSnapshotRefs extracts the profileSnapshotIndex values.
+func clusterReadinessSnapshotRefs(obj client.Object) []string {
+	cr := obj.(*clustergatev1alpha1.ClusterReadiness)
+	var keys []string
+	for _, ref := range cr.Spec.Profiles {
+		if snap := ref.SnapshotRef; snap != nil {
+			keys = append(keys, snap.Namespace+"/"+snap.Name)
+		}
+	}
+	return keys
+}
+
+// gateProfileGateCheckRefs extracts the gateCheckRefIndex values of a
+// GateProfile: the GateChecks of its current version and of every recorded
+// one, which ClusterReadiness CRs may pin.
+func gateProfileGateCheckRefs(obj client.Object) []string {
+	gp := obj.(*clustergatev1alpha1.GateProfile)
+	_, checks := gp.Current()
+	seen := map[string]bool{}
+	var names []string
+	add := func(refs []clustergatev1alpha1.ProfileCheckRef) {
+		for _, ref := range refs {
+			if ref.GateCheckRef != "" && !seen[ref.GateCheckRef] {
+				seen[ref.GateCheckRef] = true
+				names = append(names, ref.GateCheckRef)
+			}
+		}
+	}
+	add(checks)
+	for _, v := range gp.Status.Versions {
+		add(v.Checks)
+	}
+	return names
+}
+
+// profileSourceChanged passes GateProfile updates importing new checks from
+// the profile's source, which leave its generation unchanged.
+var profileSourceChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldProfile, ok := e.ObjectOld.(*clustergatev1alpha1.GateProfile)
+		newProfile, ok2 := e.ObjectNew.(*clustergatev1alpha1.GateProfile)
+		if !ok || !ok2 {
+			return false
+		}
+		oldVersion, oldChecks := oldProfile.Current()
+		newVersion, newChecks := newProfile.Current()
+		return oldVersion != newVersion || !equality.Semantic.DeepEqual(oldChecks, newChecks)
+	},
+}
+
 // SetupWithManager sets up the controller with the Manager.
-// Watches ClusterReadiness, GateProfile, and GateCheck for changes.
+// Watches ClusterReadiness, the GateProfiles, GateChecks and profile snapshot
+// ConfigMaps they reference, and the NamespaceGateChecks in the namespaces
+// they select. Status-only updates of the latter are ignored.
 func (r *ClusterReadinessReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ctx := context.Background()
+	indexer := mgr.GetFieldIndexer()
+	if err := indexer.IndexField(ctx, &clustergatev1alpha1.ClusterReadiness{}, profileRefIndex, clusterReadinessProfileRefs); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(ctx, &clustergatev1alpha1.ClusterReadiness{}, gateCheckRefIndex, clusterReadinessGateCheckRefs); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(ctx, &clustergatev1alpha1.GateProfile{}, gateCheckRefIndex, gateProfileGateCheckRefs); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(ctx, &clustergatev1alpha1.ClusterReadiness{}, profileSnapshotIndex, clusterReadinessSnapshotRefs); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&clustergatev1alpha1.ClusterReadiness{}).
 		Watches(&clustergatev1alpha1.GateProfile{}, handler.EnqueueRequestsFromMapFunc(
 			func(ctx context.Context, obj client.Object) []reconcile.Request {
-				return r.enqueueAllClusterReadiness(ctx)
+				return r.enqueueForGateProfile(ctx, obj.GetName())
 			},
-		)).
+		), builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, profileSourceChanged))).
 		Watches(&clustergatev1alpha1.GateCheck{}, handler.EnqueueRequestsFromMapFunc(
 			func(ctx context.Context, obj client.Object) []reconcile.Request {
-				return r.enqueueAllClusterReadiness(ctx)
+				return r.enqueueForGateCheck(ctx, obj.GetName())
+			},
+		), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&clustergatev1alpha1.NamespaceGateCheck{}, handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return r.enqueueForNamespace(ctx, obj.GetNamespace())
+			},
+		), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// ConfigMaps are only watched by metadata, as they are not cached.
+		WatchesMetadata(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return r.enqueueForSnapshot(ctx, obj.GetNamespace(), obj.GetName())
 			},
 		)).
 		Complete(r)
 }
 
-// enqueueAllClusterReadiness returns reconcile requests for all ClusterReadiness CRs.
-func (r *ClusterReadinessReconciler) enqueueAllClusterReadiness(ctx context.Context) []reconcile.Request {
+// enqueueForGateProfile returns reconcile requests for the ClusterReadiness
+// CRs referencing the named GateProfile.
+func (r *ClusterReadinessReconciler) enqueueForGateProfile(ctx context.Context, name string) []reconcile.Request {
 	var list clustergatev1alpha1.ClusterReadinessList
-	if err := r.List(ctx, &list); err != nil {
+	if err := r.List(ctx, &list, client.MatchingFields{profileRefIndex: name}); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, len(list.Items))
+	for i, cr := range list.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: cr.Name},
+		}
+	}
+	return requests
+}
+
+// enqueueForSnapshot returns reconcile requests for the ClusterReadiness CRs
+// reading a profile from the named ConfigMap snapshot.
+func (r *ClusterReadinessReconciler) enqueueForSnapshot(ctx context.Context, namespace, name string) []reconcile.Request {
+	var list clustergatev1alpha1.ClusterReadinessList
+	if err := r.List(ctx, &list, client.MatchingFields{profileSnapshotIndex: namespace + "/" + name}); err != nil {
 		return nil
 	}
 	requests := make([]reconcile.Request, len(list.Items))
@@ -354,6 +567,62 @@ func (r *ClusterReadinessReconciler) enqueueAllClusterReadiness(ctx context.Cont
 	return requests
 }
 
+// enqueueForGateCheck returns reconcile requests for the ClusterReadiness CRs
+// referencing the named GateCheck, inline or through a GateProfile.
+func (r *ClusterReadinessReconciler) enqueueForGateCheck(ctx context.Context, name string) []reconcile.Request {
+	seen := map[string]bool{}
+	var requests []reconcile.Request
+	add := func(reqs []reconcile.Request) {
+		for _, req := range reqs {
+			if !seen[req.Name] {
+				seen[req.Name] = true
+				requests = append(requests, req)
+			}
+		}
+	}
+
+	var list clustergatev1alpha1.ClusterReadinessList
+	if err := r.List(ctx, &list, client.MatchingFields{gateCheckRefIndex: name}); err == nil {
+		for _, cr := range list.Items {
+			add([]reconcile.Request{{NamespacedName: types.NamespacedName{Name: cr.Name}}})
+		}
+	}
+	var profiles clustergatev1alpha1.GateProfileList
+	if err := r.List(ctx, &profiles, client.MatchingFields{gateCheckRefIndex: name}); err == nil {
+		for _, gp := range profiles.Items {
+			add(r.enqueueForGateProfile(ctx, gp.Name))
+		}
+	}
+	return requests
+}
+
+// enqueueForNamespace returns reconcile requests for the ClusterReadiness CRs
+// whose checkNamespaces select the named namespace.
+func (r *ClusterReadinessReconciler) enqueueForNamespace(ctx context.Context, name string) []reconcile.Request {
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, &ns); err != nil {
+		return nil
+	}
+	var list clustergatev1alpha1.ClusterReadinessList
+	if err := r.List(ctx, &list); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, cr := range list.Items {
+		if cr.Spec.CheckNamespaces == nil {
+			continue
+		}
+		sel, err := metav1.LabelSelectorAsSelector(cr.Spec.CheckNamespaces)
+		if err != nil || !sel.Matches(labels.Set(ns.Labels)) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: cr.Name},
+		})
+	}
+	return requests
+}
+
 // runBuiltinCheck executes a built-in check by name.
 func (r *ClusterReadinessReconciler) runBuiltinCheck(ctx context.Context, idx int, resolved ResolvedCheck, sev, cat string, results []checkResult) {
 	checker, ok := checks.Get(resolved.BuiltinName)
@@ -372,7 +641,9 @@ func (r *ClusterReadinessReconciler) runBuiltinCheck(ctx context.Context, idx in
 	}
 
 	start := time.Now()
-	res, err := checker.Run(ctx, resolved.Config)
+	res, err := withCheckTimeout(ctx, resolved.Timeout, func(ctx context.Context) (checks.Result, error) {
+		return checker.Run(ctx, resolved.Config)
+	})
 	duration := time.Since(start)
 
 	results[idx] = checkResult{
@@ -386,10 +657,12 @@ func (r *ClusterReadinessReconciler) runBuiltinCheck(ctx context.Context, idx in
 	}
 }
 
-// runResolvedDynamicCheck executes a dynamic check via the GateCheck CR.
-func (r *ClusterReadinessReconciler) runResolvedDynamicCheck(ctx context.Context, idx int, resolved ResolvedCheck, sev, cat string, results []checkResult) {
+// runResolvedDynamicCheck executes a dynamic check via the GateCheck CR. The
+// CR is fetched once, for both its spec and its severity and category defaults.
+func (r *ClusterReadinessReconciler) runResolvedDynamicCheck(ctx context.Context, idx int, resolved ResolvedCheck, results []checkResult) {
 	var gc clustergatev1alpha1.GateCheck
 	if err := r.Get(ctx, types.NamespacedName{Name: resolved.GateCheckName}, &gc); err != nil {
+		sev, cat := dynamicSeverityAndCategory(resolved, nil)
 		results[idx] = checkResult{
 			name:     resolved.Identifier,
 			severity: sev,
@@ -403,8 +676,29 @@ func (r *ClusterReadinessReconciler) runResolvedDynamicCheck(ctx context.Context
 		return
 	}
 
+	sev, cat := dynamicSeverityAndCategory(resolved, &gc)
+	spec, err := ApplyParameters(gc.Spec, resolved.Values)
+	if err != nil {
+		results[idx] = checkResult{
+			name:     resolved.Identifier,
+			severity: sev,
+			category: cat,
+			source:   resolved.Source,
+			result: checks.Result{
+				Ready:   false,
+				Message: fmt.Sprintf("invalid values for GateCheck %s: %v", gc.Name, err),
+			},
+		}
+		return
+	}
+	execName := resolved.GateCheckName
+	if resolved.Instance != "" {
+		execName += "-" + resolved.Instance
+	}
 	start := time.Now()
-	res, err := r.DynamicExecutor.Execute(ctx, resolved.GateCheckName, gc.Spec)
+	res, err := withCheckTimeout(ctx, resolved.Timeout, func(ctx context.Context) (checks.Result, error) {
+		return r.DynamicExecutor.Execute(ctx, execName, spec)
+	})
 	duration := time.Since(start)
 
 	results[idx] = checkResult{
@@ -418,6 +712,238 @@ func (r *ClusterReadinessReconciler) runResolvedDynamicCheck(ctx context.Context
 	}
 }
 
+// runNamespaceGateCheck executes a NamespaceGateCheck confined to its own
+// namespace.
+func (r *ClusterReadinessReconciler) runNamespaceGateCheck(ctx context.Context, idx int, resolved ResolvedCheck, results []checkResult) {
+	var ngc clustergatev1alpha1.NamespaceGateCheck
+	key := types.NamespacedName{Namespace: resolved.Namespace, Name: resolved.GateCheckName}
+	if err := r.Get(ctx, key, &ngc); err != nil {
+		sev, cat := dynamicSeverityAndCategory(resolved, nil)
+		results[idx] = checkResult{
+			name:     resolved.Identifier,
+			severity: sev,
+			category: cat,
+			source:   resolved.Source,
+			result: checks.Result{
+				Ready:   false,
+				Message: fmt.Sprintf("NamespaceGateCheck not found: %s", key),
+			},
+		}
+		return
+	}
+
+	sev, cat := dynamicSeverityAndCategory(resolved, &clustergatev1alpha1.GateCheck{Spec: ngc.Spec})
+	results[idx] = checkResult{
+		name:     resolved.Identifier,
+		severity: sev,
+		category: cat,
+		source:   resolved.Source,
+	}
+	spec, err := ApplyParameters(ngc.Spec, nil)
+	if err != nil {
+		results[idx].result = checks.Result{Ready: false, Message: err.Error()}
+		return
+	}
+	scoped, err := ScopeToNamespace(spec, ngc.Namespace, r.RESTMapper())
+	if err != nil {
+		results[idx].result = checks.Result{Ready: false, Message: err.Error()}
+		return
+	}
+
+	start := time.Now()
+	results[idx].result, results[idx].err = withCheckTimeout(ctx, resolved.Timeout, func(ctx context.Context) (checks.Result, error) {
+		return r.DynamicExecutor.Execute(ctx, ngc.Namespace+"-"+ngc.Name, scoped)
+	})
+	results[idx].duration = time.Since(start)
+}
+
+// withCheckTimeout runs a check under timeout, when set. A check that overruns
+// it fails with a timeout message; it is left to finish in the background so a
+// check that ignores its context cannot stall the reconcile.
+func withCheckTimeout(ctx context.Context, timeout time.Duration, run func(context.Context) (checks.Result, error)) (checks.Result, error) {
+	if timeout <= 0 {
+		return run(ctx)
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		result checks.Result
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		res, err := run(checkCtx)
+		done <- outcome{res, err}
+	}()
+
+	select {
+	case out := <-done:
+		if errors.Is(checkCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil && (out.err != nil || !out.result.Ready) {
+			return timedOutResult(timeout), nil
+		}
+		return out.result, out.err
+	case <-checkCtx.Done():
+		if ctx.Err() != nil {
+			return checks.Result{}, ctx.Err()
+		}
+		return timedOutResult(timeout), nil
+	}
+}
+
+func timedOutResult(timeout time.Duration) checks.Result {
+	return checks.Result{
+		Ready:   false,
+		Message: fmt.Sprintf("check timed out after %s", timeout),
+	}
+}
+
+// splitRunIf separates the checks whose runIf does not hold from those to
+// run, with the reason each is skipped. Checks whose runIf cannot be
+// evaluated are returned with the error instead.
+func (r *ClusterReadinessReconciler) splitRunIf(ctx context.Context, resolved []ResolvedCheck) (active []ResolvedCheck, skipped map[string]string, failed map[string]error) {
+	var facts *ClusterFacts
+	var factsErr error
+	for _, rc := range resolved {
+		if rc.RunIf == "" {
+			active = append(active, rc)
+			continue
+		}
+		if facts == nil && factsErr == nil {
+			f, err := gatherClusterFacts(ctx, r.Client, r.ServerVersion)
+			facts, factsErr = &f, err
+		}
+		run := false
+		err := factsErr
+		if err == nil {
+			run, err = evalRunIf(ctx, rc.RunIf, *facts)
+		}
+		switch {
+		case err != nil:
+			if failed == nil {
+				failed = make(map[string]error)
+			}
+			failed[rc.Identifier] = fmt.Errorf("evaluating runIf: %w", err)
+		case run:
+			active = append(active, rc)
+		default:
+			if skipped == nil {
+				skipped = make(map[string]string)
+			}
+			skipped[rc.Identifier] = "skipped: runIf " + rc.RunIf + " is false"
+		}
+	}
+	return active, skipped, failed
+}
+
+// reconcileSuspended keeps the reported state of a suspended ClusterReadiness
+// without running checks, until the suspension ends at until, or until
+// spec.suspend is cleared when until is zero.
+func (r *ClusterReadinessReconciler) reconcileSuspended(ctx context.Context, cr, base *clustergatev1alpha1.ClusterReadiness, now metav1.Time, until time.Time) (ctrl.Result, error) {
+	reason := "Suspend"
+	if !until.IsZero() {
+		reason = "MaintenanceWindow"
+	}
+	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+		Type:    "Suspended",
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: suspendedMessage(until),
+	})
+
+	// Republish the frozen state, which is lost when the operator restarts.
+	existingChecks, existingCategoryLookup, _ := flattenCategories(cr.Status.Categories)
+	initializing, _ := r.initialDelay(cr, now.Time)
+	eval := evaluate(nil, existingChecks, existingCategoryLookup, cr.Spec.DegradedThreshold, initializing, &now)
+	eval.requireCategories(cr.Spec.RequiredCategories)
+	eval.applyPolicy(cr.Spec.ReadinessPolicy)
+	holdHealthy(cr, &eval, now)
+	eval.updateState(r.ReadinessState, cr.Name)
+	r.reconcileGates(cr, eval, now)
+	setStandardConditions(cr, eval, true)
+
+	if err := r.patchStatus(ctx, cr, base); err != nil {
+		log.FromContext(ctx).Error(err, "failed to update ClusterReadiness status")
+		return ctrl.Result{}, err
+	}
+	if until.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: until.Sub(now.Time)}, nil
+}
+
+// patchStatus writes cr's status as a merge patch against base, the object it
+// was computed from. The patch is guarded by base's resourceVersion; when
+// another write got there first, cr's status is re-applied to the latest
+// object and patched again, so interleaved reconciles never write status
+// computed from a stale object without noticing.
+func (r *ClusterReadinessReconciler) patchStatus(ctx context.Context, cr, base *clustergatev1alpha1.ClusterReadiness) error {
+	status := cr.Status.DeepCopy()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := r.Status().Patch(ctx, cr, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{}))
+		if !apierrors.IsConflict(err) {
+			return err
+		}
+		var latest clustergatev1alpha1.ClusterReadiness
+		if getErr := r.Get(ctx, client.ObjectKeyFromObject(cr), &latest); getErr != nil {
+			return getErr
+		}
+		base = latest.DeepCopy()
+		latest.Status = *status.DeepCopy()
+		*cr = latest
+		return err
+	})
+}
+
+// holdHealthy records in cr's status since when every critical check has
+// passed, and holds eval back from becoming ready until spec.minHealthyDuration
+// has passed since then. It returns how much longer eval is held.
+func holdHealthy(cr *clustergatev1alpha1.ClusterReadiness, eval *evaluation, now metav1.Time) time.Duration {
+	if !eval.ready() {
+		cr.Status.CriticalPassingSince = nil
+		return 0
+	}
+	if cr.Status.CriticalPassingSince == nil {
+		cr.Status.CriticalPassingSince = &now
+	}
+	if cr.Spec.MinHealthyDuration == nil {
+		return 0
+	}
+	return eval.holdReady(cr.Status.CriticalPassingSince, cr.Spec.MinHealthyDuration.Duration, now.Time)
+}
+
+// initialDelay reports whether cr is within spec.initialDelaySeconds of its
+// creation or the operator's start, and how much of the delay is left.
+func (r *ClusterReadinessReconciler) initialDelay(cr *clustergatev1alpha1.ClusterReadiness, now time.Time) (bool, time.Duration) {
+	if cr.Spec.InitialDelaySeconds <= 0 {
+		return false, 0
+	}
+	start := cr.CreationTimestamp.Time
+	if r.StartedAt.After(start) {
+		start = r.StartedAt
+	}
+	left := start.Add(time.Duration(cr.Spec.InitialDelaySeconds) * time.Second).Sub(now)
+	return left > 0, left
+}
+
+// executorReadyCondition reports whether the dynamic executor initialized every check type.
+func executorReadyCondition(initErr error) metav1.Condition {
+	if initErr != nil {
+		return metav1.Condition{
+			Type:    "ExecutorReady",
+			Status:  metav1.ConditionFalse,
+			Reason:  "InitializationFailed",
+			Message: fmt.Sprintf("script checks report Unknown: %v", initErr),
+		}
+	}
+	return metav1.Condition{
+		Type:    "ExecutorReady",
+		Status:  metav1.ConditionTrue,
+		Reason:  "Initialized",
+		Message: "all dynamic check types are available",
+	}
+}
+
 // checkResult holds the outcome of a single check execution.
 type checkResult struct {
 	name     string
@@ -427,6 +953,20 @@ type checkResult struct {
 	result   checks.Result
 	err      error
 	duration time.Duration
+
+	// previous is the check's status before this run, if it had one.
+	previous *clustergatev1alpha1.CheckStatus
+	// failureThreshold and successThreshold are the consecutive runs needed
+	// to flip the reported status away from previous.
+	failureThreshold int32
+	successThreshold int32
+	// revision identifies the definition the check ran with.
+	revision string
+	// historyLimit bounds the transitions kept in the check's history.
+	historyLimit int32
+
+	// notRun is the status of a check that was not run: Suspended or Skipped.
+	notRun string
 }
 
 // categoryAgg is a helper for accumulating per-category statistics.
@@ -460,6 +1000,11 @@ func aggregateCheck(summary *clustergatev1alpha1.ReadinessSummary, categoryMap m
 		if !ready {
 			summary.WarningFailing++
 		}
+	case clustergatev1alpha1.SeverityInfo:
+		summary.InfoTotal++
+		if !ready {
+			summary.InfoFailing++
+		}
 	}
 
 	agg, exists := categoryMap[category]
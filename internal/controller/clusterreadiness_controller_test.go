@@ -1,9 +1,32 @@
 package controller
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+	"github.com/clustergate/clustergate/internal/checks/dynamic"
+	"github.com/clustergate/clustergate/internal/metrics"
+	"github.com/clustergate/clustergate/internal/server"
 )
 
 func TestAggregateCheck(t *testing.T) {
@@ -151,3 +174,700 @@ func TestAggregateCheck_MultipleCalls(t *testing.T) {
 		t.Errorf("category failing = %d, want 2", agg.failing)
 	}
 }
+
+// stubExecutor returns a fixed result for every dynamic check.
+type stubExecutor struct {
+	result  checks.Result
+	err     error
+	initErr error
+}
+
+func (s stubExecutor) Execute(_ context.Context, _ string, _ clustergatev1alpha1.GateCheckSpec) (checks.Result, error) {
+	return s.result, s.err
+}
+
+func (s stubExecutor) Ready() error { return s.initErr }
+
+func TestReconcile_ExecutorUnavailable(t *testing.T) {
+	gc := &clustergatev1alpha1.GateCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "script"},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			Severity:    clustergatev1alpha1.SeverityCritical,
+			Category:    "apps",
+			ScriptCheck: &clustergatev1alpha1.ScriptCheckSpec{Image: "busybox"},
+		},
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Checks: []clustergatev1alpha1.CheckSpec{{GateCheckRef: "script"}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc, cr).WithStatusSubresource(cr).Build()
+	initErr := errors.New("bad rest.Config")
+	r := &ClusterReadinessReconciler{
+		Client:         c,
+		ReadinessState: server.NewReadinessState(),
+		DynamicExecutor: stubExecutor{
+			result:  checks.Result{Ready: false, Message: "script checks are unavailable"},
+			err:     fmt.Errorf("%w: %v", dynamic.ErrExecutorUnavailable, initErr),
+			initErr: initErr,
+		},
+	}
+
+	t.Cleanup(func() { metrics.DeleteClusterReadiness("prod") })
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "prod"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got clustergatev1alpha1.ClusterReadiness
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "prod"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	cond := meta.FindStatusCondition(got.Status.Conditions, "ExecutorReady")
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "InitializationFailed" {
+		t.Errorf("ExecutorReady condition = %+v, want False/InitializationFailed", cond)
+	}
+	for condType, want := range map[string]string{"Ready": "CriticalChecksFailing", "ChecksResolved": "Resolved", "Degraded": "NotDegraded", "Progressing": "Evaluated"} {
+		cond := meta.FindStatusCondition(got.Status.Conditions, condType)
+		if cond == nil || cond.Reason != want || cond.ObservedGeneration != got.Generation {
+			t.Errorf("%s condition = %+v, want reason %s at generation %d", condType, cond, want, got.Generation)
+		}
+	}
+	if len(got.Status.Categories) != 1 || len(got.Status.Categories[0].Checks) != 1 {
+		t.Fatalf("unexpected categories: %+v", got.Status.Categories)
+	}
+	if status := got.Status.Categories[0].Checks[0].Status; status != "Unknown" {
+		t.Errorf("check status = %q, want Unknown", status)
+	}
+	// Unknown critical checks still hold the gate.
+	if got.Status.State != clustergatev1alpha1.ClusterUnhealthy {
+		t.Errorf("state = %q, want Unhealthy", got.Status.State)
+	}
+}
+
+func TestReconcile_ExportsCheckValue(t *testing.T) {
+	gc := &clustergatev1alpha1.GateCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "apiserver-availability"},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			Severity: clustergatev1alpha1.SeverityCritical,
+			Category: "control-plane",
+			PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
+				Endpoint:  "http://prometheus:9090",
+				Query:     "apiserver_availability",
+				Condition: &clustergatev1alpha1.PromQLCondition{Type: "value", Operator: "gte", Threshold: 0.99},
+			},
+		},
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Checks: []clustergatev1alpha1.CheckSpec{{GateCheckRef: "apiserver-availability"}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc, cr).WithStatusSubresource(cr).Build()
+	value := 0.97
+	r := &ClusterReadinessReconciler{
+		Client:          c,
+		ReadinessState:  server.NewReadinessState(),
+		DynamicExecutor: stubExecutor{result: checks.Result{Ready: false, Message: "value 0.97 < 0.99", Value: &value}},
+	}
+
+	t.Cleanup(func() { metrics.DeleteClusterReadiness("prod") })
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "prod"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	gauge := metrics.CheckValue.WithLabelValues("dynamic:apiserver-availability", "prod", "critical", "control-plane")
+	if got := testutil.ToFloat64(gauge); got != value {
+		t.Errorf("clustergate_check_value = %v, want %v", got, value)
+	}
+}
+
+func TestReconcile_RecordsGateCheckResult(t *testing.T) {
+	gc := &clustergatev1alpha1.GateCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "api"},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{URL: "http://api.example.com/healthz"},
+		},
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Checks: []clustergatev1alpha1.CheckSpec{{GateCheckRef: "api"}, {Name: "dns"}},
+		},
+	}
+	objs := []client.Object{gc, cr,
+		&clustergatev1alpha1.GateProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "apps"},
+			Spec: clustergatev1alpha1.GateProfileSpec{
+				Checks: []clustergatev1alpha1.ProfileCheckRef{{GateCheckRef: "api"}},
+			},
+		},
+		&clustergatev1alpha1.ClusterReadiness{
+			ObjectMeta: metav1.ObjectMeta{Name: "staging"},
+			Spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Profiles: []clustergatev1alpha1.ProfileRef{{Name: "apps"}},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(objs...).WithStatusSubresource(gc, cr).
+		WithIndex(&clustergatev1alpha1.ClusterReadiness{}, profileRefIndex, clusterReadinessProfileRefs).
+		WithIndex(&clustergatev1alpha1.ClusterReadiness{}, gateCheckRefIndex, clusterReadinessGateCheckRefs).
+		WithIndex(&clustergatev1alpha1.GateProfile{}, gateCheckRefIndex, gateProfileGateCheckRefs).
+		Build()
+	r := &ClusterReadinessReconciler{
+		Client:          c,
+		ReadinessState:  server.NewReadinessState(),
+		DynamicExecutor: stubExecutor{result: checks.Result{Ready: false, Message: "HTTP 503"}},
+	}
+
+	t.Cleanup(func() { metrics.DeleteClusterReadiness("prod") })
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "prod"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got clustergatev1alpha1.GateCheck
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "api"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	last := got.Status.LastResult
+	if last == nil {
+		t.Fatal("lastResult not recorded")
+	}
+	if last.Ready || last.Message != "HTTP 503" || last.ClusterReadiness != "prod" || last.LastRunTime.IsZero() {
+		t.Errorf("lastResult = %+v, want the failing run by prod", last)
+	}
+	if fmt.Sprint(last.ConsumingClusterReadiness) != "[prod staging]" {
+		t.Errorf("consumingClusterReadiness = %v, want [prod staging]", last.ConsumingClusterReadiness)
+	}
+}
+
+func TestReconcile_FetchesGateCheckOnce(t *testing.T) {
+	gc := &clustergatev1alpha1.GateCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "api"},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			Severity:  clustergatev1alpha1.SeverityWarning,
+			Category:  "apps",
+			HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{URL: "http://api.example/healthz"},
+		},
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Checks: []clustergatev1alpha1.CheckSpec{{GateCheckRef: "api"}},
+		},
+	}
+	gateCheckGets := 0
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc, cr).WithStatusSubresource(cr).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if _, ok := obj.(*clustergatev1alpha1.GateCheck); ok {
+					gateCheckGets++
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).Build()
+	r := &ClusterReadinessReconciler{
+		Client:          c,
+		ReadinessState:  server.NewReadinessState(),
+		DynamicExecutor: stubExecutor{result: checks.Result{Ready: true}},
+	}
+
+	t.Cleanup(func() { metrics.DeleteClusterReadiness("prod") })
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "prod"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gateCheckGets != 1 {
+		t.Errorf("GateCheck fetched %d times, want 1", gateCheckGets)
+	}
+
+	var got clustergatev1alpha1.ClusterReadiness
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "prod"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Status.Categories) != 1 || got.Status.Categories[0].Category != "apps" ||
+		got.Status.Categories[0].Checks[0].Severity != clustergatev1alpha1.SeverityWarning {
+		t.Errorf("severity and category defaults not taken from the GateCheck: %+v", got.Status.Categories)
+	}
+}
+
+func TestPatchStatus_RetriesOnConflict(t *testing.T) {
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Status: clustergatev1alpha1.ClusterReadinessStatus{
+			Conditions: []metav1.Condition{{Type: "ExecutorReady", Status: metav1.ConditionTrue, Reason: "Initialized", LastTransitionTime: metav1.Now()}},
+		},
+	}
+	patches := 0
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(cr).WithStatusSubresource(cr).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				patches++
+				return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+	r := &ClusterReadinessReconciler{Client: c}
+
+	var fetched clustergatev1alpha1.ClusterReadiness
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "prod"}, &fetched); err != nil {
+		t.Fatal(err)
+	}
+	base := fetched.DeepCopy()
+
+	// Another writer changes the object after it was fetched.
+	var other clustergatev1alpha1.ClusterReadiness
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "prod"}, &other); err != nil {
+		t.Fatal(err)
+	}
+	other.Annotations = map[string]string{"example.com/touched": "true"}
+	if err := c.Update(context.Background(), &other); err != nil {
+		t.Fatal(err)
+	}
+
+	fetched.Status.State = clustergatev1alpha1.ClusterHealthy
+	if err := r.patchStatus(context.Background(), &fetched, base); err != nil {
+		t.Fatalf("patchStatus: %v", err)
+	}
+
+	var got clustergatev1alpha1.ClusterReadiness
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "prod"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.State != clustergatev1alpha1.ClusterHealthy {
+		t.Errorf("state = %q, want Healthy", got.Status.State)
+	}
+	if len(got.Status.Conditions) != 1 {
+		t.Errorf("conditions = %+v, want the existing condition kept", got.Status.Conditions)
+	}
+	if got.Annotations["example.com/touched"] != "true" {
+		t.Error("expected the concurrent write to be kept")
+	}
+	if patches != 2 {
+		t.Errorf("status patches = %d, want a conflict then a retry", patches)
+	}
+}
+
+func TestHoldHealthy(t *testing.T) {
+	now := metav1.NewTime(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	passing := []checkResult{{name: "dns", severity: "critical", category: "networking", result: checks.Result{Ready: true}}}
+	failing := []checkResult{{name: "dns", severity: "critical", category: "networking", result: checks.Result{Ready: false}}}
+	since := func(ago time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(-ago))
+		return &t
+	}
+
+	tests := []struct {
+		name      string
+		results   []checkResult
+		since     *metav1.Time
+		wantState clustergatev1alpha1.ClusterHealthState
+		wantHeld  time.Duration
+		wantSince *metav1.Time
+	}{
+		{name: "first passing run is held", results: passing, wantState: clustergatev1alpha1.ClusterUnhealthy, wantHeld: 5 * time.Minute, wantSince: &now},
+		{name: "still held", results: passing, since: since(4 * time.Minute), wantState: clustergatev1alpha1.ClusterUnhealthy, wantHeld: time.Minute, wantSince: since(4 * time.Minute)},
+		{name: "released", results: passing, since: since(5 * time.Minute), wantState: clustergatev1alpha1.ClusterHealthy, wantSince: since(5 * time.Minute)},
+		{name: "failure resets", results: failing, since: since(time.Hour), wantState: clustergatev1alpha1.ClusterUnhealthy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &clustergatev1alpha1.ClusterReadiness{
+				Spec:   clustergatev1alpha1.ClusterReadinessSpec{MinHealthyDuration: &metav1.Duration{Duration: 5 * time.Minute}},
+				Status: clustergatev1alpha1.ClusterReadinessStatus{CriticalPassingSince: tt.since},
+			}
+			eval := evaluate(tt.results, nil, nil, nil, false, &now)
+
+			held := holdHealthy(cr, &eval, now)
+			if held != tt.wantHeld || eval.state != tt.wantState {
+				t.Errorf("held %s with state %q, want %s with %q", held, eval.state, tt.wantHeld, tt.wantState)
+			}
+			if eval.ready() != (tt.wantState == clustergatev1alpha1.ClusterHealthy) {
+				t.Errorf("ready = %v with state %q", eval.ready(), eval.state)
+			}
+			if got := cr.Status.CriticalPassingSince; (got == nil) != (tt.wantSince == nil) || (got != nil && !got.Equal(tt.wantSince)) {
+				t.Errorf("criticalPassingSince = %v, want %v", got, tt.wantSince)
+			}
+		})
+	}
+}
+
+func TestInitialDelay(t *testing.T) {
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+		Spec:       clustergatev1alpha1.ClusterReadinessSpec{InitialDelaySeconds: 60},
+	}
+
+	tests := []struct {
+		name      string
+		startedAt time.Time
+		now       time.Time
+		want      bool
+		wantLeft  time.Duration
+	}{
+		{name: "after creation", now: created.Add(20 * time.Second), want: true, wantLeft: 40 * time.Second},
+		{name: "delay over", now: created.Add(time.Minute)},
+		{name: "after operator restart", startedAt: created.Add(time.Hour), now: created.Add(time.Hour + 30*time.Second), want: true, wantLeft: 30 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ClusterReadinessReconciler{StartedAt: tt.startedAt}
+			got, left := r.initialDelay(cr, tt.now)
+			if got != tt.want || (tt.want && left != tt.wantLeft) {
+				t.Errorf("initialDelay = %v, %s; want %v, %s", got, left, tt.want, tt.wantLeft)
+			}
+		})
+	}
+
+	if got, _ := (&ClusterReadinessReconciler{}).initialDelay(&clustergatev1alpha1.ClusterReadiness{}, created); got {
+		t.Error("expected no initial delay when unset")
+	}
+}
+
+func TestReconcile_InitialDelayLogsNoRepeatedTransitions(t *testing.T) {
+	gc := &clustergatev1alpha1.GateCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "api"},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{URL: "http://api.example.com/healthz"},
+		},
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", CreationTimestamp: metav1.Now()},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			// Every reconcile runs the check again.
+			Interval:            metav1.Duration{Duration: time.Nanosecond},
+			InitialDelaySeconds: 300,
+			Checks:              []clustergatev1alpha1.CheckSpec{{GateCheckRef: "api"}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc, cr).WithStatusSubresource(gc, cr).Build()
+	r := &ClusterReadinessReconciler{
+		Client:          c,
+		ReadinessState:  server.NewReadinessState(),
+		DynamicExecutor: stubExecutor{result: checks.Result{Ready: false, Message: "HTTP 503"}},
+	}
+	var lines []string
+	ctx := log.IntoContext(context.Background(), funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{}))
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "prod"}}
+
+	t.Cleanup(func() { metrics.DeleteClusterReadiness("prod") })
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.ContainsFunc(lines, func(l string) bool {
+		return strings.Contains(l, `"check transitioned"`) && strings.Contains(l, `"to"="Initializing"`)
+	}) {
+		t.Fatalf("expected the first run to log a transition to Initializing, got %q", lines)
+	}
+
+	lines = nil
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, l := range lines {
+		if strings.Contains(l, `"check transitioned"`) {
+			t.Errorf("unexpected transition while initializing: %s", l)
+		}
+	}
+}
+
+func TestWithCheckTimeout(t *testing.T) {
+	ready := checks.Result{Ready: true, Message: "ok"}
+
+	t.Run("no timeout", func(t *testing.T) {
+		res, err := withCheckTimeout(context.Background(), 0, func(ctx context.Context) (checks.Result, error) {
+			if _, ok := ctx.Deadline(); ok {
+				t.Error("expected no deadline without a timeout")
+			}
+			return ready, nil
+		})
+		if err != nil || !res.Ready {
+			t.Errorf("got %+v, %v", res, err)
+		}
+	})
+
+	t.Run("finishes in time", func(t *testing.T) {
+		res, err := withCheckTimeout(context.Background(), time.Second, func(ctx context.Context) (checks.Result, error) {
+			return ready, nil
+		})
+		if err != nil || !res.Ready {
+			t.Errorf("got %+v, %v", res, err)
+		}
+	})
+
+	t.Run("context-aware check overruns", func(t *testing.T) {
+		res, err := withCheckTimeout(context.Background(), 20*time.Millisecond, func(ctx context.Context) (checks.Result, error) {
+			<-ctx.Done()
+			return checks.Result{}, ctx.Err()
+		})
+		if err != nil || res.Ready || res.Message != "check timed out after 20ms" {
+			t.Errorf("got %+v, %v; want timed out failure", res, err)
+		}
+	})
+
+	t.Run("hung check", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		start := time.Now()
+		res, err := withCheckTimeout(context.Background(), 20*time.Millisecond, func(ctx context.Context) (checks.Result, error) {
+			<-release
+			return ready, nil
+		})
+		if err != nil || res.Ready {
+			t.Errorf("got %+v, %v; want timed out failure", res, err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("hung check stalled the caller for %s", elapsed)
+		}
+	})
+
+	t.Run("parent cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := withCheckTimeout(ctx, time.Second, func(ctx context.Context) (checks.Result, error) {
+			<-ctx.Done()
+			return checks.Result{}, ctx.Err()
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want context.Canceled", err)
+		}
+	})
+}
+
+func TestEnqueueForReferences(t *testing.T) {
+	crs := []client.Object{
+		&clustergatev1alpha1.ClusterReadiness{
+			ObjectMeta: metav1.ObjectMeta{Name: "inline"},
+			Spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Checks: []clustergatev1alpha1.CheckSpec{{GateCheckRef: "api"}},
+			},
+		},
+		&clustergatev1alpha1.ClusterReadiness{
+			ObjectMeta: metav1.ObjectMeta{Name: "via-profile"},
+			Spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Profiles: []clustergatev1alpha1.ProfileRef{{Name: "apps"}},
+			},
+		},
+		&clustergatev1alpha1.ClusterReadiness{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated"},
+			Spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Profiles: []clustergatev1alpha1.ProfileRef{{Name: "platform"}},
+				Checks:   []clustergatev1alpha1.CheckSpec{{Name: "dns"}},
+			},
+		},
+		&clustergatev1alpha1.ClusterReadiness{
+			ObjectMeta: metav1.ObjectMeta{Name: "pinned"},
+			Spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Profiles: []clustergatev1alpha1.ProfileRef{{Name: "apps", Version: "1.0.0"}},
+			},
+		},
+		&clustergatev1alpha1.ClusterReadiness{
+			ObjectMeta: metav1.ObjectMeta{Name: "snapshot"},
+			Spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Profiles: []clustergatev1alpha1.ProfileRef{{
+					Name:        "fleet-apps",
+					SnapshotRef: &clustergatev1alpha1.ProfileSnapshotReference{Namespace: "fleet", Name: "apps-1.0.0"},
+				}},
+			},
+		},
+		&clustergatev1alpha1.GateProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "apps"},
+			Spec: clustergatev1alpha1.GateProfileSpec{
+				Version: "2.0.0",
+				Checks:  []clustergatev1alpha1.ProfileCheckRef{{GateCheckRef: "api"}},
+			},
+			Status: clustergatev1alpha1.GateProfileStatus{
+				Versions: []clustergatev1alpha1.ProfileVersion{
+					{Version: "2.0.0", Checks: []clustergatev1alpha1.ProfileCheckRef{{GateCheckRef: "api"}}},
+					{Version: "1.0.0", Checks: []clustergatev1alpha1.ProfileCheckRef{{GateCheckRef: "legacy-api"}}},
+				},
+			},
+		},
+		&clustergatev1alpha1.GateProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+			Spec: clustergatev1alpha1.GateProfileSpec{
+				Checks: []clustergatev1alpha1.ProfileCheckRef{{Name: "dns"}},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(crs...).
+		WithIndex(&clustergatev1alpha1.ClusterReadiness{}, profileRefIndex, clusterReadinessProfileRefs).
+		WithIndex(&clustergatev1alpha1.ClusterReadiness{}, gateCheckRefIndex, clusterReadinessGateCheckRefs).
+		WithIndex(&clustergatev1alpha1.GateProfile{}, gateCheckRefIndex, gateProfileGateCheckRefs).
+		WithIndex(&clustergatev1alpha1.ClusterReadiness{}, profileSnapshotIndex, clusterReadinessSnapshotRefs).
+		Build()
+	r := &ClusterReadinessReconciler{Client: c}
+
+	names := func(reqs []reconcile.Request) map[string]bool {
+		got := map[string]bool{}
+		for _, req := range reqs {
+			got[req.Name] = true
+		}
+		return got
+	}
+
+	got := names(r.enqueueForGateCheck(context.Background(), "api"))
+	if len(got) != 3 || !got["inline"] || !got["via-profile"] || !got["pinned"] {
+		t.Errorf("GateCheck api enqueued %v, want inline, via-profile and pinned", got)
+	}
+	// Only a recorded version of apps references legacy-api.
+	if got := names(r.enqueueForGateCheck(context.Background(), "legacy-api")); !got["pinned"] {
+		t.Errorf("GateCheck legacy-api enqueued %v, want pinned", got)
+	}
+	got = names(r.enqueueForSnapshot(context.Background(), "fleet", "apps-1.0.0"))
+	if len(got) != 1 || !got["snapshot"] {
+		t.Errorf("snapshot ConfigMap enqueued %v, want snapshot", got)
+	}
+	got = names(r.enqueueForGateProfile(context.Background(), "platform"))
+	if len(got) != 1 || !got["unrelated"] {
+		t.Errorf("GateProfile platform enqueued %v, want unrelated", got)
+	}
+	if got := r.enqueueForGateCheck(context.Background(), "other"); len(got) != 0 {
+		t.Errorf("unreferenced GateCheck enqueued %v", got)
+	}
+}
+
+func TestSetRevisions(t *testing.T) {
+	revisions := func(generation int64, config string) []string {
+		gc := &clustergatev1alpha1.GateCheck{ObjectMeta: metav1.ObjectMeta{Name: "api", Generation: generation}}
+		c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc).Build()
+		resolved := []ResolvedCheck{
+			{Identifier: "dns", IsBuiltin: true, BuiltinName: "dns", Config: []byte(config)},
+			{Identifier: "dynamic:api", GateCheckName: "api"},
+		}
+		setRevisions(context.Background(), c, resolved)
+		return []string{resolved[0].Revision, resolved[1].Revision}
+	}
+
+	base := revisions(1, `{}`)
+	if got := revisions(1, `{}`); got[0] != base[0] || got[1] != base[1] {
+		t.Errorf("revisions not stable: %v, then %v", base, got)
+	}
+	if got := revisions(1, `{"host":"kubernetes.default"}`); got[0] == base[0] || got[1] != base[1] {
+		t.Errorf("config change: revisions %v, then %v; want only the builtin's to change", base, got)
+	}
+	if got := revisions(2, `{}`); got[0] != base[0] || got[1] == base[1] {
+		t.Errorf("GateCheck change: revisions %v, then %v; want only the dynamic check's to change", base, got)
+	}
+}
+
+func TestEnqueueForNamespace(t *testing.T) {
+	objs := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"team": "shop"}}},
+		&clustergatev1alpha1.ClusterReadiness{
+			ObjectMeta: metav1.ObjectMeta{Name: "all"},
+			Spec:       clustergatev1alpha1.ClusterReadinessSpec{CheckNamespaces: &metav1.LabelSelector{}},
+		},
+		&clustergatev1alpha1.ClusterReadiness{
+			ObjectMeta: metav1.ObjectMeta{Name: "shop"},
+			Spec: clustergatev1alpha1.ClusterReadinessSpec{
+				CheckNamespaces: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "shop"}},
+			},
+		},
+		&clustergatev1alpha1.ClusterReadiness{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-team"},
+			Spec: clustergatev1alpha1.ClusterReadinessSpec{
+				CheckNamespaces: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			},
+		},
+		&clustergatev1alpha1.ClusterReadiness{ObjectMeta: metav1.ObjectMeta{Name: "none"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(objs...).Build()
+	r := &ClusterReadinessReconciler{Client: c}
+
+	got := map[string]bool{}
+	for _, req := range r.enqueueForNamespace(context.Background(), "shop") {
+		got[req.Name] = true
+	}
+	if len(got) != 2 || !got["all"] || !got["shop"] {
+		t.Errorf("enqueued %v, want all and shop", got)
+	}
+}
+
+func TestRunNamespaceGateCheck_ScopesToNamespace(t *testing.T) {
+	ngc := &clustergatev1alpha1.NamespaceGateCheck{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "checkout"},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			Category: "apps",
+			PodCheck: &clustergatev1alpha1.PodCheckSpec{Namespace: "kube-system", MinReady: 1},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(ngc).Build()
+	exec := &recordingExecutor{}
+	r := &ClusterReadinessReconciler{Client: c, DynamicExecutor: exec}
+
+	results := make([]checkResult, 1)
+	r.runNamespaceGateCheck(context.Background(), 0, ResolvedCheck{
+		Identifier: "namespace:shop/checkout", GateCheckName: "checkout", Namespace: "shop",
+	}, results)
+
+	if spec := exec.specs["shop-checkout"]; spec.PodCheck == nil || spec.PodCheck.Namespace != "shop" {
+		t.Errorf("executed specs = %+v, want pod check shop-checkout confined to shop", exec.specs)
+	}
+	if results[0].category != "apps" || results[0].severity != "critical" {
+		t.Errorf("severity and category = %q, %q; want critical, apps", results[0].severity, results[0].category)
+	}
+}
+
+func TestRunNamespaceGateCheck_RejectsClusterScopedKinds(t *testing.T) {
+	ngc := &clustergatev1alpha1.NamespaceGateCheck{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "nodes"},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{APIVersion: "v1", Kind: "Node"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithRESTMapper(testRESTMapper()).WithObjects(ngc).Build()
+	exec := &recordingExecutor{}
+	r := &ClusterReadinessReconciler{Client: c, DynamicExecutor: exec}
+
+	results := make([]checkResult, 1)
+	r.runNamespaceGateCheck(context.Background(), 0, ResolvedCheck{
+		Identifier: "namespace:shop/nodes", GateCheckName: "nodes", Namespace: "shop",
+	}, results)
+
+	if len(exec.specs) != 0 {
+		t.Errorf("executed specs = %+v, want the Node check not run", exec.specs)
+	}
+	if res := results[0].result; res.Ready || !strings.Contains(res.Message, "v1 Node is cluster-scoped") {
+		t.Errorf("result = %+v, want a failure naming the cluster-scoped kind", res)
+	}
+}
+
+func TestRunResolvedDynamicCheck_Parameters(t *testing.T) {
+	gc := &clustergatev1alpha1.GateCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "http-service-ready"},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			Category:   "apps",
+			Parameters: []clustergatev1alpha1.CheckParameter{{Name: "url"}},
+			HTTPCheck:  &clustergatev1alpha1.HTTPCheckSpec{URL: "$(params.url)/healthz"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc).Build()
+	exec := &recordingExecutor{}
+	r := &ClusterReadinessReconciler{Client: c, DynamicExecutor: exec}
+
+	results := make([]checkResult, 2)
+	r.runResolvedDynamicCheck(context.Background(), 0, ResolvedCheck{
+		Identifier: "dynamic:http-service-ready/api", GateCheckName: "http-service-ready",
+		Instance: "api", Values: map[string]string{"url": "https://api.internal"},
+	}, results)
+	r.runResolvedDynamicCheck(context.Background(), 1, ResolvedCheck{
+		Identifier: "dynamic:http-service-ready/web", GateCheckName: "http-service-ready", Instance: "web",
+	}, results)
+
+	if spec := exec.specs["http-service-ready-api"]; spec.HTTPCheck == nil || spec.HTTPCheck.URL != "https://api.internal/healthz" {
+		t.Errorf("executed specs = %+v, want http-service-ready-api against https://api.internal/healthz", exec.specs)
+	}
+	if !results[0].result.Ready {
+		t.Errorf("api instance result = %+v, want ready", results[0].result)
+	}
+	if _, ran := exec.specs["http-service-ready-web"]; ran || results[1].result.Ready {
+		t.Errorf("web instance without values ran or passed: %+v", results[1].result)
+	}
+	if want := `invalid values for GateCheck http-service-ready: parameter "url" is required`; results[1].result.Message != want {
+		t.Errorf("message = %q, want %q", results[1].result.Message, want)
+	}
+}
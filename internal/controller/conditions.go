@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+// Standard condition types, for consumers such as kubectl wait, Argo CD
+// health checks and kstatus that do not parse the summary.
+const (
+	conditionReady          = "Ready"
+	conditionDegraded       = "Degraded"
+	conditionChecksResolved = "ChecksResolved"
+	conditionProgressing    = "Progressing"
+)
+
+// Stable reasons of the standard conditions.
+const (
+	reasonChecksPassing             = "ChecksPassing"
+	reasonCriticalChecksFailing     = "CriticalChecksFailing"
+	reasonRequiredCategoriesFailing = "RequiredCategoriesFailing"
+	reasonReadinessPolicyBlocked    = "ReadinessPolicyBlocked"
+	reasonMinHealthyDuration        = "MinHealthyDuration"
+	reasonInitialDelay              = "InitialDelay"
+	reasonWarningChecksFailing      = "WarningChecksFailing"
+	reasonNotDegraded               = "NotDegraded"
+	reasonResolved                  = "Resolved"
+	reasonResolutionFailed          = "ResolutionFailed"
+	reasonEvaluated                 = "Evaluated"
+	reasonSuspended                 = "Suspended"
+)
+
+// setStandardConditions sets the Ready, Degraded and Progressing conditions
+// of cr from eval. suspended reports that eval is a frozen state republished
+// while cr is suspended.
+func setStandardConditions(cr *clustergatev1alpha1.ClusterReadiness, eval evaluation, suspended bool) {
+	setCondition(cr, readyCondition(eval))
+	setCondition(cr, degradedCondition(eval))
+	setCondition(cr, progressingCondition(eval, suspended))
+}
+
+// setChecksResolvedCondition sets the ChecksResolved condition of cr after
+// resolving resolved checks, excluded of them by excludeChecks, or failing with err.
+func setChecksResolvedCondition(cr *clustergatev1alpha1.ClusterReadiness, resolved, excluded int, err error) {
+	if err != nil {
+		setCondition(cr, metav1.Condition{
+			Type:    conditionChecksResolved,
+			Status:  metav1.ConditionFalse,
+			Reason:  reasonResolutionFailed,
+			Message: fmt.Sprintf("failed to resolve checks: %v", err),
+		})
+		setCondition(cr, metav1.Condition{
+			Type:    conditionReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  reasonResolutionFailed,
+			Message: "checks could not be resolved",
+		})
+		return
+	}
+	setCondition(cr, metav1.Condition{
+		Type:    conditionChecksResolved,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonResolved,
+		Message: fmt.Sprintf("resolved %d checks, %d excluded", resolved, excluded),
+	})
+}
+
+// setCondition sets cond on cr, observed at cr's current generation.
+func setCondition(cr *clustergatev1alpha1.ClusterReadiness, cond metav1.Condition) {
+	cond.ObservedGeneration = cr.Generation
+	meta.SetStatusCondition(&cr.Status.Conditions, cond)
+}
+
+// readyCondition reports whether eval is ready and, if not, the first reason
+// it is held back.
+func readyCondition(eval evaluation) metav1.Condition {
+	cond := metav1.Condition{
+		Type:    conditionReady,
+		Status:  metav1.ConditionFalse,
+		Message: fmt.Sprintf("%s: %d/%d checks passing", eval.state, eval.summary.Passing, eval.summary.Total),
+	}
+	switch {
+	case eval.ready():
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = reasonChecksPassing
+	case eval.held:
+		cond.Reason = reasonMinHealthyDuration
+	case eval.initializing:
+		cond.Reason = reasonInitialDelay
+	case eval.required && eval.requiredFailing:
+		cond.Reason = reasonRequiredCategoriesFailing
+	case eval.blocked:
+		cond.Reason = reasonReadinessPolicyBlocked
+	default:
+		cond.Reason = reasonCriticalChecksFailing
+	}
+	return cond
+}
+
+// degradedCondition reports whether eval is Degraded.
+func degradedCondition(eval evaluation) metav1.Condition {
+	if eval.state == clustergatev1alpha1.ClusterDegraded {
+		return metav1.Condition{
+			Type:    conditionDegraded,
+			Status:  metav1.ConditionTrue,
+			Reason:  reasonWarningChecksFailing,
+			Message: fmt.Sprintf("%d warning checks failing", eval.summary.WarningFailing),
+		}
+	}
+	return metav1.Condition{
+		Type:    conditionDegraded,
+		Status:  metav1.ConditionFalse,
+		Reason:  reasonNotDegraded,
+		Message: fmt.Sprintf("state is %s", eval.state),
+	}
+}
+
+// progressingCondition reports whether eval's state is still settling: within
+// the initial delay, or held back by minHealthyDuration.
+func progressingCondition(eval evaluation, suspended bool) metav1.Condition {
+	cond := metav1.Condition{Type: conditionProgressing, Status: metav1.ConditionTrue}
+	switch {
+	case suspended:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = reasonSuspended
+		cond.Message = "checks are suspended"
+	case eval.state == clustergatev1alpha1.ClusterInitializing:
+		cond.Reason = reasonInitialDelay
+		cond.Message = "failing checks are reported as Initializing within the initial delay"
+	case eval.held:
+		cond.Reason = reasonMinHealthyDuration
+		cond.Message = "waiting for critical checks to pass for minHealthyDuration"
+	default:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = reasonEvaluated
+		cond.Message = "all checks evaluated"
+	}
+	return cond
+}
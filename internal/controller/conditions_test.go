@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+)
+
+func TestStandardConditions(t *testing.T) {
+	now := metav1.Now()
+	passing := []checkResult{
+		{name: "etcd", severity: "critical", category: "control-plane", result: checks.Result{Ready: true}},
+		{name: "cert", severity: "warning", category: "security", result: checks.Result{Ready: true}},
+	}
+	warningFailing := []checkResult{
+		{name: "etcd", severity: "critical", category: "control-plane", result: checks.Result{Ready: true}},
+		{name: "cert", severity: "warning", category: "security", result: checks.Result{Ready: false}},
+	}
+	criticalFailing := []checkResult{
+		{name: "etcd", severity: "critical", category: "control-plane", result: checks.Result{Ready: false}},
+	}
+
+	tests := []struct {
+		name            string
+		eval            func() evaluation
+		suspended       bool
+		wantReady       metav1.ConditionStatus
+		wantReason      string
+		wantDegraded    metav1.ConditionStatus
+		wantProgressing string
+	}{
+		{
+			name:      "healthy",
+			eval:      func() evaluation { return evaluate(passing, nil, nil, nil, false, &now) },
+			wantReady: metav1.ConditionTrue, wantReason: reasonChecksPassing,
+			wantDegraded: metav1.ConditionFalse, wantProgressing: reasonEvaluated,
+		},
+		{
+			name:      "degraded",
+			eval:      func() evaluation { return evaluate(warningFailing, nil, nil, nil, false, &now) },
+			wantReady: metav1.ConditionTrue, wantReason: reasonChecksPassing,
+			wantDegraded: metav1.ConditionTrue, wantProgressing: reasonEvaluated,
+		},
+		{
+			name:      "critical failing",
+			eval:      func() evaluation { return evaluate(criticalFailing, nil, nil, nil, false, &now) },
+			wantReady: metav1.ConditionFalse, wantReason: reasonCriticalChecksFailing,
+			wantDegraded: metav1.ConditionFalse, wantProgressing: reasonEvaluated,
+		},
+		{
+			name:      "initial delay",
+			eval:      func() evaluation { return evaluate(criticalFailing, nil, nil, nil, true, &now) },
+			wantReady: metav1.ConditionFalse, wantReason: reasonInitialDelay,
+			wantDegraded: metav1.ConditionFalse, wantProgressing: reasonInitialDelay,
+		},
+		{
+			name: "held by minHealthyDuration",
+			eval: func() evaluation {
+				e := evaluate(passing, nil, nil, nil, false, &now)
+				e.holdReady(&now, time.Minute, now.Time)
+				return e
+			},
+			wantReady: metav1.ConditionFalse, wantReason: reasonMinHealthyDuration,
+			wantDegraded: metav1.ConditionFalse, wantProgressing: reasonMinHealthyDuration,
+		},
+		{
+			name: "blocked by policy",
+			eval: func() evaluation {
+				e := evaluate(warningFailing, nil, nil, nil, false, &now)
+				e.applyPolicy(&clustergatev1alpha1.ReadinessPolicy{BlockOnWarnings: true})
+				return e
+			},
+			wantReady: metav1.ConditionFalse, wantReason: reasonReadinessPolicyBlocked,
+			wantDegraded: metav1.ConditionFalse, wantProgressing: reasonEvaluated,
+		},
+		{
+			name: "required category failing",
+			eval: func() evaluation {
+				e := evaluate(warningFailing, nil, nil, nil, false, &now)
+				e.requireCategories([]string{"security"})
+				return e
+			},
+			wantReady: metav1.ConditionFalse, wantReason: reasonRequiredCategoriesFailing,
+			wantDegraded: metav1.ConditionFalse, wantProgressing: reasonEvaluated,
+		},
+		{
+			name:      "suspended",
+			eval:      func() evaluation { return evaluate(passing, nil, nil, nil, false, &now) },
+			suspended: true,
+			wantReady: metav1.ConditionTrue, wantReason: reasonChecksPassing,
+			wantDegraded: metav1.ConditionFalse, wantProgressing: reasonSuspended,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &clustergatev1alpha1.ClusterReadiness{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+			setStandardConditions(cr, tt.eval(), tt.suspended)
+
+			ready := findCondition(t, cr, conditionReady)
+			if ready.Status != tt.wantReady || ready.Reason != tt.wantReason {
+				t.Errorf("Ready = %s/%s, want %s/%s", ready.Status, ready.Reason, tt.wantReady, tt.wantReason)
+			}
+			if ready.ObservedGeneration != 3 {
+				t.Errorf("Ready observedGeneration = %d, want 3", ready.ObservedGeneration)
+			}
+			if degraded := findCondition(t, cr, conditionDegraded); degraded.Status != tt.wantDegraded {
+				t.Errorf("Degraded = %s, want %s", degraded.Status, tt.wantDegraded)
+			}
+			if progressing := findCondition(t, cr, conditionProgressing); progressing.Reason != tt.wantProgressing {
+				t.Errorf("Progressing reason = %s, want %s", progressing.Reason, tt.wantProgressing)
+			}
+		})
+	}
+}
+
+func TestSetChecksResolvedCondition_Failed(t *testing.T) {
+	cr := &clustergatev1alpha1.ClusterReadiness{}
+	setChecksResolvedCondition(cr, 0, 0, errors.New(`gateprofile "missing" not found`))
+
+	if cond := findCondition(t, cr, conditionChecksResolved); cond.Status != metav1.ConditionFalse || cond.Reason != reasonResolutionFailed {
+		t.Errorf("ChecksResolved = %s/%s, want False/%s", cond.Status, cond.Reason, reasonResolutionFailed)
+	}
+	if cond := findCondition(t, cr, conditionReady); cond.Status != metav1.ConditionFalse || cond.Reason != reasonResolutionFailed {
+		t.Errorf("Ready = %s/%s, want False/%s", cond.Status, cond.Reason, reasonResolutionFailed)
+	}
+}
+
+// findCondition returns cr's condition of condType, failing the test if it is missing.
+func findCondition(t *testing.T, cr *clustergatev1alpha1.ClusterReadiness, condType string) metav1.Condition {
+	t.Helper()
+	for _, cond := range cr.Status.Conditions {
+		if cond.Type == condType {
+			return cond
+		}
+	}
+	t.Fatalf("condition %s not set in %+v", condType, cr.Status.Conditions)
+	return metav1.Condition{}
+}
@@ -0,0 +1,555 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+	"github.com/clustergate/clustergate/internal/checks/dynamic"
+	"github.com/clustergate/clustergate/internal/server"
+)
+
+// evaluation is the aggregated readiness of a ClusterReadiness or
+// NamespaceReadiness, built from newly executed and carried-forward checks.
+type evaluation struct {
+	state      clustergatev1alpha1.ClusterHealthState
+	summary    *clustergatev1alpha1.ReadinessSummary
+	categories []clustergatev1alpha1.CategoryStatus
+	checks     map[string]*server.CheckState
+
+	// initializing is set within the initial delay; see evaluate.
+	initializing bool
+	// held is set while the evaluation is kept from becoming ready; see holdReady.
+	held bool
+	// blocked is set when the readiness policy blocks readiness; see applyPolicy.
+	blocked bool
+	// required is set when readiness depends on required categories rather
+	// than critical checks, and requiredFailing when one of them is not
+	// fully healthy; see requireCategories.
+	required, requiredFailing bool
+}
+
+// flattenCategories returns the check statuses recorded under categories,
+// with lookups of each check's category and status by name.
+func flattenCategories(categories []clustergatev1alpha1.CategoryStatus) ([]clustergatev1alpha1.CheckStatus, map[string]string, map[string]string) {
+	var statuses []clustergatev1alpha1.CheckStatus
+	categoryLookup := make(map[string]string)
+	statusLookup := make(map[string]string)
+	for _, cat := range categories {
+		for _, c := range cat.Checks {
+			statuses = append(statuses, c)
+			categoryLookup[c.Name] = cat.Category
+			statusLookup[c.Name] = c.Status
+		}
+	}
+	return statuses, categoryLookup, statusLookup
+}
+
+// withHistory attaches to each result the thresholds of the check it ran, the
+// status the check had before this run and the spec's historyLimit.
+// results[i] is the result of due[i].
+func withHistory(results []checkResult, due []ResolvedCheck, existing []clustergatev1alpha1.CheckStatus, historyLimit *int32) {
+	for i := range results {
+		results[i].previous = previousStatus(existing, results[i].name)
+		results[i].historyLimit = resolveHistoryLimit(historyLimit)
+		results[i].failureThreshold = due[i].FailureThreshold
+		results[i].successThreshold = due[i].SuccessThreshold
+		results[i].revision = due[i].Revision
+	}
+}
+
+// defaultHistoryLimit is how many transitions a check's history keeps when
+// the spec does not set historyLimit.
+const defaultHistoryLimit = 10
+
+// resolveHistoryLimit returns the spec's historyLimit, or the default when
+// unset.
+func resolveHistoryLimit(limit *int32) int32 {
+	if limit == nil {
+		return defaultHistoryLimit
+	}
+	return *limit
+}
+
+// recordTransition returns previous's history with a transition to status
+// appended if the check changed status, keeping the newest limit entries. A
+// history is only trimmed when a transition is recorded.
+func recordTransition(previous *clustergatev1alpha1.CheckStatus, status, message string, now *metav1.Time, limit int32) []clustergatev1alpha1.CheckTransition {
+	var history []clustergatev1alpha1.CheckTransition
+	if previous != nil {
+		history = previous.History
+		if previous.Status == status {
+			return history
+		}
+	}
+	if limit <= 0 || now == nil {
+		return nil
+	}
+	history = append(slices.Clone(history), clustergatev1alpha1.CheckTransition{Status: status, Message: message, Time: *now})
+	if len(history) > int(limit) {
+		history = history[len(history)-int(limit):]
+	}
+	return history
+}
+
+// previousStatus returns the status recorded for the check named name, if any.
+func previousStatus(existing []clustergatev1alpha1.CheckStatus, name string) *clustergatev1alpha1.CheckStatus {
+	for i := range existing {
+		if existing[i].Name == name {
+			return &existing[i]
+		}
+	}
+	return nil
+}
+
+// checkOutcome returns the reported status, message and readiness of an
+// executed check. A Passing check stays Passing until it has failed
+// failureThreshold consecutive times, and a Failing check stays Failing until
+// it has passed successThreshold consecutive times.
+func checkOutcome(res checkResult) (status, message string, ready bool) {
+	status, message, ready = runOutcome(res)
+	if res.previous == nil {
+		return status, message, ready
+	}
+	successes, failures := consecutiveRuns(res)
+	switch {
+	case status == "Failing" && res.previous.Status == "Passing" && failures < res.failureThreshold:
+		return "Passing", fmt.Sprintf("%s (failure %d of %d)", message, failures, res.failureThreshold), true
+	case status == "Passing" && res.previous.Status == "Failing" && successes < res.successThreshold:
+		return "Failing", fmt.Sprintf("%s (success %d of %d)", message, successes, res.successThreshold), false
+	}
+	return status, message, ready
+}
+
+// reportedOutcome returns the status, message and readiness evaluate reports
+// for res: its outcome, except that while initializing a failing check that
+// is not Info is reported as Initializing.
+func reportedOutcome(res checkResult, initializing bool) (status, message string, ready bool) {
+	status, message, ready = checkOutcome(res)
+	if initializing && !ready && res.notRun == "" && clustergatev1alpha1.Severity(res.severity) != clustergatev1alpha1.SeverityInfo {
+		status = "Initializing"
+	}
+	return status, message, ready
+}
+
+// consecutiveRuns returns the check's consecutive successful and failed runs
+// including this one. Runs that could not be executed reset both counts.
+func consecutiveRuns(res checkResult) (successes, failures int32) {
+	if res.previous != nil {
+		successes, failures = res.previous.ConsecutiveSuccesses, res.previous.ConsecutiveFailures
+	}
+	switch status, _, _ := runOutcome(res); status {
+	case "Passing":
+		return successes + 1, 0
+	case "Failing":
+		return 0, failures + 1
+	}
+	return 0, 0
+}
+
+// retries returns the check's consecutive errored runs including this one.
+func retries(res checkResult) int32 {
+	if res.err == nil {
+		return 0
+	}
+	if res.previous == nil {
+		return 1
+	}
+	return res.previous.Retries + 1
+}
+
+// runOutcome returns the status, message and readiness of this run alone.
+func runOutcome(res checkResult) (status, message string, ready bool) {
+	if res.notRun != "" {
+		return res.notRun, res.result.Message, false
+	}
+	ready = res.result.Ready
+	message = res.result.Message
+	if res.err != nil {
+		ready = false
+		message = fmt.Sprintf("check error: %v", res.err)
+	}
+
+	status = "Passing"
+	if errors.Is(res.err, dynamic.ErrExecutorUnavailable) {
+		status = "Unknown"
+	} else if !ready {
+		status = "Failing"
+	}
+	return status, message, ready
+}
+
+// evaluate aggregates results and carried statuses into summary, category and
+// overall health state. carriedCategory maps each carried check to its category;
+// degradedThreshold is the spec's warning threshold for the Degraded state.
+// While initializing, failing checks and unhealthy states are reported as
+// Initializing; they still count as not passing. Info checks never affect
+// the state, so their failures are always reported as Failing.
+func evaluate(results []checkResult, carried []clustergatev1alpha1.CheckStatus, carriedCategory map[string]string, degradedThreshold *intstr.IntOrString, initializing bool, now *metav1.Time) evaluation {
+	healthChecks := make(map[string]*server.CheckState, len(results)+len(carried))
+	summary := &clustergatev1alpha1.ReadinessSummary{}
+	categoryMap := make(map[string]*categoryAgg)
+
+	// Process newly executed check results
+	for _, res := range results {
+		if res.notRun != "" {
+			addNotRun(healthChecks, categoryMap, res, now)
+			continue
+		}
+		status, message, ready := reportedOutcome(res, initializing)
+		successes, failures := consecutiveRuns(res)
+
+		healthChecks[res.name] = &server.CheckState{
+			Status:   status,
+			Message:  message,
+			Severity: res.severity,
+			Category: res.category,
+		}
+
+		aggregateCheck(summary, categoryMap, res.severity, res.category, ready)
+		categoryMap[res.category].checks = append(categoryMap[res.category].checks, clustergatev1alpha1.CheckStatus{
+			Name:                 res.name,
+			Source:               res.source,
+			Status:               status,
+			Severity:             clustergatev1alpha1.Severity(res.severity),
+			Message:              message,
+			LastChecked:          now,
+			Duration:             &metav1.Duration{Duration: res.duration},
+			ConsecutiveFailures:  failures,
+			ConsecutiveSuccesses: successes,
+			Revision:             res.revision,
+			Retries:              retries(res),
+			History:              recordTransition(res.previous, status, message, now, res.historyLimit),
+		})
+	}
+
+	// Process carried-forward check statuses
+	for _, cs := range carried {
+		cat := carriedCategory[cs.Name]
+		if cs.Status == "Suspended" || cs.Status == "Skipped" {
+			addNotRun(healthChecks, categoryMap, checkResult{
+				name: cs.Name, source: cs.Source, severity: string(cs.Severity), category: cat,
+				result: checks.Result{Message: cs.Message}, previous: &cs, notRun: cs.Status,
+			}, now)
+			continue
+		}
+		if initializing && cs.Status != "Passing" && cs.Severity != clustergatev1alpha1.SeverityInfo {
+			cs.Status = "Initializing"
+		} else if !initializing && cs.Status == "Initializing" {
+			cs.Status = "Failing"
+		}
+
+		healthChecks[cs.Name] = &server.CheckState{
+			Status:   cs.Status,
+			Message:  cs.Message,
+			Severity: string(cs.Severity),
+			Category: cat,
+		}
+
+		ready := cs.Status == "Passing"
+		aggregateCheck(summary, categoryMap, string(cs.Severity), cat, ready)
+		categoryMap[cat].checks = append(categoryMap[cat].checks, cs)
+	}
+
+	// Build categories with nested checks
+	categories := make([]clustergatev1alpha1.CategoryStatus, 0, len(categoryMap))
+	for _, agg := range categoryMap {
+		var catState string
+		if agg.criticalFailing {
+			catState = "Unhealthy"
+		} else if agg.warningFailing {
+			catState = "Degraded"
+		} else {
+			catState = "Healthy"
+		}
+		if initializing && catState != "Healthy" {
+			catState = string(clustergatev1alpha1.ClusterInitializing)
+		}
+
+		// Sort checks within category for deterministic output
+		sort.Slice(agg.checks, func(i, j int) bool {
+			return agg.checks[i].Name < agg.checks[j].Name
+		})
+
+		categories = append(categories, clustergatev1alpha1.CategoryStatus{
+			Category: agg.category,
+			State:    catState,
+			Checks:   agg.checks,
+			Total:    agg.total,
+			Passing:  agg.passing,
+			Failing:  agg.failing,
+		})
+	}
+	// Sort for deterministic output
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Category < categories[j].Category
+	})
+
+	// Compute the health state:
+	// Healthy = all checks passing
+	// Degraded = all critical passing but at least degradedThreshold warning checks failing
+	// Unhealthy = any critical check failing
+	var healthState clustergatev1alpha1.ClusterHealthState
+	if summary.CriticalTotal != summary.CriticalPassing {
+		healthState = clustergatev1alpha1.ClusterUnhealthy
+	} else if summary.WarningFailing >= degradedWarnings(degradedThreshold, summary.WarningTotal) {
+		healthState = clustergatev1alpha1.ClusterDegraded
+	} else {
+		healthState = clustergatev1alpha1.ClusterHealthy
+	}
+	if initializing && healthState != clustergatev1alpha1.ClusterHealthy {
+		healthState = clustergatev1alpha1.ClusterInitializing
+	}
+
+	return evaluation{
+		state:        healthState,
+		summary:      summary,
+		categories:   categories,
+		checks:       healthChecks,
+		initializing: initializing,
+	}
+}
+
+// holdReady keeps a ready evaluation not ready, with an Unhealthy (or
+// Initializing) state, until every critical check has passed since since
+// for minDuration. It returns how much longer the evaluation is held.
+func (e *evaluation) holdReady(since *metav1.Time, minDuration time.Duration, now time.Time) time.Duration {
+	if !e.ready() || since == nil || minDuration <= 0 {
+		return 0
+	}
+	left := since.Add(minDuration).Sub(now)
+	if left <= 0 {
+		return 0
+	}
+	e.held = true
+	e.state = e.unhealthyState()
+	return left
+}
+
+// requireCategories makes readiness depend on the required categories being
+// fully healthy instead of on critical checks: a failing non-info check in a
+// required category makes the evaluation Unhealthy (or Initializing), while
+// other failures only make it Degraded. Required categories without checks
+// are added as Unhealthy.
+func (e *evaluation) requireCategories(required []string) {
+	if len(required) == 0 {
+		return
+	}
+	e.required = true
+	isRequired := make(map[string]bool, len(required))
+	for _, cat := range required {
+		isRequired[cat] = true
+	}
+
+	present := make(map[string]bool, len(e.categories))
+	for i, cat := range e.categories {
+		present[cat.Category] = true
+		if !isRequired[cat.Category] {
+			if cat.State == string(clustergatev1alpha1.ClusterUnhealthy) {
+				e.categories[i].State = string(clustergatev1alpha1.ClusterDegraded)
+			}
+			continue
+		}
+		e.categories[i].Required = true
+		failing := false
+		for _, cs := range cat.Checks {
+			if cs.Severity != clustergatev1alpha1.SeverityInfo && cs.Status != "Passing" && cs.Status != "Suspended" && cs.Status != "Skipped" {
+				failing = true
+			}
+		}
+		if failing {
+			e.requiredFailing = true
+			e.categories[i].State = string(e.unhealthyState())
+		}
+	}
+	for _, cat := range required {
+		if !present[cat] {
+			present[cat] = true
+			e.requiredFailing = true
+			e.categories = append(e.categories, clustergatev1alpha1.CategoryStatus{
+				Category: cat,
+				State:    string(e.unhealthyState()),
+				Required: true,
+			})
+		}
+	}
+	sort.Slice(e.categories, func(i, j int) bool {
+		return e.categories[i].Category < e.categories[j].Category
+	})
+
+	if e.requiredFailing {
+		e.state = e.unhealthyState()
+	} else if e.state == clustergatev1alpha1.ClusterUnhealthy {
+		e.state = clustergatev1alpha1.ClusterDegraded
+	}
+}
+
+// applyPolicy makes the evaluation not ready, with an Unhealthy (or
+// Initializing) state, when failures other than critical ones block readiness
+// under policy. Categories with blocking failures become Unhealthy too.
+func (e *evaluation) applyPolicy(policy *clustergatev1alpha1.ReadinessPolicy) {
+	if policy == nil {
+		return
+	}
+	blockingCategories := make(map[string]bool, len(policy.BlockingCategories))
+	for _, cat := range policy.BlockingCategories {
+		blockingCategories[cat] = true
+	}
+
+	total, failing := 0, 0
+	for i, cat := range e.categories {
+		categoryBlocked := false
+		for _, cs := range cat.Checks {
+			if cs.Severity == clustergatev1alpha1.SeverityInfo || cs.Status == "Suspended" || cs.Status == "Skipped" {
+				continue
+			}
+			total++
+			if cs.Status == "Passing" {
+				continue
+			}
+			failing++
+			if blockingCategories[cat.Category] || (policy.BlockOnWarnings && cs.Severity == clustergatev1alpha1.SeverityWarning) {
+				categoryBlocked = true
+			}
+		}
+		if categoryBlocked {
+			e.blocked = true
+			e.categories[i].State = string(e.unhealthyState())
+		}
+	}
+	if policy.MaxFailing != nil {
+		maxFailing, err := intstr.GetScaledValueFromIntOrPercent(policy.MaxFailing, total, false)
+		if err == nil && failing > maxFailing {
+			e.blocked = true
+		}
+	}
+	if e.blocked {
+		e.state = e.unhealthyState()
+	}
+}
+
+// unhealthyState is the state reported for a not-ready evaluation: Unhealthy,
+// or Initializing within the initial delay.
+func (e *evaluation) unhealthyState() clustergatev1alpha1.ClusterHealthState {
+	if e.initializing {
+		return clustergatev1alpha1.ClusterInitializing
+	}
+	return clustergatev1alpha1.ClusterUnhealthy
+}
+
+// addNotRun records a suspended or skipped check under its category without
+// counting it as passing or failing. It keeps the check's last run time,
+// counts and history, so it runs as soon as it is no longer suspended or
+// skipped.
+func addNotRun(healthChecks map[string]*server.CheckState, categoryMap map[string]*categoryAgg, res checkResult, now *metav1.Time) {
+	cs := clustergatev1alpha1.CheckStatus{
+		Name:     res.name,
+		Source:   res.source,
+		Status:   res.notRun,
+		Severity: clustergatev1alpha1.Severity(res.severity),
+		Message:  res.result.Message,
+	}
+	if res.previous != nil {
+		cs.LastChecked = res.previous.LastChecked
+		cs.Duration = res.previous.Duration
+		cs.ConsecutiveFailures = res.previous.ConsecutiveFailures
+		cs.ConsecutiveSuccesses = res.previous.ConsecutiveSuccesses
+		cs.Revision = res.previous.Revision
+	}
+	cs.History = recordTransition(res.previous, res.notRun, cs.Message, now, res.historyLimit)
+
+	healthChecks[res.name] = &server.CheckState{
+		Status:   cs.Status,
+		Message:  cs.Message,
+		Severity: res.severity,
+		Category: res.category,
+	}
+	agg, exists := categoryMap[res.category]
+	if !exists {
+		agg = &categoryAgg{category: res.category}
+		categoryMap[res.category] = agg
+	}
+	agg.checks = append(agg.checks, cs)
+}
+
+// degradedWarnings returns how many failing warning checks make the state
+// Degraded: threshold as a count, or as a percentage of warningTotal rounded
+// up. Unset, invalid or zero thresholds keep the default of one.
+func degradedWarnings(threshold *intstr.IntOrString, warningTotal int) int {
+	if threshold == nil {
+		return 1
+	}
+	n, err := intstr.GetScaledValueFromIntOrPercent(threshold, warningTotal, true)
+	if err != nil || n < 1 {
+		return 1
+	}
+	return n
+}
+
+// ready reports whether every critical check is passing, or with required
+// categories every required category is healthy, and nothing else blocks
+// readiness.
+func (e evaluation) ready() bool {
+	if e.held || e.blocked {
+		return false
+	}
+	if e.required {
+		return !e.requiredFailing
+	}
+	return e.summary.CriticalTotal == e.summary.CriticalPassing
+}
+
+// updateState publishes the evaluation to the readyz state under key.
+func (e evaluation) updateState(state *server.ReadinessState, key string) {
+	summary, categorySummaries := e.views()
+	state.Update(key, string(e.state), e.checks, summary, categorySummaries)
+}
+
+// restoreState publishes the evaluation to state under key unless key
+// already has a state, and reports whether it did.
+func (e evaluation) restoreState(state *server.ReadinessState, key string) bool {
+	summary, categorySummaries := e.views()
+	return state.Restore(key, string(e.state), e.checks, summary, categorySummaries)
+}
+
+// views returns the evaluation's summary and category summaries as served by
+// /readyz.
+func (e evaluation) views() (*server.ReadinessSummaryView, []server.CategorySummaryView) {
+	summary := &server.ReadinessSummaryView{
+		Total:           e.summary.Total,
+		Passing:         e.summary.Passing,
+		Failing:         e.summary.Failing,
+		CriticalTotal:   e.summary.CriticalTotal,
+		CriticalPassing: e.summary.CriticalPassing,
+		WarningFailing:  e.summary.WarningFailing,
+		InfoFailing:     e.summary.InfoFailing,
+	}
+	categorySummaries := make([]server.CategorySummaryView, len(e.categories))
+	for i, cs := range e.categories {
+		categorySummaries[i] = server.CategorySummaryView{
+			Category: cs.Category,
+			State:    cs.State,
+			Required: cs.Required,
+			Total:    cs.Total,
+			Passing:  cs.Passing,
+			Failing:  cs.Failing,
+		}
+	}
+	return summary, categorySummaries
+}
+
+// statuses returns every check status across categories.
+func (e evaluation) statuses() []clustergatev1alpha1.CheckStatus {
+	var all []clustergatev1alpha1.CheckStatus
+	for _, cat := range e.categories {
+		all = append(all, cat.Checks...)
+	}
+	return all
+}
@@ -0,0 +1,366 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+)
+
+func TestEvaluate_DegradedThreshold(t *testing.T) {
+	// warnings returns total warning check results of which failing fail.
+	warnings := func(total, failing int) []checkResult {
+		results := make([]checkResult, total)
+		for i := range results {
+			results[i] = checkResult{
+				name:     fmt.Sprintf("warn-%d", i),
+				severity: "warning",
+				category: "apps",
+				result:   checks.Result{Ready: i >= failing},
+			}
+		}
+		return results
+	}
+	count := intstr.FromInt32(3)
+	percent := intstr.FromString("20%")
+	zero := intstr.FromInt32(0)
+
+	tests := []struct {
+		name      string
+		results   []checkResult
+		threshold *intstr.IntOrString
+		want      clustergatev1alpha1.ClusterHealthState
+	}{
+		{name: "default degrades on one failure", results: warnings(10, 1), want: clustergatev1alpha1.ClusterDegraded},
+		{name: "below count", results: warnings(10, 2), threshold: &count, want: clustergatev1alpha1.ClusterHealthy},
+		{name: "at count", results: warnings(10, 3), threshold: &count, want: clustergatev1alpha1.ClusterDegraded},
+		{name: "below percentage", results: warnings(10, 1), threshold: &percent, want: clustergatev1alpha1.ClusterHealthy},
+		{name: "at percentage", results: warnings(10, 2), threshold: &percent, want: clustergatev1alpha1.ClusterDegraded},
+		{name: "percentage rounds up", results: warnings(3, 1), threshold: &percent, want: clustergatev1alpha1.ClusterDegraded},
+		{name: "zero keeps the default", results: warnings(10, 1), threshold: &zero, want: clustergatev1alpha1.ClusterDegraded},
+		{name: "no failures", results: warnings(10, 0), threshold: &zero, want: clustergatev1alpha1.ClusterHealthy},
+	}
+
+	now := metav1.Now()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluate(tt.results, nil, nil, tt.threshold, false, &now)
+			if got.state != tt.want {
+				t.Errorf("state = %q, want %q", got.state, tt.want)
+			}
+			if !got.ready() {
+				t.Error("warning failures must not affect readiness")
+			}
+		})
+	}
+}
+
+func TestCheckOutcome_Thresholds(t *testing.T) {
+	previous := func(status string, successes, failures int32) *clustergatev1alpha1.CheckStatus {
+		return &clustergatev1alpha1.CheckStatus{
+			Name: "c", Status: status, ConsecutiveSuccesses: successes, ConsecutiveFailures: failures,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		ready         bool
+		previous      *clustergatev1alpha1.CheckStatus
+		wantStatus    string
+		wantSuccesses int32
+		wantFailures  int32
+	}{
+		{name: "first run fails immediately", ready: false, wantStatus: "Failing", wantFailures: 1},
+		{name: "first failure is held", ready: false, previous: previous("Passing", 4, 0), wantStatus: "Passing", wantFailures: 1},
+		{name: "second failure is held", ready: false, previous: previous("Passing", 0, 1), wantStatus: "Passing", wantFailures: 2},
+		{name: "third failure flips", ready: false, previous: previous("Passing", 0, 2), wantStatus: "Failing", wantFailures: 3},
+		{name: "success resets failures", ready: true, previous: previous("Passing", 0, 2), wantStatus: "Passing", wantSuccesses: 1},
+		{name: "first success is held", ready: true, previous: previous("Failing", 0, 5), wantStatus: "Failing", wantSuccesses: 1},
+		{name: "second success flips", ready: true, previous: previous("Failing", 1, 0), wantStatus: "Passing", wantSuccesses: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := checkResult{
+				name:             "c",
+				result:           checks.Result{Ready: tt.ready, Message: "msg"},
+				previous:         tt.previous,
+				failureThreshold: 3,
+				successThreshold: 2,
+			}
+			status, _, ready := checkOutcome(res)
+			if status != tt.wantStatus || ready != (status == "Passing") {
+				t.Errorf("outcome = %q, ready %v; want %q", status, ready, tt.wantStatus)
+			}
+			successes, failures := consecutiveRuns(res)
+			if successes != tt.wantSuccesses || failures != tt.wantFailures {
+				t.Errorf("consecutive = %d/%d, want %d/%d", successes, failures, tt.wantSuccesses, tt.wantFailures)
+			}
+		})
+	}
+}
+
+func TestWithHistory(t *testing.T) {
+	results := []checkResult{{name: "a"}, {name: "b"}}
+	due := []ResolvedCheck{{Identifier: "a", FailureThreshold: 3}, {Identifier: "b", SuccessThreshold: 2}}
+	existing := []clustergatev1alpha1.CheckStatus{{Name: "a", Status: "Passing", ConsecutiveSuccesses: 7}}
+
+	limit := int32(3)
+	withHistory(results, due, existing, &limit)
+
+	if results[0].previous == nil || results[0].previous.ConsecutiveSuccesses != 7 {
+		t.Errorf("a previous = %+v, want the existing status", results[0].previous)
+	}
+	if results[0].failureThreshold != 3 || results[1].successThreshold != 2 {
+		t.Errorf("thresholds not attached: %+v", results)
+	}
+	if results[1].previous != nil {
+		t.Errorf("b previous = %+v, want nil", results[1].previous)
+	}
+	if results[0].historyLimit != 3 {
+		t.Errorf("historyLimit = %d, want 3", results[0].historyLimit)
+	}
+}
+
+func TestEvaluate_History(t *testing.T) {
+	at := func(minute int) metav1.Time {
+		return metav1.NewTime(time.Date(2026, 1, 1, 0, minute, 0, 0, time.UTC))
+	}
+	// Run the check through pass, fail, fail, pass, fail with a limit of 3.
+	var previous *clustergatev1alpha1.CheckStatus
+	for minute, ready := range []bool{true, false, false, true, false} {
+		now := at(minute)
+		res := checkResult{
+			name: "c", category: "c", historyLimit: 3, previous: previous,
+			result: checks.Result{Ready: ready, Message: fmt.Sprintf("run %d", minute)},
+		}
+		previous = &evaluate([]checkResult{res}, nil, nil, nil, false, &now).statuses()[0]
+	}
+
+	want := []clustergatev1alpha1.CheckTransition{
+		{Status: "Failing", Message: "run 1", Time: at(1)},
+		{Status: "Passing", Message: "run 3", Time: at(3)},
+		{Status: "Failing", Message: "run 4", Time: at(4)},
+	}
+	if !reflect.DeepEqual(previous.History, want) {
+		t.Errorf("history = %+v, want %+v", previous.History, want)
+	}
+
+	// A suspended check records the transition and keeps its history.
+	now := at(5)
+	suspended := checkResult{name: "c", category: "c", historyLimit: 3, previous: previous, notRun: "Suspended"}
+	history := evaluate([]checkResult{suspended}, nil, nil, nil, false, &now).statuses()[0].History
+	if len(history) != 3 || history[2].Status != "Suspended" || history[0].Status != "Passing" {
+		t.Errorf("suspended history = %+v, want the Suspended transition appended", history)
+	}
+
+	// A limit of 0 drops the history at the next transition.
+	res := checkResult{name: "c", category: "c", previous: previous, result: checks.Result{Ready: true}}
+	if history := evaluate([]checkResult{res}, nil, nil, nil, false, &now).statuses()[0].History; history != nil {
+		t.Errorf("history = %+v, want none", history)
+	}
+}
+
+func TestEvaluate_Retries(t *testing.T) {
+	now := metav1.Now()
+	results := []checkResult{
+		{name: "first-error", category: "c", err: errors.New("boom")},
+		{name: "repeat-error", category: "c", err: errors.New("boom"), previous: &clustergatev1alpha1.CheckStatus{Retries: 2}},
+		{name: "recovered", category: "c", result: checks.Result{Ready: true}, previous: &clustergatev1alpha1.CheckStatus{Retries: 3}},
+		{name: "failed", category: "c", result: checks.Result{Ready: false}},
+	}
+
+	want := map[string]int32{"first-error": 1, "repeat-error": 3, "recovered": 0, "failed": 0}
+	for _, cs := range evaluate(results, nil, nil, nil, false, &now).statuses() {
+		if cs.Retries != want[cs.Name] {
+			t.Errorf("%s retries = %d, want %d", cs.Name, cs.Retries, want[cs.Name])
+		}
+	}
+}
+
+func TestEvaluate_Initializing(t *testing.T) {
+	now := metav1.Now()
+	results := []checkResult{
+		{name: "dns", severity: "critical", category: "networking", result: checks.Result{Ready: false}},
+		{name: "etcd", severity: "critical", category: "control-plane", result: checks.Result{Ready: true}},
+	}
+	carried := []clustergatev1alpha1.CheckStatus{
+		{Name: "ingress", Status: "Initializing", Severity: clustergatev1alpha1.SeverityWarning},
+	}
+	carriedCategory := map[string]string{"ingress": "networking"}
+
+	got := evaluate(results, carried, carriedCategory, nil, true, &now)
+	if got.state != clustergatev1alpha1.ClusterInitializing {
+		t.Errorf("state = %q, want Initializing", got.state)
+	}
+	if got.ready() {
+		t.Error("initializing checks must still hold the gate")
+	}
+	for _, cs := range got.statuses() {
+		if want := map[string]string{"dns": "Initializing", "etcd": "Passing", "ingress": "Initializing"}[cs.Name]; cs.Status != want {
+			t.Errorf("%s status = %q, want %q", cs.Name, cs.Status, want)
+		}
+	}
+
+	// Once the delay is over, carried Initializing checks report Failing.
+	got = evaluate(results, carried, carriedCategory, nil, false, &now)
+	if got.state != clustergatev1alpha1.ClusterUnhealthy {
+		t.Errorf("state = %q, want Unhealthy", got.state)
+	}
+	for _, cs := range got.statuses() {
+		if cs.Name == "ingress" && cs.Status != "Failing" {
+			t.Errorf("ingress status = %q, want Failing", cs.Status)
+		}
+	}
+}
+
+func TestEvaluate_Info(t *testing.T) {
+	now := metav1.Now()
+	results := []checkResult{
+		{name: "dns", severity: "critical", category: "networking", result: checks.Result{Ready: true}},
+		{name: "audit", severity: "info", category: "security", result: checks.Result{Ready: false}},
+	}
+
+	got := evaluate(results, nil, nil, nil, false, &now)
+	if got.state != clustergatev1alpha1.ClusterHealthy || !got.ready() {
+		t.Errorf("state = %q, ready = %v; a failing info check must not affect readiness", got.state, got.ready())
+	}
+	if got.summary.InfoTotal != 1 || got.summary.InfoFailing != 1 || got.summary.Failing != 1 {
+		t.Errorf("summary = %+v, want the info check counted as failing", got.summary)
+	}
+	for _, cat := range got.categories {
+		if cat.State != "Healthy" {
+			t.Errorf("category %s state = %q, want Healthy", cat.Category, cat.State)
+		}
+	}
+
+	// Info failures are not hidden while initializing.
+	got = evaluate(results, nil, nil, nil, true, &now)
+	if got.state != clustergatev1alpha1.ClusterHealthy {
+		t.Errorf("initializing state = %q, want Healthy", got.state)
+	}
+	if st := got.checks["audit"].Status; st != "Failing" {
+		t.Errorf("initializing info status = %q, want Failing", st)
+	}
+}
+
+func TestApplyPolicy(t *testing.T) {
+	now := metav1.Now()
+	results := []checkResult{
+		{name: "etcd", severity: "critical", category: "control-plane", result: checks.Result{Ready: true}},
+		{name: "ingress", severity: "warning", category: "networking", result: checks.Result{Ready: false}},
+		{name: "cert", severity: "warning", category: "security", result: checks.Result{Ready: true}},
+		{name: "audit", severity: "info", category: "security", result: checks.Result{Ready: false}},
+	}
+	zero := intstr.FromInt32(0)
+	one := intstr.FromInt32(1)
+	half := intstr.FromString("50%")
+
+	tests := []struct {
+		name        string
+		policy      *clustergatev1alpha1.ReadinessPolicy
+		wantReady   bool
+		wantBlocked string // category expected to become Unhealthy
+	}{
+		{name: "no policy", policy: nil, wantReady: true},
+		{name: "block on warnings", policy: &clustergatev1alpha1.ReadinessPolicy{BlockOnWarnings: true}, wantBlocked: "networking"},
+		{name: "blocking category with a failure", policy: &clustergatev1alpha1.ReadinessPolicy{BlockingCategories: []string{"networking"}}, wantBlocked: "networking"},
+		{name: "blocking category failing only info", policy: &clustergatev1alpha1.ReadinessPolicy{BlockingCategories: []string{"security"}}, wantReady: true},
+		{name: "max failing count exceeded", policy: &clustergatev1alpha1.ReadinessPolicy{MaxFailing: &zero}},
+		{name: "max failing count not exceeded", policy: &clustergatev1alpha1.ReadinessPolicy{MaxFailing: &one}, wantReady: true},
+		{name: "max failing percentage not exceeded", policy: &clustergatev1alpha1.ReadinessPolicy{MaxFailing: &half}, wantReady: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := evaluate(results, nil, nil, nil, false, &now)
+			eval.applyPolicy(tt.policy)
+
+			if eval.ready() != tt.wantReady {
+				t.Errorf("ready = %v, want %v", eval.ready(), tt.wantReady)
+			}
+			if !tt.wantReady && eval.state != clustergatev1alpha1.ClusterUnhealthy {
+				t.Errorf("state = %q, want Unhealthy", eval.state)
+			}
+			for _, cat := range eval.categories {
+				if cat.Category == tt.wantBlocked && cat.State != "Unhealthy" {
+					t.Errorf("category %s state = %q, want Unhealthy", cat.Category, cat.State)
+				}
+			}
+		})
+	}
+}
+
+func TestRequireCategories(t *testing.T) {
+	now := metav1.Now()
+	check := func(name, severity, category string, ready bool) checkResult {
+		return checkResult{name: name, severity: severity, category: category, result: checks.Result{Ready: ready}}
+	}
+
+	tests := []struct {
+		name      string
+		results   []checkResult
+		required  []string
+		wantState clustergatev1alpha1.ClusterHealthState
+		wantReady bool
+	}{
+		{
+			name:      "critical failure outside required categories only degrades",
+			results:   []checkResult{check("dns", "critical", "networking", true), check("registry", "critical", "apps", false)},
+			required:  []string{"networking"},
+			wantState: clustergatev1alpha1.ClusterDegraded,
+			wantReady: true,
+		},
+		{
+			name:      "warning failure in a required category blocks",
+			results:   []checkResult{check("dns", "warning", "networking", false)},
+			required:  []string{"networking"},
+			wantState: clustergatev1alpha1.ClusterUnhealthy,
+		},
+		{
+			name:      "info failure in a required category does not block",
+			results:   []checkResult{check("dns", "critical", "networking", true), check("mtu", "info", "networking", false)},
+			required:  []string{"networking"},
+			wantState: clustergatev1alpha1.ClusterHealthy,
+			wantReady: true,
+		},
+		{
+			name:      "missing required category blocks",
+			results:   []checkResult{check("dns", "critical", "networking", true)},
+			required:  []string{"networking", "control-plane"},
+			wantState: clustergatev1alpha1.ClusterUnhealthy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := evaluate(tt.results, nil, nil, nil, false, &now)
+			eval.requireCategories(tt.required)
+
+			if eval.state != tt.wantState {
+				t.Errorf("state = %q, want %q", eval.state, tt.wantState)
+			}
+			if eval.ready() != tt.wantReady {
+				t.Errorf("ready = %v, want %v", eval.ready(), tt.wantReady)
+			}
+			required := map[string]bool{}
+			for _, cat := range tt.required {
+				required[cat] = true
+			}
+			for _, cat := range eval.categories {
+				if cat.Required != required[cat.Category] {
+					t.Errorf("category %s required = %v", cat.Category, cat.Required)
+				}
+				delete(required, cat.Category)
+			}
+			if len(required) != 0 {
+				t.Errorf("required categories missing from status: %v", required)
+			}
+		})
+	}
+}
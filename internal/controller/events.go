@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+// Event reasons emitted on ClusterReadiness CRs and GateChecks.
+const (
+	eventReasonCheckFailing = "CheckFailing"
+	eventReasonCheckPassing = "CheckPassing"
+	eventReasonStateChanged = "StateChanged"
+
+	eventActionEvaluate = "Evaluate"
+
+	// maxEventNote is the longest note the events API accepts.
+	maxEventNote = 1024
+)
+
+// recordCheckEvents emits an event on cr for each executed check that went
+// from Passing to Failing or back, and on the check's GateCheck for dynamic
+// checks. results[i] is the outcome of executed[i]; previous maps each check
+// to its status before this run.
+func (r *ClusterReadinessReconciler) recordCheckEvents(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, executed []ResolvedCheck, results []checkResult, previous map[string]string) {
+	if r.Recorder == nil {
+		return
+	}
+	for i, rc := range executed {
+		status, message, _ := checkOutcome(results[i])
+		from := previous[rc.Identifier]
+		if from == status || !isPassingOrFailing(from) || !isPassingOrFailing(status) {
+			continue
+		}
+		eventType, reason := corev1.EventTypeWarning, eventReasonCheckFailing
+		if status == "Passing" {
+			eventType, reason = corev1.EventTypeNormal, eventReasonCheckPassing
+		}
+
+		var gc *clustergatev1alpha1.GateCheck
+		if !rc.IsBuiltin && rc.Namespace == "" {
+			gc = &clustergatev1alpha1.GateCheck{}
+			if err := r.Get(ctx, types.NamespacedName{Name: rc.GateCheckName}, gc); err != nil {
+				log.FromContext(ctx).V(1).Info("not recording event on GateCheck", "gateCheck", rc.GateCheckName, "error", err)
+				gc = nil
+			}
+		}
+		if gc != nil {
+			r.Recorder.Eventf(cr, gc, eventType, reason, eventActionEvaluate, "%s",
+				eventNote("check %s is %s: %s", rc.Identifier, status, message))
+			r.Recorder.Eventf(gc, cr, eventType, reason, eventActionEvaluate, "%s",
+				eventNote("check %s is %s in ClusterReadiness %s: %s", rc.Identifier, status, cr.Name, message))
+			continue
+		}
+		r.Recorder.Eventf(cr, nil, eventType, reason, eventActionEvaluate, "%s",
+			eventNote("check %s is %s: %s", rc.Identifier, status, message))
+	}
+}
+
+// recordStateEvent emits an event on cr when its overall state changed from
+// from to to: Normal when it became Healthy, Warning otherwise.
+func (r *ClusterReadinessReconciler) recordStateEvent(cr *clustergatev1alpha1.ClusterReadiness, from, to clustergatev1alpha1.ClusterHealthState, detail string) {
+	if r.Recorder == nil || from == "" || from == to {
+		return
+	}
+	eventType := corev1.EventTypeWarning
+	if to == clustergatev1alpha1.ClusterHealthy {
+		eventType = corev1.EventTypeNormal
+	}
+	r.Recorder.Eventf(cr, nil, eventType, eventReasonStateChanged, eventActionEvaluate, "%s",
+		eventNote("state changed from %s to %s: %s", from, to, detail))
+}
+
+// isPassingOrFailing reports whether status is Passing or Failing.
+func isPassingOrFailing(status string) bool {
+	return status == "Passing" || status == "Failing"
+}
+
+// eventNote formats an event note, truncated to the length the events API
+// accepts.
+func eventNote(format string, args ...any) string {
+	note := fmt.Sprintf(format, args...)
+	if len(note) <= maxEventNote {
+		return note
+	}
+	note = note[:maxEventNote-len("...")]
+	for !utf8.ValidString(note) {
+		note = note[:len(note)-1]
+	}
+	return note + "..."
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+)
+
+// drainEvents returns the events recorded so far.
+func drainEvents(recorder *events.FakeRecorder) []string {
+	var got []string
+	for {
+		select {
+		case e := <-recorder.Events:
+			got = append(got, e)
+		default:
+			return got
+		}
+	}
+}
+
+func TestRecordCheckEvents(t *testing.T) {
+	gc := &clustergatev1alpha1.GateCheck{ObjectMeta: metav1.ObjectMeta{Name: "api"}}
+	recorder := events.NewFakeRecorder(10)
+	r := &ClusterReadinessReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc).Build(),
+		Recorder: recorder,
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}
+
+	executed := []ResolvedCheck{
+		{Identifier: "dynamic:api", GateCheckName: "api"},
+		{Identifier: "dns", IsBuiltin: true},
+		{Identifier: "etcd", IsBuiltin: true},
+		{Identifier: "new", IsBuiltin: true},
+	}
+	results := []checkResult{
+		{result: checks.Result{Ready: false, Message: "HTTP 503"}},
+		{result: checks.Result{Ready: true, Message: "resolved"}},
+		{result: checks.Result{Ready: true}},
+		{result: checks.Result{Ready: false}},
+	}
+	previous := map[string]string{"dynamic:api": "Passing", "dns": "Failing", "etcd": "Passing"}
+	r.recordCheckEvents(context.Background(), cr, executed, results, previous)
+
+	want := []string{
+		"Warning CheckFailing check dynamic:api is Failing: HTTP 503",
+		"Warning CheckFailing check dynamic:api is Failing in ClusterReadiness prod: HTTP 503",
+		"Normal CheckPassing check dns is Passing: resolved",
+	}
+	got := drainEvents(recorder)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+}
+
+func TestRecordStateEvent(t *testing.T) {
+	recorder := events.NewFakeRecorder(10)
+	r := &ClusterReadinessReconciler{Recorder: recorder}
+	cr := &clustergatev1alpha1.ClusterReadiness{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}
+
+	r.recordStateEvent(cr, "", clustergatev1alpha1.ClusterHealthy, "first evaluation")
+	r.recordStateEvent(cr, clustergatev1alpha1.ClusterHealthy, clustergatev1alpha1.ClusterHealthy, "unchanged")
+	r.recordStateEvent(cr, clustergatev1alpha1.ClusterHealthy, clustergatev1alpha1.ClusterUnhealthy, "1/2 critical checks passing")
+	r.recordStateEvent(cr, clustergatev1alpha1.ClusterUnhealthy, clustergatev1alpha1.ClusterHealthy, "2/2 critical checks passing")
+
+	want := []string{
+		"Warning StateChanged state changed from Healthy to Unhealthy: 1/2 critical checks passing",
+		"Normal StateChanged state changed from Unhealthy to Healthy: 2/2 critical checks passing",
+	}
+	got := drainEvents(recorder)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+}
+
+func TestEventNote_Truncates(t *testing.T) {
+	note := eventNote("check dns is Failing: %s", strings.Repeat("é", maxEventNote))
+	if len(note) > maxEventNote || !utf8.ValidString(note) || !strings.HasSuffix(note, "...") {
+		t.Errorf("note of %d bytes, valid %v; want at most %d valid bytes ending in ...", len(note), utf8.ValidString(note), maxEventNote)
+	}
+}
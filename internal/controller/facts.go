@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/version"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// runIfCostLimit bounds the evaluation cost of a runIf expression.
+const runIfCostLimit = 100000
+
+// ServerVersioner reports the API server version. It is implemented by the
+// discovery client.
+type ServerVersioner interface {
+	ServerVersion() (*version.Info, error)
+}
+
+// ClusterFacts are the cluster properties runIf expressions are evaluated
+// against, as the "cluster" variable.
+type ClusterFacts struct {
+	// Provider is the cloud provider from the nodes' providerIDs, such as
+	// "aws", "gce" or "azure", or empty on bare metal.
+	Provider string
+
+	// Version is the API server version, e.g. "v1.31.2", and Major and
+	// Minor its numeric parts. Empty and zero when unknown.
+	Version      string
+	Major, Minor int
+
+	// NodeCount is the number of nodes.
+	NodeCount int
+}
+
+// gatherClusterFacts collects ClusterFacts from the nodes and, when versioner
+// is set, the API server version.
+func gatherClusterFacts(ctx context.Context, c client.Client, versioner ServerVersioner) (ClusterFacts, error) {
+	var nodes corev1.NodeList
+	if err := c.List(ctx, &nodes); err != nil {
+		return ClusterFacts{}, fmt.Errorf("listing nodes: %w", err)
+	}
+	facts := ClusterFacts{NodeCount: len(nodes.Items)}
+	for _, n := range nodes.Items {
+		if provider, _, ok := strings.Cut(n.Spec.ProviderID, "://"); ok && provider != "" {
+			facts.Provider = provider
+			break
+		}
+	}
+
+	if versioner != nil {
+		info, err := versioner.ServerVersion()
+		if err != nil {
+			return ClusterFacts{}, fmt.Errorf("getting server version: %w", err)
+		}
+		facts.Version = info.GitVersion
+		facts.Major, _ = strconv.Atoi(strings.TrimRight(info.Major, "+"))
+		facts.Minor, _ = strconv.Atoi(strings.TrimRight(info.Minor, "+"))
+	}
+	return facts, nil
+}
+
+// celValue returns the facts as the "cluster" CEL variable.
+func (f ClusterFacts) celValue() map[string]interface{} {
+	return map[string]interface{}{
+		"provider":  f.Provider,
+		"version":   f.Version,
+		"major":     f.Major,
+		"minor":     f.Minor,
+		"nodeCount": f.NodeCount,
+	}
+}
+
+// compileRunIf compiles a runIf expression into a program that evaluates to
+// a bool.
+func compileRunIf(expr string) (cel.Program, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("cluster", cel.MapType(cel.StringType, cel.DynType)),
+		cel.CrossTypeNumericComparisons(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("expression must evaluate to bool, got %s", ast.OutputType())
+	}
+	return env.Program(ast, cel.CostLimit(runIfCostLimit))
+}
+
+// evalRunIf reports whether expr holds for facts.
+func evalRunIf(ctx context.Context, expr string, facts ClusterFacts) (bool, error) {
+	prg, err := compileRunIf(expr)
+	if err != nil {
+		return false, err
+	}
+	out, _, err := prg.ContextEval(ctx, map[string]interface{}{"cluster": facts.celValue()})
+	if err != nil {
+		return false, err
+	}
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression returned %s, want bool", out.Type())
+	}
+	return result, nil
+}
@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+	"github.com/clustergate/clustergate/internal/metrics"
+	"github.com/clustergate/clustergate/internal/server"
+)
+
+// stubVersioner reports a fixed API server version.
+type stubVersioner struct {
+	info version.Info
+	err  error
+}
+
+func (s stubVersioner) ServerVersion() (*version.Info, error) {
+	return &s.info, s.err
+}
+
+func TestGatherClusterFacts(t *testing.T) {
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "b"}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-0123"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(nodes[0], nodes[1]).Build()
+
+	facts, err := gatherClusterFacts(context.Background(), c, stubVersioner{info: version.Info{Major: "1", Minor: "31+", GitVersion: "v1.31.2-eks-1"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := ClusterFacts{Provider: "aws", Version: "v1.31.2-eks-1", Major: 1, Minor: 31, NodeCount: 2}
+	if facts != want {
+		t.Errorf("facts = %+v, want %+v", facts, want)
+	}
+
+	if _, err := gatherClusterFacts(context.Background(), c, stubVersioner{err: errors.New("unreachable")}); err == nil {
+		t.Error("expected the version error")
+	}
+}
+
+func TestEvalRunIf(t *testing.T) {
+	facts := ClusterFacts{Provider: "gce", Version: "v1.30.1", Major: 1, Minor: 30, NodeCount: 5}
+
+	tests := []struct {
+		expr    string
+		want    bool
+		wantErr bool
+	}{
+		{expr: `cluster.provider == "gce"`, want: true},
+		{expr: `cluster.provider == ""`, want: false},
+		{expr: `cluster.minor >= 31`, want: false},
+		{expr: `cluster.nodeCount > 3 && cluster.version.startsWith("v1.30")`, want: true},
+		{expr: `cluster.provider`, wantErr: true},
+		{expr: `cluster.provider ==`, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := evalRunIf(context.Background(), tt.expr, facts)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestReconcile_RunIf(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "metal-1"}}
+	gc := &clustergatev1alpha1.GateCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "cloud-lb"},
+		Spec:       clustergatev1alpha1.GateCheckSpec{Severity: clustergatev1alpha1.SeverityCritical, Category: "networking"},
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Checks: []clustergatev1alpha1.CheckSpec{
+				{GateCheckRef: "cloud-lb", RunIf: `cluster.provider != ""`},
+				{GateCheckRef: "broken", RunIf: `cluster.nope(`},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(node, gc, cr).WithStatusSubresource(cr).Build()
+	r := &ClusterReadinessReconciler{
+		Client:          c,
+		ReadinessState:  server.NewReadinessState(),
+		DynamicExecutor: stubExecutor{result: checks.Result{Ready: false, Message: "should not run"}},
+	}
+
+	t.Cleanup(func() { metrics.DeleteClusterReadiness("prod") })
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "prod"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got clustergatev1alpha1.ClusterReadiness
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "prod"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	statuses, _, _ := flattenCategories(got.Status.Categories)
+	byName := map[string]clustergatev1alpha1.CheckStatus{}
+	for _, cs := range statuses {
+		byName[cs.Name] = cs
+	}
+	if cs := byName["dynamic:cloud-lb"]; cs.Status != "Skipped" {
+		t.Errorf("cloud-lb = %+v, want Skipped on bare metal", cs)
+	}
+	if cs := byName["dynamic:broken"]; cs.Status != "Failing" {
+		t.Errorf("broken = %+v, want Failing for an invalid runIf", cs)
+	}
+	if got.Status.Summary.Total != 1 {
+		t.Errorf("summary total = %d, want the skipped check uncounted", got.Status.Summary.Total)
+	}
+}
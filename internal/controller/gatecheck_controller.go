@@ -2,15 +2,22 @@ package controller
 
 import (
 	"context"
+	"fmt"
 
+	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks/dynamic"
 )
 
 // GateCheckReconciler reconciles a GateCheck object.
@@ -18,10 +25,14 @@ import (
 type GateCheckReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Namespace is where the CronJobs of scheduled script checks are created.
+	Namespace string
 }
 
 // +kubebuilder:rbac:groups=clustergate.io,resources=gatechecks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=clustergate.io,resources=gatechecks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=clustergate.io,resources=gatechecks/finalizers,verbs=update
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
 
 func (r *GateCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
@@ -33,33 +44,97 @@ func (r *GateCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	logger.V(1).Info("reconciling GateCheck", "name", gateCheck.Name)
 
+	condition := validGateCheckCondition("GateCheck", gateCheck.Spec)
+	condition.ObservedGeneration = gateCheck.Generation
+
+	meta.SetStatusCondition(&gateCheck.Status.Conditions, condition)
+
+	if err := r.Status().Update(ctx, &gateCheck); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.syncScriptCronJob(ctx, &gateCheck, condition.Status == metav1.ConditionTrue); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// ValidateGateCheckSpec returns the reason and message of the False Valid
+// condition the GateCheck reconciler sets for spec, or nil if it is valid.
+func ValidateGateCheckSpec(spec clustergatev1alpha1.GateCheckSpec) error {
+	if cond := validGateCheckCondition("GateCheck", spec); cond.Status != metav1.ConditionTrue {
+		return fmt.Errorf("%s: %s", cond.Reason, cond.Message)
+	}
+	return nil
+}
+
+// validGateCheckCondition returns the Valid condition of the spec of a kind
+// of check object.
+func validGateCheckCondition(kind string, spec clustergatev1alpha1.GateCheckSpec) metav1.Condition {
 	// Validate that exactly one check type is specified.
 	checkTypeCount := 0
-	if gateCheck.Spec.PodCheck != nil {
+	if spec.PodCheck != nil {
+		checkTypeCount++
+	}
+	if spec.HTTPCheck != nil {
+		checkTypeCount++
+	}
+	if spec.ResourceCheck != nil {
+		checkTypeCount++
+	}
+	if spec.PromQLCheck != nil {
 		checkTypeCount++
 	}
-	if gateCheck.Spec.HTTPCheck != nil {
+	if spec.ScriptCheck != nil {
 		checkTypeCount++
 	}
-	if gateCheck.Spec.ResourceCheck != nil {
+	if spec.GitCheck != nil {
 		checkTypeCount++
 	}
-	if gateCheck.Spec.PromQLCheck != nil {
+	if spec.BackupCheck != nil {
 		checkTypeCount++
 	}
-	if gateCheck.Spec.ScriptCheck != nil {
+	if spec.AlertmanagerCheck != nil {
 		checkTypeCount++
 	}
 
-	condition := metav1.Condition{
-		Type:               "Valid",
-		ObservedGeneration: gateCheck.Generation,
+	var celErr error
+	if res := spec.ResourceCheck; checkTypeCount == 1 && res != nil {
+		// Expressions referencing parameters are only complete once the
+		// referencing check's values are substituted.
+		var exprs []string
+		for _, expr := range res.CELExpressions {
+			if !parameterRef.MatchString(expr) {
+				exprs = append(exprs, expr)
+			}
+		}
+		celErr = dynamic.ValidateCELExpressions(exprs)
 	}
+	paramErr := validateParameters(spec)
 
-	if checkTypeCount == 1 {
+	condition := metav1.Condition{Type: "Valid"}
+
+	if http := spec.HTTPCheck; checkTypeCount == 1 && http != nil && (http.URL == "") == (http.ServiceRef == nil) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "InvalidHTTPTarget"
+		condition.Message = "httpCheck must set exactly one of url or serviceRef"
+	} else if script := spec.ScriptCheck; checkTypeCount == 1 && script != nil && script.Schedule != "" && script.RunOnAllNodes {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "InvalidScriptCheck"
+		condition.Message = "scriptCheck schedule cannot be combined with runOnAllNodes"
+	} else if paramErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "InvalidParameters"
+		condition.Message = paramErr.Error()
+	} else if celErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "InvalidCELExpression"
+		condition.Message = celErr.Error()
+	} else if checkTypeCount == 1 {
 		condition.Status = metav1.ConditionTrue
 		condition.Reason = "SpecValid"
-		condition.Message = "GateCheck spec is valid"
+		condition.Message = kind + " spec is valid"
 	} else if checkTypeCount == 0 {
 		condition.Status = metav1.ConditionFalse
 		condition.Reason = "NoCheckType"
@@ -70,18 +145,46 @@ func (r *GateCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		condition.Message = "Exactly one check type must be specified, found multiple"
 	}
 
-	meta.SetStatusCondition(&gateCheck.Status.Conditions, condition)
+	return condition
+}
 
-	if err := r.Status().Update(ctx, &gateCheck); err != nil {
-		return ctrl.Result{}, err
+// syncScriptCronJob creates or updates the CronJob of a valid scheduled script
+// check, and deletes it once the check is no longer scheduled or valid.
+func (r *GateCheckReconciler) syncScriptCronJob(ctx context.Context, gateCheck *clustergatev1alpha1.GateCheck, valid bool) error {
+	script := gateCheck.Spec.ScriptCheck
+	if !valid || script == nil || script.Schedule == "" {
+		existing := &batchv1.CronJob{}
+		key := types.NamespacedName{Namespace: r.Namespace, Name: dynamic.ScriptCronJobName(gateCheck.Name)}
+		if err := r.Get(ctx, key, existing); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(existing, gateCheck) {
+			return nil
+		}
+		log.FromContext(ctx).Info("deleting script check CronJob", "cronJob", key.Name)
+		return client.IgnoreNotFound(r.Delete(ctx, existing, client.PropagationPolicy(metav1.DeletePropagationBackground)))
 	}
 
-	return ctrl.Result{}, nil
+	desired := dynamic.ScriptCronJob(r.Namespace, gateCheck.Name, script)
+	cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, cronJob, func() error {
+		cronJob.Labels = desired.Labels
+		cronJob.Spec = desired.Spec
+		return controllerutil.SetControllerReference(gateCheck, cronJob, r.Client.Scheme())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sync script check CronJob %s: %w", desired.Name, err)
+	}
+	if op != controllerutil.OperationResultNone {
+		log.FromContext(ctx).Info("synced script check CronJob", "cronJob", desired.Name, "operation", op)
+	}
+	return nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *GateCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&clustergatev1alpha1.GateCheck{}).
+		For(&clustergatev1alpha1.GateCheck{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&batchv1.CronJob{}).
 		Complete(r)
 }
@@ -1,8 +1,18 @@
 package controller
 
 import (
+	"context"
 	"testing"
 
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
 )
 
@@ -79,3 +89,179 @@ func TestGateCheckValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestGateCheckReconcile_HTTPTarget(t *testing.T) {
+	tests := []struct {
+		name       string
+		http       *clustergatev1alpha1.HTTPCheckSpec
+		wantReason string
+	}{
+		{
+			name:       "url",
+			http:       &clustergatev1alpha1.HTTPCheckSpec{URL: "https://example.com/healthz"},
+			wantReason: "SpecValid",
+		},
+		{
+			name: "serviceRef",
+			http: &clustergatev1alpha1.HTTPCheckSpec{ServiceRef: &clustergatev1alpha1.HTTPServiceReference{
+				Namespace: "vault", Name: "vault", Port: intstr.FromInt32(8200),
+			}},
+			wantReason: "SpecValid",
+		},
+		{
+			name:       "neither",
+			http:       &clustergatev1alpha1.HTTPCheckSpec{},
+			wantReason: "InvalidHTTPTarget",
+		},
+		{
+			name: "both",
+			http: &clustergatev1alpha1.HTTPCheckSpec{URL: "https://example.com", ServiceRef: &clustergatev1alpha1.HTTPServiceReference{
+				Namespace: "vault", Name: "vault", Port: intstr.FromInt32(8200),
+			}},
+			wantReason: "InvalidHTTPTarget",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gc := &clustergatev1alpha1.GateCheck{
+				ObjectMeta: metav1.ObjectMeta{Name: "http"},
+				Spec:       clustergatev1alpha1.GateCheckSpec{HTTPCheck: tt.http},
+			}
+			c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc).WithStatusSubresource(gc).Build()
+			r := &GateCheckReconciler{Client: c}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "http"}}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got clustergatev1alpha1.GateCheck
+			if err := c.Get(context.Background(), types.NamespacedName{Name: "http"}, &got); err != nil {
+				t.Fatal(err)
+			}
+			cond := meta.FindStatusCondition(got.Status.Conditions, "Valid")
+			if cond == nil || cond.Reason != tt.wantReason {
+				t.Errorf("Valid condition = %+v, want reason %q", cond, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestGateCheckReconcile_CELExpressions(t *testing.T) {
+	tests := []struct {
+		name       string
+		exprs      []string
+		wantReason string
+	}{
+		{name: "valid", exprs: []string{"object.status.readyReplicas >= object.spec.replicas"}, wantReason: "SpecValid"},
+		{name: "syntax error", exprs: []string{"object.status.readyReplicas >="}, wantReason: "InvalidCELExpression"},
+		{name: "not bool", exprs: []string{"1 + 2"}, wantReason: "InvalidCELExpression"},
+		{name: "undeclared parameter", exprs: []string{"object.status.readyReplicas >= $(params.min)"}, wantReason: "InvalidParameters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gc := &clustergatev1alpha1.GateCheck{
+				ObjectMeta: metav1.ObjectMeta{Name: "deploy"},
+				Spec: clustergatev1alpha1.GateCheckSpec{ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{
+					APIVersion: "apps/v1", Kind: "Deployment", Name: "api", CELExpressions: tt.exprs,
+				}},
+			}
+			c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc).WithStatusSubresource(gc).Build()
+			r := &GateCheckReconciler{Client: c}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "deploy"}}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got clustergatev1alpha1.GateCheck
+			if err := c.Get(context.Background(), types.NamespacedName{Name: "deploy"}, &got); err != nil {
+				t.Fatal(err)
+			}
+			cond := meta.FindStatusCondition(got.Status.Conditions, "Valid")
+			if cond == nil || cond.Reason != tt.wantReason {
+				t.Errorf("Valid condition = %+v, want reason %s", cond, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestGateCheckReconcile_ScriptCronJob(t *testing.T) {
+	gc := &clustergatev1alpha1.GateCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "disk-bench", UID: "gc-uid"},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			ScriptCheck: &clustergatev1alpha1.ScriptCheckSpec{
+				Image:    "my-probe:1.0",
+				Schedule: "*/15 * * * *",
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc).WithStatusSubresource(gc).Build()
+	r := &GateCheckReconciler{Client: c, Namespace: "clustergate-system"}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "disk-bench"}}
+	cronJobKey := types.NamespacedName{Namespace: "clustergate-system", Name: "clustergate-disk-bench"}
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var cronJob batchv1.CronJob
+	if err := c.Get(context.Background(), cronJobKey, &cronJob); err != nil {
+		t.Fatalf("expected CronJob to be created: %v", err)
+	}
+	if cronJob.Spec.Schedule != "*/15 * * * *" {
+		t.Errorf("schedule = %q, want */15 * * * *", cronJob.Spec.Schedule)
+	}
+	if ref := metav1.GetControllerOf(&cronJob); ref == nil || ref.Kind != "GateCheck" || ref.Name != "disk-bench" {
+		t.Errorf("expected CronJob to be controlled by the GateCheck, got %+v", ref)
+	}
+	if image := cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Image; image != "my-probe:1.0" {
+		t.Errorf("image = %q, want my-probe:1.0", image)
+	}
+
+	var updated clustergatev1alpha1.GateCheck
+	if err := c.Get(context.Background(), req.NamespacedName, &updated); err != nil {
+		t.Fatal(err)
+	}
+	updated.Spec.ScriptCheck.Schedule = ""
+	if err := c.Update(context.Background(), &updated); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(context.Background(), cronJobKey, &cronJob); !apierrors.IsNotFound(err) {
+		t.Errorf("expected CronJob to be deleted once unscheduled, got %v", err)
+	}
+}
+
+func TestGateCheckReconcile_ScheduleWithRunOnAllNodes(t *testing.T) {
+	gc := &clustergatev1alpha1.GateCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-probe"},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			ScriptCheck: &clustergatev1alpha1.ScriptCheckSpec{
+				Image:         "my-probe:1.0",
+				Schedule:      "@hourly",
+				RunOnAllNodes: true,
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc).WithStatusSubresource(gc).Build()
+	r := &GateCheckReconciler{Client: c, Namespace: "clustergate-system"}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "node-probe"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got clustergatev1alpha1.GateCheck
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "node-probe"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	if cond := meta.FindStatusCondition(got.Status.Conditions, "Valid"); cond == nil || cond.Reason != "InvalidScriptCheck" {
+		t.Errorf("Valid condition = %+v, want reason InvalidScriptCheck", cond)
+	}
+	var cronJobs batchv1.CronJobList
+	if err := c.List(context.Background(), &cronJobs); err != nil {
+		t.Fatal(err)
+	}
+	if len(cronJobs.Items) != 0 {
+		t.Errorf("expected no CronJob for an invalid check, got %d", len(cronJobs.Items))
+	}
+}
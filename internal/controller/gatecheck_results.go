@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+// recordGateCheckResults writes the outcome of the GateChecks cr just ran to
+// their status. results[i] is the outcome of executed[i]. When several
+// instances of a GateCheck ran, the first failing one is recorded; when
+// several ClusterReadiness CRs run it, the failing run of the first of them
+// by name is kept over the others' runs, so CRs that disagree do not
+// overwrite each other. A GateCheck's status is written when its readiness
+// or consumers change, and otherwise at most once per check interval, so
+// steady results cost few writes while lastRunTime stays current.
+func (r *ClusterReadinessReconciler) recordGateCheckResults(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, executed []ResolvedCheck, results []checkResult, now metav1.Time) error {
+	latest := map[string]*clustergatev1alpha1.GateCheckResult{}
+	intervals := map[string]time.Duration{}
+	var names []string
+	for i, rc := range executed {
+		if rc.IsBuiltin || rc.Namespace != "" {
+			continue
+		}
+		if interval, ok := intervals[rc.GateCheckName]; !ok || rc.Interval < interval {
+			intervals[rc.GateCheckName] = rc.Interval
+		}
+		res := results[i]
+		_, message, ready := runOutcome(res)
+		if rc.Instance != "" {
+			message = fmt.Sprintf("%s: %s", rc.Instance, message)
+		}
+		if prev, ok := latest[rc.GateCheckName]; ok {
+			if !prev.Ready || ready {
+				continue
+			}
+		} else {
+			names = append(names, rc.GateCheckName)
+		}
+		latest[rc.GateCheckName] = &clustergatev1alpha1.GateCheckResult{
+			Ready:            ready,
+			Message:          message,
+			LastRunTime:      now,
+			DurationMillis:   res.duration.Milliseconds(),
+			ClusterReadiness: cr.Name,
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	consumers, err := r.gateCheckConsumers(ctx, names)
+	if err != nil {
+		return err
+	}
+	var gateChecks clustergatev1alpha1.GateCheckList
+	if err := r.List(ctx, &gateChecks, client.UnsafeDisableDeepCopy); err != nil {
+		return fmt.Errorf("failed to list GateChecks: %w", err)
+	}
+	cached := make(map[string]*clustergatev1alpha1.GateCheck, len(gateChecks.Items))
+	for i := range gateChecks.Items {
+		cached[gateChecks.Items[i].Name] = &gateChecks.Items[i]
+	}
+
+	// The patch replaces every field of lastResult, so no read is needed.
+	var errs []error
+	for _, name := range names {
+		gc, ok := cached[name]
+		if !ok {
+			continue
+		}
+		// Of the consumers' latest runs, the failing one of the first
+		// consumer by name wins, so every consumer records the same run.
+		own := latest[name]
+		result, failing := own, false
+		var consuming []string
+		for _, consumer := range consumers[name] {
+			consuming = append(consuming, consumer.name)
+			run := consumer.failing
+			if consumer.name == cr.Name {
+				run = own
+			}
+			if !failing && run != nil && !run.Ready {
+				result, failing = run, true
+			}
+		}
+		if !slices.Contains(consuming, cr.Name) {
+			consuming = append(consuming, cr.Name)
+			sort.Strings(consuming)
+		}
+		result.ConsumingClusterReadiness = consuming
+		if last := gc.Status.LastResult; last != nil && last.Ready == result.Ready &&
+			slices.Equal(last.ConsumingClusterReadiness, result.ConsumingClusterReadiness) &&
+			result.LastRunTime.Sub(last.LastRunTime.Time) < intervals[name] {
+			continue
+		}
+
+		base := &clustergatev1alpha1.GateCheck{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		patched := base.DeepCopy()
+		patched.Status.LastResult = result
+		if err := r.Status().Patch(ctx, patched, client.MergeFrom(base)); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("GateCheck %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// gateCheckConsumer is a ClusterReadiness referencing a GateCheck.
+type gateCheckConsumer struct {
+	name string
+	// failing is the consumer's latest run of the GateCheck, from its
+	// status, when that run failed.
+	failing *clustergatev1alpha1.GateCheckResult
+}
+
+// gateCheckConsumers returns, for each of the named GateChecks, the
+// ClusterReadiness CRs referencing it, inline or through the GateProfile
+// version or snapshot they use, sorted by name. Rather than looking each
+// GateCheck up, it lists the ClusterReadiness CRs and GateProfiles once,
+// without copying them out of the cache; only snapshots are read.
+func (r *ClusterReadinessReconciler) gateCheckConsumers(ctx context.Context, names []string) (map[string][]gateCheckConsumer, error) {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var profiles clustergatev1alpha1.GateProfileList
+	if err := r.List(ctx, &profiles, client.UnsafeDisableDeepCopy); err != nil {
+		return nil, fmt.Errorf("failed to list GateProfiles: %w", err)
+	}
+	byName := make(map[string]*clustergatev1alpha1.GateProfile, len(profiles.Items))
+	for i := range profiles.Items {
+		byName[profiles.Items[i].Name] = &profiles.Items[i]
+	}
+
+	var list clustergatev1alpha1.ClusterReadinessList
+	if err := r.List(ctx, &list, client.UnsafeDisableDeepCopy); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterReadiness CRs: %w", err)
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+	result := make(map[string][]gateCheckConsumer, len(names))
+	for i := range list.Items {
+		cr := &list.Items[i]
+		refs := clusterReadinessGateCheckRefs(cr)
+		for _, ref := range cr.Spec.Profiles {
+			// Profiles that cannot be resolved reference no GateChecks.
+			var checks []clustergatev1alpha1.ProfileCheckRef
+			if ref.SnapshotRef != nil {
+				checks, _ = readProfileSnapshot(ctx, r.Client, ref)
+			} else if gp, ok := byName[ref.Name]; ok {
+				checks, _ = profileVersionChecks(gp, ref.Version)
+			}
+			for _, check := range checks {
+				if check.GateCheckRef != "" {
+					refs = append(refs, check.GateCheckRef)
+				}
+			}
+		}
+		failing := failingGateCheckRuns(cr, wanted)
+		for _, ref := range refs {
+			if !wanted[ref] {
+				continue
+			}
+			if n := len(result[ref]); n > 0 && result[ref][n-1].name == cr.Name {
+				continue
+			}
+			result[ref] = append(result[ref], gateCheckConsumer{name: cr.Name, failing: failing[ref]})
+		}
+	}
+	return result, nil
+}
+
+// failingGateCheckRuns returns the latest runs of the wanted GateChecks that
+// failed in cr's status, the first failing instance's for a GateCheck run as
+// several instances.
+func failingGateCheckRuns(cr *clustergatev1alpha1.ClusterReadiness, wanted map[string]bool) map[string]*clustergatev1alpha1.GateCheckResult {
+	failing := map[string]*clustergatev1alpha1.GateCheckResult{}
+	for _, category := range cr.Status.Categories {
+		for _, cs := range category.Checks {
+			ref, ok := strings.CutPrefix(cs.Name, "dynamic:")
+			if !ok || cs.ConsecutiveFailures == 0 || cs.LastChecked == nil {
+				continue
+			}
+			ref, instance, _ := strings.Cut(ref, "/")
+			if !wanted[ref] || failing[ref] != nil {
+				continue
+			}
+			message := cs.Message
+			if instance != "" {
+				message = fmt.Sprintf("%s: %s", instance, message)
+			}
+			run := &clustergatev1alpha1.GateCheckResult{
+				Message:          message,
+				LastRunTime:      *cs.LastChecked,
+				ClusterReadiness: cr.Name,
+			}
+			if cs.Duration != nil {
+				run.DurationMillis = cs.Duration.Milliseconds()
+			}
+			failing[ref] = run
+		}
+	}
+	return failing
+}
@@ -0,0 +1,229 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+)
+
+func TestRecordGateCheckResults_FirstFailingInstance(t *testing.T) {
+	gc := &clustergatev1alpha1.GateCheck{ObjectMeta: metav1.ObjectMeta{Name: "service-health"}}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc).WithStatusSubresource(gc).Build()
+	r := &ClusterReadinessReconciler{Client: c}
+	cr := &clustergatev1alpha1.ClusterReadiness{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}
+
+	executed := []ResolvedCheck{
+		{GateCheckName: "service-health", Instance: "api"},
+		{GateCheckName: "service-health", Instance: "web"},
+		{GateCheckName: "service-health", Instance: "db"},
+		{IsBuiltin: true, BuiltinName: "dns"},
+		{GateCheckName: "deleted"},
+	}
+	results := []checkResult{
+		{result: checks.Result{Ready: true, Message: "ok"}},
+		{result: checks.Result{Ready: false, Message: "HTTP 503"}, duration: 1500 * time.Millisecond},
+		{err: errors.New("connection refused")},
+		{result: checks.Result{Ready: true}},
+		{result: checks.Result{Ready: true}},
+	}
+	if err := r.recordGateCheckResults(context.Background(), cr, executed, results, metav1.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got clustergatev1alpha1.GateCheck
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(gc), &got); err != nil {
+		t.Fatal(err)
+	}
+	last := got.Status.LastResult
+	if last == nil || last.Ready || last.Message != "web: HTTP 503" || last.DurationMillis != 1500 {
+		t.Errorf("lastResult = %+v, want the web instance's failure", last)
+	}
+}
+
+func TestRecordGateCheckResults_RateLimitsUnchanged(t *testing.T) {
+	gc := &clustergatev1alpha1.GateCheck{ObjectMeta: metav1.ObjectMeta{Name: "api"}}
+	objs := []client.Object{gc,
+		&clustergatev1alpha1.GateProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "apps"},
+			Spec: clustergatev1alpha1.GateProfileSpec{
+				Checks: []clustergatev1alpha1.ProfileCheckRef{{GateCheckRef: "api"}},
+			},
+		},
+		&clustergatev1alpha1.ClusterReadiness{
+			ObjectMeta: metav1.ObjectMeta{Name: "staging"},
+			Spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Profiles: []clustergatev1alpha1.ProfileRef{{Name: "apps"}},
+			},
+		},
+	}
+	var patches int
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(objs...).WithStatusSubresource(gc).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				patches++
+				return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+	r := &ClusterReadinessReconciler{Client: c}
+	cr := &clustergatev1alpha1.ClusterReadiness{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}
+	executed := []ResolvedCheck{{GateCheckName: "api", Interval: time.Minute}}
+	start := time.Now().Truncate(time.Second)
+	record := func(at time.Duration, res checks.Result) {
+		t.Helper()
+		now := metav1.NewTime(start.Add(at))
+		if err := r.recordGateCheckResults(context.Background(), cr, executed, []checkResult{{result: res}}, now); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	get := func() *clustergatev1alpha1.GateCheckResult {
+		t.Helper()
+		var got clustergatev1alpha1.GateCheck
+		if err := c.Get(context.Background(), client.ObjectKeyFromObject(gc), &got); err != nil {
+			t.Fatal(err)
+		}
+		return got.Status.LastResult
+	}
+
+	record(0, checks.Result{Ready: true, Message: "ok"})
+	record(10*time.Second, checks.Result{Ready: true, Message: "ok"})
+	if patches != 1 {
+		t.Errorf("%d status patches for an unchanged result within the interval, want 1", patches)
+	}
+	if got := get(); fmt.Sprint(got.ConsumingClusterReadiness) != "[prod staging]" {
+		t.Errorf("consumingClusterReadiness = %v, want [prod staging]", got.ConsumingClusterReadiness)
+	}
+
+	record(time.Minute+10*time.Second, checks.Result{Ready: true, Message: "ok"})
+	if patches != 2 {
+		t.Errorf("%d status patches once the recorded run is older than the interval, want 2", patches)
+	}
+	if got := get(); !got.LastRunTime.Equal(&metav1.Time{Time: start.Add(time.Minute + 10*time.Second)}) {
+		t.Errorf("lastRunTime = %v, want the latest run", got.LastRunTime)
+	}
+
+	record(time.Minute+20*time.Second, checks.Result{Ready: false, Message: "HTTP 503"})
+	if patches != 3 {
+		t.Errorf("%d status patches after the result changed, want 3", patches)
+	}
+}
+
+func TestRecordGateCheckResults_ConsumersDisagree(t *testing.T) {
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	gc := &clustergatev1alpha1.GateCheck{ObjectMeta: metav1.ObjectMeta{Name: "api"}}
+	readiness := func(name string) *clustergatev1alpha1.ClusterReadiness {
+		return &clustergatev1alpha1.ClusterReadiness{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Checks: []clustergatev1alpha1.CheckSpec{{GateCheckRef: "api"}},
+			},
+		}
+	}
+	failing := readiness("canary")
+	failing.Status.Categories = []clustergatev1alpha1.CategoryStatus{{
+		Category: "networking",
+		Checks: []clustergatev1alpha1.CheckStatus{{
+			Name:                "dynamic:api",
+			Status:              "Failing",
+			Message:             "HTTP 503",
+			LastChecked:         &now,
+			ConsecutiveFailures: 1,
+		}},
+	}}
+	prod := readiness("prod")
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc, failing, prod).WithStatusSubresource(gc).Build()
+	r := &ClusterReadinessReconciler{Client: c}
+	executed := []ResolvedCheck{{GateCheckName: "api", Interval: time.Minute}}
+	record := func(cr *clustergatev1alpha1.ClusterReadiness, res checks.Result) *clustergatev1alpha1.GateCheckResult {
+		t.Helper()
+		if err := r.recordGateCheckResults(context.Background(), cr, executed, []checkResult{{result: res}}, now); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got clustergatev1alpha1.GateCheck
+		if err := c.Get(context.Background(), client.ObjectKeyFromObject(gc), &got); err != nil {
+			t.Fatal(err)
+		}
+		return got.Status.LastResult
+	}
+
+	// prod passing does not hide canary's failure.
+	last := record(prod, checks.Result{Ready: true, Message: "ok"})
+	if last.Ready || last.Message != "HTTP 503" || last.ClusterReadiness != "canary" {
+		t.Errorf("lastResult = %+v, want canary's failure", last)
+	}
+	// Neither does prod failing differently: canary comes first.
+	last = record(prod, checks.Result{Ready: false, Message: "timeout"})
+	if last.Ready || last.Message != "HTTP 503" || last.ClusterReadiness != "canary" {
+		t.Errorf("lastResult = %+v, want canary's failure", last)
+	}
+	if fmt.Sprint(last.ConsumingClusterReadiness) != "[canary prod]" {
+		t.Errorf("consumingClusterReadiness = %v, want [canary prod]", last.ConsumingClusterReadiness)
+	}
+}
+
+func TestGateCheckConsumers_PinnedVersionsAndSnapshots(t *testing.T) {
+	profileRef := func(name, version string) clustergatev1alpha1.ProfileRef {
+		return clustergatev1alpha1.ProfileRef{Name: name, Version: version}
+	}
+	readiness := func(name string, refs ...clustergatev1alpha1.ProfileRef) *clustergatev1alpha1.ClusterReadiness {
+		return &clustergatev1alpha1.ClusterReadiness{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       clustergatev1alpha1.ClusterReadinessSpec{Profiles: refs},
+		}
+	}
+	snapshot := profileRef("apps", "1.0.0")
+	snapshot.SnapshotRef = &clustergatev1alpha1.ProfileSnapshotReference{Namespace: "fleet", Name: "apps-1.0.0"}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(
+		&clustergatev1alpha1.GateProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "apps"},
+			Spec: clustergatev1alpha1.GateProfileSpec{
+				Version: "2.0.0",
+				Checks:  []clustergatev1alpha1.ProfileCheckRef{{GateCheckRef: "api"}},
+			},
+			Status: clustergatev1alpha1.GateProfileStatus{
+				Versions: []clustergatev1alpha1.ProfileVersion{
+					{Version: "2.0.0", Checks: []clustergatev1alpha1.ProfileCheckRef{{GateCheckRef: "api"}}},
+					{Version: "1.0.0", Checks: []clustergatev1alpha1.ProfileCheckRef{{GateCheckRef: "legacy-api"}}},
+				},
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "fleet", Name: "apps-1.0.0"},
+			Data:       map[string]string{profileSnapshotKey: "version: 1.0.0\nchecks:\n- gateCheckRef: legacy-api\n"},
+		},
+		readiness("current", profileRef("apps", "")),
+		readiness("pinned", profileRef("apps", "1.0.0")),
+		readiness("snapshot", snapshot),
+		readiness("unknown-version", profileRef("apps", "0.1.0")),
+	).Build()
+	r := &ClusterReadinessReconciler{Client: c}
+
+	consumers, err := r.gateCheckConsumers(context.Background(), []string{"api", "legacy-api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := func(consumers []gateCheckConsumer) string {
+		var names []string
+		for _, consumer := range consumers {
+			names = append(names, consumer.name)
+		}
+		return fmt.Sprint(names)
+	}
+	if got := names(consumers["api"]); got != "[current]" {
+		t.Errorf("api consumers = %s, want [current]", got)
+	}
+	if got := names(consumers["legacy-api"]); got != "[pinned snapshot]" {
+		t.Errorf("legacy-api consumers = %s, want [pinned snapshot]", got)
+	}
+}
@@ -2,7 +2,10 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -14,10 +17,18 @@ import (
 )
 
 // GateProfileReconciler reconciles a GateProfile object.
-// It validates the spec and updates status conditions.
+// It validates the spec, imports the profile's source and updates status
+// conditions.
 type GateProfileReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// Namespace is the default namespace of the Secrets and ConfigMaps a
+	// profile source references.
+	Namespace string
+
+	// HTTPClient pulls OCI bundles. Defaults to a client with a 30s timeout.
+	HTTPClient *http.Client
 }
 
 // +kubebuilder:rbac:groups=clustergate.io,resources=gateprofiles,verbs=get;list;watch;create;update;patch;delete
@@ -34,42 +45,122 @@ func (r *GateProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	logger.V(1).Info("reconciling GateProfile", "name", profile.Name)
 
 	// Validate that each check reference has exactly one of Name or GateCheckRef.
-	condition := metav1.Condition{
-		Type:               "Valid",
-		ObservedGeneration: profile.Generation,
+	condition := validateProfileChecks(profile.Spec.Checks)
+	condition.Type = "Valid"
+	condition.ObservedGeneration = profile.Generation
+	if condition.Status == metav1.ConditionTrue {
+		condition.Reason = "SpecValid"
+		condition.Message = "GateProfile spec is valid"
+	}
+	meta.SetStatusCondition(&profile.Status.Conditions, condition)
+
+	var result ctrl.Result
+	if src := profile.Spec.Source; src != nil {
+		sourceCondition := r.importSource(ctx, &profile, metav1.Now())
+		sourceCondition.ObservedGeneration = profile.Generation
+		meta.SetStatusCondition(&profile.Status.Conditions, sourceCondition)
+		result.RequeueAfter = defaultSourceInterval
+		if src.Interval != nil && src.Interval.Duration > 0 {
+			result.RequeueAfter = src.Interval.Duration
+		}
+	} else {
+		profile.Status.Source = nil
+		meta.RemoveStatusCondition(&profile.Status.Conditions, conditionSourceReady)
+	}
+
+	if condition.Status == metav1.ConditionTrue {
+		recordProfileVersion(&profile, metav1.Now())
+	}
+
+	if err := r.Status().Update(ctx, &profile); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return result, nil
+}
+
+// ValidateProfileChecks returns the reason and message of the False Valid
+// condition the GateProfile reconciler sets for checks, or nil if they are
+// valid.
+func ValidateProfileChecks(checks []clustergatev1alpha1.ProfileCheckRef) error {
+	if cond := validateProfileChecks(checks); cond.Status != metav1.ConditionTrue {
+		return fmt.Errorf("%s: %s", cond.Reason, cond.Message)
 	}
+	return nil
+}
 
-	valid := true
-	for i, check := range profile.Spec.Checks {
+// validateProfileChecks validates each of checks, returning a True condition,
+// or a False one with the reason and message of the first invalid check.
+func validateProfileChecks(checks []clustergatev1alpha1.ProfileCheckRef) metav1.Condition {
+	var condition metav1.Condition
+	for i, check := range checks {
 		if check.Name == "" && check.GateCheckRef == "" {
 			condition.Status = metav1.ConditionFalse
 			condition.Reason = "InvalidCheckRef"
 			condition.Message = "check at index " + string(rune('0'+i)) + " must specify either name or gateCheckRef"
-			valid = false
-			break
+			return condition
 		}
 		if check.Name != "" && check.GateCheckRef != "" {
 			condition.Status = metav1.ConditionFalse
 			condition.Reason = "AmbiguousCheckRef"
 			condition.Message = "check at index " + string(rune('0'+i)) + " must specify only one of name or gateCheckRef"
-			valid = false
-			break
+			return condition
+		}
+		if check.GateCheckRef == "" && (check.Instance != "" || check.Values != nil) {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "InvalidCheckRef"
+			condition.Message = "check " + check.Identifier() + ": instance and values require gateCheckRef"
+			return condition
+		}
+		if check.RunIf != "" {
+			if _, err := compileRunIf(check.RunIf); err != nil {
+				condition.Status = metav1.ConditionFalse
+				condition.Reason = "InvalidRunIf"
+				condition.Message = "check " + check.Identifier() + ": invalid runIf: " + err.Error()
+				return condition
+			}
 		}
 	}
+	condition.Status = metav1.ConditionTrue
+	return condition
+}
 
-	if valid {
-		condition.Status = metav1.ConditionTrue
-		condition.Reason = "SpecValid"
-		condition.Message = "GateProfile spec is valid"
-	}
-
-	meta.SetStatusCondition(&profile.Status.Conditions, condition)
+// defaultVersionHistoryLimit is how many versions a GateProfile records when
+// spec.versionHistoryLimit is unset.
+const defaultVersionHistoryLimit = 10
 
-	if err := r.Status().Update(ctx, &profile); err != nil {
-		return ctrl.Result{}, err
+// recordProfileVersion records profile's current checks as its current version
+// at the front of status.versions, and drops the versions beyond
+// spec.versionHistoryLimit. Unversioned profiles record nothing.
+func recordProfileVersion(profile *clustergatev1alpha1.GateProfile, now metav1.Time) {
+	version, checks := profile.Current()
+	if version == "" {
+		return
+	}
+	current := clustergatev1alpha1.ProfileVersion{
+		Version:    version,
+		Checks:     checks,
+		RecordedAt: now,
+	}
+	versions := []clustergatev1alpha1.ProfileVersion{current}
+	for _, v := range profile.Status.Versions {
+		if v.Version == current.Version {
+			if equality.Semantic.DeepEqual(v.Checks, current.Checks) {
+				versions[0].RecordedAt = v.RecordedAt
+			}
+			continue
+		}
+		versions = append(versions, v)
 	}
 
-	return ctrl.Result{}, nil
+	limit := defaultVersionHistoryLimit
+	if profile.Spec.VersionHistoryLimit != nil {
+		limit = int(*profile.Spec.VersionHistoryLimit)
+	}
+	if len(versions) > limit {
+		versions = versions[:limit]
+	}
+	profile.Status.Versions = versions
 }
 
 // SetupWithManager sets up the controller with the Manager.
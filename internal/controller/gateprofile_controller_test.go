@@ -2,6 +2,9 @@ package controller
 
 import (
 	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
 )
@@ -63,3 +66,39 @@ func TestGateProfileValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordProfileVersion(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(earlier.Add(time.Hour))
+	v1Checks := []clustergatev1alpha1.ProfileCheckRef{{Name: "dns"}}
+	v2Checks := []clustergatev1alpha1.ProfileCheckRef{{Name: "dns"}, {Name: "etcd"}}
+	limit := int32(2)
+
+	profile := &clustergatev1alpha1.GateProfile{
+		Spec: clustergatev1alpha1.GateProfileSpec{Version: "1", Checks: v1Checks, VersionHistoryLimit: &limit},
+	}
+	recordProfileVersion(profile, earlier)
+	recordProfileVersion(profile, now)
+	if got := profile.Status.Versions; len(got) != 1 || got[0].Version != "1" || !got[0].RecordedAt.Equal(&earlier) {
+		t.Fatalf("versions = %+v, want version 1 recorded once at %v", got, earlier)
+	}
+
+	profile.Spec.Version, profile.Spec.Checks = "2", v2Checks
+	recordProfileVersion(profile, now)
+	if got := profile.Status.Versions; len(got) != 2 || got[0].Version != "2" || got[1].Version != "1" || len(got[1].Checks) != 1 {
+		t.Fatalf("versions = %+v, want 2 then 1 with its own checks", got)
+	}
+
+	// Versions beyond the limit are dropped, oldest first.
+	profile.Spec.Version, profile.Spec.Checks = "3", v1Checks
+	recordProfileVersion(profile, now)
+	if got := profile.Status.Versions; len(got) != 2 || got[0].Version != "3" || got[1].Version != "2" {
+		t.Errorf("versions = %+v, want 3 then 2", got)
+	}
+
+	unversioned := &clustergatev1alpha1.GateProfile{Spec: clustergatev1alpha1.GateProfileSpec{Checks: v1Checks}}
+	recordProfileVersion(unversioned, now)
+	if unversioned.Status.Versions != nil {
+		t.Errorf("unversioned profile recorded %+v", unversioned.Status.Versions)
+	}
+}
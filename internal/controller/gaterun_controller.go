@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/version"
+)
+
+const (
+	defaultGateRunRetain = 10
+
+	// gateRunPendingRequeue is how long an on-demand GateRun waits for its
+	// ClusterReadiness to complete a first evaluation.
+	gateRunPendingRequeue = 10 * time.Second
+)
+
+// GateRunReconciler records on-demand GateRuns from the latest evaluation of
+// their ClusterReadiness. Scheduled GateRuns are recorded by the
+// ClusterReadinessReconciler as they are created.
+type GateRunReconciler struct {
+	client.Client
+}
+
+// +kubebuilder:rbac:groups=clustergate.io,resources=gateruns,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=clustergate.io,resources=gateruns/status,verbs=get;update;patch
+
+func (r *GateRunReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var run clustergatev1alpha1.GateRun
+	if err := r.Get(ctx, req.NamespacedName, &run); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Evidence is written once and never touched again.
+	if run.Status.RecordedAt != nil || run.Spec.Trigger == clustergatev1alpha1.GateRunScheduled {
+		return ctrl.Result{}, nil
+	}
+
+	now := metav1.Now()
+	var cr clustergatev1alpha1.ClusterReadiness
+	if err := r.Get(ctx, types.NamespacedName{Name: run.Spec.ClusterReadiness}, &cr); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		run.Status = clustergatev1alpha1.GateRunStatus{
+			RecordedAt:      &now,
+			OperatorVersion: version.Version,
+			Message:         fmt.Sprintf("ClusterReadiness %s not found", run.Spec.ClusterReadiness),
+		}
+	} else {
+		if cr.Status.LastChecked == nil {
+			logger.V(1).Info("ClusterReadiness not evaluated yet, waiting", "gateRun", run.Name)
+			return ctrl.Result{RequeueAfter: gateRunPendingRequeue}, nil
+		}
+		run.Status = gateRunStatus(cr.Status, now)
+	}
+
+	if err := r.Status().Update(ctx, &run); err != nil {
+		return ctrl.Result{}, err
+	}
+	logger.Info("recorded GateRun", "gateRun", run.Name, "clusterReadiness", run.Spec.ClusterReadiness, "state", run.Status.State)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GateRunReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clustergatev1alpha1.GateRun{}).
+		Complete(r)
+}
+
+// gateRunStatus captures status as GateRun evidence recorded at now. Checks
+// last evaluated at status.LastChecked ran in the recorded cycle.
+func gateRunStatus(status clustergatev1alpha1.ClusterReadinessStatus, now metav1.Time) clustergatev1alpha1.GateRunStatus {
+	var runChecks []clustergatev1alpha1.GateRunCheck
+	for _, cat := range status.Categories {
+		for _, cs := range cat.Checks {
+			runChecks = append(runChecks, clustergatev1alpha1.GateRunCheck{
+				CheckStatus: *cs.DeepCopy(),
+				Category:    cat.Category,
+				Executed:    cs.LastChecked != nil && cs.LastChecked.Equal(status.LastChecked),
+			})
+		}
+	}
+	return clustergatev1alpha1.GateRunStatus{
+		RecordedAt:      &now,
+		OperatorVersion: version.Version,
+		State:           status.State,
+		Summary:         status.Summary.DeepCopy(),
+		Checks:          runChecks,
+	}
+}
+
+// shouldRecordGateRun reports whether an evaluation cycle that executed
+// checks is recorded under policy.
+func shouldRecordGateRun(policy *clustergatev1alpha1.GateRunPolicy, previousState, state clustergatev1alpha1.ClusterHealthState) bool {
+	if policy == nil {
+		return false
+	}
+	switch policy.Record {
+	case clustergatev1alpha1.GateRunRecordNever:
+		return false
+	case clustergatev1alpha1.GateRunRecordOnStateChange:
+		return previousState != state
+	default:
+		return true
+	}
+}
+
+// recordGateRun creates a scheduled GateRun from cr's current status. The
+// GateRun is owned by cr and deleted with it.
+func (r *ClusterReadinessReconciler) recordGateRun(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, now metav1.Time) error {
+	run := &clustergatev1alpha1.GateRun{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: cr.Name + "-"},
+		Spec: clustergatev1alpha1.GateRunSpec{
+			ClusterReadiness: cr.Name,
+			Trigger:          clustergatev1alpha1.GateRunScheduled,
+		},
+	}
+	if err := controllerutil.SetOwnerReference(cr, run, r.Scheme()); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, run); err != nil {
+		return err
+	}
+	run.Status = gateRunStatus(cr.Status, now)
+	return r.Status().Update(ctx, run)
+}
+
+// pruneGateRuns deletes cr's GateRuns beyond the retention of its policy:
+// everything older than MaxAge, then the oldest runs beyond Retain.
+func (r *ClusterReadinessReconciler) pruneGateRuns(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, now time.Time) error {
+	policy := cr.Spec.GateRuns
+	if policy == nil {
+		return nil
+	}
+
+	var list clustergatev1alpha1.GateRunList
+	if err := r.List(ctx, &list); err != nil {
+		return err
+	}
+	var runs []clustergatev1alpha1.GateRun
+	for _, run := range list.Items {
+		if run.Spec.ClusterReadiness == cr.Name {
+			runs = append(runs, run)
+		}
+	}
+	// Newest first.
+	sort.Slice(runs, func(i, j int) bool {
+		ti, tj := runs[i].CreationTimestamp, runs[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return tj.Before(&ti)
+		}
+		return runs[i].Name > runs[j].Name
+	})
+
+	retain := int(policy.Retain)
+	if retain <= 0 {
+		retain = defaultGateRunRetain
+	}
+	for i := range runs {
+		expired := policy.MaxAge != nil && now.Sub(runs[i].CreationTimestamp.Time) > policy.MaxAge.Duration
+		if i < retain && !expired {
+			continue
+		}
+		if err := r.Delete(ctx, &runs[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
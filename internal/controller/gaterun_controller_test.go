@@ -0,0 +1,228 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+	"github.com/clustergate/clustergate/internal/metrics"
+	"github.com/clustergate/clustergate/internal/server"
+	"github.com/clustergate/clustergate/internal/version"
+)
+
+func TestShouldRecordGateRun(t *testing.T) {
+	policy := func(mode clustergatev1alpha1.GateRunRecordMode) *clustergatev1alpha1.GateRunPolicy {
+		return &clustergatev1alpha1.GateRunPolicy{Record: mode}
+	}
+	tests := []struct {
+		name     string
+		policy   *clustergatev1alpha1.GateRunPolicy
+		previous clustergatev1alpha1.ClusterHealthState
+		want     bool
+	}{
+		{name: "no policy", policy: nil, want: false},
+		{name: "never", policy: policy(clustergatev1alpha1.GateRunRecordNever), want: false},
+		{name: "default records every cycle", policy: policy(""), previous: clustergatev1alpha1.ClusterHealthy, want: true},
+		{name: "every cycle", policy: policy(clustergatev1alpha1.GateRunRecordEveryCycle), previous: clustergatev1alpha1.ClusterHealthy, want: true},
+		{name: "state unchanged", policy: policy(clustergatev1alpha1.GateRunRecordOnStateChange), previous: clustergatev1alpha1.ClusterHealthy, want: false},
+		{name: "state changed", policy: policy(clustergatev1alpha1.GateRunRecordOnStateChange), previous: clustergatev1alpha1.ClusterUnhealthy, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldRecordGateRun(tt.policy, tt.previous, clustergatev1alpha1.ClusterHealthy); got != tt.want {
+				t.Errorf("shouldRecordGateRun = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcile_RecordsGateRun(t *testing.T) {
+	gc := &clustergatev1alpha1.GateCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "api"},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			Category:  "apps",
+			HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{URL: "http://api.example/healthz"},
+		},
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", UID: "prod-uid"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Checks:   []clustergatev1alpha1.CheckSpec{{GateCheckRef: "api"}},
+			GateRuns: &clustergatev1alpha1.GateRunPolicy{Record: clustergatev1alpha1.GateRunRecordEveryCycle},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc, cr).
+		WithStatusSubresource(cr, &clustergatev1alpha1.GateRun{}).Build()
+	r := &ClusterReadinessReconciler{
+		Client:          c,
+		ReadinessState:  server.NewReadinessState(),
+		DynamicExecutor: stubExecutor{result: checks.Result{Ready: true, Message: "ok"}},
+	}
+
+	t.Cleanup(func() { metrics.DeleteClusterReadiness("prod") })
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "prod"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var runs clustergatev1alpha1.GateRunList
+	if err := c.List(context.Background(), &runs); err != nil {
+		t.Fatal(err)
+	}
+	if len(runs.Items) != 1 {
+		t.Fatalf("got %d GateRuns, want 1", len(runs.Items))
+	}
+	run := runs.Items[0]
+	if run.Spec.ClusterReadiness != "prod" || run.Spec.Trigger != clustergatev1alpha1.GateRunScheduled {
+		t.Errorf("spec = %+v, want scheduled run of prod", run.Spec)
+	}
+	if len(run.OwnerReferences) != 1 || run.OwnerReferences[0].Name != "prod" {
+		t.Errorf("ownerReferences = %+v, want owned by prod", run.OwnerReferences)
+	}
+	if run.Status.RecordedAt == nil || run.Status.OperatorVersion != version.Version || run.Status.State != clustergatev1alpha1.ClusterHealthy {
+		t.Errorf("status = %+v, want recorded Healthy run", run.Status)
+	}
+	if len(run.Status.Checks) != 1 || run.Status.Checks[0].Name != "dynamic:api" || run.Status.Checks[0].Category != "apps" ||
+		run.Status.Checks[0].Duration == nil || !run.Status.Checks[0].Executed {
+		t.Errorf("checks = %+v, want dynamic:api executed in apps with a duration", run.Status.Checks)
+	}
+
+	// A cycle that only carries results forward is not recorded.
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "prod"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.List(context.Background(), &runs); err != nil {
+		t.Fatal(err)
+	}
+	if len(runs.Items) != 1 {
+		t.Errorf("got %d GateRuns after a carried cycle, want 1", len(runs.Items))
+	}
+}
+
+func TestPruneGateRuns(t *testing.T) {
+	now := time.Now()
+	run := func(name, cr string, age time.Duration) *clustergatev1alpha1.GateRun {
+		return &clustergatev1alpha1.GateRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(now.Add(-age))},
+			Spec:       clustergatev1alpha1.GateRunSpec{ClusterReadiness: cr},
+		}
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			GateRuns: &clustergatev1alpha1.GateRunPolicy{
+				Retain: 2,
+				MaxAge: &metav1.Duration{Duration: 24 * time.Hour},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(
+		run("newest", "prod", time.Minute),
+		run("expired", "prod", 48*time.Hour),
+		run("second", "prod", time.Hour),
+		run("third", "prod", 2*time.Hour),
+		run("other", "staging", 72*time.Hour),
+	).Build()
+	r := &ClusterReadinessReconciler{Client: c}
+
+	if err := r.pruneGateRuns(context.Background(), cr, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var runs clustergatev1alpha1.GateRunList
+	if err := c.List(context.Background(), &runs); err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]bool)
+	for _, r := range runs.Items {
+		got[r.Name] = true
+	}
+	want := map[string]bool{"newest": true, "second": true, "other": true}
+	if len(got) != len(want) {
+		t.Fatalf("remaining GateRuns = %v, want %v", got, want)
+	}
+	for name := range want {
+		if !got[name] {
+			t.Errorf("GateRun %s was deleted, remaining %v", name, got)
+		}
+	}
+}
+
+func TestGateRunReconcile_OnDemand(t *testing.T) {
+	lastChecked := metav1.Now()
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Status: clustergatev1alpha1.ClusterReadinessStatus{
+			State:       clustergatev1alpha1.ClusterDegraded,
+			LastChecked: &lastChecked,
+			Summary:     &clustergatev1alpha1.ReadinessSummary{Total: 2, Passing: 1, Failing: 1},
+			Categories: []clustergatev1alpha1.CategoryStatus{
+				{Category: "apps", Checks: []clustergatev1alpha1.CheckStatus{{Name: "api", Status: "Passing", LastChecked: &lastChecked}}},
+				{Category: "storage", Checks: []clustergatev1alpha1.CheckStatus{{Name: "backups", Status: "Failing"}}},
+			},
+		},
+	}
+	pending := &clustergatev1alpha1.ClusterReadiness{ObjectMeta: metav1.ObjectMeta{Name: "new"}}
+	onDemand := func(name, target string) *clustergatev1alpha1.GateRun {
+		return &clustergatev1alpha1.GateRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       clustergatev1alpha1.GateRunSpec{ClusterReadiness: target, Trigger: clustergatev1alpha1.GateRunOnDemand},
+		}
+	}
+	runs := []*clustergatev1alpha1.GateRun{
+		onDemand("release-42", "prod"),
+		onDemand("missing", "gone"),
+		onDemand("waiting", "new"),
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).
+		WithObjects(cr, pending, runs[0], runs[1], runs[2]).
+		WithStatusSubresource(runs[0], runs[1], runs[2]).Build()
+	r := &GateRunReconciler{Client: c}
+
+	reconcile := func(name string) (ctrl.Result, clustergatev1alpha1.GateRun) {
+		t.Helper()
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got clustergatev1alpha1.GateRun
+		if err := c.Get(context.Background(), types.NamespacedName{Name: name}, &got); err != nil {
+			t.Fatal(err)
+		}
+		return result, got
+	}
+
+	_, got := reconcile("release-42")
+	if got.Status.RecordedAt == nil || got.Status.State != clustergatev1alpha1.ClusterDegraded || len(got.Status.Checks) != 2 {
+		t.Errorf("status = %+v, want Degraded evidence with 2 checks", got.Status)
+	}
+	if got.Status.Checks[1].Name != "backups" || got.Status.Checks[1].Category != "storage" {
+		t.Errorf("checks = %+v, want backups recorded under storage", got.Status.Checks)
+	}
+	if !got.Status.Checks[0].Executed || got.Status.Checks[1].Executed {
+		t.Errorf("checks = %+v, want only api executed in the recorded cycle", got.Status.Checks)
+	}
+
+	// Recorded evidence is never rewritten.
+	recordedAt := got.Status.RecordedAt
+	_, got = reconcile("release-42")
+	if !got.Status.RecordedAt.Equal(recordedAt) {
+		t.Error("recorded GateRun was rewritten")
+	}
+
+	_, got = reconcile("missing")
+	if got.Status.RecordedAt == nil || got.Status.Message == "" || len(got.Status.Checks) != 0 {
+		t.Errorf("status = %+v, want recorded message for a missing ClusterReadiness", got.Status)
+	}
+
+	result, got := reconcile("waiting")
+	if got.Status.RecordedAt != nil || result.RequeueAfter == 0 {
+		t.Errorf("status = %+v, result = %+v, want requeue until the first evaluation", got.Status, result)
+	}
+}
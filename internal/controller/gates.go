@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+// gateConditionPrefix prefixes the type of the condition reporting each gate's
+// readiness, followed by the gate's name.
+const gateConditionPrefix = "GateReady-"
+
+// gateKey is the gate readyz state key of gate in the ClusterReadiness named name.
+func gateKey(gate, name string) string {
+	return gate + "/" + name
+}
+
+// reconcileGates evaluates cr's gates from eval, the evaluation of all its
+// checks, records them in cr's status and conditions, and publishes them to
+// the gate readyz state. Gates no longer in the spec are cleaned up.
+func (r *ClusterReadinessReconciler) reconcileGates(cr *clustergatev1alpha1.ClusterReadiness, eval evaluation, now metav1.Time) {
+	defined := make(map[string]bool, len(cr.Spec.Gates))
+	var statuses []clustergatev1alpha1.GateStatus
+	for _, gate := range cr.Spec.Gates {
+		defined[gate.Name] = true
+		gateEval := evaluateGate(gate, eval, cr.Spec.DegradedThreshold, &now)
+		statuses = append(statuses, clustergatev1alpha1.GateStatus{
+			Name:    gate.Name,
+			State:   gateEval.state,
+			Ready:   gateEval.ready(),
+			Summary: gateEval.summary,
+		})
+		meta.SetStatusCondition(&cr.Status.Conditions, gateCondition(gate.Name, gateEval))
+		if r.GateReadinessState != nil {
+			gateEval.updateState(r.GateReadinessState, gateKey(gate.Name, cr.Name))
+		}
+	}
+
+	for _, gs := range cr.Status.Gates {
+		if !defined[gs.Name] && r.GateReadinessState != nil {
+			r.GateReadinessState.Remove(gateKey(gs.Name, cr.Name))
+		}
+	}
+	for _, cond := range slices.Clone(cr.Status.Conditions) {
+		if name, ok := strings.CutPrefix(cond.Type, gateConditionPrefix); ok && !defined[name] {
+			meta.RemoveStatusCondition(&cr.Status.Conditions, cond.Type)
+		}
+	}
+	cr.Status.Gates = statuses
+}
+
+// evaluateGate evaluates the checks of eval that belong to gate, under the
+// gate's own required categories and readiness policy.
+func evaluateGate(gate clustergatev1alpha1.ReadinessGate, eval evaluation, degradedThreshold *intstr.IntOrString, now *metav1.Time) evaluation {
+	statuses, categoryLookup, _ := flattenCategories(eval.categories)
+	var selected []clustergatev1alpha1.CheckStatus
+	for _, cs := range statuses {
+		if inGate(gate, cs.Name, categoryLookup[cs.Name]) {
+			selected = append(selected, cs)
+		}
+	}
+	gateEval := evaluate(nil, selected, categoryLookup, degradedThreshold, eval.initializing, now)
+	gateEval.requireCategories(gate.RequiredCategories)
+	gateEval.applyPolicy(gate.ReadinessPolicy)
+	return gateEval
+}
+
+// inGate reports whether the check named name in category belongs to gate: it
+// is in one of the gate's categories or matches one of its check patterns, or
+// the gate selects neither.
+func inGate(gate clustergatev1alpha1.ReadinessGate, name, category string) bool {
+	if len(gate.Categories) == 0 && len(gate.Checks) == 0 {
+		return true
+	}
+	if slices.Contains(gate.Categories, category) {
+		return true
+	}
+	for _, pattern := range gate.Checks {
+		if matched, err := path.Match(pattern, name); matched || (err != nil && pattern == name) {
+			return true
+		}
+	}
+	return false
+}
+
+// gateCondition reports the readiness of the gate named name.
+func gateCondition(name string, eval evaluation) metav1.Condition {
+	status := metav1.ConditionFalse
+	if eval.ready() {
+		status = metav1.ConditionTrue
+	}
+	return metav1.Condition{
+		Type:    gateConditionPrefix + name,
+		Status:  status,
+		Reason:  string(eval.state),
+		Message: fmt.Sprintf("%d/%d checks passing", eval.summary.Passing, eval.summary.Total),
+	}
+}
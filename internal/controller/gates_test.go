@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+	"github.com/clustergate/clustergate/internal/server"
+)
+
+func TestReconcileGates(t *testing.T) {
+	now := metav1.Now()
+	eval := evaluate([]checkResult{
+		{name: "etcd", severity: "critical", category: "control-plane", result: checks.Result{Ready: true}},
+		{name: "dynamic:ingress-lb", severity: "critical", category: "networking", result: checks.Result{Ready: false}},
+		{name: "cert", severity: "warning", category: "security", result: checks.Result{Ready: false}},
+	}, nil, nil, nil, false, &now)
+
+	state := server.NewReadinessState()
+	state.Update(gateKey("removed", "prod"), "Healthy", nil, nil, nil)
+	r := &ClusterReadinessReconciler{GateReadinessState: state}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Gates: []clustergatev1alpha1.ReadinessGate{
+				{Name: "workloads", Categories: []string{"control-plane"}},
+				{Name: "ingress-traffic", Checks: []string{"dynamic:ingress-*"}},
+				{Name: "strict", Categories: []string{"control-plane", "security"}, ReadinessPolicy: &clustergatev1alpha1.ReadinessPolicy{BlockOnWarnings: true}},
+				{Name: "all"},
+			},
+		},
+		Status: clustergatev1alpha1.ClusterReadinessStatus{
+			Gates:      []clustergatev1alpha1.GateStatus{{Name: "removed", State: clustergatev1alpha1.ClusterHealthy, Ready: true}},
+			Conditions: []metav1.Condition{{Type: gateConditionPrefix + "removed", Status: metav1.ConditionTrue, Reason: "Healthy"}},
+		},
+	}
+
+	r.reconcileGates(cr, eval, now)
+
+	want := map[string]struct {
+		state clustergatev1alpha1.ClusterHealthState
+		ready bool
+		total int
+	}{
+		"workloads":       {state: clustergatev1alpha1.ClusterHealthy, ready: true, total: 1},
+		"ingress-traffic": {state: clustergatev1alpha1.ClusterUnhealthy, total: 1},
+		"strict":          {state: clustergatev1alpha1.ClusterUnhealthy, total: 2},
+		"all":             {state: clustergatev1alpha1.ClusterUnhealthy, total: 3},
+	}
+	if len(cr.Status.Gates) != len(want) {
+		t.Fatalf("gates = %+v, want %d", cr.Status.Gates, len(want))
+	}
+	for _, gs := range cr.Status.Gates {
+		w := want[gs.Name]
+		if gs.State != w.state || gs.Ready != w.ready || gs.Summary.Total != w.total {
+			t.Errorf("gate %s = {%s %v %d}, want {%s %v %d}", gs.Name, gs.State, gs.Ready, gs.Summary.Total, w.state, w.ready, w.total)
+		}
+		cond := meta.FindStatusCondition(cr.Status.Conditions, gateConditionPrefix+gs.Name)
+		if cond == nil || (cond.Status == metav1.ConditionTrue) != w.ready {
+			t.Errorf("gate %s condition = %+v, want ready %v", gs.Name, cond, w.ready)
+		}
+	}
+	if meta.FindStatusCondition(cr.Status.Conditions, gateConditionPrefix+"removed") != nil {
+		t.Error("expected the removed gate's condition to be deleted")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/readyz/{gate}", server.GateReadyzHandler(state))
+	gateReady := func(gate string) bool {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz/"+gate, nil))
+		return rec.Code == http.StatusOK
+	}
+	for gate, w := range want {
+		if got := gateReady(gate); got != w.ready {
+			t.Errorf("/readyz/%s ready = %v, want %v", gate, got, w.ready)
+		}
+	}
+	if gateReady("removed") {
+		t.Error("expected the removed gate's readyz state to be deleted")
+	}
+}
+
+func TestInGate(t *testing.T) {
+	tests := []struct {
+		name     string
+		gate     clustergatev1alpha1.ReadinessGate
+		check    string
+		category string
+		want     bool
+	}{
+		{name: "no selectors", gate: clustergatev1alpha1.ReadinessGate{}, check: "dns", category: "networking", want: true},
+		{name: "category", gate: clustergatev1alpha1.ReadinessGate{Categories: []string{"networking"}}, check: "dns", category: "networking", want: true},
+		{name: "other category", gate: clustergatev1alpha1.ReadinessGate{Categories: []string{"storage"}}, check: "dns", category: "networking"},
+		{name: "check pattern", gate: clustergatev1alpha1.ReadinessGate{Checks: []string{"dynamic:batch-*"}}, check: "dynamic:batch-queue", category: "workloads", want: true},
+		{name: "malformed pattern matches literally", gate: clustergatev1alpha1.ReadinessGate{Checks: []string{"dns["}}, check: "dns[", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inGate(tt.gate, tt.check, tt.category); got != tt.want {
+				t.Errorf("inGate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
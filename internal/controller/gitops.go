@@ -0,0 +1,207 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+const (
+	// gitOpsFinalizer holds a ClusterReadiness with a GitOps suspension
+	// action until the objects it paused are resumed.
+	gitOpsFinalizer = "clustergate.io/gitops-suspend"
+
+	// suspendedByAnnotation names the ClusterReadiness that paused a GitOps
+	// object.
+	suspendedByAnnotation = "clustergate.io/suspended-by"
+)
+
+// gitOpsGVKs maps each GitOps kind to the API version it is managed at.
+var gitOpsGVKs = map[clustergatev1alpha1.GitOpsKind]schema.GroupVersionKind{
+	clustergatev1alpha1.GitOpsKustomization: {Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Kustomization"},
+	clustergatev1alpha1.GitOpsHelmRelease:   {Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"},
+	clustergatev1alpha1.GitOpsAppProject:    {Group: "argoproj.io", Version: "v1alpha1", Kind: "AppProject"},
+}
+
+// denySyncWindow returns the Argo CD sync window added to paused AppProjects:
+// it denies automated syncs of every application at all times, and leaves
+// manual syncs possible for remediation.
+func denySyncWindow() map[string]any {
+	return map[string]any{
+		"kind":         "deny",
+		"schedule":     "* * * * *",
+		"duration":     "1h",
+		"applications": []any{"*"},
+		"manualSync":   true,
+	}
+}
+
+// gitOpsTargets returns the targets of cr's GitOps suspension action, with
+// their namespaces resolved.
+func (r *ClusterReadinessReconciler) gitOpsTargets(cr *clustergatev1alpha1.ClusterReadiness) []clustergatev1alpha1.GitOpsReference {
+	if cr.Spec.Actions == nil || cr.Spec.Actions.SuspendGitOps == nil {
+		return nil
+	}
+	var targets []clustergatev1alpha1.GitOpsReference
+	for _, ref := range cr.Spec.Actions.SuspendGitOps.Targets {
+		if ref.Namespace == "" {
+			ref.Namespace = "flux-system"
+			if ref.Kind == clustergatev1alpha1.GitOpsAppProject {
+				ref.Namespace = "argocd"
+			}
+		}
+		targets = append(targets, ref)
+	}
+	return targets
+}
+
+// reconcileGitOps pauses cr's GitOps targets while state is Unhealthy, and
+// resumes the objects it paused once it is Healthy or Degraded or they are
+// no longer targeted. While state is Initializing, within the initial delay
+// after the operator restarted, whether the cluster is unhealthy is not known
+// yet, so the targets it holds paused stay paused and no others are paused.
+// Objects paused by someone else are left alone. The objects it holds paused
+// are recorded in cr's status.
+func (r *ClusterReadinessReconciler) reconcileGitOps(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, state clustergatev1alpha1.ClusterHealthState) error {
+	var desired []clustergatev1alpha1.GitOpsReference
+	switch state {
+	case clustergatev1alpha1.ClusterUnhealthy:
+		desired = r.gitOpsTargets(cr)
+	case clustergatev1alpha1.ClusterInitializing:
+		for _, ref := range r.gitOpsTargets(cr) {
+			if slices.Contains(cr.Status.SuspendedGitOps, ref) {
+				desired = append(desired, ref)
+			}
+		}
+	}
+
+	var errs []error
+	var paused []clustergatev1alpha1.GitOpsReference
+	for _, ref := range desired {
+		if slices.Contains(cr.Status.SuspendedGitOps, ref) {
+			paused = append(paused, ref)
+			continue
+		}
+		ok, err := r.pauseGitOpsObject(ctx, cr, ref)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if ok {
+			paused = append(paused, ref)
+		}
+	}
+	for _, ref := range cr.Status.SuspendedGitOps {
+		if slices.Contains(desired, ref) {
+			continue
+		}
+		if err := r.resumeGitOpsObject(ctx, cr, ref); err != nil {
+			// Keep it recorded so resuming it is retried.
+			errs = append(errs, err)
+			paused = append(paused, ref)
+		}
+	}
+	cr.Status.SuspendedGitOps = paused
+	return errors.Join(errs...)
+}
+
+// resumeGitOps resumes every GitOps object cr holds paused.
+func (r *ClusterReadinessReconciler) resumeGitOps(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness) error {
+	var errs []error
+	for _, ref := range cr.Status.SuspendedGitOps {
+		errs = append(errs, r.resumeGitOpsObject(ctx, cr, ref))
+	}
+	return errors.Join(errs...)
+}
+
+// pauseGitOpsObject suspends the Flux object or adds the deny sync window to
+// the AppProject ref names, and reports whether cr now holds it paused.
+func (r *ClusterReadinessReconciler) pauseGitOpsObject(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, ref clustergatev1alpha1.GitOpsReference) (bool, error) {
+	obj, err := r.getGitOpsObject(ctx, ref)
+	if err != nil {
+		return false, err
+	}
+	if by := obj.GetAnnotations()[suspendedByAnnotation]; by != "" {
+		// Paused earlier by cr, whose status lost track of it, or by another
+		// ClusterReadiness.
+		return by == cr.Name, nil
+	}
+
+	base := obj.DeepCopy()
+	if ref.Kind == clustergatev1alpha1.GitOpsAppProject {
+		windows, _, _ := unstructured.NestedSlice(obj.Object, "spec", "syncWindows")
+		if err := unstructured.SetNestedSlice(obj.Object, append(windows, denySyncWindow()), "spec", "syncWindows"); err != nil {
+			return false, err
+		}
+	} else {
+		if suspended, _, _ := unstructured.NestedBool(obj.Object, "spec", "suspend"); suspended {
+			return false, nil
+		}
+		if err := unstructured.SetNestedField(obj.Object, true, "spec", "suspend"); err != nil {
+			return false, err
+		}
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[suspendedByAnnotation] = cr.Name
+	obj.SetAnnotations(annotations)
+	if err := r.Patch(ctx, obj, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})); err != nil {
+		return false, fmt.Errorf("failed to pause %s %s/%s: %w", ref.Kind, ref.Namespace, ref.Name, err)
+	}
+	log.FromContext(ctx).Info("paused GitOps object", "kind", ref.Kind, "namespace", ref.Namespace, "name", ref.Name)
+	return true, nil
+}
+
+// resumeGitOpsObject undoes pauseGitOpsObject, unless the object is gone or
+// no longer paused by cr.
+func (r *ClusterReadinessReconciler) resumeGitOpsObject(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, ref clustergatev1alpha1.GitOpsReference) error {
+	obj, err := r.getGitOpsObject(ctx, ref)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if obj.GetAnnotations()[suspendedByAnnotation] != cr.Name {
+		return nil
+	}
+
+	base := obj.DeepCopy()
+	if ref.Kind == clustergatev1alpha1.GitOpsAppProject {
+		windows, _, _ := unstructured.NestedSlice(obj.Object, "spec", "syncWindows")
+		windows = slices.DeleteFunc(windows, func(w any) bool {
+			return equality.Semantic.DeepEqual(w, any(denySyncWindow()))
+		})
+		if err := unstructured.SetNestedSlice(obj.Object, windows, "spec", "syncWindows"); err != nil {
+			return err
+		}
+	} else {
+		unstructured.RemoveNestedField(obj.Object, "spec", "suspend")
+	}
+	annotations := obj.GetAnnotations()
+	delete(annotations, suspendedByAnnotation)
+	obj.SetAnnotations(annotations)
+	if err := r.Patch(ctx, obj, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})); err != nil {
+		return fmt.Errorf("failed to resume %s %s/%s: %w", ref.Kind, ref.Namespace, ref.Name, err)
+	}
+	log.FromContext(ctx).Info("resumed GitOps object", "kind", ref.Kind, "namespace", ref.Namespace, "name", ref.Name)
+	return nil
+}
+
+// getGitOpsObject fetches the object ref names.
+func (r *ClusterReadinessReconciler) getGitOpsObject(ctx context.Context, ref clustergatev1alpha1.GitOpsReference) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gitOpsGVKs[ref.Kind])
+	if err := r.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, obj); err != nil {
+		return nil, fmt.Errorf("failed to get %s %s/%s: %w", ref.Kind, ref.Namespace, ref.Name, err)
+	}
+	return obj, nil
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+// gitOpsObject returns an unstructured GitOps object of kind with spec.
+func gitOpsObject(kind clustergatev1alpha1.GitOpsKind, namespace, name string, spec map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	obj.SetGroupVersionKind(gitOpsGVKs[kind])
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func TestReconcileGitOps(t *testing.T) {
+	apps := gitOpsObject(clustergatev1alpha1.GitOpsKustomization, "flux-system", "apps", map[string]any{"interval": "10m"})
+	manual := gitOpsObject(clustergatev1alpha1.GitOpsHelmRelease, "flux-system", "ingress", map[string]any{"suspend": true})
+	existingWindow := map[string]any{"kind": "allow", "schedule": "0 9 * * 1-5", "duration": "8h", "applications": []any{"*"}}
+	project := gitOpsObject(clustergatev1alpha1.GitOpsAppProject, "argocd", "platform", map[string]any{"syncWindows": []any{existingWindow}})
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(apps, manual, project).Build()
+	r := &ClusterReadinessReconciler{Client: c}
+	ctx := context.Background()
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Actions: &clustergatev1alpha1.Actions{
+				SuspendGitOps: &clustergatev1alpha1.SuspendGitOpsAction{
+					Targets: []clustergatev1alpha1.GitOpsReference{
+						{Kind: clustergatev1alpha1.GitOpsKustomization, Name: "apps"},
+						{Kind: clustergatev1alpha1.GitOpsHelmRelease, Name: "ingress"},
+						{Kind: clustergatev1alpha1.GitOpsAppProject, Name: "platform"},
+						{Kind: clustergatev1alpha1.GitOpsKustomization, Name: "missing"},
+					},
+				},
+			},
+		},
+	}
+	get := func(obj *unstructured.Unstructured) *unstructured.Unstructured {
+		t.Helper()
+		got := &unstructured.Unstructured{}
+		got.SetGroupVersionKind(obj.GroupVersionKind())
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), got); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	if err := r.reconcileGitOps(ctx, cr, clustergatev1alpha1.ClusterUnhealthy); err == nil {
+		t.Error("expected an error for the missing Kustomization")
+	}
+	if suspended, _, _ := unstructured.NestedBool(get(apps).Object, "spec", "suspend"); !suspended {
+		t.Error("Kustomization apps was not suspended")
+	}
+	if by := get(manual).GetAnnotations()[suspendedByAnnotation]; by != "" {
+		t.Errorf("HelmRelease suspended by hand was annotated as paused by %q", by)
+	}
+	windows, _, _ := unstructured.NestedSlice(get(project).Object, "spec", "syncWindows")
+	if len(windows) != 2 || windows[1].(map[string]any)["kind"] != "deny" {
+		t.Errorf("sync windows = %v, want the deny window added", windows)
+	}
+	want := []clustergatev1alpha1.GitOpsReference{
+		{Kind: clustergatev1alpha1.GitOpsKustomization, Name: "apps", Namespace: "flux-system"},
+		{Kind: clustergatev1alpha1.GitOpsAppProject, Name: "platform", Namespace: "argocd"},
+	}
+	if len(cr.Status.SuspendedGitOps) != 2 || cr.Status.SuspendedGitOps[0] != want[0] || cr.Status.SuspendedGitOps[1] != want[1] {
+		t.Errorf("status.suspendedGitOps = %+v, want %+v", cr.Status.SuspendedGitOps, want)
+	}
+
+	// Within the initial delay after a restart, what was paused stays paused
+	// and nothing else is paused.
+	apps2 := gitOpsObject(clustergatev1alpha1.GitOpsKustomization, "flux-system", "apps2", map[string]any{"interval": "10m"})
+	if err := c.Create(ctx, apps2); err != nil {
+		t.Fatal(err)
+	}
+	cr.Spec.Actions.SuspendGitOps.Targets = append(cr.Spec.Actions.SuspendGitOps.Targets,
+		clustergatev1alpha1.GitOpsReference{Kind: clustergatev1alpha1.GitOpsKustomization, Name: "apps2"})
+	if err := r.reconcileGitOps(ctx, cr, clustergatev1alpha1.ClusterInitializing); err != nil {
+		t.Fatal(err)
+	}
+	if suspended, _, _ := unstructured.NestedBool(get(apps).Object, "spec", "suspend"); !suspended {
+		t.Error("Kustomization apps was resumed while Initializing")
+	}
+	if _, found, _ := unstructured.NestedBool(get(apps2).Object, "spec", "suspend"); found {
+		t.Error("Kustomization apps2 was suspended while Initializing")
+	}
+	if len(cr.Status.SuspendedGitOps) != 2 || cr.Status.SuspendedGitOps[0] != want[0] || cr.Status.SuspendedGitOps[1] != want[1] {
+		t.Errorf("status.suspendedGitOps = %+v, want %+v", cr.Status.SuspendedGitOps, want)
+	}
+
+	// Once Healthy, only what was paused is resumed.
+	if err := r.reconcileGitOps(ctx, cr, clustergatev1alpha1.ClusterHealthy); err != nil {
+		t.Fatal(err)
+	}
+	got := get(apps)
+	if _, found, _ := unstructured.NestedBool(got.Object, "spec", "suspend"); found || got.GetAnnotations()[suspendedByAnnotation] != "" {
+		t.Errorf("Kustomization apps = %v, want it resumed", got.Object)
+	}
+	if suspended, _, _ := unstructured.NestedBool(get(manual).Object, "spec", "suspend"); !suspended {
+		t.Error("HelmRelease suspended by hand was resumed")
+	}
+	windows, _, _ = unstructured.NestedSlice(get(project).Object, "spec", "syncWindows")
+	if len(windows) != 1 || windows[0].(map[string]any)["kind"] != "allow" {
+		t.Errorf("sync windows = %v, want only the existing window", windows)
+	}
+	if len(cr.Status.SuspendedGitOps) != 0 {
+		t.Errorf("status.suspendedGitOps = %+v, want none", cr.Status.SuspendedGitOps)
+	}
+}
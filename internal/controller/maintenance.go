@@ -0,0 +1,341 @@
+package controller
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+// maxMaintenanceWindow bounds a window's duration, and so how far back
+// maintenanceWindowEnd searches for the window's start.
+const maxMaintenanceWindow = 7 * 24 * time.Hour
+
+// cronSchedule is a parsed five-field cron expression. Each field is a bitset
+// of the values it matches.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar record unrestricted day fields: when both day
+	// fields are restricted, a time matches if either does, as in cron.
+	domStar, dowStar bool
+}
+
+// cronFields are the bounds of each cron field, in order.
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day-of-month", 1, 31},
+	{"month", 1, 12},
+	{"day-of-week", 0, 7},
+}
+
+// parseCron parses a five-field cron expression supporting "*", values,
+// ranges, steps and lists. Day-of-week 7 is Sunday, like 0.
+func parseCron(expr string) (cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return cronSchedule{}, fmt.Errorf("cron expression %q must have %d fields, got %d", expr, len(cronFields), len(fields))
+	}
+	var sets [5]uint64
+	for i, f := range fields {
+		set, err := parseCronField(f, cronFields[i].min, cronFields[i].max)
+		if err != nil {
+			return cronSchedule{}, fmt.Errorf("cron %s field %q: %w", cronFields[i].name, f, err)
+		}
+		sets[i] = set
+	}
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+	return cronSchedule{
+		minute: sets[0], hour: sets[1], dom: sets[2], month: sets[3], dow: sets[4],
+		domStar: fields[2] == "*", dowStar: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField parses one comma-separated cron field into a bitset.
+func parseCronField(field string, lo, hi int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step %q", part[i+1:])
+			}
+			rangePart, step = part[:i], n
+		}
+
+		start, end := lo, hi
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid value %q", bounds[0])
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value %q", bounds[1])
+				}
+			} else if step > 1 {
+				end = hi
+			}
+		}
+		if start < lo || end > hi || start > end {
+			return 0, fmt.Errorf("range %d-%d outside %d-%d", start, end, lo, hi)
+		}
+		for v := start; v <= end; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// matches reports whether t, to the minute, is in the schedule.
+func (c cronSchedule) matches(t time.Time) bool {
+	return c.minute&(1<<t.Minute()) != 0 && c.hour&(1<<t.Hour()) != 0 && c.matchesDay(t)
+}
+
+// matchesDay reports whether the day of t is in the schedule.
+func (c cronSchedule) matchesDay(t time.Time) bool {
+	if c.month&(1<<int(t.Month())) == 0 {
+		return false
+	}
+	domMatch := c.dom&(1<<t.Day()) != 0
+	dowMatch := c.dow&(1<<int(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// next returns the first minute at or after t, in t's location, that is in
+// the schedule, or the zero time when there is none until limit. Rather than
+// testing every minute, it skips whole days, hours and minutes that cannot
+// match.
+func (c cronSchedule) next(t, limit time.Time) time.Time {
+	t = t.Truncate(time.Minute)
+	for !t.After(limit) {
+		var n time.Time
+		y, mo, d := t.Date()
+		switch {
+		case !c.matchesDay(t):
+			n = time.Date(y, mo, d+1, 0, 0, 0, 0, t.Location())
+		case c.hour&(1<<t.Hour()) == 0:
+			if h := nextBit(c.hour, t.Hour()); h >= 0 {
+				n = time.Date(y, mo, d, h, 0, 0, 0, t.Location())
+			} else {
+				n = time.Date(y, mo, d+1, 0, 0, 0, 0, t.Location())
+			}
+		case c.minute&(1<<t.Minute()) == 0:
+			if m := nextBit(c.minute, t.Minute()); m >= 0 {
+				n = t.Add(time.Duration(m-t.Minute()) * time.Minute)
+			} else {
+				n = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			}
+		default:
+			return t
+		}
+		// Wall clock times repeated when clocks go back may map earlier.
+		if !n.After(t) {
+			n = t.Add(time.Minute)
+		}
+		t = n
+	}
+	return time.Time{}
+}
+
+// prev returns the last minute at or before t, in t's location, that is in
+// the schedule, or the zero time when there is none back to limit.
+func (c cronSchedule) prev(t, limit time.Time) time.Time {
+	t = t.Truncate(time.Minute)
+	for !t.Before(limit) {
+		var n time.Time
+		y, mo, d := t.Date()
+		dayStart := time.Date(y, mo, d, 0, 0, 0, 0, t.Location())
+		switch {
+		case !c.matchesDay(t):
+			n = dayStart.Add(-time.Minute)
+		case c.hour&(1<<t.Hour()) == 0:
+			if h := prevBit(c.hour, t.Hour()); h >= 0 {
+				n = time.Date(y, mo, d, h, 59, 0, 0, t.Location())
+			} else {
+				n = dayStart.Add(-time.Minute)
+			}
+		case c.minute&(1<<t.Minute()) == 0:
+			if m := prevBit(c.minute, t.Minute()); m >= 0 {
+				n = t.Add(-time.Duration(t.Minute()-m) * time.Minute)
+			} else {
+				n = t.Add(-time.Duration(t.Minute()+1) * time.Minute)
+			}
+		default:
+			return t
+		}
+		// Wall clock times skipped when clocks go forward may map later.
+		if !n.Before(t) {
+			n = t.Add(-time.Minute)
+		}
+		t = n
+	}
+	return time.Time{}
+}
+
+// nextBit returns the lowest value above v in set, or -1.
+func nextBit(set uint64, v int) int {
+	if v >= 63 {
+		return -1
+	}
+	rest := set >> (v + 1)
+	if rest == 0 {
+		return -1
+	}
+	return v + 1 + bits.TrailingZeros64(rest)
+}
+
+// prevBit returns the highest value below v in set, or -1.
+func prevBit(set uint64, v int) int {
+	rest := set & (1<<v - 1)
+	if rest == 0 {
+		return -1
+	}
+	return 63 - bits.LeadingZeros64(rest)
+}
+
+// maintenanceWindowEnd returns when the occurrence of w open at now closes,
+// or the zero time when w is not open at now.
+func maintenanceWindowEnd(w clustergatev1alpha1.MaintenanceWindow, now time.Time) (time.Time, error) {
+	sched, loc, err := parseMaintenanceWindow(w)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// The latest start whose window covers now.
+	start := sched.prev(now.In(loc), now.Add(-w.Duration.Duration+time.Nanosecond))
+	if start.IsZero() {
+		return time.Time{}, nil
+	}
+	return start.Add(w.Duration.Duration).In(now.Location()), nil
+}
+
+// nextMaintenanceWindowStart returns the first start of w after now and
+// within horizon, or the zero time when there is none.
+func nextMaintenanceWindowStart(w clustergatev1alpha1.MaintenanceWindow, now time.Time, horizon time.Duration) (time.Time, error) {
+	sched, loc, err := parseMaintenanceWindow(w)
+	if err != nil {
+		return time.Time{}, err
+	}
+	start := sched.next(now.Truncate(time.Minute).Add(time.Minute).In(loc), now.Add(horizon))
+	if start.IsZero() {
+		return time.Time{}, nil
+	}
+	return start.In(now.Location()), nil
+}
+
+// locations caches the time zones of maintenance windows, which
+// time.LoadLocation would read from disk on every reconcile.
+var locations sync.Map
+
+// parseMaintenanceWindow validates w and returns its schedule and location.
+func parseMaintenanceWindow(w clustergatev1alpha1.MaintenanceWindow) (cronSchedule, *time.Location, error) {
+	if w.Duration.Duration <= 0 || w.Duration.Duration > maxMaintenanceWindow {
+		return cronSchedule{}, nil, fmt.Errorf("maintenance window duration %s must be positive and at most %s", w.Duration.Duration, maxMaintenanceWindow)
+	}
+	sched, err := parseCron(w.Schedule)
+	if err != nil {
+		return cronSchedule{}, nil, err
+	}
+	loc := time.UTC
+	if w.TimeZone != "" {
+		if cached, ok := locations.Load(w.TimeZone); ok {
+			return sched, cached.(*time.Location), nil
+		}
+		if loc, err = time.LoadLocation(w.TimeZone); err != nil {
+			return cronSchedule{}, nil, fmt.Errorf("maintenance window time zone: %w", err)
+		}
+		locations.Store(w.TimeZone, loc)
+	}
+	return sched, loc, nil
+}
+
+// suspension reports whether suspend or an open maintenance window suspends
+// execution at now, and until when; the zero time means until Suspend is
+// cleared. Invalid windows are skipped and returned as err.
+func suspension(suspend bool, windows []clustergatev1alpha1.MaintenanceWindow, now time.Time) (suspended bool, until time.Time, err error) {
+	if suspend {
+		return true, time.Time{}, nil
+	}
+	var errs []string
+	for _, w := range windows {
+		end, werr := maintenanceWindowEnd(w, now)
+		if werr != nil {
+			errs = append(errs, werr.Error())
+			continue
+		}
+		if end.After(until) {
+			suspended, until = true, end
+		}
+	}
+	if len(errs) > 0 {
+		err = fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return suspended, until, err
+}
+
+// nextSuspension returns how long until one of windows next opens, if that
+// is within horizon, or horizon otherwise.
+func nextSuspension(windows []clustergatev1alpha1.MaintenanceWindow, now time.Time, horizon time.Duration) time.Duration {
+	next := horizon
+	for _, w := range windows {
+		start, err := nextMaintenanceWindowStart(w, now, horizon)
+		if err == nil && !start.IsZero() && start.Sub(now) < next {
+			next = start.Sub(now)
+		}
+	}
+	return next
+}
+
+// suspendedMessage describes a suspension lasting until, the zero time
+// meaning until Suspend is cleared.
+func suspendedMessage(until time.Time) string {
+	if until.IsZero() {
+		return "suspended"
+	}
+	return "suspended for maintenance until " + until.UTC().Format(time.RFC3339)
+}
+
+// splitSuspended separates the checks suspended at now from those to run,
+// recording when each suspension ends. It also returns how long, within
+// horizon, until a check's suspension next starts or ends.
+func splitSuspended(resolved []ResolvedCheck, now time.Time, horizon time.Duration) (active []ResolvedCheck, suspended map[string]time.Time, change time.Duration, err error) {
+	change = horizon
+	var errs []string
+	for _, rc := range resolved {
+		isSuspended, until, serr := suspension(rc.Suspend, rc.MaintenanceWindows, now)
+		if serr != nil {
+			errs = append(errs, fmt.Sprintf("check %s: %v", rc.Identifier, serr))
+		}
+		if !isSuspended {
+			active = append(active, rc)
+			change = min(change, nextSuspension(rc.MaintenanceWindows, now, horizon))
+			continue
+		}
+		if suspended == nil {
+			suspended = make(map[string]time.Time)
+		}
+		suspended[rc.Identifier] = until
+		if !until.IsZero() {
+			change = min(change, until.Sub(now))
+		}
+	}
+	if len(errs) > 0 {
+		err = fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return active, suspended, change, err
+}
@@ -0,0 +1,248 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+	"github.com/clustergate/clustergate/internal/metrics"
+	"github.com/clustergate/clustergate/internal/server"
+)
+
+func TestParseCron(t *testing.T) {
+	// 2025-01-04 is a Saturday.
+	sat0200 := time.Date(2025, 1, 4, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr    string
+		t       time.Time
+		want    bool
+		wantErr bool
+	}{
+		{expr: "0 2 * * 6", t: sat0200, want: true},
+		{expr: "0 2 * * 6", t: sat0200.Add(time.Minute), want: false},
+		{expr: "0 2 * * 0", t: sat0200, want: false},
+		{expr: "0 2 * * 7", t: sat0200.AddDate(0, 0, 1), want: true},
+		{expr: "*/15 * * * *", t: sat0200.Add(45 * time.Minute), want: true},
+		{expr: "*/15 * * * *", t: sat0200.Add(50 * time.Minute), want: false},
+		{expr: "0 1-3 * * 1-5", t: sat0200, want: false},
+		{expr: "0 1,2 4 * *", t: sat0200, want: true},
+		// Both day fields restricted: either matches.
+		{expr: "0 2 1 * 6", t: sat0200, want: true},
+		{expr: "0 2 * 2 *", t: sat0200, want: false},
+		{expr: "0 2 * *", wantErr: true},
+		{expr: "60 2 * * *", wantErr: true},
+		{expr: "0 2 * * 1-x", wantErr: true},
+		{expr: "*/0 2 * * *", wantErr: true},
+	}
+	for _, tt := range tests {
+		sched, err := parseCron(tt.expr)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseCron(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			continue
+		}
+		if err == nil && sched.matches(tt.t) != tt.want {
+			t.Errorf("%q matches %s = %v, want %v", tt.expr, tt.t, !tt.want, tt.want)
+		}
+	}
+}
+
+func TestCronScheduleNextPrev(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// scan finds the first match from t stepping by step, as a reference.
+	scan := func(sched cronSchedule, t, limit time.Time, step time.Duration) time.Time {
+		for ; (step > 0 && !t.After(limit)) || (step < 0 && !t.Before(limit)); t = t.Add(step) {
+			if sched.matches(t) {
+				return t
+			}
+		}
+		return time.Time{}
+	}
+
+	// Spans the switch to daylight saving time in Berlin on 2025-03-30.
+	from := time.Date(2025, 3, 27, 0, 0, 0, 0, time.UTC)
+	for _, expr := range []string{"0 2 * * 6", "*/20 2-3 * * *", "30 1 29-31 * 0", "15 */5 * 3 *", "0 0 1 1 *", "59 23 * * 1-5"} {
+		sched, err := parseCron(expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, loc := range []*time.Location{time.UTC, berlin} {
+			for offset := time.Duration(0); offset < 6*24*time.Hour; offset += 97 * time.Minute {
+				now := from.Add(offset).In(loc)
+				limit := 3 * 24 * time.Hour
+				if got, want := sched.next(now, now.Add(limit)), scan(sched, now, now.Add(limit), time.Minute); !got.Equal(want) {
+					t.Errorf("%q next from %s = %s, want %s", expr, now, got, want)
+				}
+				if got, want := sched.prev(now, now.Add(-limit)), scan(sched, now, now.Add(-limit), -time.Minute); !got.Equal(want) {
+					t.Errorf("%q prev from %s = %s, want %s", expr, now, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSuspension(t *testing.T) {
+	window := clustergatev1alpha1.MaintenanceWindow{Schedule: "0 2 * * 6", Duration: metav1.Duration{Duration: 2 * time.Hour}}
+	sat0200 := time.Date(2025, 1, 4, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		suspend   bool
+		windows   []clustergatev1alpha1.MaintenanceWindow
+		now       time.Time
+		want      bool
+		wantUntil time.Time
+		wantErr   bool
+	}{
+		{name: "suspend", suspend: true, now: sat0200, want: true},
+		{name: "before window", windows: []clustergatev1alpha1.MaintenanceWindow{window}, now: sat0200.Add(-time.Minute)},
+		{name: "in window", windows: []clustergatev1alpha1.MaintenanceWindow{window}, now: sat0200.Add(90 * time.Minute), want: true, wantUntil: sat0200.Add(2 * time.Hour)},
+		{name: "after window", windows: []clustergatev1alpha1.MaintenanceWindow{window}, now: sat0200.Add(2 * time.Hour)},
+		{
+			name: "time zone",
+			windows: []clustergatev1alpha1.MaintenanceWindow{{
+				Schedule: "0 3 * * 6", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Europe/Berlin",
+			}},
+			now: sat0200.Add(30 * time.Minute), want: true, wantUntil: sat0200.Add(time.Hour),
+		},
+		{
+			name:    "invalid window is skipped",
+			windows: []clustergatev1alpha1.MaintenanceWindow{{Schedule: "bad", Duration: metav1.Duration{Duration: time.Hour}}, window},
+			now:     sat0200, want: true, wantUntil: sat0200.Add(2 * time.Hour), wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, until, err := suspension(tt.suspend, tt.windows, tt.now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want || !until.Equal(tt.wantUntil) {
+				t.Errorf("suspension = %v until %s, want %v until %s", got, until, tt.want, tt.wantUntil)
+			}
+		})
+	}
+}
+
+func TestNextSuspension(t *testing.T) {
+	windows := []clustergatev1alpha1.MaintenanceWindow{{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}}}
+	now := time.Date(2025, 1, 4, 1, 58, 30, 0, time.UTC)
+
+	if got := nextSuspension(windows, now, 5*time.Minute); got != 90*time.Second {
+		t.Errorf("nextSuspension = %s, want 1m30s", got)
+	}
+	if got := nextSuspension(windows, now, time.Minute); got != time.Minute {
+		t.Errorf("nextSuspension beyond horizon = %s, want the 1m horizon", got)
+	}
+}
+
+func TestReconcile_Suspended(t *testing.T) {
+	lastChecked := metav1.NewTime(time.Now().Add(-time.Hour))
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Suspend: true,
+			Checks:  []clustergatev1alpha1.CheckSpec{{GateCheckRef: "ingress"}},
+		},
+		Status: clustergatev1alpha1.ClusterReadinessStatus{
+			State: clustergatev1alpha1.ClusterHealthy,
+			Categories: []clustergatev1alpha1.CategoryStatus{{
+				Category: "networking",
+				Checks: []clustergatev1alpha1.CheckStatus{{
+					Name: "dynamic:ingress", Status: "Passing", Severity: clustergatev1alpha1.SeverityCritical, LastChecked: &lastChecked,
+				}},
+			}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(cr).WithStatusSubresource(cr).Build()
+	state := server.NewReadinessState()
+	r := &ClusterReadinessReconciler{
+		Client:          c,
+		ReadinessState:  state,
+		DynamicExecutor: stubExecutor{result: checks.Result{Ready: false, Message: "should not run"}},
+	}
+
+	res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "prod"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.RequeueAfter != 0 {
+		t.Errorf("RequeueAfter = %s, want none while suspended", res.RequeueAfter)
+	}
+
+	var got clustergatev1alpha1.ClusterReadiness
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "prod"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.State != clustergatev1alpha1.ClusterHealthy {
+		t.Errorf("state = %q, want the frozen Healthy", got.Status.State)
+	}
+	cs := got.Status.Categories[0].Checks[0]
+	if cs.Status != "Passing" || cs.LastChecked.Unix() != lastChecked.Unix() {
+		t.Errorf("check = %+v, want it not run", cs)
+	}
+	if cond := meta.FindStatusCondition(got.Status.Conditions, "Suspended"); cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != "Suspend" {
+		t.Errorf("Suspended condition = %+v, want True/Suspend", cond)
+	}
+	if !state.IsReady() {
+		t.Error("expected the frozen state to be published to /readyz")
+	}
+}
+
+func TestReconcile_SuspendedCheck(t *testing.T) {
+	gc := &clustergatev1alpha1.GateCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress"},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			Severity: clustergatev1alpha1.SeverityCritical,
+			Category: "networking",
+		},
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Checks: []clustergatev1alpha1.CheckSpec{
+				{GateCheckRef: "ingress", MaintenanceWindows: []clustergatev1alpha1.MaintenanceWindow{
+					{Schedule: "* * * * *", Duration: metav1.Duration{Duration: time.Hour}},
+				}},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc, cr).WithStatusSubresource(cr).Build()
+	r := &ClusterReadinessReconciler{
+		Client:          c,
+		ReadinessState:  server.NewReadinessState(),
+		DynamicExecutor: stubExecutor{result: checks.Result{Ready: false, Message: "should not run"}},
+	}
+
+	t.Cleanup(func() { metrics.DeleteClusterReadiness("prod") })
+	res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "prod"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.RequeueAfter <= 0 {
+		t.Errorf("RequeueAfter = %s, want a requeue", res.RequeueAfter)
+	}
+
+	var got clustergatev1alpha1.ClusterReadiness
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "prod"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.State != clustergatev1alpha1.ClusterHealthy || got.Status.Summary.Total != 0 {
+		t.Errorf("state = %q with %d checks, want Healthy with the suspended check uncounted", got.Status.State, got.Status.Summary.Total)
+	}
+	cs := got.Status.Categories[0].Checks[0]
+	if cs.Status != "Suspended" || cs.Severity != clustergatev1alpha1.SeverityCritical {
+		t.Errorf("check = %+v, want a Suspended critical check", cs)
+	}
+}
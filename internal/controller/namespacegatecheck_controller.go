@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+// NamespaceGateCheckReconciler reconciles a NamespaceGateCheck object.
+// It validates the spec and updates status conditions.
+type NamespaceGateCheckReconciler struct {
+	client.Client
+}
+
+// +kubebuilder:rbac:groups=clustergate.io,resources=namespacegatechecks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=clustergate.io,resources=namespacegatechecks/status,verbs=get;update;patch
+
+func (r *NamespaceGateCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var check clustergatev1alpha1.NamespaceGateCheck
+	if err := r.Get(ctx, req.NamespacedName, &check); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	logger.V(1).Info("reconciling NamespaceGateCheck", "namespace", check.Namespace, "name", check.Name)
+
+	condition := validGateCheckCondition("NamespaceGateCheck", check.Spec)
+	if condition.Status == metav1.ConditionTrue {
+		// Kinds the API server does not serve yet may be installed later, and
+		// are rejected when the check runs if they are still unknown.
+		if _, err := ScopeToNamespace(check.Spec, check.Namespace, r.RESTMapper()); err != nil && !meta.IsNoMatchError(err) {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "UnsupportedCheckType"
+			condition.Message = err.Error()
+		}
+	}
+	condition.ObservedGeneration = check.Generation
+	meta.SetStatusCondition(&check.Status.Conditions, condition)
+
+	if err := r.Status().Update(ctx, &check); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NamespaceGateCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clustergatev1alpha1.NamespaceGateCheck{}).
+		Complete(r)
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+func TestNamespaceGateCheckReconcile(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       clustergatev1alpha1.GateCheckSpec
+		wantReason string
+	}{
+		{
+			name:       "pod check",
+			spec:       clustergatev1alpha1.GateCheckSpec{PodCheck: &clustergatev1alpha1.PodCheckSpec{Namespace: "shop", MinReady: 1}},
+			wantReason: "SpecValid",
+		},
+		{
+			name:       "no check type",
+			spec:       clustergatev1alpha1.GateCheckSpec{},
+			wantReason: "NoCheckType",
+		},
+		{
+			name: "cluster-scoped resource check",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+			},
+			wantReason: "UnsupportedCheckType",
+		},
+		{
+			name: "namespaced resource check",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{APIVersion: "apps/v1", Kind: "Deployment"},
+			},
+			wantReason: "SpecValid",
+		},
+		{
+			// The kind's CRD may be installed later; the check fails until it is.
+			name: "resource check of a kind not yet served",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{APIVersion: "example.com/v1", Kind: "Widget"},
+			},
+			wantReason: "SpecValid",
+		},
+		{
+			name:       "script check is not namespaced",
+			spec:       clustergatev1alpha1.GateCheckSpec{ScriptCheck: &clustergatev1alpha1.ScriptCheckSpec{Image: "busybox"}},
+			wantReason: "UnsupportedCheckType",
+		},
+		{
+			name: "http check of a URL",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{URL: "http://169.254.169.254/latest/meta-data/"},
+			},
+			wantReason: "UnsupportedCheckType",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := types.NamespacedName{Namespace: "shop", Name: "checkout"}
+			ngc := &clustergatev1alpha1.NamespaceGateCheck{
+				ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+				Spec:       tt.spec,
+			}
+			c := fake.NewClientBuilder().WithScheme(testScheme()).WithRESTMapper(testRESTMapper()).WithObjects(ngc).WithStatusSubresource(ngc).Build()
+			r := &NamespaceGateCheckReconciler{Client: c}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got clustergatev1alpha1.NamespaceGateCheck
+			if err := c.Get(context.Background(), key, &got); err != nil {
+				t.Fatal(err)
+			}
+			cond := meta.FindStatusCondition(got.Status.Conditions, "Valid")
+			if cond == nil || cond.Reason != tt.wantReason {
+				t.Errorf("Valid condition = %+v, want reason %s", cond, tt.wantReason)
+			}
+		})
+	}
+}
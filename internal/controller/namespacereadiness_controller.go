@@ -0,0 +1,355 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+	"github.com/clustergate/clustergate/internal/metrics"
+	"github.com/clustergate/clustergate/internal/server"
+)
+
+// NamespaceReadinessReconciler reconciles a NamespaceReadiness object. Its
+// ReadinessState is kept separate from the cluster-wide one, so namespace
+// results never affect the cluster /readyz.
+type NamespaceReadinessReconciler struct {
+	client.Client
+	ReadinessState  *server.ReadinessState
+	DynamicExecutor DynamicCheckExecutor
+}
+
+// +kubebuilder:rbac:groups=clustergate.io,resources=namespacereadinesses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=clustergate.io,resources=namespacereadinesses/status,verbs=get;update;patch
+
+func (r *NamespaceReadinessReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	key := NamespaceReadinessKey(req.Namespace, req.Name)
+
+	var nr clustergatev1alpha1.NamespaceReadiness
+	if err := r.Get(ctx, req.NamespacedName, &nr); err != nil {
+		// CR deleted — clean up state.
+		r.ReadinessState.Remove(key)
+		metrics.DeleteNamespaceReadiness(req.Namespace, req.Name)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	logger.V(1).Info("reconciling NamespaceReadiness", "namespace", nr.Namespace, "name", nr.Name)
+	reconcileStart := time.Now()
+	transitions := newTransitionLogger(logger, key)
+
+	interval := defaultInterval
+	if nr.Spec.Interval.Duration > 0 {
+		interval = nr.Spec.Interval.Duration
+	}
+
+	specs := make(map[string]clustergatev1alpha1.GateCheckSpec, len(nr.Spec.Checks))
+	resolvedChecks := make([]ResolvedCheck, 0, len(nr.Spec.Checks))
+	for _, c := range nr.Spec.Checks {
+		checkInterval := interval
+		if c.Interval != nil && c.Interval.Duration > 0 {
+			checkInterval = c.Interval.Duration
+		}
+		severity := string(c.Severity)
+		if severity == "" {
+			severity = string(clustergatev1alpha1.SeverityCritical)
+		}
+		category := c.Category
+		if category == "" {
+			category = "custom"
+		}
+		specs[c.Name] = c.GateCheckSpec
+		resolvedChecks = append(resolvedChecks, ResolvedCheck{
+			Identifier:    c.Name,
+			GateCheckName: c.Name,
+			Severity:      severity,
+			Category:      category,
+			Interval:      checkInterval,
+			Source:        "inline",
+		})
+	}
+
+	if r.DynamicExecutor != nil {
+		meta.SetStatusCondition(&nr.Status.Conditions, executorReadyCondition(r.DynamicExecutor.Ready()))
+	}
+
+	now := metav1.Now()
+	existingChecks, existingCategoryLookup, existingStatusLookup := flattenCategories(nr.Status.Categories)
+	dueChecks, carriedStatuses, nextRequeue := CheckSchedule(resolvedChecks, existingChecks, now.Time, nr.Spec.JitterPercent)
+
+	// Run only due checks concurrently.
+	results := make([]checkResult, len(dueChecks))
+	var wg sync.WaitGroup
+	for i, rc := range dueChecks {
+		wg.Add(1)
+		go func(idx int, resolved ResolvedCheck) {
+			defer wg.Done()
+			results[idx] = r.runNamespaceCheck(ctx, nr.Namespace, resolved, specs[resolved.Identifier])
+		}(i, rc)
+	}
+	wg.Wait()
+	withHistory(results, dueChecks, existingChecks, nr.Spec.HistoryLimit)
+
+	for _, res := range results {
+		status, message, ready := checkOutcome(res)
+		transitions.Check(res.name, existingStatusLookup[res.name], status, message, res.duration)
+
+		readyVal := float64(0)
+		if ready {
+			readyVal = 1
+		}
+		metrics.NamespaceCheckReady.WithLabelValues(res.name, nr.Namespace, nr.Name, res.severity, res.category).Set(readyVal)
+		metrics.CheckDuration.WithLabelValues(res.name, res.severity, res.category).Observe(res.duration.Seconds())
+	}
+
+	eval := evaluate(results, carriedStatuses, existingCategoryLookup, nr.Spec.DegradedThreshold, false, &now)
+
+	namespaceReadyVal := float64(0)
+	if eval.ready() {
+		namespaceReadyVal = 1
+	}
+	metrics.NamespaceReady.WithLabelValues(nr.Namespace, nr.Name).Set(namespaceReadyVal)
+	for _, s := range []string{"Healthy", "Degraded", "Unhealthy"} {
+		stateVal := float64(0)
+		if s == string(eval.state) {
+			stateVal = 1
+		}
+		metrics.NamespaceHealthState.WithLabelValues(nr.Namespace, nr.Name, s).Set(stateVal)
+	}
+
+	eval.updateState(r.ReadinessState, key)
+
+	previousState := nr.Status.State
+	nr.Status.State = eval.state
+	nr.Status.LastChecked = &now
+	nr.Status.Categories = eval.categories
+	nr.Status.Summary = eval.summary
+
+	if err := r.Status().Update(ctx, &nr); err != nil {
+		logger.Error(err, "failed to update NamespaceReadiness status")
+		return ctrl.Result{}, err
+	}
+
+	transitions.Cluster(string(previousState), string(eval.state),
+		fmt.Sprintf("%d/%d critical checks passing, %d warning checks failing",
+			eval.summary.CriticalPassing, eval.summary.CriticalTotal, eval.summary.WarningFailing),
+		time.Since(reconcileStart),
+	)
+
+	logger.V(1).Info("reconciliation complete",
+		"state", eval.state,
+		"total", eval.summary.Total,
+		"checksExecuted", len(dueChecks),
+		"checksCarried", len(carriedStatuses),
+		"requeueAfter", nextRequeue,
+	)
+	return ctrl.Result{RequeueAfter: nextRequeue}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NamespaceReadinessReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clustergatev1alpha1.NamespaceReadiness{}).
+		Complete(r)
+}
+
+// runNamespaceCheck confines spec to namespace and executes it.
+func (r *NamespaceReadinessReconciler) runNamespaceCheck(ctx context.Context, namespace string, resolved ResolvedCheck, spec clustergatev1alpha1.GateCheckSpec) checkResult {
+	res := checkResult{
+		name:     resolved.Identifier,
+		severity: resolved.Severity,
+		category: resolved.Category,
+		source:   resolved.Source,
+	}
+
+	spec, err := ApplyParameters(spec, nil)
+	if err != nil {
+		res.result = checks.Result{Ready: false, Message: err.Error()}
+		return res
+	}
+	scoped, err := ScopeToNamespace(spec, namespace, r.RESTMapper())
+	if err != nil {
+		res.result = checks.Result{Ready: false, Message: err.Error()}
+		return res
+	}
+
+	start := time.Now()
+	res.result, res.err = r.DynamicExecutor.Execute(ctx, namespace+"-"+resolved.Identifier, scoped)
+	res.duration = time.Since(start)
+	return res
+}
+
+// NamespaceReadinessKey is the ReadinessState key of a NamespaceReadiness.
+func NamespaceReadinessKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// ScopeToNamespace returns a copy of spec with every namespaced reference
+// forced to namespace, so a NamespaceReadiness or NamespaceGateCheck cannot
+// read pods, resources, Services or Secrets outside its own namespace. Check
+// types that are not tied to a namespace (scripts, Git, backups) are rejected,
+// as are resource checks and dry-run manifests of kinds mapper does not know
+// to be namespaced, which a namespace would not confine. HTTP, PromQL and
+// Alertmanager checks must target a Service through serviceRef: URLs,
+// endpoints, proxies and resolve overrides could reach any address, and HTTP
+// redirects are not followed for the same reason.
+func ScopeToNamespace(spec clustergatev1alpha1.GateCheckSpec, namespace string, mapper meta.RESTMapper) (clustergatev1alpha1.GateCheckSpec, error) {
+	scoped := *spec.DeepCopy()
+
+	count := 0
+	for _, set := range []bool{
+		scoped.PodCheck != nil, scoped.HTTPCheck != nil, scoped.ResourceCheck != nil, scoped.PromQLCheck != nil,
+		scoped.ScriptCheck != nil, scoped.GitCheck != nil, scoped.BackupCheck != nil, scoped.AlertmanagerCheck != nil,
+	} {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		return scoped, fmt.Errorf("exactly one check type must be specified, found %d", count)
+	}
+
+	switch {
+	case scoped.PodCheck != nil:
+		scoped.PodCheck.Namespace = namespace
+	case scoped.ResourceCheck != nil:
+		rc := scoped.ResourceCheck
+		rc.Namespace = namespace
+		rc.NamespaceSelector = nil
+		rc.ExcludeNamespaces = nil
+		if err := requireNamespaced(mapper, rc.APIVersion, rc.Kind); err != nil {
+			return scoped, err
+		}
+		if rc.Manifest != nil && len(rc.Manifest.Raw) > 0 {
+			if err := scopeManifest(rc, namespace, mapper); err != nil {
+				return scoped, err
+			}
+		}
+	case scoped.HTTPCheck != nil:
+		hc := scoped.HTTPCheck
+		if err := requireServiceRef("httpCheck.url", hc.ServiceRef, hc.URL); err != nil {
+			return scoped, err
+		}
+		switch {
+		case hc.ProxyURL != "":
+			return scoped, fmt.Errorf("httpCheck.proxyURL is not supported in namespaced checks")
+		case hc.ResolveOverrides != nil:
+			return scoped, fmt.Errorf("httpCheck.resolveOverrides is not supported in namespaced checks")
+		}
+		hc.ServiceRef.Namespace = namespace
+		followRedirects := false
+		hc.FollowRedirects = &followRedirects
+		if hc.TLS != nil {
+			scopeSecretRef(hc.TLS.CABundleSecretRef, namespace)
+			scopeSecretRef(hc.TLS.ClientCertSecretRef, namespace)
+		}
+		for i := range hc.HeadersFromSecret {
+			scopeSecretRef(&hc.HeadersFromSecret[i].SecretRef, namespace)
+		}
+		scopeSecretRef(hc.BasicAuthSecretRef, namespace)
+	case scoped.PromQLCheck != nil:
+		pc := scoped.PromQLCheck
+		if err := requireServiceRef("promqlCheck.endpoint", pc.ServiceRef, pc.Endpoint); err != nil {
+			return scoped, err
+		}
+		pc.ServiceRef.Namespace = namespace
+		scopeSecretRef(pc.BearerTokenSecretRef, namespace)
+		scopeSecretRef(pc.BasicAuthSecretRef, namespace)
+		if pc.Tenant != nil {
+			scopeSecretRef(pc.Tenant.SecretRef, namespace)
+		}
+		if pc.VariablesFrom != nil {
+			pc.VariablesFrom.Namespace = namespace
+		}
+		if pc.TLS != nil {
+			scopeSecretRef(pc.TLS.CABundleSecretRef, namespace)
+			scopeSecretRef(pc.TLS.ClientCertSecretRef, namespace)
+		}
+	case scoped.AlertmanagerCheck != nil:
+		ac := scoped.AlertmanagerCheck
+		if err := requireServiceRef("alertmanagerCheck.endpoint", ac.ServiceRef, ac.Endpoint); err != nil {
+			return scoped, err
+		}
+		ac.ServiceRef.Namespace = namespace
+		scopeSecretRef(ac.BearerTokenSecretRef, namespace)
+		scopeSecretRef(ac.BasicAuthSecretRef, namespace)
+		if ac.TLS != nil {
+			scopeSecretRef(ac.TLS.CABundleSecretRef, namespace)
+			scopeSecretRef(ac.TLS.ClientCertSecretRef, namespace)
+		}
+	default:
+		return scoped, fmt.Errorf("check type is not supported in namespaced checks; use podCheck, httpCheck, resourceCheck, promqlCheck or alertmanagerCheck")
+	}
+	return scoped, nil
+}
+
+// requireServiceRef returns an error unless a check targets a Service
+// through ref rather than through the URL field names.
+func requireServiceRef(field string, ref *clustergatev1alpha1.HTTPServiceReference, url string) error {
+	if url != "" {
+		return fmt.Errorf("%s is not supported in namespaced checks; use serviceRef", field)
+	}
+	if ref == nil {
+		return fmt.Errorf("namespaced checks must target a Service in their namespace through serviceRef")
+	}
+	return nil
+}
+
+// requireNamespaced returns an error unless mapper maps the kind of
+// apiVersion to a namespaced resource.
+func requireNamespaced(mapper meta.RESTMapper, apiVersion, kind string) error {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return fmt.Errorf("invalid apiVersion %q: %w", apiVersion, err)
+	}
+	mapping, err := mapper.RESTMapping(gv.WithKind(kind).GroupKind(), gv.Version)
+	if err != nil {
+		return fmt.Errorf("cannot tell whether %s %s is namespaced: %w", apiVersion, kind, err)
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return fmt.Errorf("%s %s is cluster-scoped; namespaced checks can only check namespaced kinds", apiVersion, kind)
+	}
+	return nil
+}
+
+// scopeManifest rejects rc's dry-run manifest unless its kind, defaulted as
+// the executor does, is namespaced, and forces its namespace to namespace.
+func scopeManifest(rc *clustergatev1alpha1.ResourceCheckSpec, namespace string, mapper meta.RESTMapper) error {
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(rc.Manifest.Raw, &obj.Object); err != nil {
+		return fmt.Errorf("invalid manifest: %w", err)
+	}
+	if obj.GetAPIVersion() == "" {
+		obj.SetAPIVersion(rc.APIVersion)
+	}
+	if obj.GetKind() == "" {
+		obj.SetKind(rc.Kind)
+	}
+	if err := requireNamespaced(mapper, obj.GetAPIVersion(), obj.GetKind()); err != nil {
+		return fmt.Errorf("manifest: %w", err)
+	}
+	obj.SetNamespace(namespace)
+	raw, err := json.Marshal(obj.Object)
+	if err != nil {
+		return fmt.Errorf("invalid manifest: %w", err)
+	}
+	rc.Manifest.Raw = raw
+	return nil
+}
+
+func scopeSecretRef(ref *clustergatev1alpha1.SecretReference, namespace string) {
+	if ref != nil {
+		ref.Namespace = namespace
+	}
+}
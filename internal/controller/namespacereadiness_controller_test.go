@@ -0,0 +1,363 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+	"github.com/clustergate/clustergate/internal/metrics"
+	"github.com/clustergate/clustergate/internal/server"
+)
+
+func TestScopeToNamespace(t *testing.T) {
+	secret := func(ns string) *clustergatev1alpha1.SecretReference {
+		return &clustergatev1alpha1.SecretReference{Name: "creds", Namespace: ns}
+	}
+
+	tests := []struct {
+		name    string
+		spec    clustergatev1alpha1.GateCheckSpec
+		wantErr string
+		check   func(t *testing.T, spec clustergatev1alpha1.GateCheckSpec)
+	}{
+		{
+			name: "pod check namespace is forced",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				PodCheck: &clustergatev1alpha1.PodCheckSpec{Namespace: "kube-system"},
+			},
+			check: func(t *testing.T, spec clustergatev1alpha1.GateCheckSpec) {
+				if spec.PodCheck.Namespace != "shop" {
+					t.Errorf("pod namespace = %q, want shop", spec.PodCheck.Namespace)
+				}
+			},
+		},
+		{
+			name: "resource check namespace is forced",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{
+					APIVersion:        "apps/v1",
+					Kind:              "Deployment",
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+					ExcludeNamespaces: []string{"kube-system"},
+				},
+			},
+			check: func(t *testing.T, spec clustergatev1alpha1.GateCheckSpec) {
+				if spec.ResourceCheck.Namespace != "shop" {
+					t.Errorf("resource namespace = %q, want shop", spec.ResourceCheck.Namespace)
+				}
+				if spec.ResourceCheck.NamespaceSelector != nil || spec.ResourceCheck.ExcludeNamespaces != nil {
+					t.Errorf("resource check still selects other namespaces: %+v", spec.ResourceCheck)
+				}
+			},
+		},
+		{
+			name: "cluster-scoped resource check is rejected",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{APIVersion: "v1", Kind: "Node"},
+			},
+			wantErr: "v1 Node is cluster-scoped",
+		},
+		{
+			name: "cluster role resource check is rejected",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+			},
+			wantErr: "ClusterRole is cluster-scoped",
+		},
+		{
+			name: "resource check of unknown kind is rejected",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{APIVersion: "example.com/v1", Kind: "Widget"},
+			},
+			wantErr: "cannot tell whether example.com/v1 Widget is namespaced",
+		},
+		{
+			name: "cluster-scoped dry-run manifest is rejected",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Mode:       clustergatev1alpha1.ResourceDryRun,
+					Manifest: &apiextensionsv1.JSON{Raw: []byte(
+						`{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"ClusterRole","metadata":{"name":"escalate"}}`)},
+				},
+			},
+			wantErr: "manifest: rbac.authorization.k8s.io/v1 ClusterRole is cluster-scoped",
+		},
+		{
+			name: "dry-run manifest namespace is forced",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				ResourceCheck: &clustergatev1alpha1.ResourceCheckSpec{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Mode:       clustergatev1alpha1.ResourceDryRun,
+					Manifest:   &apiextensionsv1.JSON{Raw: []byte(`{"metadata":{"name":"probe","namespace":"kube-system"}}`)},
+				},
+			},
+			check: func(t *testing.T, spec clustergatev1alpha1.GateCheckSpec) {
+				var manifest map[string]any
+				if err := json.Unmarshal(spec.ResourceCheck.Manifest.Raw, &manifest); err != nil {
+					t.Fatal(err)
+				}
+				if ns := manifest["metadata"].(map[string]any)["namespace"]; ns != "shop" {
+					t.Errorf("manifest namespace = %v, want shop", ns)
+				}
+			},
+		},
+		{
+			name: "http service and secret references are forced",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{
+					ServiceRef: &clustergatev1alpha1.HTTPServiceReference{Namespace: "other", Name: "api"},
+					TLS: &clustergatev1alpha1.HTTPTLSConfig{
+						CABundleSecretRef:   secret("other"),
+						ClientCertSecretRef: secret(""),
+					},
+					HeadersFromSecret: []clustergatev1alpha1.HTTPHeaderFromSecret{
+						{Name: "Authorization", SecretRef: *secret("other"), Key: "token"},
+					},
+					BasicAuthSecretRef: secret("other"),
+				},
+			},
+			check: func(t *testing.T, spec clustergatev1alpha1.GateCheckSpec) {
+				hc := spec.HTTPCheck
+				for name, ns := range map[string]string{
+					"serviceRef":          hc.ServiceRef.Namespace,
+					"caBundleSecretRef":   hc.TLS.CABundleSecretRef.Namespace,
+					"clientCertSecretRef": hc.TLS.ClientCertSecretRef.Namespace,
+					"headersFromSecret":   hc.HeadersFromSecret[0].SecretRef.Namespace,
+					"basicAuthSecretRef":  hc.BasicAuthSecretRef.Namespace,
+				} {
+					if ns != "shop" {
+						t.Errorf("%s namespace = %q, want shop", name, ns)
+					}
+				}
+			},
+		},
+		{
+			name: "promql service and secret references are forced",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{
+					ServiceRef:           &clustergatev1alpha1.HTTPServiceReference{Namespace: "monitoring", Name: "prometheus"},
+					BearerTokenSecretRef: secret("monitoring"),
+					TLS:                  &clustergatev1alpha1.HTTPTLSConfig{CABundleSecretRef: secret("monitoring")},
+					Tenant:               &clustergatev1alpha1.PromQLTenant{SecretRef: secret("monitoring")},
+				},
+			},
+			check: func(t *testing.T, spec clustergatev1alpha1.GateCheckSpec) {
+				pc := spec.PromQLCheck
+				for name, ns := range map[string]string{
+					"serviceRef":           pc.ServiceRef.Namespace,
+					"bearerTokenSecretRef": pc.BearerTokenSecretRef.Namespace,
+					"caBundleSecretRef":    pc.TLS.CABundleSecretRef.Namespace,
+					"tenant.secretRef":     pc.Tenant.SecretRef.Namespace,
+				} {
+					if ns != "shop" {
+						t.Errorf("%s namespace = %q, want shop", name, ns)
+					}
+				}
+			},
+		},
+		{
+			name: "http redirects are not followed",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{
+					ServiceRef: &clustergatev1alpha1.HTTPServiceReference{Namespace: "shop", Name: "api"},
+				},
+			},
+			check: func(t *testing.T, spec clustergatev1alpha1.GateCheckSpec) {
+				if f := spec.HTTPCheck.FollowRedirects; f == nil || *f {
+					t.Errorf("followRedirects = %v, want false", f)
+				}
+			},
+		},
+		{
+			name: "http URL is rejected",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{URL: "http://api.payments.svc:8080/healthz"},
+			},
+			wantErr: "httpCheck.url is not supported in namespaced checks",
+		},
+		{
+			name: "http proxy is rejected",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{
+					ServiceRef: &clustergatev1alpha1.HTTPServiceReference{Name: "api"},
+					ProxyURL:   "http://169.254.169.254",
+				},
+			},
+			wantErr: "httpCheck.proxyURL is not supported",
+		},
+		{
+			name: "http resolve overrides are rejected",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{
+					ServiceRef:       &clustergatev1alpha1.HTTPServiceReference{Name: "api"},
+					ResolveOverrides: &clustergatev1alpha1.HTTPResolveOverrides{Nameserver: "10.0.0.10:53"},
+				},
+			},
+			wantErr: "httpCheck.resolveOverrides is not supported",
+		},
+		{
+			name: "promql endpoint is rejected",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				PromQLCheck: &clustergatev1alpha1.PromQLCheckSpec{Endpoint: "http://prometheus.monitoring:9090", Query: "up"},
+			},
+			wantErr: "promqlCheck.endpoint is not supported",
+		},
+		{
+			name: "alertmanager endpoint is rejected",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				AlertmanagerCheck: &clustergatev1alpha1.AlertmanagerCheckSpec{Endpoint: "http://alertmanager.monitoring:9093"},
+			},
+			wantErr: "alertmanagerCheck.endpoint is not supported",
+		},
+		{
+			name: "alertmanager check without a Service is rejected",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				AlertmanagerCheck: &clustergatev1alpha1.AlertmanagerCheckSpec{},
+			},
+			wantErr: "must target a Service in their namespace through serviceRef",
+		},
+		{
+			name: "script checks are rejected",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				ScriptCheck: &clustergatev1alpha1.ScriptCheckSpec{Image: "busybox"},
+			},
+			wantErr: "not supported in namespaced checks",
+		},
+		{
+			name:    "no check type",
+			spec:    clustergatev1alpha1.GateCheckSpec{},
+			wantErr: "exactly one check type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.spec.DeepCopy()
+			got, err := ScopeToNamespace(tt.spec, "shop", testRESTMapper())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tt.check(t, got)
+			if original.PodCheck != nil && tt.spec.PodCheck.Namespace != original.PodCheck.Namespace {
+				t.Error("input spec was modified")
+			}
+		})
+	}
+}
+
+// testRESTMapper maps a few namespaced and cluster-scoped kinds, as the
+// manager's RESTMapper would.
+func testRESTMapper() meta.RESTMapper {
+	m := meta.NewDefaultRESTMapper(nil)
+	m.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	m.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	m.Add(schema.GroupVersionKind{Version: "v1", Kind: "Node"}, meta.RESTScopeRoot)
+	m.Add(schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}, meta.RESTScopeRoot)
+	return m
+}
+
+// recordingExecutor passes every check and records the specs it was given.
+type recordingExecutor struct {
+	mu    sync.Mutex
+	specs map[string]clustergatev1alpha1.GateCheckSpec
+}
+
+func (e *recordingExecutor) Execute(_ context.Context, name string, spec clustergatev1alpha1.GateCheckSpec) (checks.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.specs == nil {
+		e.specs = make(map[string]clustergatev1alpha1.GateCheckSpec)
+	}
+	e.specs[name] = spec
+	return checks.Result{Ready: true, Message: "ok"}, nil
+}
+
+func (e *recordingExecutor) Ready() error { return nil }
+
+func TestNamespaceReadinessReconcile(t *testing.T) {
+	nr := &clustergatev1alpha1.NamespaceReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+		Spec: clustergatev1alpha1.NamespaceReadinessSpec{
+			Checks: []clustergatev1alpha1.NamespaceCheck{
+				{
+					Name: "pods",
+					GateCheckSpec: clustergatev1alpha1.GateCheckSpec{
+						Category: "apps",
+						PodCheck: &clustergatev1alpha1.PodCheckSpec{Namespace: "kube-system"},
+					},
+				},
+				{
+					Name: "backups",
+					GateCheckSpec: clustergatev1alpha1.GateCheckSpec{
+						Severity:    clustergatev1alpha1.SeverityWarning,
+						BackupCheck: &clustergatev1alpha1.BackupCheckSpec{},
+					},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(nr).WithStatusSubresource(nr).Build()
+	executor := &recordingExecutor{}
+	state := server.NewReadinessState()
+	r := &NamespaceReadinessReconciler{Client: c, ReadinessState: state, DynamicExecutor: executor}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "shop", Name: "checkout"}}
+
+	t.Cleanup(func() { metrics.DeleteNamespaceReadiness("shop", "checkout") })
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if spec, ok := executor.specs["shop-pods"]; !ok || spec.PodCheck.Namespace != "shop" {
+		t.Errorf("executed specs = %+v, want pod check scoped to shop", executor.specs)
+	}
+	if _, ok := executor.specs["shop-backups"]; ok {
+		t.Error("backup check must not reach the executor")
+	}
+
+	var got clustergatev1alpha1.NamespaceReadiness
+	if err := c.Get(context.Background(), req.NamespacedName, &got); err != nil {
+		t.Fatal(err)
+	}
+	// The rejected backup check is a warning, so the namespace is only degraded.
+	if got.Status.State != clustergatev1alpha1.ClusterDegraded {
+		t.Errorf("state = %q, want Degraded", got.Status.State)
+	}
+	if got.Status.Summary == nil || got.Status.Summary.Total != 2 || got.Status.Summary.Passing != 1 {
+		t.Errorf("summary = %+v, want 1/2 passing", got.Status.Summary)
+	}
+	if !state.IsReady() {
+		t.Error("expected readiness state to be published under the namespaced key")
+	}
+
+	// Deleting the CR clears its state.
+	if err := c.Delete(context.Background(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.IsReady() {
+		t.Error("expected state to be removed after deletion")
+	}
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+const (
+	// defaultNodeTaintKey is the key of the node taint when none is set.
+	defaultNodeTaintKey = "clustergate.io/not-ready"
+
+	// nodeTaintFinalizer holds a ClusterReadiness with a node taint action
+	// until its taint is removed from every node.
+	nodeTaintFinalizer = "clustergate.io/node-taint"
+)
+
+// desiredNodeTaint returns the taint cr's node taint action applies, or nil
+// when it has none.
+func desiredNodeTaint(cr *clustergatev1alpha1.ClusterReadiness) *corev1.Taint {
+	if cr.Spec.Actions == nil || cr.Spec.Actions.NodeTaint == nil {
+		return nil
+	}
+	action := cr.Spec.Actions.NodeTaint
+	taint := &corev1.Taint{Key: action.Key, Value: action.Value, Effect: action.Effect}
+	if taint.Key == "" {
+		taint.Key = defaultNodeTaintKey
+	}
+	if taint.Value == "" {
+		taint.Value = cr.Name
+	}
+	if taint.Effect == "" {
+		taint.Effect = corev1.TaintEffectNoSchedule
+	}
+	return taint
+}
+
+// removeNodeTaints removes every taint cr's node taint action may have
+// applied from every node.
+func (r *ClusterReadinessReconciler) removeNodeTaints(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness) error {
+	return r.taintNodes(ctx, cr.Status.NodeTaint, desiredNodeTaint(cr), nil)
+}
+
+// reconcileNodeTaints taints the nodes selected by cr's node taint action
+// while ready is unset, and removes the taint once it is set, from nodes no
+// longer selected, and when the taint changes or the action is removed. The
+// taint it manages is recorded in cr's status.
+func (r *ClusterReadinessReconciler) reconcileNodeTaints(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, ready bool) error {
+	desired := desiredNodeTaint(cr)
+	previous := cr.Status.NodeTaint
+	if desired == nil && previous == nil {
+		return nil
+	}
+	var selector labels.Selector
+	if desired != nil && !ready {
+		selector = labels.Everything()
+		if s := cr.Spec.Actions.NodeTaint.NodeSelector; s != nil {
+			var err error
+			if selector, err = metav1.LabelSelectorAsSelector(s); err != nil {
+				return fmt.Errorf("invalid node selector: %w", err)
+			}
+		}
+	}
+	stale := previous
+	if stale != nil && desired != nil && sameTaint(*stale, *desired) {
+		stale = nil
+	}
+	err := r.taintNodes(ctx, stale, desired, selector)
+	if err == nil || previous == nil {
+		// Until the stale taint is gone from every node it stays recorded;
+		// a new taint is recorded even if only some nodes got it.
+		cr.Status.NodeTaint = desired
+	}
+	return err
+}
+
+// taintNodes removes stale from every node, applies desired to the nodes
+// selector matches, and removes desired from the others. A nil selector
+// matches no node.
+func (r *ClusterReadinessReconciler) taintNodes(ctx context.Context, stale, desired *corev1.Taint, selector labels.Selector) error {
+	if stale == nil && desired == nil {
+		return nil
+	}
+	var nodes corev1.NodeList
+	if err := r.List(ctx, &nodes); err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	var errs []error
+	var tainted, untainted int
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		want := desired != nil && selector != nil && selector.Matches(labels.Set(node.Labels))
+		var taints []corev1.Taint
+		has := false
+		for _, t := range node.Spec.Taints {
+			switch {
+			case desired != nil && sameTaint(t, *desired):
+				if want && !has {
+					taints = append(taints, t)
+					has = true
+				}
+			case stale != nil && sameTaint(t, *stale):
+			default:
+				taints = append(taints, t)
+			}
+		}
+		if want && !has {
+			taints = append(taints, *desired)
+		}
+		if slices.EqualFunc(taints, node.Spec.Taints, sameTaint) {
+			continue
+		}
+		base := node.DeepCopy()
+		node.Spec.Taints = taints
+		if err := r.Patch(ctx, node, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update taints of node %s: %w", node.Name, err))
+			continue
+		}
+		if want && !has {
+			tainted++
+		} else {
+			untainted++
+		}
+	}
+	if tainted > 0 || untainted > 0 {
+		log.FromContext(ctx).Info("updated node taints", "tainted", tainted, "untainted", untainted)
+	}
+	return errors.Join(errs...)
+}
+
+// sameTaint reports whether a and b have the same key, value and effect.
+func sameTaint(a, b corev1.Taint) bool {
+	return a.Key == b.Key && a.Value == b.Value && a.Effect == b.Effect
+}
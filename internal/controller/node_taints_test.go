@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+// nodeTaints returns the taints of each node by name.
+func nodeTaints(t *testing.T, c client.Client) map[string][]string {
+	t.Helper()
+	var nodes corev1.NodeList
+	if err := c.List(context.Background(), &nodes); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string][]string{}
+	for _, n := range nodes.Items {
+		got[n.Name] = nil
+		for _, taint := range n.Spec.Taints {
+			got[n.Name] = append(got[n.Name], taint.ToString())
+		}
+	}
+	return got
+}
+
+func TestReconcileNodeTaints(t *testing.T) {
+	existing := corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}
+	nodes := []client.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Labels: map[string]string{"role": "worker"}},
+			Spec: corev1.NodeSpec{Taints: []corev1.Taint{existing}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-2", Labels: map[string]string{"role": "worker"}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "control-plane", Labels: map[string]string{"role": "control-plane"}}},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(nodes...).Build()
+	r := &ClusterReadinessReconciler{Client: c}
+	ctx := context.Background()
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Actions: &clustergatev1alpha1.Actions{
+				NodeTaint: &clustergatev1alpha1.NodeTaintAction{
+					NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+				},
+			},
+		},
+	}
+
+	steps := []struct {
+		name  string
+		ready bool
+		edit  func()
+		want  map[string][]string
+	}{
+		{
+			name: "not ready taints the selected nodes",
+			want: map[string][]string{
+				"worker-1":      {"dedicated=gpu:NoSchedule", "clustergate.io/not-ready=prod:NoSchedule"},
+				"worker-2":      {"clustergate.io/not-ready=prod:NoSchedule"},
+				"control-plane": nil,
+			},
+		},
+		{
+			name: "changed taint replaces the old one",
+			edit: func() { cr.Spec.Actions.NodeTaint.Effect = corev1.TaintEffectPreferNoSchedule },
+			want: map[string][]string{
+				"worker-1":      {"dedicated=gpu:NoSchedule", "clustergate.io/not-ready=prod:PreferNoSchedule"},
+				"worker-2":      {"clustergate.io/not-ready=prod:PreferNoSchedule"},
+				"control-plane": nil,
+			},
+		},
+		{
+			name: "narrowed selector untaints the others",
+			edit: func() {
+				cr.Spec.Actions.NodeTaint.NodeSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"role": "nope"}}
+			},
+			want: map[string][]string{
+				"worker-1":      {"dedicated=gpu:NoSchedule"},
+				"worker-2":      nil,
+				"control-plane": nil,
+			},
+		},
+		{
+			name: "empty selector taints every node",
+			edit: func() { cr.Spec.Actions.NodeTaint.NodeSelector = nil },
+			want: map[string][]string{
+				"worker-1":      {"dedicated=gpu:NoSchedule", "clustergate.io/not-ready=prod:PreferNoSchedule"},
+				"worker-2":      {"clustergate.io/not-ready=prod:PreferNoSchedule"},
+				"control-plane": {"clustergate.io/not-ready=prod:PreferNoSchedule"},
+			},
+		},
+		{
+			name:  "ready removes the taint",
+			ready: true,
+			want: map[string][]string{
+				"worker-1":      {"dedicated=gpu:NoSchedule"},
+				"worker-2":      nil,
+				"control-plane": nil,
+			},
+		},
+	}
+	for _, step := range steps {
+		if step.edit != nil {
+			step.edit()
+		}
+		if err := r.reconcileNodeTaints(ctx, cr, step.ready); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		got := nodeTaints(t, c)
+		for node, want := range step.want {
+			if fmt.Sprint(got[node]) != fmt.Sprint(want) {
+				t.Errorf("%s: taints of %s = %v, want %v", step.name, node, got[node], want)
+			}
+		}
+	}
+	if cr.Status.NodeTaint == nil || cr.Status.NodeTaint.Effect != corev1.TaintEffectPreferNoSchedule {
+		t.Errorf("status.nodeTaint = %+v, want the current taint", cr.Status.NodeTaint)
+	}
+
+	// Removing the action removes the recorded taint.
+	if err := r.reconcileNodeTaints(ctx, cr, false); err != nil {
+		t.Fatal(err)
+	}
+	cr.Spec.Actions = nil
+	if err := r.reconcileNodeTaints(ctx, cr, false); err != nil {
+		t.Fatal(err)
+	}
+	for node, taints := range nodeTaints(t, c) {
+		if len(taints) > 1 || (len(taints) == 1 && taints[0] != "dedicated=gpu:NoSchedule") {
+			t.Errorf("taints of %s = %v, want the action's taint removed", node, taints)
+		}
+	}
+	if cr.Status.NodeTaint != nil {
+		t.Errorf("status.nodeTaint = %+v, want none", cr.Status.NodeTaint)
+	}
+}
+
+func TestNodeTaintFinalizer(t *testing.T) {
+	taint := corev1.Taint{Key: defaultNodeTaintKey, Value: "prod", Effect: corev1.TaintEffectNoSchedule}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}, Spec: corev1.NodeSpec{Taints: []corev1.Taint{taint}}}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Actions: &clustergatev1alpha1.Actions{NodeTaint: &clustergatev1alpha1.NodeTaintAction{}},
+		},
+		Status: clustergatev1alpha1.ClusterReadinessStatus{NodeTaint: &taint},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(node, cr).Build()
+	r := &ClusterReadinessReconciler{Client: c}
+	ctx := context.Background()
+
+	if err := r.syncActionFinalizers(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	if !controllerutil.ContainsFinalizer(cr, nodeTaintFinalizer) {
+		t.Fatal("finalizer not added")
+	}
+	if err := c.Delete(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(cr), cr); err != nil {
+		t.Fatalf("ClusterReadiness deleted before its taint was removed: %v", err)
+	}
+	if err := r.finalizeActions(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	if got := nodeTaints(t, c)["worker-1"]; len(got) != 0 {
+		t.Errorf("taints = %v, want none", got)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(cr), cr); err == nil {
+		t.Error("ClusterReadiness still exists after its finalizer was removed")
+	}
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"cmp"
+	"strings"
+	"time"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+// notifierThrottle is what a state change notifier of a ClusterReadiness
+// last sent, and the change it is holding back for its group wait.
+type notifierThrottle struct {
+	// sent is the state last notified and sentAt when.
+	sent   clustergatev1alpha1.ClusterHealthState
+	sentAt time.Time
+
+	// pendingSince is when the first change not yet notified was seen; zero
+	// when there is none.
+	pendingSince time.Time
+}
+
+// next decides whether the notifier sends the change of state from from to
+// to, seen at now, with the given group wait and repeat interval. It returns
+// the state to report the change from when it sends, and otherwise how long
+// until it may send, or zero when it is not waiting to.
+func (t *notifierThrottle) next(from, to clustergatev1alpha1.ClusterHealthState, now time.Time, groupWait, repeat time.Duration) (old clustergatev1alpha1.ClusterHealthState, send bool, wait time.Duration) {
+	if t.sent == "" {
+		// Nothing notified yet, as on the first evaluation or after a
+		// restart: the receiver last heard of the state before this one.
+		t.sent, t.sentAt = cmp.Or(from, to), now
+	}
+	if from != "" && from != to && t.pendingSince.IsZero() {
+		t.pendingSince = now
+	}
+
+	if !t.pendingSince.IsZero() {
+		if to != clustergatev1alpha1.ClusterHealthy {
+			if left := t.pendingSince.Add(groupWait).Sub(now); left > 0 {
+				return "", false, left
+			}
+		}
+		t.pendingSince = time.Time{}
+		if to == t.sent {
+			return "", false, 0
+		}
+		old = t.sent
+		t.sent, t.sentAt = to, now
+		return old, true, 0
+	}
+
+	if repeat <= 0 || to == clustergatev1alpha1.ClusterHealthy {
+		return "", false, 0
+	}
+	if left := t.sentAt.Add(repeat).Sub(now); left > 0 {
+		return "", false, left
+	}
+	t.sentAt = now
+	return to, true, 0
+}
+
+// throttle returns the throttle of the notifier of kind and name of cr.
+func (r *ClusterReadinessReconciler) throttle(cr, kind, name string) *notifierThrottle {
+	r.throttlesMu.Lock()
+	defer r.throttlesMu.Unlock()
+	if r.throttles == nil {
+		r.throttles = map[string]*notifierThrottle{}
+	}
+	key := cr + "/" + kind + "/" + name
+	t, ok := r.throttles[key]
+	if !ok {
+		t = &notifierThrottle{}
+		r.throttles[key] = t
+	}
+	return t
+}
+
+// forgetThrottles drops the throttles of cr's notifiers.
+func (r *ClusterReadinessReconciler) forgetThrottles(cr string) {
+	r.throttlesMu.Lock()
+	defer r.throttlesMu.Unlock()
+	for key := range r.throttles {
+		if strings.HasPrefix(key, cr+"/") {
+			delete(r.throttles, key)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+func TestNotifierThrottle(t *testing.T) {
+	const (
+		healthy   = clustergatev1alpha1.ClusterHealthy
+		degraded  = clustergatev1alpha1.ClusterDegraded
+		unhealthy = clustergatev1alpha1.ClusterUnhealthy
+	)
+	type step struct {
+		at       time.Duration
+		from, to clustergatev1alpha1.ClusterHealthState
+		wantOld  clustergatev1alpha1.ClusterHealthState
+		wantSend bool
+		wantWait time.Duration
+	}
+	tests := []struct {
+		name              string
+		groupWait, repeat time.Duration
+		steps             []step
+	}{
+		{
+			name: "no throttling sends each change once",
+			steps: []step{
+				{at: 0, from: "", to: healthy},
+				{at: time.Minute, from: healthy, to: unhealthy, wantOld: healthy, wantSend: true},
+				{at: 2 * time.Minute, from: unhealthy, to: unhealthy},
+				{at: 3 * time.Minute, from: unhealthy, to: healthy, wantOld: unhealthy, wantSend: true},
+			},
+		},
+		{
+			name:      "group wait collapses changes",
+			groupWait: 5 * time.Minute,
+			steps: []step{
+				{at: 0, from: healthy, to: healthy},
+				{at: time.Minute, from: healthy, to: degraded, wantWait: 5 * time.Minute},
+				{at: 2 * time.Minute, from: degraded, to: unhealthy, wantWait: 4 * time.Minute},
+				{at: 6 * time.Minute, from: unhealthy, to: unhealthy, wantOld: healthy, wantSend: true},
+			},
+		},
+		{
+			name:      "change reverted within group wait is dropped",
+			groupWait: 5 * time.Minute,
+			steps: []step{
+				{at: 0, from: degraded, to: degraded},
+				{at: time.Minute, from: degraded, to: unhealthy, wantWait: 5 * time.Minute},
+				{at: 2 * time.Minute, from: unhealthy, to: degraded, wantWait: 4 * time.Minute},
+				{at: 6 * time.Minute, from: degraded, to: degraded},
+			},
+		},
+		{
+			name:      "recoveries skip the group wait",
+			groupWait: 5 * time.Minute,
+			steps: []step{
+				{at: 0, from: unhealthy, to: unhealthy},
+				{at: time.Minute, from: unhealthy, to: healthy, wantOld: unhealthy, wantSend: true},
+				// A failure that recovers within the group wait was never sent.
+				{at: 2 * time.Minute, from: healthy, to: unhealthy, wantWait: 5 * time.Minute},
+				{at: 3 * time.Minute, from: unhealthy, to: healthy},
+			},
+		},
+		{
+			name:   "repeat interval re-sends sustained failures",
+			repeat: time.Hour,
+			steps: []step{
+				{at: 0, from: healthy, to: unhealthy, wantOld: healthy, wantSend: true},
+				{at: 30 * time.Minute, from: unhealthy, to: unhealthy, wantWait: 30 * time.Minute},
+				{at: 61 * time.Minute, from: unhealthy, to: unhealthy, wantOld: unhealthy, wantSend: true},
+				{at: 90 * time.Minute, from: unhealthy, to: healthy, wantOld: unhealthy, wantSend: true},
+				{at: 200 * time.Minute, from: healthy, to: healthy},
+			},
+		},
+	}
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var th notifierThrottle
+			for i, s := range tt.steps {
+				old, send, wait := th.next(s.from, s.to, start.Add(s.at), tt.groupWait, tt.repeat)
+				if old != s.wantOld || send != s.wantSend || wait != s.wantWait {
+					t.Errorf("step %d: next(%s, %s) = %q, %v, %v; want %q, %v, %v",
+						i, s.from, s.to, old, send, wait, s.wantOld, s.wantSend, s.wantWait)
+				}
+			}
+		})
+	}
+}
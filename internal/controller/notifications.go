@@ -0,0 +1,451 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/metrics"
+	"github.com/clustergate/clustergate/internal/notify"
+)
+
+// notifyStateChange sends cr's change of state to each of its webhooks and
+// chat providers, as far as their group wait and repeat interval allow; the
+// event's OldState is the state before this evaluation. It returns how long
+// until one of them may send next, or zero. Deliveries run in the
+// background, so slow or unreachable receivers never hold up the reconcile;
+// their failures are logged and counted.
+func (r *ClusterReadinessReconciler) notifyStateChange(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, event notify.Event, now time.Time) time.Duration {
+	if cr.Spec.Notifications == nil {
+		return 0
+	}
+	logger := log.FromContext(ctx)
+	from, to := clustergatev1alpha1.ClusterHealthState(event.OldState), clustergatev1alpha1.ClusterHealthState(event.NewState)
+	var next time.Duration
+	due := func(kind, name string, groupWait, repeat *metav1.Duration) (notify.Event, bool) {
+		old, send, wait := r.throttle(cr.Name, kind, name).next(from, to, now, durationOf(groupWait), durationOf(repeat))
+		if wait > 0 && (next == 0 || wait < next) {
+			next = wait
+		}
+		e := event
+		e.OldState = string(old)
+		return e, send
+	}
+
+	for _, wh := range cr.Spec.Notifications.Webhooks {
+		e, send := due("webhook", wh.Name, wh.GroupWait, wh.RepeatInterval)
+		if !send {
+			continue
+		}
+		e, send = filterEvent(e, severityStrings(wh.Severities), nil)
+		if !send {
+			continue
+		}
+		target, err := r.webhookTarget(ctx, wh)
+		if err != nil {
+			logger.Error(err, "failed to notify webhook", "webhook", wh.Name)
+			metrics.NotificationsSent.WithLabelValues(cr.Name, wh.Name, "failure").Inc()
+			continue
+		}
+		payload, err := json.Marshal(e)
+		if err != nil {
+			logger.Error(err, "failed to encode notification")
+			continue
+		}
+		r.deliver(ctx, cr.Name, wh.Name, func(ctx context.Context) error {
+			return r.sender().Post(ctx, target, payload)
+		})
+	}
+	for _, p := range cr.Spec.Notifications.Providers {
+		e, send := due("provider", p.Name, p.GroupWait, p.RepeatInterval)
+		if !send {
+			continue
+		}
+		e, send = filterEvent(e, severityStrings(p.Severities), p.Categories)
+		if !send {
+			continue
+		}
+		target, body, err := r.providerMessage(ctx, p, e)
+		if err != nil {
+			logger.Error(err, "failed to notify provider", "provider", p.Name)
+			metrics.NotificationsSent.WithLabelValues(cr.Name, p.Name, "failure").Inc()
+			continue
+		}
+		r.deliver(ctx, cr.Name, p.Name, func(ctx context.Context) error {
+			return r.sender().Post(ctx, target, body)
+		})
+	}
+	return next
+}
+
+// filterEvent limits the failing checks event lists to severities and
+// categories. When either is set, it reports whether the event is still worth
+// sending: it lists a failing check, or the state became Healthy.
+func filterEvent(event notify.Event, severities, categories []string) (notify.Event, bool) {
+	if len(severities) == 0 && len(categories) == 0 {
+		return event, true
+	}
+	filtered := event.Filter(severities, categories)
+	return filtered, len(filtered.FailingChecks) > 0 || filtered.NewState == string(clustergatev1alpha1.ClusterHealthy)
+}
+
+// durationOf returns d's duration, or zero when unset.
+func durationOf(d *metav1.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d.Duration
+}
+
+// notifyCheckIncidents opens an incident with each of cr's incident
+// integrations for every executed check that started failing, and resolves it
+// when the check passes again. results[i] is the outcome of executed[i];
+// previous maps each check to its status before this run. Within the initial
+// delay failing checks are reported as Initializing and open no incident, and
+// a check that was Initializing is compared by the status it had before, so
+// an incident open when the operator restarted is neither opened again nor
+// left unresolved.
+func (r *ClusterReadinessReconciler) notifyCheckIncidents(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, executed []ResolvedCheck, results []checkResult, previous map[string]string, initializing bool, now metav1.Time) {
+	if cr.Spec.Notifications == nil || len(cr.Spec.Notifications.Incidents) == 0 {
+		return
+	}
+	var incidents []notify.Incident
+	for i, rc := range executed {
+		status, message, _ := reportedOutcome(results[i], initializing)
+		from := previous[rc.Identifier]
+		if from == "Initializing" {
+			from = statusBeforeInitializing(results[i].previous)
+		}
+		opens := status == "Failing" && from != "Failing"
+		resolves := status == "Passing" && from == "Failing"
+		if !opens && !resolves {
+			continue
+		}
+		incidents = append(incidents, notify.Incident{
+			ClusterReadiness: cr.Name,
+			Check:            rc.Identifier,
+			Severity:         rc.Severity,
+			Category:         rc.Category,
+			Message:          message,
+			Resolved:         resolves,
+			Time:             now.UTC().Truncate(time.Second),
+		})
+	}
+	if len(incidents) == 0 {
+		return
+	}
+
+	logger := log.FromContext(ctx)
+	for _, in := range cr.Spec.Notifications.Incidents {
+		severities := severityStrings(in.Severities)
+		if len(severities) == 0 {
+			severities = []string{string(clustergatev1alpha1.SeverityCritical)}
+		}
+		secret, err := getSecret(ctx, r.Client, &in.SecretRef, r.Namespace)
+		if err != nil {
+			logger.Error(err, "failed to notify incident integration", "integration", in.Name)
+			metrics.NotificationsSent.WithLabelValues(cr.Name, in.Name, "failure").Inc()
+			continue
+		}
+		for _, incident := range incidents {
+			if !slices.Contains(severities, incident.Severity) {
+				continue
+			}
+			target, body, err := incidentRequest(in, secret, incident)
+			if err != nil {
+				logger.Error(err, "failed to notify incident integration", "integration", in.Name, "check", incident.Check)
+				metrics.NotificationsSent.WithLabelValues(cr.Name, in.Name, "failure").Inc()
+				continue
+			}
+			r.deliver(ctx, cr.Name, in.Name, func(ctx context.Context) error {
+				return r.sender().Post(ctx, target, body)
+			})
+		}
+	}
+}
+
+// statusBeforeInitializing returns the last status other than Initializing
+// in cs's history, or "" when there is none.
+func statusBeforeInitializing(cs *clustergatev1alpha1.CheckStatus) string {
+	if cs == nil {
+		return ""
+	}
+	for i := len(cs.History) - 1; i >= 0; i-- {
+		if status := cs.History[i].Status; status != "Initializing" {
+			return status
+		}
+	}
+	return ""
+}
+
+// evaluationTTL is how long an evaluation's outcome, like a pushed alert or a
+// ready marker Lease, stays current without being renewed: four times the
+// longest interval between evaluations of the checks.
+func evaluationTTL(interval time.Duration, resolved []ResolvedCheck) time.Duration {
+	for _, rc := range resolved {
+		interval = max(interval, rc.Interval)
+	}
+	return 4 * interval
+}
+
+// notifyAlertmanagers pushes an alert for each failing check of statuses to
+// cr's Alertmanagers, lasting ttl, and a resolved alert for each check of
+// results that stopped failing. previous maps each check to its status
+// before this run.
+func (r *ClusterReadinessReconciler) notifyAlertmanagers(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, statuses []clustergatev1alpha1.CheckStatus, results []checkResult, previous, categories map[string]string, ttl time.Duration, now metav1.Time) {
+	if cr.Spec.Notifications == nil || len(cr.Spec.Notifications.Alertmanagers) == 0 {
+		return
+	}
+	type checkAlert struct {
+		check            notify.Check
+		startsAt, endsAt time.Time
+	}
+	var alerts []checkAlert
+	for _, cs := range statuses {
+		if cs.Status != "Failing" {
+			continue
+		}
+		startsAt := now.Time
+		if n := len(cs.History); n > 0 && cs.History[n-1].Status == "Failing" {
+			startsAt = cs.History[n-1].Time.Time
+		}
+		alerts = append(alerts, checkAlert{
+			check:    notify.Check{Name: cs.Name, Status: cs.Status, Severity: string(cs.Severity), Category: categories[cs.Name], Message: cs.Message},
+			startsAt: startsAt,
+			endsAt:   now.Add(ttl),
+		})
+	}
+	for _, res := range results {
+		status, message, _ := checkOutcome(res)
+		if previous[res.name] != "Failing" || status == "Failing" {
+			continue
+		}
+		alerts = append(alerts, checkAlert{
+			check:  notify.Check{Name: res.name, Status: status, Severity: res.severity, Category: res.category, Message: message},
+			endsAt: now.Time,
+		})
+	}
+	if len(alerts) == 0 {
+		return
+	}
+
+	logger := log.FromContext(ctx)
+	for _, am := range cr.Spec.Notifications.Alertmanagers {
+		severities := severityStrings(am.Severities)
+		var payload []notify.Alert
+		for _, a := range alerts {
+			if len(severities) == 0 || slices.Contains(severities, a.check.Severity) {
+				payload = append(payload, notify.CheckAlert(cr.Name, a.check, am.Labels, a.startsAt, a.endsAt))
+			}
+		}
+		if len(payload) == 0 {
+			continue
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			logger.Error(err, "failed to encode alerts")
+			return
+		}
+		target, err := r.httpTarget(ctx, am.URL, am.SecretRef, am.Retries, am.Timeout)
+		if err != nil {
+			logger.Error(err, "failed to notify Alertmanager", "alertmanager", am.Name)
+			metrics.NotificationsSent.WithLabelValues(cr.Name, am.Name, "failure").Inc()
+			continue
+		}
+		target.URL = notify.AlertmanagerURL(target.URL)
+		r.deliver(ctx, cr.Name, am.Name, func(ctx context.Context) error {
+			return r.sender().Post(ctx, target, body)
+		})
+	}
+}
+
+// incidentRequest builds the request opening or resolving incident with in,
+// authenticated by secret.
+func incidentRequest(in clustergatev1alpha1.IncidentNotification, secret *corev1.Secret, incident notify.Incident) (notify.Target, []byte, error) {
+	target := notify.Target{Header: http.Header{}, Retries: notify.DefaultRetries}
+	if in.Retries != nil {
+		target.Retries = int(*in.Retries)
+	}
+	if in.Timeout != nil {
+		target.Timeout = in.Timeout.Duration
+	}
+	endpoint := string(secret.Data["url"])
+
+	var body []byte
+	var err error
+	switch in.Type {
+	case clustergatev1alpha1.IncidentProviderPagerDuty:
+		key := string(secret.Data["routingKey"])
+		if key == "" {
+			return target, nil, fmt.Errorf("secret %s has no routingKey key", in.SecretRef.Name)
+		}
+		target.URL = cmp.Or(endpoint, notify.PagerDutyURL)
+		body, err = notify.PagerDutyPayload(key, incident)
+	case clustergatev1alpha1.IncidentProviderOpsgenie:
+		key := string(secret.Data["apiKey"])
+		if key == "" {
+			return target, nil, fmt.Errorf("secret %s has no apiKey key", in.SecretRef.Name)
+		}
+		target.Header.Set("Authorization", "GenieKey "+key)
+		target.URL, body, err = notify.OpsgenieRequest(cmp.Or(endpoint, notify.OpsgenieURL), incident)
+	default:
+		err = fmt.Errorf("unknown incident provider type %q", in.Type)
+	}
+	return target, body, err
+}
+
+// providerMessage resolves p's webhook URL and renders event as its payload.
+func (r *ClusterReadinessReconciler) providerMessage(ctx context.Context, p clustergatev1alpha1.NotificationProvider, event notify.Event) (notify.Target, []byte, error) {
+	target := notify.Target{Retries: notify.DefaultRetries}
+	if p.Retries != nil {
+		target.Retries = int(*p.Retries)
+	}
+	if p.Timeout != nil {
+		target.Timeout = p.Timeout.Duration
+	}
+	secret, err := getSecret(ctx, r.Client, &p.SecretRef, r.Namespace)
+	if err != nil {
+		return target, nil, err
+	}
+	target.URL = string(secret.Data["url"])
+	if target.URL == "" {
+		return target, nil, fmt.Errorf("secret %s has no url key", p.SecretRef.Name)
+	}
+	text, err := notify.Render(p.Template, event)
+	if err != nil {
+		return target, nil, err
+	}
+	var body []byte
+	switch p.Type {
+	case clustergatev1alpha1.NotificationProviderSlack:
+		body, err = notify.SlackPayload(text)
+	case clustergatev1alpha1.NotificationProviderTeams:
+		body, err = notify.TeamsPayload(text)
+	default:
+		err = fmt.Errorf("unknown provider type %q", p.Type)
+	}
+	return target, body, err
+}
+
+// severityStrings converts severities for notify.Event.Filter.
+func severityStrings(severities []clustergatev1alpha1.Severity) []string {
+	out := make([]string, len(severities))
+	for i, s := range severities {
+		out[i] = string(s)
+	}
+	return out
+}
+
+// deliver runs send in the background, detached from the reconcile's
+// cancellation, and records its outcome.
+func (r *ClusterReadinessReconciler) deliver(ctx context.Context, cr, name string, send func(context.Context) error) {
+	ctx = context.WithoutCancel(ctx)
+	r.notifications.Add(1)
+	go func() {
+		defer r.notifications.Done()
+		if err := send(ctx); err != nil {
+			log.FromContext(ctx).Error(err, "failed to deliver notification", "notification", name)
+			metrics.NotificationsSent.WithLabelValues(cr, name, "failure").Inc()
+			return
+		}
+		metrics.NotificationsSent.WithLabelValues(cr, name, "success").Inc()
+	}()
+}
+
+// sender returns the configured notification sender, or a default one.
+func (r *ClusterReadinessReconciler) sender() *notify.Sender {
+	if r.Notifier != nil {
+		return r.Notifier
+	}
+	return &notify.Sender{}
+}
+
+// webhookTarget resolves wh's URL and authentication.
+func (r *ClusterReadinessReconciler) webhookTarget(ctx context.Context, wh clustergatev1alpha1.WebhookNotification) (notify.Target, error) {
+	target, err := r.httpTarget(ctx, wh.URL, wh.SecretRef, wh.Retries, wh.Timeout)
+	if err == nil && target.URL == "" {
+		err = fmt.Errorf("webhook %s has no URL", wh.Name)
+	}
+	return target, err
+}
+
+// httpTarget builds a target for url, authenticated by the "token" key or
+// the "username" and "password" keys of the referenced Secret, whose "url"
+// key overrides url.
+func (r *ClusterReadinessReconciler) httpTarget(ctx context.Context, url string, ref *clustergatev1alpha1.SecretReference, retries *int32, timeout *metav1.Duration) (notify.Target, error) {
+	target := notify.Target{URL: url, Header: http.Header{}, Retries: notify.DefaultRetries}
+	if retries != nil {
+		target.Retries = int(*retries)
+	}
+	if timeout != nil {
+		target.Timeout = timeout.Duration
+	}
+	if ref == nil {
+		return target, nil
+	}
+	secret, err := getSecret(ctx, r.Client, ref, r.Namespace)
+	if err != nil {
+		return target, err
+	}
+	if url := secret.Data["url"]; len(url) > 0 {
+		target.URL = string(url)
+	}
+	if token := secret.Data["token"]; len(token) > 0 {
+		target.Header.Set("Authorization", "Bearer "+string(token))
+	} else if user := secret.Data["username"]; len(user) > 0 {
+		creds := base64.StdEncoding.EncodeToString([]byte(string(user) + ":" + string(secret.Data["password"])))
+		target.Header.Set("Authorization", "Basic "+creds)
+	}
+	return target, nil
+}
+
+// getSecret fetches the referenced Secret through c, defaulting to
+// defaultNamespace, the operator namespace.
+func getSecret(ctx context.Context, c client.Reader, ref *clustergatev1alpha1.SecretReference, defaultNamespace string) (*corev1.Secret, error) {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	var secret corev1.Secret
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get Secret %s/%s: %w", namespace, ref.Name, err)
+	}
+	return &secret, nil
+}
+
+// stateChangeEvent describes cr changing state from from to to, with the
+// checks of statuses that were not passing.
+func stateChangeEvent(cr string, from, to clustergatev1alpha1.ClusterHealthState, message string, statuses []clustergatev1alpha1.CheckStatus, categories map[string]string, now metav1.Time) notify.Event {
+	event := notify.Event{
+		ClusterReadiness: cr,
+		OldState:         string(from),
+		NewState:         string(to),
+		Message:          message,
+		Time:             now.UTC().Truncate(time.Second),
+	}
+	for _, cs := range statuses {
+		if cs.Status == "Passing" || cs.Status == "Suspended" || cs.Status == "Skipped" {
+			continue
+		}
+		event.FailingChecks = append(event.FailingChecks, notify.Check{
+			Name:     cs.Name,
+			Status:   cs.Status,
+			Severity: string(cs.Severity),
+			Category: categories[cs.Name],
+			Message:  cs.Message,
+		})
+	}
+	return event
+}
@@ -0,0 +1,386 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+	"github.com/clustergate/clustergate/internal/metrics"
+	"github.com/clustergate/clustergate/internal/notify"
+	"github.com/clustergate/clustergate/internal/server"
+)
+
+func TestNotifyStateChange_Webhooks(t *testing.T) {
+	received := make(chan *http.Request, 2)
+	payloads := make(chan notify.Event, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event notify.Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		received <- r
+		payloads <- event
+	}))
+	defer srv.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clustergate-system", Name: "hooks"},
+		Data:       map[string][]byte{"url": []byte(srv.URL + "/secret"), "token": []byte("s3cret")},
+	}
+	r := &ClusterReadinessReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(secret).Build(),
+		Namespace: "clustergate-system",
+		Notifier:  &notify.Sender{Backoff: time.Millisecond},
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Notifications: &clustergatev1alpha1.Notifications{
+				Webhooks: []clustergatev1alpha1.WebhookNotification{
+					{Name: "plain", URL: srv.URL + "/plain"},
+					{Name: "authenticated", URL: srv.URL + "/ignored", SecretRef: &clustergatev1alpha1.SecretReference{Name: "hooks"}},
+					{Name: "missing-secret", SecretRef: &clustergatev1alpha1.SecretReference{Name: "nope"}},
+				},
+			},
+		},
+	}
+	statuses := []clustergatev1alpha1.CheckStatus{
+		{Name: "dns", Status: "Passing", Severity: clustergatev1alpha1.SeverityCritical},
+		{Name: "etcd", Status: "Failing", Severity: clustergatev1alpha1.SeverityCritical, Message: "2/3 members healthy"},
+		{Name: "backups", Status: "Suspended", Severity: clustergatev1alpha1.SeverityWarning},
+	}
+	t.Cleanup(func() { metrics.DeleteClusterReadiness("prod") })
+
+	event := stateChangeEvent("prod", clustergatev1alpha1.ClusterHealthy, clustergatev1alpha1.ClusterUnhealthy,
+		"0/1 critical checks passing", statuses, map[string]string{"etcd": "control-plane"}, metav1.Now())
+	r.notifyStateChange(context.Background(), cr, event, time.Now())
+	r.notifications.Wait()
+	close(received)
+	close(payloads)
+
+	auth := map[string]string{}
+	for req := range received {
+		auth[req.URL.Path] = req.Header.Get("Authorization")
+	}
+	if len(auth) != 2 || auth["/plain"] != "" || auth["/secret"] != "Bearer s3cret" {
+		t.Errorf("requests = %v, want /plain unauthenticated and /secret with the token", auth)
+	}
+	for got := range payloads {
+		if got.OldState != "Healthy" || got.NewState != "Unhealthy" || len(got.FailingChecks) != 1 ||
+			got.FailingChecks[0] != (notify.Check{Name: "etcd", Status: "Failing", Severity: "critical", Category: "control-plane", Message: "2/3 members healthy"}) {
+			t.Errorf("payload = %+v, want the Healthy to Unhealthy change with etcd failing", got)
+		}
+	}
+
+	if got := testutil.ToFloat64(metrics.NotificationsSent.WithLabelValues("prod", "authenticated", "success")); got != 1 {
+		t.Errorf("authenticated successes = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.NotificationsSent.WithLabelValues("prod", "missing-secret", "failure")); got != 1 {
+		t.Errorf("missing-secret failures = %v, want 1", got)
+	}
+}
+
+func TestNotifyStateChange_Providers(t *testing.T) {
+	received := make(chan string, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		received <- r.URL.Path
+		if r.URL.Path == "/slack" && msg["text"] != "prod: etcd" && msg["text"] != "prod:" {
+			t.Errorf("slack text = %q, want the rendered template", msg["text"])
+		}
+	}))
+	defer srv.Close()
+
+	secrets := []client.Object{
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clustergate-system", Name: "slack"}, Data: map[string][]byte{"url": []byte(srv.URL + "/slack")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clustergate-system", Name: "teams"}, Data: map[string][]byte{"url": []byte(srv.URL + "/teams")}},
+	}
+	r := &ClusterReadinessReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(secrets...).Build(),
+		Namespace: "clustergate-system",
+		Notifier:  &notify.Sender{Backoff: time.Millisecond},
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Notifications: &clustergatev1alpha1.Notifications{
+				Providers: []clustergatev1alpha1.NotificationProvider{
+					{
+						Name: "slack", Type: clustergatev1alpha1.NotificationProviderSlack,
+						SecretRef: clustergatev1alpha1.SecretReference{Name: "slack"},
+						Template:  `{{.ClusterReadiness}}:{{range .FailingChecks}} {{.Name}}{{end}}`,
+					},
+					{
+						Name: "teams", Type: clustergatev1alpha1.NotificationProviderTeams,
+						SecretRef:  clustergatev1alpha1.SecretReference{Name: "teams"},
+						Severities: []clustergatev1alpha1.Severity{clustergatev1alpha1.SeverityWarning},
+					},
+				},
+			},
+		},
+	}
+	statuses := []clustergatev1alpha1.CheckStatus{
+		{Name: "etcd", Status: "Failing", Severity: clustergatev1alpha1.SeverityCritical},
+	}
+	t.Cleanup(func() { metrics.DeleteClusterReadiness("prod") })
+
+	// teams only lists warnings, so it skips a change with only a critical failure.
+	r.notifyStateChange(context.Background(), cr, stateChangeEvent("prod", clustergatev1alpha1.ClusterHealthy,
+		clustergatev1alpha1.ClusterUnhealthy, "", statuses, nil, metav1.Now()), time.Now())
+	// Recoveries reach every provider.
+	r.notifyStateChange(context.Background(), cr, stateChangeEvent("prod", clustergatev1alpha1.ClusterUnhealthy,
+		clustergatev1alpha1.ClusterHealthy, "", nil, nil, metav1.Now()), time.Now())
+	r.notifications.Wait()
+	close(received)
+
+	counts := map[string]int{}
+	for path := range received {
+		counts[path]++
+	}
+	if counts["/slack"] != 2 || counts["/teams"] != 1 {
+		t.Errorf("deliveries = %v, want 2 to slack and 1 to teams", counts)
+	}
+}
+
+func TestNotifyCheckIncidents(t *testing.T) {
+	type request struct{ path, auth, action, dedup string }
+	received := make(chan request, 8)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			EventAction string `json:"event_action"`
+			DedupKey    string `json:"dedup_key"`
+			Alias       string `json:"alias"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		received <- request{r.URL.EscapedPath(), r.Header.Get("Authorization"), body.EventAction, body.DedupKey + body.Alias}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	secrets := []client.Object{
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clustergate-system", Name: "pagerduty"},
+			Data: map[string][]byte{"routingKey": []byte("r0uting"), "url": []byte(srv.URL + "/v2/enqueue")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clustergate-system", Name: "opsgenie"},
+			Data: map[string][]byte{"apiKey": []byte("g3nie"), "url": []byte(srv.URL)}},
+	}
+	r := &ClusterReadinessReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(secrets...).Build(),
+		Namespace: "clustergate-system",
+		Notifier:  &notify.Sender{Backoff: time.Millisecond},
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Notifications: &clustergatev1alpha1.Notifications{
+				Incidents: []clustergatev1alpha1.IncidentNotification{
+					{Name: "pagerduty", Type: clustergatev1alpha1.IncidentProviderPagerDuty, SecretRef: clustergatev1alpha1.SecretReference{Name: "pagerduty"}},
+					{
+						Name: "opsgenie", Type: clustergatev1alpha1.IncidentProviderOpsgenie, SecretRef: clustergatev1alpha1.SecretReference{Name: "opsgenie"},
+						Severities: []clustergatev1alpha1.Severity{clustergatev1alpha1.SeverityWarning},
+					},
+				},
+			},
+		},
+	}
+	executed := []ResolvedCheck{
+		{Identifier: "etcd", IsBuiltin: true, Severity: "critical"},
+		{Identifier: "dns", IsBuiltin: true, Severity: "critical"},
+		{Identifier: "backups", IsBuiltin: true, Severity: "warning"},
+		{Identifier: "still-failing", IsBuiltin: true, Severity: "critical"},
+		{Identifier: "failing-before-restart", IsBuiltin: true, Severity: "critical"},
+		{Identifier: "recovered-after-restart", IsBuiltin: true, Severity: "critical"},
+	}
+	// Both checks failed, then were Initializing after the operator restarted.
+	restarted := &clustergatev1alpha1.CheckStatus{History: []clustergatev1alpha1.CheckTransition{
+		{Status: "Passing"}, {Status: "Failing"}, {Status: "Initializing"},
+	}}
+	results := []checkResult{
+		{result: checks.Result{Ready: false, Message: "2/3 members healthy"}},
+		{result: checks.Result{Ready: true}},
+		{result: checks.Result{Ready: false}},
+		{result: checks.Result{Ready: false}},
+		{result: checks.Result{Ready: false}, previous: restarted},
+		{result: checks.Result{Ready: true}, previous: restarted},
+	}
+	previous := map[string]string{
+		"etcd": "Passing", "dns": "Failing", "still-failing": "Failing",
+		"failing-before-restart": "Initializing", "recovered-after-restart": "Initializing",
+	}
+	t.Cleanup(func() { metrics.DeleteClusterReadiness("prod") })
+
+	r.notifyCheckIncidents(context.Background(), cr, executed, results, previous, false, metav1.Now())
+	r.notifications.Wait()
+	close(received)
+
+	got := map[request]bool{}
+	for req := range received {
+		got[req] = true
+	}
+	want := map[request]bool{
+		{"/v2/enqueue", "", "trigger", "clustergate/prod/etcd"}:                    true,
+		{"/v2/enqueue", "", "resolve", "clustergate/prod/dns"}:                     true,
+		{"/v2/enqueue", "", "resolve", "clustergate/prod/recovered-after-restart"}: true,
+		{"/v2/alerts", "GenieKey g3nie", "", "clustergate/prod/backups"}:           true,
+	}
+	if len(got) != len(want) {
+		t.Errorf("requests = %v, want %v", got, want)
+	}
+	for req := range want {
+		if !got[req] {
+			t.Errorf("missing request %+v; got %v", req, got)
+		}
+	}
+}
+
+func TestReconcile_NoIncidentsWithinInitialDelay(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	critical := clustergatev1alpha1.SeverityCritical
+	gc := &clustergatev1alpha1.GateCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "api"},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{URL: "http://api.example.com/healthz"},
+		},
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", CreationTimestamp: metav1.Now()},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			// Every reconcile runs the check again.
+			Interval:            metav1.Duration{Duration: time.Nanosecond},
+			InitialDelaySeconds: 300,
+			Checks:              []clustergatev1alpha1.CheckSpec{{GateCheckRef: "api", Severity: &critical}},
+			Notifications: &clustergatev1alpha1.Notifications{
+				Incidents: []clustergatev1alpha1.IncidentNotification{{
+					Name: "pagerduty", Type: clustergatev1alpha1.IncidentProviderPagerDuty,
+					SecretRef: clustergatev1alpha1.SecretReference{Name: "pagerduty"},
+				}},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clustergate-system", Name: "pagerduty"},
+		Data:       map[string][]byte{"routingKey": []byte("r0uting"), "url": []byte(srv.URL)},
+	}
+	r := &ClusterReadinessReconciler{
+		Client:          fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gc, cr, secret).WithStatusSubresource(gc, cr).Build(),
+		Namespace:       "clustergate-system",
+		ReadinessState:  server.NewReadinessState(),
+		DynamicExecutor: stubExecutor{result: checks.Result{Ready: false, Message: "HTTP 503"}},
+		Notifier:        &notify.Sender{Backoff: time.Millisecond},
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "prod"}}
+	t.Cleanup(func() { metrics.DeleteClusterReadiness("prod") })
+
+	for range 2 {
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	r.notifications.Wait()
+	if n := requests.Load(); n != 0 {
+		t.Errorf("%d incident requests within the initial delay, want none", n)
+	}
+}
+
+func TestNotifyAlertmanagers(t *testing.T) {
+	type request struct {
+		path, auth string
+		alerts     []notify.Alert
+	}
+	received := make(chan request, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alerts []notify.Alert
+		if err := json.NewDecoder(r.Body).Decode(&alerts); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		received <- request{r.URL.Path, r.Header.Get("Authorization"), alerts}
+	}))
+	defer srv.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clustergate-system", Name: "alertmanager"},
+		Data:       map[string][]byte{"token": []byte("s3cret")},
+	}
+	r := &ClusterReadinessReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(secret).Build(),
+		Namespace: "clustergate-system",
+		Notifier:  &notify.Sender{Backoff: time.Millisecond},
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Notifications: &clustergatev1alpha1.Notifications{
+				Alertmanagers: []clustergatev1alpha1.AlertmanagerNotification{{
+					Name: "main", URL: srv.URL, SecretRef: &clustergatev1alpha1.SecretReference{Name: "alertmanager"},
+					Labels: map[string]string{"team": "platform"},
+				}},
+			},
+		},
+	}
+	now := metav1.Now()
+	failingSince := metav1.NewTime(now.Add(-time.Hour))
+	statuses := []clustergatev1alpha1.CheckStatus{
+		{Name: "etcd", Status: "Failing", Severity: clustergatev1alpha1.SeverityCritical, Message: "2/3 members healthy",
+			History: []clustergatev1alpha1.CheckTransition{{Status: "Failing", Time: failingSince}}},
+		{Name: "dns", Status: "Passing", Severity: clustergatev1alpha1.SeverityCritical},
+		{Name: "backups", Status: "Passing", Severity: clustergatev1alpha1.SeverityWarning},
+	}
+	results := []checkResult{
+		{name: "dns", severity: "critical", result: checks.Result{Ready: true}},
+		{name: "backups", severity: "warning", result: checks.Result{Ready: true}},
+	}
+	previous := map[string]string{"etcd": "Failing", "dns": "Failing", "backups": "Passing"}
+	t.Cleanup(func() { metrics.DeleteClusterReadiness("prod") })
+
+	r.notifyAlertmanagers(context.Background(), cr, statuses, results, previous, map[string]string{"etcd": "control-plane"}, 4*time.Minute, now)
+	r.notifications.Wait()
+	close(received)
+
+	req, ok := <-received
+	if !ok {
+		t.Fatal("no alerts were pushed")
+	}
+	if req.path != "/api/v2/alerts" || req.auth != "Bearer s3cret" || len(req.alerts) != 2 {
+		t.Fatalf("request = %+v, want two authenticated alerts", req)
+	}
+	firing, resolved := req.alerts[0], req.alerts[1]
+	if firing.Labels["check"] != "etcd" || firing.Labels["category"] != "control-plane" || firing.Labels["team"] != "platform" ||
+		!firing.StartsAt.Equal(failingSince.Time) ||
+		!firing.EndsAt.Equal(now.Add(4*time.Minute)) {
+		t.Errorf("firing alert = %+v, want etcd firing since its transition for the TTL", firing)
+	}
+	if resolved.Labels["check"] != "dns" || !resolved.EndsAt.Equal(now.Time) {
+		t.Errorf("resolved alert = %+v, want dns ending now", resolved)
+	}
+}
+
+func TestEvaluationTTL(t *testing.T) {
+	resolved := []ResolvedCheck{{Interval: time.Minute}, {Interval: 10 * time.Minute}}
+	if got := evaluationTTL(time.Minute, resolved); got != 40*time.Minute {
+		t.Errorf("evaluationTTL() = %v, want 40m", got)
+	}
+}
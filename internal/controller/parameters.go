@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+// parameterRef matches a $(params.<name>) reference to a GateCheck parameter.
+var parameterRef = regexp.MustCompile(`\$\(params\.([A-Za-z_][A-Za-z0-9_]*)\)`)
+
+// ApplyParameters returns a copy of spec with every $(params.<name>) in its
+// string fields replaced by values[name], or by the parameter's default.
+// Values for undeclared parameters, required parameters without a value and
+// references to undeclared parameters are errors.
+func ApplyParameters(spec clustergatev1alpha1.GateCheckSpec, values map[string]string) (clustergatev1alpha1.GateCheckSpec, error) {
+	if len(spec.Parameters) == 0 && len(values) == 0 {
+		return spec, nil
+	}
+
+	resolved := make(map[string]string, len(spec.Parameters))
+	for _, p := range spec.Parameters {
+		if v, ok := values[p.Name]; ok {
+			resolved[p.Name] = v
+		} else if p.Default != nil {
+			resolved[p.Name] = *p.Default
+		} else {
+			return spec, fmt.Errorf("parameter %q is required", p.Name)
+		}
+	}
+	var unknown []string
+	for name := range values {
+		if _, ok := resolved[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return spec, fmt.Errorf("unknown parameters %q", unknown)
+	}
+	return substituteParameters(spec, resolved)
+}
+
+// substituteParameters returns a copy of spec with every $(params.<name>)
+// in its string fields replaced by resolved[name]. It works over the generic
+// JSON form of spec, so every string field is covered.
+func substituteParameters(spec clustergatev1alpha1.GateCheckSpec, resolved map[string]string) (clustergatev1alpha1.GateCheckSpec, error) {
+	template := *spec.DeepCopy()
+	template.Parameters = nil
+	raw, err := json.Marshal(template)
+	if err != nil {
+		return spec, err
+	}
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return spec, err
+	}
+	var undeclared error
+	doc = substitute(doc, func(s string) string {
+		return parameterRef.ReplaceAllStringFunc(s, func(ref string) string {
+			name := parameterRef.FindStringSubmatch(ref)[1]
+			v, ok := resolved[name]
+			if !ok && undeclared == nil {
+				undeclared = fmt.Errorf("undeclared parameter %q", name)
+			}
+			return v
+		})
+	})
+	if undeclared != nil {
+		return spec, undeclared
+	}
+
+	if raw, err = json.Marshal(doc); err != nil {
+		return spec, err
+	}
+	var out clustergatev1alpha1.GateCheckSpec
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return spec, fmt.Errorf("substituting parameters: %w", err)
+	}
+	out.Parameters = spec.Parameters
+	return out, nil
+}
+
+// substitute returns v, a decoded JSON value, with replace applied to every
+// string in it.
+func substitute(v any, replace func(string) string) any {
+	switch v := v.(type) {
+	case string:
+		return replace(v)
+	case map[string]any:
+		for k, elem := range v {
+			v[k] = substitute(elem, replace)
+		}
+	case []any:
+		for i, elem := range v {
+			v[i] = substitute(elem, replace)
+		}
+	}
+	return v
+}
+
+// validateParameters reports references in spec to undeclared parameters.
+func validateParameters(spec clustergatev1alpha1.GateCheckSpec) error {
+	declared := make(map[string]string, len(spec.Parameters))
+	for _, p := range spec.Parameters {
+		declared[p.Name] = ""
+	}
+	_, err := substituteParameters(spec, declared)
+	return err
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"testing"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+func TestApplyParameters(t *testing.T) {
+	path, port := "/healthz", "443"
+	template := clustergatev1alpha1.GateCheckSpec{
+		Parameters: []clustergatev1alpha1.CheckParameter{
+			{Name: "host"},
+			{Name: "path", Default: &path},
+		},
+		HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{
+			URL:                 "https://$(params.host)$(params.path)",
+			ExpectedStatusCodes: []int{200},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		spec    clustergatev1alpha1.GateCheckSpec
+		values  map[string]string
+		wantURL string
+		wantErr string
+	}{
+		{name: "values and defaults", spec: template, values: map[string]string{"host": "api.internal"}, wantURL: "https://api.internal/healthz"},
+		{name: "value overrides default", spec: template, values: map[string]string{"host": "api.internal", "path": "/ready"}, wantURL: "https://api.internal/ready"},
+		{name: "required parameter missing", spec: template, wantErr: `parameter "host" is required`},
+		{name: "unknown value", spec: template, values: map[string]string{"host": "a", "port": "8080"}, wantErr: `unknown parameters ["port"]`},
+		{
+			name: "undeclared reference",
+			spec: clustergatev1alpha1.GateCheckSpec{
+				Parameters: []clustergatev1alpha1.CheckParameter{{Name: "port", Default: &port}},
+				HTTPCheck:  &clustergatev1alpha1.HTTPCheckSpec{URL: "https://$(params.host):$(params.port)"},
+			},
+			wantErr: `undeclared parameter "host"`,
+		},
+		{
+			name:    "not a template",
+			spec:    clustergatev1alpha1.GateCheckSpec{HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{URL: "https://$(params.host)"}},
+			wantURL: "https://$(params.host)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyParameters(tt.spec, tt.values)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.HTTPCheck.URL != tt.wantURL {
+				t.Errorf("url = %q, want %q", got.HTTPCheck.URL, tt.wantURL)
+			}
+			if codes := got.HTTPCheck.ExpectedStatusCodes; tt.spec.Parameters != nil && (len(codes) != 1 || codes[0] != 200) {
+				t.Errorf("expectedStatusCodes = %v, want [200] preserved", codes)
+			}
+		})
+	}
+
+	if template.HTTPCheck.URL != "https://$(params.host)$(params.path)" {
+		t.Errorf("template was modified: %q", template.HTTPCheck.URL)
+	}
+}
@@ -0,0 +1,229 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/bundle"
+	"github.com/clustergate/clustergate/internal/library"
+)
+
+const (
+	// conditionSourceReady reports whether a GateProfile's source was imported.
+	conditionSourceReady = "SourceReady"
+
+	// bundleKey is the ConfigMap key of a profile bundle; its signature is
+	// under bundleKey + ".sig".
+	bundleKey = "bundle.yaml"
+
+	// defaultSourceInterval is how often a profile source is pulled when
+	// spec.source.interval is unset.
+	defaultSourceInterval = 10 * time.Minute
+
+	// sourcePullTimeout bounds an OCI pull with the default HTTP client.
+	sourcePullTimeout = 30 * time.Second
+)
+
+// Reasons of the SourceReady condition.
+const (
+	reasonImported           = "Imported"
+	reasonFetchFailed        = "FetchFailed"
+	reasonVerificationFailed = "VerificationFailed"
+	reasonInvalidBundle      = "InvalidBundle"
+	reasonGateCheckConflict  = "GateCheckConflict"
+)
+
+// importSource pulls profile's bundle, verifies it, materializes its
+// GateChecks and records its version and checks in status.source. It returns
+// the SourceReady condition; on failure the last imported content is kept.
+func (r *GateProfileReconciler) importSource(ctx context.Context, profile *clustergatev1alpha1.GateProfile, now metav1.Time) metav1.Condition {
+	src := profile.Spec.Source
+	failed := func(reason string, err error) metav1.Condition {
+		log.FromContext(ctx).Info("failed to import GateProfile source", "name", profile.Name, "reason", reason, "error", err.Error())
+		return metav1.Condition{Type: conditionSourceReady, Status: metav1.ConditionFalse, Reason: reason, Message: err.Error()}
+	}
+
+	data, signature, err := r.fetchBundle(ctx, src)
+	if err != nil {
+		return failed(reasonFetchFailed, err)
+	}
+	if src.Verify != nil {
+		secret, err := getSecret(ctx, r.Client, &src.Verify.PublicKeyRef, r.Namespace)
+		if err != nil {
+			return failed(reasonVerificationFailed, err)
+		}
+		if err := bundle.Verify(secret.Data["publicKey"], data, signature); err != nil {
+			return failed(reasonVerificationFailed, err)
+		}
+	}
+	b, err := bundle.Load(data)
+	if err != nil {
+		return failed(reasonInvalidBundle, err)
+	}
+	if src.Verify == nil {
+		if err := requireVerifiedGateChecks(b.GateChecks); err != nil {
+			return failed(reasonVerificationFailed, err)
+		}
+	}
+	if cond := validateProfileChecks(b.Profile.Spec.Checks); cond.Status != metav1.ConditionTrue {
+		return failed(reasonInvalidBundle, fmt.Errorf("%s: %s", cond.Reason, cond.Message))
+	}
+	names, err := r.materializeGateChecks(ctx, profile, b.GateChecks)
+	if err != nil {
+		return failed(reasonGateCheckConflict, err)
+	}
+
+	digest := bundle.Digest(data)
+	profile.Status.Source = &clustergatev1alpha1.ProfileSourceStatus{
+		Digest:       digest,
+		Version:      b.Profile.Spec.Version,
+		Checks:       b.Profile.Spec.Checks,
+		GateChecks:   names,
+		LastSyncTime: now,
+	}
+	return metav1.Condition{
+		Type:    conditionSourceReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonImported,
+		Message: fmt.Sprintf("imported %s: %d checks, %d GateChecks", digest, len(b.Profile.Spec.Checks), len(names)),
+	}
+}
+
+// requireVerifiedGateChecks returns an error when one of gateChecks, from a
+// bundle without a verified signature, runs code or reaches systems on the
+// operator's behalf: script checks run arbitrary images, Git and backup
+// checks use the operator's credentials.
+func requireVerifiedGateChecks(gateChecks []*clustergatev1alpha1.GateCheck) error {
+	for _, gc := range gateChecks {
+		var checkType string
+		switch {
+		case gc.Spec.ScriptCheck != nil:
+			checkType = "scriptCheck"
+		case gc.Spec.GitCheck != nil:
+			checkType = "gitCheck"
+		case gc.Spec.BackupCheck != nil:
+			checkType = "backupCheck"
+		default:
+			continue
+		}
+		return fmt.Errorf("GateCheck %s has a %s, which bundles may only carry when verified with spec.source.verify", gc.Name, checkType)
+	}
+	return nil
+}
+
+// fetchBundle returns the bundle src locates and its signature, if any.
+func (r *GateProfileReconciler) fetchBundle(ctx context.Context, src *clustergatev1alpha1.ProfileSource) ([]byte, string, error) {
+	if ref := src.ConfigMapRef; ref != nil {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = r.Namespace
+		}
+		var cm corev1.ConfigMap
+		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &cm); err != nil {
+			return nil, "", fmt.Errorf("failed to get ConfigMap %s/%s: %w", namespace, ref.Name, err)
+		}
+		data, ok := cm.Data[bundleKey]
+		if !ok {
+			return nil, "", fmt.Errorf("ConfigMap %s/%s has no %q key", namespace, ref.Name, bundleKey)
+		}
+		return []byte(data), cm.Data[bundleKey+".sig"], nil
+	}
+
+	var opts bundle.PullOptions
+	if src.SecretRef != nil {
+		secret, err := getSecret(ctx, r.Client, src.SecretRef, r.Namespace)
+		if err != nil {
+			return nil, "", err
+		}
+		opts.Username = string(secret.Data["username"])
+		opts.Password = string(secret.Data["password"])
+	}
+	opts.Insecure = src.Insecure
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: sourcePullTimeout}
+	}
+	artifact, err := bundle.Pull(ctx, httpClient, src.OCIRef, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	return artifact.Data, artifact.Signature, nil
+}
+
+// materializeGateChecks creates or updates gateChecks as GateChecks controlled
+// by profile, and deletes those it materialized before that are no longer in
+// the bundle. GateChecks of the same name not controlled by profile are
+// conflicts. It returns the names of the materialized GateChecks.
+func (r *GateProfileReconciler) materializeGateChecks(ctx context.Context, profile *clustergatev1alpha1.GateProfile, gateChecks []*clustergatev1alpha1.GateCheck) ([]string, error) {
+	isController := true
+	owner := metav1.OwnerReference{
+		APIVersion: clustergatev1alpha1.GroupVersion.String(),
+		Kind:       "GateProfile",
+		Name:       profile.Name,
+		UID:        profile.UID,
+		Controller: &isController,
+	}
+
+	var names []string
+	for _, desired := range gateChecks {
+		var existing clustergatev1alpha1.GateCheck
+		err := r.Get(ctx, types.NamespacedName{Name: desired.Name}, &existing)
+		switch {
+		case apierrors.IsNotFound(err):
+			gc := &clustergatev1alpha1.GateCheck{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            desired.Name,
+					Labels:          map[string]string{library.ManagedByLabel: library.ManagedByValue},
+					OwnerReferences: []metav1.OwnerReference{owner},
+				},
+				Spec: desired.Spec,
+			}
+			if err := r.Create(ctx, gc); err != nil {
+				return nil, fmt.Errorf("creating GateCheck %s: %w", desired.Name, err)
+			}
+		case err != nil:
+			return nil, err
+		case !metav1.IsControlledBy(&existing, profile):
+			return nil, fmt.Errorf("GateCheck %s exists and is not controlled by GateProfile %s", desired.Name, profile.Name)
+		case !equality.Semantic.DeepEqual(existing.Spec, desired.Spec):
+			existing.Spec = desired.Spec
+			if err := r.Update(ctx, &existing); err != nil {
+				return nil, fmt.Errorf("updating GateCheck %s: %w", desired.Name, err)
+			}
+		}
+		names = append(names, desired.Name)
+	}
+
+	if profile.Status.Source != nil {
+		for _, name := range profile.Status.Source.GateChecks {
+			if slices.Contains(names, name) {
+				continue
+			}
+			var stale clustergatev1alpha1.GateCheck
+			if err := r.Get(ctx, types.NamespacedName{Name: name}, &stale); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			if metav1.IsControlledBy(&stale, profile) {
+				if err := r.Delete(ctx, &stale); client.IgnoreNotFound(err) != nil {
+					return nil, fmt.Errorf("deleting GateCheck %s: %w", name, err)
+				}
+			}
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+const testProfileBundle = `apiVersion: clustergate.io/v1alpha1
+kind: GateCheck
+metadata:
+  name: platform-ingress
+spec:
+  category: networking
+  severity: critical
+  httpCheck:
+    url: http://ingress.example.com/healthz
+---
+apiVersion: clustergate.io/v1alpha1
+kind: GateProfile
+metadata:
+  name: platform
+spec:
+  version: 1.4.0
+  checks:
+    - name: dns
+    - gateCheckRef: platform-ingress
+`
+
+func TestGateProfileReconciler_ImportSource(t *testing.T) {
+	ctx := context.Background()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clustergate-system", Name: "profile-key"},
+		Data:       map[string][]byte{"publicKey": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})},
+	}
+	bundleCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clustergate-system", Name: "platform-profile"},
+		Data: map[string]string{
+			bundleKey:          testProfileBundle,
+			bundleKey + ".sig": base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(testProfileBundle))),
+		},
+	}
+	profile := &clustergatev1alpha1.GateProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "fleet-platform", UID: "profile-uid"},
+		Spec: clustergatev1alpha1.GateProfileSpec{
+			Source: &clustergatev1alpha1.ProfileSource{
+				ConfigMapRef: &clustergatev1alpha1.ConfigMapReference{Name: "platform-profile"},
+				Verify: &clustergatev1alpha1.ProfileVerification{
+					PublicKeyRef: clustergatev1alpha1.SecretReference{Name: "profile-key"},
+				},
+				Interval: &metav1.Duration{Duration: time.Minute},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(key, bundleCM, profile).
+		WithStatusSubresource(profile).Build()
+	r := &GateProfileReconciler{Client: c, Namespace: "clustergate-system"}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: profile.Name}}
+
+	reconcile := func() *clustergatev1alpha1.GateProfile {
+		t.Helper()
+		result, err := r.Reconcile(ctx, req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.RequeueAfter != time.Minute {
+			t.Errorf("RequeueAfter = %v, want the source interval", result.RequeueAfter)
+		}
+		var got clustergatev1alpha1.GateProfile
+		if err := c.Get(ctx, req.NamespacedName, &got); err != nil {
+			t.Fatal(err)
+		}
+		return &got
+	}
+
+	got := reconcile()
+	if cond := meta.FindStatusCondition(got.Status.Conditions, conditionSourceReady); cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Fatalf("SourceReady = %+v, want True", cond)
+	}
+	if version, checks := got.Current(); version != "1.4.0" || len(checks) != 2 {
+		t.Errorf("current = %s %+v, want the bundle's version and checks", version, checks)
+	}
+	if len(got.Status.Versions) != 1 || got.Status.Versions[0].Version != "1.4.0" {
+		t.Errorf("versions = %+v, want the imported version recorded", got.Status.Versions)
+	}
+	var gc clustergatev1alpha1.GateCheck
+	if err := c.Get(ctx, types.NamespacedName{Name: "platform-ingress"}, &gc); err != nil {
+		t.Fatalf("bundled GateCheck not created: %v", err)
+	}
+	if !metav1.IsControlledBy(&gc, got) {
+		t.Errorf("GateCheck owners = %+v, want controlled by the profile", gc.OwnerReferences)
+	}
+
+	// Imported checks resolve like the profile's own.
+	spec := clustergatev1alpha1.ClusterReadinessSpec{Profiles: []clustergatev1alpha1.ProfileRef{{Name: profile.Name}}}
+	resolved, _, err := ResolveChecks(ctx, c, spec, time.Minute)
+	if err != nil || len(resolved) != 2 {
+		t.Errorf("resolved %d checks, err %v; want the 2 imported", len(resolved), err)
+	}
+
+	// A tampered bundle is rejected and the last import is kept.
+	bundleCM.Data[bundleKey] = strings.Replace(testProfileBundle, "- name: dns\n", "", 1)
+	if err := c.Update(ctx, bundleCM); err != nil {
+		t.Fatal(err)
+	}
+	got = reconcile()
+	if cond := meta.FindStatusCondition(got.Status.Conditions, conditionSourceReady); cond == nil || cond.Reason != reasonVerificationFailed {
+		t.Errorf("SourceReady = %+v, want %s", cond, reasonVerificationFailed)
+	}
+	if _, checks := got.Current(); len(checks) != 2 {
+		t.Errorf("current checks = %+v, want the last import kept", checks)
+	}
+
+	// GateChecks dropped from the bundle are deleted.
+	got.Spec.Source.Verify = nil
+	if err := c.Update(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	bundleCM.Data[bundleKey] = testProfileBundle[strings.Index(testProfileBundle, "apiVersion: clustergate.io/v1alpha1\nkind: GateProfile"):]
+	if err := c.Update(ctx, bundleCM); err != nil {
+		t.Fatal(err)
+	}
+	got = reconcile()
+	if got.Status.Source == nil || len(got.Status.Source.GateChecks) != 0 {
+		t.Errorf("source = %+v, want no GateChecks", got.Status.Source)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "platform-ingress"}, &gc); !apierrors.IsNotFound(err) {
+		t.Errorf("stale GateCheck: err = %v, want NotFound", err)
+	}
+
+	// Unverified bundles cannot carry script checks.
+	bundleCM.Data[bundleKey] = strings.Replace(testProfileBundle, "  httpCheck:\n    url: http://ingress.example.com/healthz\n",
+		"  scriptCheck:\n    image: registry.example.com/anything:latest\n", 1)
+	if err := c.Update(ctx, bundleCM); err != nil {
+		t.Fatal(err)
+	}
+	got = reconcile()
+	cond := meta.FindStatusCondition(got.Status.Conditions, conditionSourceReady)
+	if cond == nil || cond.Reason != reasonVerificationFailed || !strings.Contains(cond.Message, "GateCheck platform-ingress has a scriptCheck") {
+		t.Errorf("SourceReady = %+v, want %s for the scriptCheck", cond, reasonVerificationFailed)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "platform-ingress"}, &gc); !apierrors.IsNotFound(err) {
+		t.Errorf("unverified script GateCheck: err = %v, want NotFound", err)
+	}
+}
+
+func TestMaterializeGateChecks_Conflict(t *testing.T) {
+	existing := &clustergatev1alpha1.GateCheck{ObjectMeta: metav1.ObjectMeta{Name: "platform-ingress"}}
+	profile := &clustergatev1alpha1.GateProfile{ObjectMeta: metav1.ObjectMeta{Name: "fleet-platform", UID: "profile-uid"}}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(existing).Build()
+	r := &GateProfileReconciler{Client: c}
+
+	desired := &clustergatev1alpha1.GateCheck{ObjectMeta: metav1.ObjectMeta{Name: "platform-ingress"}}
+	_, err := r.materializeGateChecks(context.Background(), profile, []*clustergatev1alpha1.GateCheck{desired})
+	if err == nil || !strings.Contains(err.Error(), "not controlled by GateProfile fleet-platform") {
+		t.Errorf("error = %v, want a conflict", err)
+	}
+	var gc clustergatev1alpha1.GateCheck
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(existing), &gc); err != nil || len(gc.OwnerReferences) != 0 {
+		t.Errorf("existing GateCheck was modified: %+v, %v", gc, err)
+	}
+}
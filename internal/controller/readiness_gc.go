@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/metrics"
+	"github.com/clustergate/clustergate/internal/server"
+)
+
+const defaultReadinessGCInterval = 5 * time.Minute
+
+// ReadinessStateGC periodically reconciles ReadinessState against the live
+// ClusterReadiness CRs. It evicts entries left behind by renames or missed
+// delete events, which would otherwise hold /readyz at 503 indefinitely.
+type ReadinessStateGC struct {
+	Client         client.Reader
+	ReadinessState *server.ReadinessState
+	// GateReadinessState, when set, is pruned of gates that are no longer
+	// defined by a live ClusterReadiness.
+	GateReadinessState *server.ReadinessState
+	// NamespaceReadinessState, when set, is pruned of NamespaceReadiness CRs
+	// that no longer exist, which would otherwise hold their namespace's
+	// readyz path at 503.
+	NamespaceReadinessState *server.ReadinessState
+	Interval                time.Duration
+}
+
+// Start runs the collector until the context is cancelled. It implements manager.Runnable.
+func (g *ReadinessStateGC) Start(ctx context.Context) error {
+	interval := g.Interval
+	if interval <= 0 {
+		interval = defaultReadinessGCInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := g.Collect(ctx); err != nil {
+				log.FromContext(ctx).Error(err, "failed to garbage-collect readiness state")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection returns false: every replica serves /readyz from its own state.
+func (g *ReadinessStateGC) NeedLeaderElection() bool {
+	return false
+}
+
+// Collect evicts state for ClusterReadiness and NamespaceReadiness CRs that
+// no longer exist and returns the evicted keys: ClusterReadiness names, then
+// NamespaceReadiness namespace/name keys. State set after the CRs were listed
+// is kept, as its CR may have been created since.
+func (g *ReadinessStateGC) Collect(ctx context.Context) ([]string, error) {
+	listed := time.Now()
+	var list clustergatev1alpha1.ClusterReadinessList
+	if err := g.Client.List(ctx, &list); err != nil {
+		return nil, err
+	}
+
+	live := make(map[string]bool, len(list.Items))
+	liveGates := make(map[string]bool)
+	for _, cr := range list.Items {
+		live[cr.Name] = true
+		for _, gate := range cr.Spec.Gates {
+			liveGates[gateKey(gate.Name, cr.Name)] = true
+		}
+	}
+
+	if g.GateReadinessState != nil {
+		for _, key := range g.GateReadinessState.Prune(liveGates, listed) {
+			log.FromContext(ctx).Info("evicted stale gate readiness state", "gate", key)
+			metrics.ReadinessStateEvictions.Inc()
+		}
+	}
+
+	evicted := g.ReadinessState.Prune(live, listed)
+	for _, name := range evicted {
+		log.FromContext(ctx).Info("evicted stale readiness state", "clusterReadiness", name)
+		metrics.DeleteClusterReadiness(name)
+		metrics.ReadinessStateEvictions.Inc()
+	}
+
+	if g.NamespaceReadinessState != nil {
+		namespaced, err := g.collectNamespaces(ctx)
+		if err != nil {
+			return evicted, err
+		}
+		evicted = append(evicted, namespaced...)
+	}
+	return evicted, nil
+}
+
+// collectNamespaces evicts state for NamespaceReadiness CRs that no longer
+// exist and returns the evicted keys.
+func (g *ReadinessStateGC) collectNamespaces(ctx context.Context) ([]string, error) {
+	listed := time.Now()
+	var list clustergatev1alpha1.NamespaceReadinessList
+	if err := g.Client.List(ctx, &list); err != nil {
+		return nil, err
+	}
+	live := make(map[string]bool, len(list.Items))
+	for _, nr := range list.Items {
+		live[NamespaceReadinessKey(nr.Namespace, nr.Name)] = true
+	}
+
+	evicted := g.NamespaceReadinessState.Prune(live, listed)
+	for _, key := range evicted {
+		log.FromContext(ctx).Info("evicted stale namespace readiness state", "namespaceReadiness", key)
+		if namespace, name, ok := strings.Cut(key, "/"); ok {
+			metrics.DeleteNamespaceReadiness(namespace, name)
+		}
+		metrics.ReadinessStateEvictions.Inc()
+	}
+	return evicted, nil
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/metrics"
+	"github.com/clustergate/clustergate/internal/server"
+)
+
+func TestReadinessStateGC_Collect(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(
+		&clustergatev1alpha1.ClusterReadiness{ObjectMeta: metav1.ObjectMeta{Name: "new-name"}},
+	).Build()
+
+	state := server.NewReadinessState()
+	state.Update("new-name", "Healthy", nil, nil, nil)
+	state.Update("old-name", "Unhealthy", nil, nil, nil)
+	metrics.ClusterReady.WithLabelValues("old-name").Set(0)
+
+	before := testutil.ToFloat64(metrics.ReadinessStateEvictions)
+	gc := &ReadinessStateGC{Client: c, ReadinessState: state}
+
+	evicted, err := gc.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(evicted) != 1 || evicted[0] != "old-name" {
+		t.Errorf("evicted = %v, want [old-name]", evicted)
+	}
+	if !state.IsReady() {
+		t.Error("expected ready once the stale entry is evicted")
+	}
+	if got := testutil.ToFloat64(metrics.ReadinessStateEvictions) - before; got != 1 {
+		t.Errorf("evictions metric increased by %v, want 1", got)
+	}
+	if n := testutil.CollectAndCount(metrics.ClusterReady, "clustergate_cluster_ready"); n != 0 {
+		t.Errorf("expected stale cluster_ready series to be deleted, %d remain", n)
+	}
+}
+
+func TestReadinessStateGC_CollectGates(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(
+		&clustergatev1alpha1.ClusterReadiness{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+			Spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Gates: []clustergatev1alpha1.ReadinessGate{{Name: "workloads"}},
+			},
+		},
+	).Build()
+
+	gates := server.NewReadinessState()
+	gates.Update("workloads/prod", "Healthy", nil, nil, nil)
+	gates.Update("batch/prod", "Unhealthy", nil, nil, nil)
+	gates.Update("workloads/deleted", "Unhealthy", nil, nil, nil)
+
+	before := testutil.ToFloat64(metrics.ReadinessStateEvictions)
+	gc := &ReadinessStateGC{Client: c, ReadinessState: server.NewReadinessState(), GateReadinessState: gates}
+	if _, err := gc.Collect(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := testutil.ToFloat64(metrics.ReadinessStateEvictions) - before; got != 2 {
+		t.Errorf("evictions metric increased by %v, want 2", got)
+	}
+	if evicted := gates.Prune(map[string]bool{}, time.Now()); len(evicted) != 1 || evicted[0] != "workloads/prod" {
+		t.Errorf("remaining gate state = %v, want [workloads/prod]", evicted)
+	}
+}
+
+func TestReadinessStateGC_CollectNamespaces(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(
+		&clustergatev1alpha1.NamespaceReadiness{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "new-name"}},
+	).Build()
+
+	namespaces := server.NewReadinessState()
+	namespaces.Update("shop/new-name", "Healthy", nil, nil, nil)
+	namespaces.Update("shop/old-name", "Unhealthy", nil, nil, nil)
+	namespaces.Update("deleted/checkout", "Unhealthy", nil, nil, nil)
+	metrics.NamespaceReady.WithLabelValues("shop", "old-name").Set(0)
+
+	before := testutil.ToFloat64(metrics.ReadinessStateEvictions)
+	gc := &ReadinessStateGC{Client: c, ReadinessState: server.NewReadinessState(), NamespaceReadinessState: namespaces}
+
+	evicted, err := gc.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sort.Strings(evicted)
+	if len(evicted) != 2 || evicted[0] != "deleted/checkout" || evicted[1] != "shop/old-name" {
+		t.Errorf("evicted = %v, want [deleted/checkout shop/old-name]", evicted)
+	}
+	if !namespaces.IsReady() {
+		t.Error("expected ready once the stale entries are evicted")
+	}
+	if got := testutil.ToFloat64(metrics.ReadinessStateEvictions) - before; got != 2 {
+		t.Errorf("evictions metric increased by %v, want 2", got)
+	}
+	if n := testutil.CollectAndCount(metrics.NamespaceReady, "clustergate_namespace_ready"); n != 0 {
+		t.Errorf("expected stale namespace_ready series to be deleted, %d remain", n)
+	}
+}
+
+func TestReadinessStateGC_CollectSparesStateSetAfterListing(t *testing.T) {
+	state := server.NewReadinessState()
+	state.Update("deleted", "Unhealthy", nil, nil, nil)
+	// A ClusterReadiness created and reconciled while the collector lists,
+	// so the list does not include it.
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			state.Update("created", "Healthy", nil, nil, nil)
+			return c.List(ctx, list, opts...)
+		},
+	}).Build()
+
+	before := testutil.ToFloat64(metrics.ReadinessStateEvictions)
+	evicted, err := (&ReadinessStateGC{Client: c, ReadinessState: state}).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(evicted) != 1 || evicted[0] != "deleted" {
+		t.Errorf("evicted = %v, want [deleted]", evicted)
+	}
+	if got := testutil.ToFloat64(metrics.ReadinessStateEvictions) - before; got != 1 {
+		t.Errorf("evictions metric increased by %v, want 1", got)
+	}
+	if !state.IsReady() {
+		t.Error("expected the state set after listing to be kept")
+	}
+}
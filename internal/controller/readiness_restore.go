@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/server"
+)
+
+// ReadinessStateRestorer fills ReadinessState from the status persisted on
+// ClusterReadiness and NamespaceReadiness CRs when the operator starts, so
+// /readyz serves the last evaluation instead of 503 until the first reconcile
+// completes. Entries already set by a reconcile are left alone.
+type ReadinessStateRestorer struct {
+	Client         client.Reader
+	ReadinessState *server.ReadinessState
+	// GateReadinessState, when set, is restored from each gate's status.
+	GateReadinessState *server.ReadinessState
+	// NamespaceReadinessState, when set, is restored from NamespaceReadiness CRs.
+	NamespaceReadinessState *server.ReadinessState
+}
+
+// Start restores the state once and returns. It implements manager.Runnable.
+func (r *ReadinessStateRestorer) Start(ctx context.Context) error {
+	restored, err := r.Restore(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to restore readiness state")
+		return nil
+	}
+	log.FromContext(ctx).Info("restored readiness state from status", "count", restored)
+	return nil
+}
+
+// NeedLeaderElection returns false: standby replicas serve /readyz too, and
+// would otherwise have no state until they become leader.
+func (r *ReadinessStateRestorer) NeedLeaderElection() bool {
+	return false
+}
+
+// Restore publishes the persisted status of every evaluated ClusterReadiness
+// and NamespaceReadiness that has no state yet, and returns how many entries
+// it restored.
+func (r *ReadinessStateRestorer) Restore(ctx context.Context) (int, error) {
+	restored := 0
+	var list clustergatev1alpha1.ClusterReadinessList
+	if err := r.Client.List(ctx, &list); err != nil {
+		return restored, err
+	}
+	for _, cr := range list.Items {
+		if cr.Status.LastChecked == nil {
+			continue
+		}
+		eval := persistedEvaluation(cr.Status, cr.Spec.DegradedThreshold)
+		eval.requireCategories(cr.Spec.RequiredCategories)
+		eval.applyPolicy(cr.Spec.ReadinessPolicy)
+		eval.state = cr.Status.State
+		if eval.restoreState(r.ReadinessState, cr.Name) {
+			restored++
+		}
+		if r.GateReadinessState != nil {
+			restored += r.restoreGates(&cr, eval)
+		}
+	}
+
+	if r.NamespaceReadinessState == nil {
+		return restored, nil
+	}
+	var namespaced clustergatev1alpha1.NamespaceReadinessList
+	if err := r.Client.List(ctx, &namespaced); err != nil {
+		return restored, err
+	}
+	for _, nr := range namespaced.Items {
+		if nr.Status.LastChecked == nil {
+			continue
+		}
+		eval := persistedEvaluation(nr.Status, nr.Spec.DegradedThreshold)
+		eval.state = nr.Status.State
+		if eval.restoreState(r.NamespaceReadinessState, NamespaceReadinessKey(nr.Namespace, nr.Name)) {
+			restored++
+		}
+	}
+	return restored, nil
+}
+
+// restoreGates publishes the persisted state of each of cr's gates, evaluated
+// from eval, and returns how many it restored.
+func (r *ReadinessStateRestorer) restoreGates(cr *clustergatev1alpha1.ClusterReadiness, eval evaluation) int {
+	restored := 0
+	for _, gate := range cr.Spec.Gates {
+		for _, gs := range cr.Status.Gates {
+			if gs.Name != gate.Name {
+				continue
+			}
+			gateEval := evaluateGate(gate, eval, cr.Spec.DegradedThreshold, cr.Status.LastChecked)
+			gateEval.state = gs.State
+			if gateEval.restoreState(r.GateReadinessState, gateKey(gate.Name, cr.Name)) {
+				restored++
+			}
+		}
+	}
+	return restored
+}
+
+// persistedEvaluation rebuilds the evaluation recorded in status from its
+// check statuses, without running any check.
+func persistedEvaluation(status clustergatev1alpha1.ClusterReadinessStatus, degradedThreshold *intstr.IntOrString) evaluation {
+	statuses, categoryLookup, _ := flattenCategories(status.Categories)
+	initializing := status.State == clustergatev1alpha1.ClusterInitializing
+	return evaluate(nil, statuses, categoryLookup, degradedThreshold, initializing, status.LastChecked)
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/server"
+)
+
+func TestReadinessStateRestorer_Restore(t *testing.T) {
+	lastChecked := metav1.Now()
+	evaluated := clustergatev1alpha1.ClusterReadinessStatus{
+		State:       clustergatev1alpha1.ClusterDegraded,
+		LastChecked: &lastChecked,
+		Categories: []clustergatev1alpha1.CategoryStatus{
+			{Category: "networking", Checks: []clustergatev1alpha1.CheckStatus{
+				{Name: "dns", Status: "Passing", Severity: clustergatev1alpha1.SeverityCritical},
+			}},
+			{Category: "storage", Checks: []clustergatev1alpha1.CheckStatus{
+				{Name: "backups", Status: "Failing", Severity: clustergatev1alpha1.SeverityWarning, Message: "last backup 3d ago"},
+			}},
+		},
+		Gates: []clustergatev1alpha1.GateStatus{{Name: "network", State: clustergatev1alpha1.ClusterHealthy, Ready: true}},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(
+		&clustergatev1alpha1.ClusterReadiness{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+			Spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Gates: []clustergatev1alpha1.ReadinessGate{{Name: "network", Categories: []string{"networking"}}},
+			},
+			Status: evaluated,
+		},
+		&clustergatev1alpha1.ClusterReadiness{ObjectMeta: metav1.ObjectMeta{Name: "never-evaluated"}},
+		&clustergatev1alpha1.ClusterReadiness{ObjectMeta: metav1.ObjectMeta{Name: "fresh"}, Status: evaluated},
+		&clustergatev1alpha1.NamespaceReadiness{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "checkout"},
+			Status:     evaluated,
+		},
+	).Build()
+
+	state := server.NewReadinessState()
+	state.Update("fresh", "Unhealthy", nil, nil, nil)
+	gates := server.NewReadinessState()
+	namespaced := server.NewReadinessState()
+	r := &ReadinessStateRestorer{Client: c, ReadinessState: state, GateReadinessState: gates, NamespaceReadinessState: namespaced}
+
+	restored, err := r.Restore(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restored != 3 {
+		t.Errorf("restored %d entries, want prod, its gate and the NamespaceReadiness", restored)
+	}
+
+	readyz := func(handler http.HandlerFunc, pattern, path string) (int, map[string]server.ClusterState) {
+		t.Helper()
+		mux := http.NewServeMux()
+		mux.HandleFunc(pattern, handler)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		var body struct {
+			Clusters map[string]server.ClusterState `json:"clusters"`
+		}
+		_ = json.Unmarshal(rec.Body.Bytes(), &body)
+		return rec.Code, body.Clusters
+	}
+
+	// The entry set by a reconcile is kept, so /readyz stays 503.
+	code, clusters := readyz(server.ReadyzHandler(state), "/readyz", "/readyz")
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("/readyz = %d, want 503 from the fresher entry", code)
+	}
+	prod := clusters["prod"]
+	if prod.State != "Degraded" || prod.Summary == nil || prod.Summary.WarningFailing != 1 || prod.Checks["backups"] == nil {
+		t.Errorf("prod = %+v, want the persisted Degraded evaluation", prod)
+	}
+	if clusters["fresh"].State != "Unhealthy" {
+		t.Errorf("fresh = %+v, want the reconciled state kept", clusters["fresh"])
+	}
+	if _, ok := clusters["never-evaluated"]; ok {
+		t.Error("expected no state for a ClusterReadiness never evaluated")
+	}
+
+	if code, _ := readyz(server.GateReadyzHandler(gates), "/readyz/{gate}", "/readyz/network"); code != http.StatusOK {
+		t.Errorf("/readyz/network = %d, want 200", code)
+	}
+	if code, _ := readyz(server.NamespaceReadyzHandler(namespaced), "/readyz/namespaces/{namespace}", "/readyz/namespaces/shop"); code != http.StatusOK {
+		t.Errorf("/readyz/namespaces/shop = %d, want 200", code)
+	}
+}
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+// readyMarkerLabel names the ClusterReadiness a ready marker belongs to.
+const readyMarkerLabel = "clustergate.io/cluster-readiness"
+
+// reconcileReadyMarkers creates or renews cr's ready markers while ready is
+// set, and deletes them once it is not, along with markers no longer
+// configured. The markers it maintains are recorded in cr's status. A Lease
+// lasts ttl unless renewed.
+func (r *ClusterReadinessReconciler) reconcileReadyMarkers(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, ready bool, state clustergatev1alpha1.ClusterHealthState, ttl time.Duration, now metav1.Time) error {
+	var desired []clustergatev1alpha1.ReadyMarker
+	if ready && cr.Spec.Actions != nil {
+		for _, m := range cr.Spec.Actions.ReadyMarkers {
+			if m.Namespace == "" {
+				m.Namespace = r.Namespace
+			}
+			desired = append(desired, m)
+		}
+	}
+
+	var errs []error
+	var maintained []clustergatev1alpha1.ReadyMarker
+	for _, m := range desired {
+		if err := r.applyReadyMarker(ctx, cr, m, state, ttl, now); err != nil {
+			errs = append(errs, err)
+		}
+		maintained = append(maintained, m)
+	}
+	for _, m := range cr.Status.ReadyMarkers {
+		if slices.Contains(desired, m) {
+			continue
+		}
+		if err := r.deleteReadyMarker(ctx, cr, m); err != nil {
+			// Keep it recorded so the deletion is retried.
+			errs = append(errs, err)
+			maintained = append(maintained, m)
+		}
+	}
+	cr.Status.ReadyMarkers = maintained
+	return errors.Join(errs...)
+}
+
+// applyReadyMarker creates or updates the object of marker m.
+func (r *ClusterReadinessReconciler) applyReadyMarker(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, m clustergatev1alpha1.ReadyMarker, state clustergatev1alpha1.ClusterHealthState, ttl time.Duration, now metav1.Time) error {
+	obj := readyMarkerObject(m)
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+		if obj.GetResourceVersion() != "" && !metav1.IsControlledBy(obj, cr) {
+			return fmt.Errorf("it exists and is not managed by ClusterReadiness %s", cr.Name)
+		}
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[readyMarkerLabel] = cr.Name
+		obj.SetLabels(labels)
+
+		switch obj := obj.(type) {
+		case *corev1.ConfigMap:
+			readySince := obj.Data["readySince"]
+			if readySince == "" {
+				readySince = now.UTC().Format(time.RFC3339)
+			}
+			obj.Data = map[string]string{
+				"clusterReadiness": cr.Name,
+				"ready":            "true",
+				"state":            string(state),
+				"readySince":       readySince,
+			}
+		case *coordinationv1.Lease:
+			holder := "clustergate/" + cr.Name
+			duration := int32(ttl.Seconds())
+			obj.Spec.HolderIdentity = &holder
+			obj.Spec.LeaseDurationSeconds = &duration
+			if obj.Spec.AcquireTime == nil {
+				obj.Spec.AcquireTime = &metav1.MicroTime{Time: now.Time}
+			}
+			obj.Spec.RenewTime = &metav1.MicroTime{Time: now.Time}
+		}
+		return controllerutil.SetControllerReference(cr, obj, r.Scheme())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sync ready marker %s %s/%s: %w", m.Kind, m.Namespace, m.Name, err)
+	}
+	if op == controllerutil.OperationResultCreated {
+		log.FromContext(ctx).Info("created ready marker", "kind", m.Kind, "namespace", m.Namespace, "name", m.Name)
+	}
+	return nil
+}
+
+// deleteReadyMarker deletes the object of marker m, if cr manages it.
+func (r *ClusterReadinessReconciler) deleteReadyMarker(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, m clustergatev1alpha1.ReadyMarker) error {
+	obj := readyMarkerObject(m)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(obj, cr) {
+		return nil
+	}
+	log.FromContext(ctx).Info("deleting ready marker", "kind", m.Kind, "namespace", m.Namespace, "name", m.Name)
+	if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete ready marker %s %s/%s: %w", m.Kind, m.Namespace, m.Name, err)
+	}
+	return nil
+}
+
+// readyMarkerObject returns an empty object of marker m's kind and name.
+func readyMarkerObject(m clustergatev1alpha1.ReadyMarker) client.Object {
+	meta := metav1.ObjectMeta{Namespace: m.Namespace, Name: m.Name}
+	if m.Kind == clustergatev1alpha1.ReadyMarkerLease {
+		return &coordinationv1.Lease{ObjectMeta: meta}
+	}
+	return &corev1.ConfigMap{ObjectMeta: meta}
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+func TestReconcileReadyMarkers(t *testing.T) {
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", UID: "uid-prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Actions: &clustergatev1alpha1.Actions{
+				ReadyMarkers: []clustergatev1alpha1.ReadyMarker{
+					{Kind: clustergatev1alpha1.ReadyMarkerConfigMap, Name: "cluster-ready"},
+					{Kind: clustergatev1alpha1.ReadyMarkerLease, Name: "cluster-ready", Namespace: "kube-public"},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(cr).Build()
+	r := &ClusterReadinessReconciler{Client: c, Namespace: "clustergate-system"}
+	ctx := context.Background()
+	start := metav1.NewTime(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+
+	if err := r.reconcileReadyMarkers(ctx, cr, true, clustergatev1alpha1.ClusterHealthy, 4*time.Minute, start); err != nil {
+		t.Fatal(err)
+	}
+	var cm corev1.ConfigMap
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "clustergate-system", Name: "cluster-ready"}, &cm); err != nil {
+		t.Fatal(err)
+	}
+	if cm.Data["ready"] != "true" || cm.Data["state"] != "Healthy" || cm.Data["readySince"] != "2026-03-02T09:00:00Z" ||
+		cm.Labels[readyMarkerLabel] != "prod" || !metav1.IsControlledBy(&cm, cr) {
+		t.Errorf("ConfigMap = %+v, want a ready marker owned by prod", cm)
+	}
+	if len(cr.Status.ReadyMarkers) != 2 || cr.Status.ReadyMarkers[0].Namespace != "clustergate-system" {
+		t.Errorf("status.readyMarkers = %+v, want both markers with namespaces resolved", cr.Status.ReadyMarkers)
+	}
+
+	// A later evaluation renews the Lease and keeps readySince.
+	later := metav1.NewTime(start.Add(time.Minute))
+	if err := r.reconcileReadyMarkers(ctx, cr, true, clustergatev1alpha1.ClusterDegraded, 4*time.Minute, later); err != nil {
+		t.Fatal(err)
+	}
+	var lease coordinationv1.Lease
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "kube-public", Name: "cluster-ready"}, &lease); err != nil {
+		t.Fatal(err)
+	}
+	if *lease.Spec.HolderIdentity != "clustergate/prod" || *lease.Spec.LeaseDurationSeconds != 240 ||
+		!lease.Spec.AcquireTime.Equal(&metav1.MicroTime{Time: start.Time}) || !lease.Spec.RenewTime.Equal(&metav1.MicroTime{Time: later.Time}) {
+		t.Errorf("Lease = %+v, want it renewed at the later evaluation", lease.Spec)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "clustergate-system", Name: "cluster-ready"}, &cm); err != nil {
+		t.Fatal(err)
+	}
+	if cm.Data["state"] != "Degraded" || cm.Data["readySince"] != "2026-03-02T09:00:00Z" {
+		t.Errorf("ConfigMap data = %v, want the new state since the first evaluation", cm.Data)
+	}
+
+	// Once not ready, both are deleted.
+	if err := r.reconcileReadyMarkers(ctx, cr, false, clustergatev1alpha1.ClusterUnhealthy, 4*time.Minute, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "clustergate-system", Name: "cluster-ready"}, &cm); !apierrors.IsNotFound(err) {
+		t.Errorf("ConfigMap still exists: %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "kube-public", Name: "cluster-ready"}, &lease); !apierrors.IsNotFound(err) {
+		t.Errorf("Lease still exists: %v", err)
+	}
+	if len(cr.Status.ReadyMarkers) != 0 {
+		t.Errorf("status.readyMarkers = %+v, want none", cr.Status.ReadyMarkers)
+	}
+}
+
+func TestReconcileReadyMarkers_LeavesUnmanagedObjects(t *testing.T) {
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", UID: "uid-prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Actions: &clustergatev1alpha1.Actions{
+				ReadyMarkers: []clustergatev1alpha1.ReadyMarker{{Kind: clustergatev1alpha1.ReadyMarkerConfigMap, Name: "kube-root-ca.crt", Namespace: "default"}},
+			},
+		},
+	}
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "kube-root-ca.crt"},
+		Data:       map[string]string{"ca.crt": "..."},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(cr, existing).Build()
+	r := &ClusterReadinessReconciler{Client: c}
+	ctx := context.Background()
+
+	err := r.reconcileReadyMarkers(ctx, cr, true, clustergatev1alpha1.ClusterHealthy, time.Minute, metav1.Now())
+	if err == nil || !strings.Contains(err.Error(), "not managed by ClusterReadiness prod") {
+		t.Errorf("error = %v, want the ConfigMap reported as unmanaged", err)
+	}
+	if err := r.reconcileReadyMarkers(ctx, cr, false, clustergatev1alpha1.ClusterUnhealthy, time.Minute, metav1.Now()); err != nil {
+		t.Fatal(err)
+	}
+	var cm corev1.ConfigMap
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "kube-root-ca.crt"}, &cm); err != nil || cm.Data["ca.crt"] != "..." {
+		t.Errorf("unmanaged ConfigMap = %+v, %v; want it untouched", cm.Data, err)
+	}
+}
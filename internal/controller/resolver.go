@@ -3,18 +3,30 @@ package controller
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"path"
+	"strconv"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
 	"github.com/clustergate/clustergate/internal/checks"
 )
 
+// profileSnapshotKey is the ConfigMap key holding a GateProfile spec snapshot.
+const profileSnapshotKey = "spec"
+
 // ResolvedCheck is the fully-resolved, flat representation of a check to execute.
 type ResolvedCheck struct {
-	// Identifier is the unique key: "dns" for built-ins, "dynamic:name" for dynamic.
+	// Identifier is the unique key: "dns" for built-ins, "dynamic:name" or
+	// "dynamic:name/instance" for dynamic, "namespace:ns/name" for
+	// NamespaceGateChecks.
 	Identifier string
 
 	// IsBuiltin is true for compiled built-in checks.
@@ -23,9 +35,18 @@ type ResolvedCheck struct {
 	// BuiltinName is the registered name of a built-in check.
 	BuiltinName string
 
-	// GateCheckName is the metadata.name of the GateCheck CR.
+	// GateCheckName is the metadata.name of the GateCheck CR, or of the
+	// NamespaceGateCheck when Namespace is set.
 	GateCheckName string
 
+	// Namespace is the namespace of a NamespaceGateCheck; empty otherwise.
+	Namespace string
+
+	// Instance names one of several uses of the same GateCheck, and Values
+	// are passed to its parameters.
+	Instance string
+	Values   map[string]string
+
 	// Severity is the resolved severity for this check.
 	Severity string
 
@@ -35,44 +56,133 @@ type ResolvedCheck struct {
 	// Interval is the resolved interval for this check.
 	Interval time.Duration
 
+	// Timeout bounds a single execution of this check; zero means no limit.
+	Timeout time.Duration
+
+	// FailureThreshold and SuccessThreshold are the consecutive runs needed to
+	// flip the reported status; zero behaves as one.
+	FailureThreshold int32
+	SuccessThreshold int32
+
+	// RunIf is a CEL expression over ClusterFacts; the check is skipped
+	// when it is false.
+	RunIf string
+
+	// Suspend and MaintenanceWindows suspend the check, always or during
+	// the windows.
+	Suspend            bool
+	MaintenanceWindows []clustergatev1alpha1.MaintenanceWindow
+
 	// Config is raw JSON configuration for built-in checks.
 	Config json.RawMessage
 
 	// Source tracks where this check originated: "inline" or "profile:<name>".
 	Source string
+
+	// Revision identifies the check's definition; see checkRevision.
+	Revision string
+}
+
+// checkRevision identifies the definition of rc, given the generation of its
+// GateCheck for dynamic checks, so that a changed definition can be detected.
+func checkRevision(rc ResolvedCheck, gateCheckGeneration int64) string {
+	h := fnv.New64a()
+	_ = json.NewEncoder(h).Encode(struct {
+		Config     json.RawMessage
+		Severity   string
+		Category   string
+		Generation int64
+		Values     map[string]string `json:",omitempty"`
+	}{rc.Config, rc.Severity, rc.Category, gateCheckGeneration, rc.Values})
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// setRevisions sets the Revision of each check from its definition and, for
+// dynamic checks, the generation of its GateCheck or NamespaceGateCheck.
+// GateChecks are listed once rather than fetched per check.
+func setRevisions(ctx context.Context, c client.Client, checks []ResolvedCheck) {
+	generations := map[types.NamespacedName]int64{}
+	listedGateChecks := false
+	for _, rc := range checks {
+		switch {
+		case rc.IsBuiltin:
+		case rc.Namespace != "":
+			// NamespaceGateChecks were just listed by ResolveChecks and are
+			// served from the cache.
+			var ngc clustergatev1alpha1.NamespaceGateCheck
+			key := types.NamespacedName{Namespace: rc.Namespace, Name: rc.GateCheckName}
+			if err := c.Get(ctx, key, &ngc); err == nil {
+				generations[key] = ngc.Generation
+			}
+		case !listedGateChecks:
+			listedGateChecks = true
+			var gcs clustergatev1alpha1.GateCheckList
+			if err := c.List(ctx, &gcs); err == nil {
+				for _, gc := range gcs.Items {
+					generations[types.NamespacedName{Name: gc.Name}] = gc.Generation
+				}
+			}
+		}
+	}
+	for i, rc := range checks {
+		key := types.NamespacedName{Namespace: rc.Namespace, Name: rc.GateCheckName}
+		checks[i].Revision = checkRevision(rc, generations[key])
+	}
 }
 
 // ResolveChecks resolves profiles and inline checks into a flat list of checks to execute.
 // Merge semantics:
 // 1. Profiles processed in listing order; later profiles override earlier for same identifier
-// 2. Inline spec.checks[] override any profile-sourced check with same identifier
-func ResolveChecks(ctx context.Context, c client.Client, spec clustergatev1alpha1.ClusterReadinessSpec, defaultInterval time.Duration) ([]ResolvedCheck, error) {
+// 2. Checks matching a ProfileRef's excludeChecks are skipped for that profile
+// 3. NamespaceGateChecks in the namespaces selected by checkNamespaces are added
+// 4. Inline spec.checks[] override any profile-sourced check with same identifier
+//
+// It also returns the excluded checks that did not end up resolved from
+// another source.
+func ResolveChecks(ctx context.Context, c client.Client, spec clustergatev1alpha1.ClusterReadinessSpec, defaultInterval time.Duration) ([]ResolvedCheck, []clustergatev1alpha1.ExcludedCheck, error) {
 	resolved := make(map[string]ResolvedCheck)
+	var excluded []clustergatev1alpha1.ExcludedCheck
 
 	// Process profiles in order
 	for _, profileRef := range spec.Profiles {
-		var profile clustergatev1alpha1.GateProfile
-		if err := c.Get(ctx, types.NamespacedName{Name: profileRef.Name}, &profile); err != nil {
-			return nil, err
+		profileChecks, err := resolveProfileVersion(ctx, c, profileRef)
+		if err != nil {
+			return nil, nil, err
 		}
 
-		for _, checkRef := range profile.Spec.Checks {
+		for _, checkRef := range profileChecks {
+			if pattern, ok := excludedBy(profileRef.ExcludeChecks, checkRef); ok {
+				excluded = append(excluded, clustergatev1alpha1.ExcludedCheck{
+					Name:    checkRef.Identifier(),
+					Profile: profileRef.Name,
+					Pattern: pattern,
+				})
+				continue
+			}
 			if !checkRef.IsEnabled() {
 				// Explicitly disabled in profile — remove if previously added
 				delete(resolved, checkRef.Identifier())
 				continue
 			}
 
-			rc := resolveProfileCheckRef(checkRef, profile.Name, defaultInterval)
+			rc := resolveProfileCheckRef(checkRef, profileRef.Name, defaultInterval)
 			resolved[rc.Identifier] = rc
 		}
 	}
 
+	namespaced, err := resolveNamespaceGateChecks(ctx, c, spec.CheckNamespaces, defaultInterval)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, rc := range namespaced {
+		resolved[rc.Identifier] = rc
+	}
+
 	// Process inline checks — these override profile entries with same identifier
 	for _, cs := range spec.Checks {
 		if !cs.IsEnabled() {
 			// Explicitly disabled inline — remove if previously added
-			id := inlineIdentifier(cs)
+			id := cs.Identifier()
 			delete(resolved, id)
 			continue
 		}
@@ -92,7 +202,123 @@ func ResolveChecks(ctx context.Context, c client.Client, spec clustergatev1alpha
 	for _, rc := range resolved {
 		result = append(result, rc)
 	}
-	return result, nil
+	omitted := excluded[:0]
+	for _, ex := range excluded {
+		if _, ok := resolved[ex.Name]; !ok {
+			omitted = append(omitted, ex)
+		}
+	}
+	if len(omitted) == 0 {
+		omitted = nil
+	}
+	return result, omitted, nil
+}
+
+// resolveProfileVersion returns the checks of the profile version ref selects:
+// its ConfigMap snapshot, the GateProfile's current checks, or the recorded
+// checks of a pinned older version.
+func resolveProfileVersion(ctx context.Context, c client.Client, ref clustergatev1alpha1.ProfileRef) ([]clustergatev1alpha1.ProfileCheckRef, error) {
+	if ref.SnapshotRef != nil {
+		return readProfileSnapshot(ctx, c, ref)
+	}
+	var profile clustergatev1alpha1.GateProfile
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, &profile); err != nil {
+		return nil, err
+	}
+	return profileVersionChecks(&profile, ref.Version)
+}
+
+// readProfileSnapshot returns the checks of the ConfigMap snapshot ref reads
+// its profile from.
+func readProfileSnapshot(ctx context.Context, c client.Reader, ref clustergatev1alpha1.ProfileRef) ([]clustergatev1alpha1.ProfileCheckRef, error) {
+	snap := ref.SnapshotRef
+	var cm corev1.ConfigMap
+	if err := c.Get(ctx, types.NamespacedName{Namespace: snap.Namespace, Name: snap.Name}, &cm); err != nil {
+		return nil, fmt.Errorf("profile %s snapshot: %w", ref.Name, err)
+	}
+	var spec clustergatev1alpha1.GateProfileSpec
+	if err := yaml.Unmarshal([]byte(cm.Data[profileSnapshotKey]), &spec); err != nil {
+		return nil, fmt.Errorf("profile %s snapshot %s/%s: %w", ref.Name, snap.Namespace, snap.Name, err)
+	}
+	if ref.Version != "" && spec.Version != ref.Version {
+		return nil, fmt.Errorf("profile %s snapshot %s/%s is version %q, want %q", ref.Name, snap.Namespace, snap.Name, spec.Version, ref.Version)
+	}
+	return spec.Checks, nil
+}
+
+// profileVersionChecks returns the checks of profile at version: its current
+// checks when version is unset or current, its recorded ones otherwise.
+func profileVersionChecks(profile *clustergatev1alpha1.GateProfile, version string) ([]clustergatev1alpha1.ProfileCheckRef, error) {
+	if profile.Spec.Source != nil && profile.Status.Source == nil {
+		return nil, fmt.Errorf("GateProfile %s has not imported its source yet", profile.Name)
+	}
+	if current, checks := profile.Current(); version == "" || version == current {
+		return checks, nil
+	}
+	for _, v := range profile.Status.Versions {
+		if v.Version == version {
+			return v.Checks, nil
+		}
+	}
+	return nil, fmt.Errorf("GateProfile %s has no recorded version %q", profile.Name, version)
+}
+
+// excludedBy returns the first of patterns matching ref's name, gateCheckRef
+// or identifier. Patterns are globs as understood by path.Match; malformed
+// patterns only match literally.
+func excludedBy(patterns []string, ref clustergatev1alpha1.ProfileCheckRef) (string, bool) {
+	candidates := []string{ref.Identifier()}
+	if ref.GateCheckRef != "" {
+		candidates = append(candidates, ref.GateCheckRef)
+	}
+	for _, pattern := range patterns {
+		for _, name := range candidates {
+			if matched, err := path.Match(pattern, name); matched || (err != nil && pattern == name) {
+				return pattern, true
+			}
+		}
+	}
+	return "", false
+}
+
+// resolveNamespaceGateChecks resolves the NamespaceGateChecks in the
+// namespaces matching selector; a nil selector matches none.
+func resolveNamespaceGateChecks(ctx context.Context, c client.Client, selector *metav1.LabelSelector, defaultInterval time.Duration) ([]ResolvedCheck, error) {
+	if selector == nil {
+		return nil, nil
+	}
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkNamespaces: %w", err)
+	}
+	var namespaces corev1.NamespaceList
+	if err := c.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return nil, fmt.Errorf("listing namespaces: %w", err)
+	}
+
+	var resolved []ResolvedCheck
+	for _, ns := range namespaces.Items {
+		var list clustergatev1alpha1.NamespaceGateCheckList
+		if err := c.List(ctx, &list, client.InNamespace(ns.Name)); err != nil {
+			return nil, fmt.Errorf("listing NamespaceGateChecks in %s: %w", ns.Name, err)
+		}
+		for _, ngc := range list.Items {
+			rc := ResolvedCheck{
+				Identifier:    "namespace:" + ngc.Namespace + "/" + ngc.Name,
+				GateCheckName: ngc.Name,
+				Namespace:     ngc.Namespace,
+				Severity:      string(ngc.Spec.Severity),
+				Category:      ngc.Spec.Category,
+				Interval:      defaultInterval,
+				Source:        "namespace:" + ngc.Namespace,
+			}
+			if ngc.Spec.Interval != nil && ngc.Spec.Interval.Duration > 0 {
+				rc.Interval = ngc.Spec.Interval.Duration
+			}
+			resolved = append(resolved, rc)
+		}
+	}
+	return resolved, nil
 }
 
 // resolveProfileCheckRef converts a profile check reference to a ResolvedCheck.
@@ -103,9 +329,11 @@ func resolveProfileCheckRef(ref clustergatev1alpha1.ProfileCheckRef, profileName
 	}
 
 	if ref.GateCheckRef != "" {
-		rc.Identifier = "dynamic:" + ref.GateCheckRef
+		rc.Identifier = ref.Identifier()
 		rc.IsBuiltin = false
 		rc.GateCheckName = ref.GateCheckRef
+		rc.Instance = ref.Instance
+		rc.Values = ref.Values
 	} else {
 		rc.Identifier = ref.Name
 		rc.IsBuiltin = true
@@ -120,6 +348,18 @@ func resolveProfileCheckRef(ref clustergatev1alpha1.ProfileCheckRef, profileName
 	if ref.Interval != nil && ref.Interval.Duration > 0 {
 		rc.Interval = ref.Interval.Duration
 	}
+	if ref.Timeout != nil && ref.Timeout.Duration > 0 {
+		rc.Timeout = ref.Timeout.Duration
+	}
+	if ref.FailureThreshold != nil {
+		rc.FailureThreshold = *ref.FailureThreshold
+	}
+	if ref.SuccessThreshold != nil {
+		rc.SuccessThreshold = *ref.SuccessThreshold
+	}
+	rc.RunIf = ref.RunIf
+	rc.Suspend = ref.Suspend
+	rc.MaintenanceWindows = ref.MaintenanceWindows
 
 	if ref.Config != nil {
 		rc.Config = ref.Config.Raw
@@ -136,9 +376,11 @@ func resolveInlineCheck(cs clustergatev1alpha1.CheckSpec, defaultInterval time.D
 	}
 
 	if cs.GateCheckRef != "" {
-		rc.Identifier = "dynamic:" + cs.GateCheckRef
+		rc.Identifier = cs.Identifier()
 		rc.IsBuiltin = false
 		rc.GateCheckName = cs.GateCheckRef
+		rc.Instance = cs.Instance
+		rc.Values = cs.Values
 	} else {
 		rc.Identifier = cs.Name
 		rc.IsBuiltin = true
@@ -153,6 +395,18 @@ func resolveInlineCheck(cs clustergatev1alpha1.CheckSpec, defaultInterval time.D
 	if cs.Interval != nil && cs.Interval.Duration > 0 {
 		rc.Interval = cs.Interval.Duration
 	}
+	if cs.Timeout != nil && cs.Timeout.Duration > 0 {
+		rc.Timeout = cs.Timeout.Duration
+	}
+	if cs.FailureThreshold != nil {
+		rc.FailureThreshold = *cs.FailureThreshold
+	}
+	if cs.SuccessThreshold != nil {
+		rc.SuccessThreshold = *cs.SuccessThreshold
+	}
+	rc.RunIf = cs.RunIf
+	rc.Suspend = cs.Suspend
+	rc.MaintenanceWindows = cs.MaintenanceWindows
 
 	if cs.Config != nil {
 		rc.Config = cs.Config.Raw
@@ -173,15 +427,28 @@ func mergeOverrides(base, override ResolvedCheck) ResolvedCheck {
 	if override.Config == nil {
 		override.Config = base.Config
 	}
-	return override
-}
-
-// inlineIdentifier computes the identifier for an inline CheckSpec.
-func inlineIdentifier(cs clustergatev1alpha1.CheckSpec) string {
-	if cs.GateCheckRef != "" {
-		return "dynamic:" + cs.GateCheckRef
+	if override.Values == nil {
+		override.Values = base.Values
+	}
+	if override.Timeout == 0 {
+		override.Timeout = base.Timeout
+	}
+	if override.FailureThreshold == 0 {
+		override.FailureThreshold = base.FailureThreshold
+	}
+	if override.SuccessThreshold == 0 {
+		override.SuccessThreshold = base.SuccessThreshold
+	}
+	if override.RunIf == "" {
+		override.RunIf = base.RunIf
+	}
+	if !override.Suspend {
+		override.Suspend = base.Suspend
 	}
-	return cs.Name
+	if override.MaintenanceWindows == nil {
+		override.MaintenanceWindows = base.MaintenanceWindows
+	}
+	return override
 }
 
 // ResolveSeverityAndCategory resolves final severity and category for a check,
@@ -211,20 +478,32 @@ func ResolveSeverityAndCategory(rc ResolvedCheck, ctx context.Context, c client.
 		// For dynamic checks, fetch the GateCheck CR for defaults
 		var gc clustergatev1alpha1.GateCheck
 		if err := c.Get(ctx, types.NamespacedName{Name: rc.GateCheckName}, &gc); err == nil {
-			if sev == "" {
-				sev = string(gc.Spec.Severity)
-			}
-			if cat == "" {
-				cat = gc.Spec.Category
-			}
+			return dynamicSeverityAndCategory(rc, &gc)
 		}
+		return dynamicSeverityAndCategory(rc, nil)
+	}
+
+	return sev, cat
+}
+
+// dynamicSeverityAndCategory resolves severity and category for a dynamic check
+// from its GateCheck, which is nil when the CR could not be fetched.
+func dynamicSeverityAndCategory(rc ResolvedCheck, gc *clustergatev1alpha1.GateCheck) (string, string) {
+	sev := rc.Severity
+	cat := rc.Category
+	if gc != nil {
 		if sev == "" {
-			sev = "critical"
+			sev = string(gc.Spec.Severity)
 		}
 		if cat == "" {
-			cat = "custom"
+			cat = gc.Spec.Category
 		}
 	}
-
+	if sev == "" {
+		sev = "critical"
+	}
+	if cat == "" {
+		cat = "custom"
+	}
 	return sev, cat
 }
@@ -3,13 +3,17 @@ package controller
 import (
 	"context"
 	"encoding/json"
+	"reflect"
+	"sort"
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
@@ -32,7 +36,7 @@ func TestResolveChecks_InlineBuiltin(t *testing.T) {
 		},
 	}
 
-	result, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
+	result, _, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -62,7 +66,7 @@ func TestResolveChecks_InlineDynamic(t *testing.T) {
 		},
 	}
 
-	result, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
+	result, _, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -92,7 +96,7 @@ func TestResolveChecks_InlineWithOverrides(t *testing.T) {
 		},
 	}
 
-	result, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
+	result, _, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -121,7 +125,7 @@ func TestResolveChecks_DisabledInlineExcluded(t *testing.T) {
 		},
 	}
 
-	result, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
+	result, _, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -152,7 +156,7 @@ func TestResolveChecks_ProfileResolution(t *testing.T) {
 		},
 	}
 
-	result, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
+	result, _, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -191,7 +195,7 @@ func TestResolveChecks_ProfileDisabledExcluded(t *testing.T) {
 		},
 	}
 
-	result, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
+	result, _, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -236,7 +240,7 @@ func TestResolveChecks_LaterProfileOverridesEarlier(t *testing.T) {
 		},
 	}
 
-	result, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
+	result, _, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -279,7 +283,7 @@ func TestResolveChecks_InlineOverridesProfile(t *testing.T) {
 		},
 	}
 
-	result, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
+	result, _, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -295,6 +299,74 @@ func TestResolveChecks_InlineOverridesProfile(t *testing.T) {
 	}
 }
 
+func TestResolveChecks_Timeout(t *testing.T) {
+	profile := &clustergatev1alpha1.GateProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "base-profile"},
+		Spec: clustergatev1alpha1.GateProfileSpec{
+			Checks: []clustergatev1alpha1.ProfileCheckRef{
+				{Name: "dns", Timeout: &metav1.Duration{Duration: 5 * time.Second}},
+				{GateCheckRef: "ingress", Timeout: &metav1.Duration{Duration: 5 * time.Second}},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(profile).Build()
+
+	spec := clustergatev1alpha1.ClusterReadinessSpec{
+		Profiles: []clustergatev1alpha1.ProfileRef{{Name: "base-profile"}},
+		Checks: []clustergatev1alpha1.CheckSpec{
+			{Name: "dns", Category: "networking"},
+			{GateCheckRef: "ingress", Timeout: &metav1.Duration{Duration: 10 * time.Second}},
+		},
+	}
+
+	result, _, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	timeouts := map[string]time.Duration{}
+	for _, rc := range result {
+		timeouts[rc.Identifier] = rc.Timeout
+	}
+	if timeouts["dns"] != 5*time.Second {
+		t.Errorf("dns timeout = %s, want 5s (preserved from profile)", timeouts["dns"])
+	}
+	if timeouts["dynamic:ingress"] != 10*time.Second {
+		t.Errorf("ingress timeout = %s, want 10s (inline override)", timeouts["dynamic:ingress"])
+	}
+}
+
+func TestResolveChecks_Thresholds(t *testing.T) {
+	three, two := int32(3), int32(2)
+	profile := &clustergatev1alpha1.GateProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "base-profile"},
+		Spec: clustergatev1alpha1.GateProfileSpec{
+			Checks: []clustergatev1alpha1.ProfileCheckRef{
+				{Name: "dns", FailureThreshold: &three, SuccessThreshold: &three},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(profile).Build()
+
+	spec := clustergatev1alpha1.ClusterReadinessSpec{
+		Profiles: []clustergatev1alpha1.ProfileRef{{Name: "base-profile"}},
+		Checks:   []clustergatev1alpha1.CheckSpec{{Name: "dns", SuccessThreshold: &two}},
+	}
+
+	result, _, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 check, got %d", len(result))
+	}
+	if result[0].FailureThreshold != 3 {
+		t.Errorf("failureThreshold = %d, want 3 (preserved from profile)", result[0].FailureThreshold)
+	}
+	if result[0].SuccessThreshold != 2 {
+		t.Errorf("successThreshold = %d, want 2 (inline override)", result[0].SuccessThreshold)
+	}
+}
+
 func TestResolveChecks_ProfileNotFound(t *testing.T) {
 	c := fake.NewClientBuilder().WithScheme(testScheme()).Build()
 
@@ -304,7 +376,7 @@ func TestResolveChecks_ProfileNotFound(t *testing.T) {
 		},
 	}
 
-	_, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
+	_, _, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
 	if err == nil {
 		t.Error("expected error for missing profile, got nil")
 	}
@@ -399,3 +471,194 @@ func TestResolveSeverityAndCategory_DynamicCRNotFound(t *testing.T) {
 		t.Errorf("category = %q, want %q (fallback)", cat, "custom")
 	}
 }
+
+func TestResolveChecks_ExcludeChecks(t *testing.T) {
+	profile := &clustergatev1alpha1.GateProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+		Spec: clustergatev1alpha1.GateProfileSpec{
+			Checks: []clustergatev1alpha1.ProfileCheckRef{
+				{Name: "dns"},
+				{Name: "etcd"},
+				{GateCheckRef: "ingress-nginx"},
+				{GateCheckRef: "ingress-internal"},
+				{GateCheckRef: "cert-manager"},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(profile).Build()
+
+	spec := clustergatev1alpha1.ClusterReadinessSpec{
+		Profiles: []clustergatev1alpha1.ProfileRef{
+			{Name: "platform", ExcludeChecks: []string{"etcd", "ingress-*", "dynamic:cert-manager"}},
+		},
+		// Inline checks are never excluded.
+		Checks: []clustergatev1alpha1.CheckSpec{{GateCheckRef: "ingress-internal"}},
+	}
+
+	result, excluded, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := map[string]bool{}
+	for _, rc := range result {
+		got[rc.Identifier] = true
+	}
+	if len(got) != 2 || !got["dns"] || !got["dynamic:ingress-internal"] {
+		t.Errorf("resolved = %v, want dns and dynamic:ingress-internal", got)
+	}
+
+	want := []clustergatev1alpha1.ExcludedCheck{
+		{Name: "etcd", Profile: "platform", Pattern: "etcd"},
+		{Name: "dynamic:ingress-nginx", Profile: "platform", Pattern: "ingress-*"},
+		{Name: "dynamic:cert-manager", Profile: "platform", Pattern: "dynamic:cert-manager"},
+	}
+	if !reflect.DeepEqual(excluded, want) {
+		t.Errorf("excluded = %+v, want %+v", excluded, want)
+	}
+}
+
+func TestResolveChecks_CheckNamespaces(t *testing.T) {
+	objs := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"readiness": "contribute"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "sandbox"}},
+		&clustergatev1alpha1.NamespaceGateCheck{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "checkout"},
+			Spec: clustergatev1alpha1.GateCheckSpec{
+				Severity: clustergatev1alpha1.SeverityWarning,
+				Category: "apps",
+				Interval: &metav1.Duration{Duration: 30 * time.Second},
+				PodCheck: &clustergatev1alpha1.PodCheckSpec{Namespace: "shop", MinReady: 1},
+			},
+		},
+		&clustergatev1alpha1.NamespaceGateCheck{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "sandbox", Name: "experiment"},
+			Spec:       clustergatev1alpha1.GateCheckSpec{PodCheck: &clustergatev1alpha1.PodCheckSpec{Namespace: "sandbox"}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(objs...).Build()
+
+	spec := clustergatev1alpha1.ClusterReadinessSpec{
+		CheckNamespaces: &metav1.LabelSelector{MatchLabels: map[string]string{"readiness": "contribute"}},
+	}
+	result, _, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 check, got %d: %+v", len(result), result)
+	}
+	rc := result[0]
+	if rc.Identifier != "namespace:shop/checkout" || rc.Namespace != "shop" || rc.GateCheckName != "checkout" || rc.IsBuiltin {
+		t.Errorf("resolved check = %+v", rc)
+	}
+	if rc.Severity != "warning" || rc.Category != "apps" || rc.Interval != 30*time.Second || rc.Source != "namespace:shop" {
+		t.Errorf("resolved defaults = %+v", rc)
+	}
+
+	// Without checkNamespaces no namespaced checks are included.
+	result, _, err = ResolveChecks(context.Background(), c, clustergatev1alpha1.ClusterReadinessSpec{}, 60*time.Second)
+	if err != nil || len(result) != 0 {
+		t.Errorf("without checkNamespaces: %d checks, err %v", len(result), err)
+	}
+}
+
+func TestResolveChecks_Instances(t *testing.T) {
+	profile := &clustergatev1alpha1.GateProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "services"},
+		Spec: clustergatev1alpha1.GateProfileSpec{
+			Checks: []clustergatev1alpha1.ProfileCheckRef{
+				{GateCheckRef: "http-service-ready", Instance: "api", Values: map[string]string{"url": "https://api.internal"}},
+				{GateCheckRef: "http-service-ready", Instance: "web", Values: map[string]string{"url": "https://web.internal"}},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(profile).Build()
+
+	spec := clustergatev1alpha1.ClusterReadinessSpec{
+		Profiles: []clustergatev1alpha1.ProfileRef{{Name: "services"}},
+		Checks: []clustergatev1alpha1.CheckSpec{
+			{GateCheckRef: "http-service-ready", Instance: "web", Values: map[string]string{"url": "https://web.example.com"}},
+		},
+	}
+	result, _, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := map[string]ResolvedCheck{}
+	for _, rc := range result {
+		got[rc.Identifier] = rc
+	}
+	want := map[string]string{
+		"dynamic:http-service-ready/api": "https://api.internal",
+		"dynamic:http-service-ready/web": "https://web.example.com",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("resolved = %v, want %v", got, want)
+	}
+	for id, url := range want {
+		rc := got[id]
+		if rc.GateCheckName != "http-service-ready" || rc.Values["url"] != url {
+			t.Errorf("%s = {gateCheck %q, values %v}, want url %s", id, rc.GateCheckName, rc.Values, url)
+		}
+	}
+}
+
+func TestResolveChecks_ProfileVersion(t *testing.T) {
+	profile := &clustergatev1alpha1.GateProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+		Spec: clustergatev1alpha1.GateProfileSpec{
+			Version: "2.0.0",
+			Checks:  []clustergatev1alpha1.ProfileCheckRef{{Name: "dns"}, {Name: "etcd"}},
+		},
+		Status: clustergatev1alpha1.GateProfileStatus{
+			Versions: []clustergatev1alpha1.ProfileVersion{
+				{Version: "2.0.0", Checks: []clustergatev1alpha1.ProfileCheckRef{{Name: "dns"}, {Name: "etcd"}}},
+				{Version: "1.0.0", Checks: []clustergatev1alpha1.ProfileCheckRef{{Name: "dns"}}},
+			},
+		},
+	}
+	snapshot := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "fleet", Name: "platform-0.9"},
+		Data:       map[string]string{"spec": "version: 0.9.0\nchecks:\n  - name: node-ready\n"},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(profile, snapshot).Build()
+	snapshotRef := &clustergatev1alpha1.ProfileSnapshotReference{Namespace: "fleet", Name: "platform-0.9"}
+
+	tests := []struct {
+		name    string
+		ref     clustergatev1alpha1.ProfileRef
+		want    []string
+		wantErr string
+	}{
+		{name: "current", ref: clustergatev1alpha1.ProfileRef{Name: "platform"}, want: []string{"dns", "etcd"}},
+		{name: "pinned to current", ref: clustergatev1alpha1.ProfileRef{Name: "platform", Version: "2.0.0"}, want: []string{"dns", "etcd"}},
+		{name: "pinned to recorded", ref: clustergatev1alpha1.ProfileRef{Name: "platform", Version: "1.0.0"}, want: []string{"dns"}},
+		{name: "unknown version", ref: clustergatev1alpha1.ProfileRef{Name: "platform", Version: "3.0.0"}, wantErr: `GateProfile platform has no recorded version "3.0.0"`},
+		{name: "snapshot", ref: clustergatev1alpha1.ProfileRef{Name: "platform", SnapshotRef: snapshotRef}, want: []string{"node-ready"}},
+		{name: "snapshot version mismatch", ref: clustergatev1alpha1.ProfileRef{Name: "platform", Version: "1.0.0", SnapshotRef: snapshotRef}, wantErr: `profile platform snapshot fleet/platform-0.9 is version "0.9.0", want "1.0.0"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := clustergatev1alpha1.ClusterReadinessSpec{Profiles: []clustergatev1alpha1.ProfileRef{tt.ref}}
+			result, _, err := ResolveChecks(context.Background(), c, spec, 60*time.Second)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, rc := range result {
+				got = append(got, rc.Identifier)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolved = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"encoding/binary"
+	"hash/fnv"
 	"time"
 
 	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
@@ -9,7 +11,10 @@ import (
 // CheckSchedule determines which resolved checks are due for execution based on
 // their individual intervals and existing status timestamps.
 // Returns the checks that need to run and the shortest remaining interval for requeue.
-func CheckSchedule(resolved []ResolvedCheck, existingStatuses []clustergatev1alpha1.CheckStatus, now time.Time) (due []ResolvedCheck, carried []clustergatev1alpha1.CheckStatus, nextRequeue time.Duration) {
+// Each run after the first is delayed by a splay of up to jitterPercent of the
+// check's interval, so checks sharing an interval drift apart. Checks whose
+// last run errored are retried with backoff instead.
+func CheckSchedule(resolved []ResolvedCheck, existingStatuses []clustergatev1alpha1.CheckStatus, now time.Time, jitterPercent int32) (due []ResolvedCheck, carried []clustergatev1alpha1.CheckStatus, nextRequeue time.Duration) {
 	// Build a lookup map from existing statuses
 	statusMap := make(map[string]clustergatev1alpha1.CheckStatus, len(existingStatuses))
 	for _, s := range existingStatuses {
@@ -21,14 +26,20 @@ func CheckSchedule(resolved []ResolvedCheck, existingStatuses []clustergatev1alp
 	for _, rc := range resolved {
 		existing, hasExisting := statusMap[rc.Identifier]
 
-		if !hasExisting || existing.LastChecked == nil {
-			// No prior result — must run
+		if !hasExisting || existing.LastChecked == nil || existing.Status == "Suspended" || existing.Status == "Skipped" ||
+			existing.Revision != rc.Revision {
+			// No prior result, no longer suspended or skipped, or the
+			// definition changed — must run
 			due = append(due, rc)
 			continue
 		}
 
+		interval := rc.Interval + splay(rc, existing.LastChecked.Time, jitterPercent)
+		if existing.Retries > 0 {
+			interval = retryBackoff(existing.Retries, rc.Interval)
+		}
 		elapsed := now.Sub(existing.LastChecked.Time)
-		if elapsed >= rc.Interval {
+		if elapsed >= interval {
 			// Stale — must run
 			due = append(due, rc)
 			continue
@@ -37,7 +48,7 @@ func CheckSchedule(resolved []ResolvedCheck, existingStatuses []clustergatev1alp
 		// Not yet due — carry forward existing result
 		carried = append(carried, existing)
 
-		remaining := rc.Interval - elapsed
+		remaining := interval - elapsed
 		if nextRequeue == 0 || remaining < nextRequeue {
 			nextRequeue = remaining
 		}
@@ -51,6 +62,20 @@ func CheckSchedule(resolved []ResolvedCheck, existingStatuses []clustergatev1alp
 	return due, carried, nextRequeue
 }
 
+// retryBaseDelay is the delay before retrying a check whose run errored once.
+const retryBaseDelay = 5 * time.Second
+
+// retryBackoff returns the delay before retrying a check after retries
+// consecutive errored runs: retryBaseDelay doubled per retry, capped at the
+// check's interval.
+func retryBackoff(retries int32, interval time.Duration) time.Duration {
+	delay := retryBaseDelay
+	for i := int32(1); i < retries && delay < interval; i++ {
+		delay *= 2
+	}
+	return min(delay, interval)
+}
+
 // shortestInterval returns the shortest interval from a set of resolved checks.
 func shortestInterval(checks []ResolvedCheck) time.Duration {
 	if len(checks) == 0 {
@@ -64,3 +89,17 @@ func shortestInterval(checks []ResolvedCheck) time.Duration {
 	}
 	return shortest
 }
+
+// splay returns the delay, up to jitterPercent of rc's interval, added to the
+// run after last. It is derived from the check and its last run, so it is
+// stable across reconciles but differs between checks and between runs.
+func splay(rc ResolvedCheck, last time.Time, jitterPercent int32) time.Duration {
+	maxSplay := rc.Interval * time.Duration(jitterPercent) / 100
+	if maxSplay <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(rc.Identifier))
+	_, _ = h.Write(binary.BigEndian.AppendUint64(nil, uint64(last.UnixNano())))
+	return time.Duration(h.Sum64() % uint64(maxSplay))
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -106,6 +107,20 @@ func TestCheckSchedule(t *testing.T) {
 			wantCarriedCount: 0,
 			wantRequeue:      interval,
 		},
+		{
+			name: "changed definition - only that check due",
+			resolved: []ResolvedCheck{
+				{Identifier: "dns", Interval: interval, Revision: "b"},
+				{Identifier: "dynamic:ingress", Interval: interval, Revision: "a"},
+			},
+			existingStatuses: []clustergatev1alpha1.CheckStatus{
+				{Name: "dns", LastChecked: timePtr(now.Add(-30 * time.Second)), Revision: "a"},
+				{Name: "dynamic:ingress", LastChecked: timePtr(now.Add(-30 * time.Second)), Revision: "a"},
+			},
+			wantDueCount:     1,
+			wantCarriedCount: 1,
+			wantRequeue:      30 * time.Second,
+		},
 		{
 			name: "different intervals - requeue uses shortest remaining",
 			resolved: []ResolvedCheck{
@@ -124,7 +139,7 @@ func TestCheckSchedule(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			due, carried, requeue := CheckSchedule(tt.resolved, tt.existingStatuses, now)
+			due, carried, requeue := CheckSchedule(tt.resolved, tt.existingStatuses, now, 0)
 
 			if len(due) != tt.wantDueCount {
 				t.Errorf("due count = %d, want %d", len(due), tt.wantDueCount)
@@ -156,7 +171,7 @@ func TestCheckScheduleCarriedStatusIntegrity(t *testing.T) {
 		},
 	}
 
-	_, carried, _ := CheckSchedule(resolved, existing, now)
+	_, carried, _ := CheckSchedule(resolved, existing, now, 0)
 
 	if len(carried) != 1 {
 		t.Fatalf("expected 1 carried status, got %d", len(carried))
@@ -220,3 +235,84 @@ func timePtr(t time.Time) *metav1.Time {
 	mt := metav1.NewTime(t)
 	return &mt
 }
+
+func TestCheckScheduleJitter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	lastChecked := now.Add(-60 * time.Second)
+
+	var resolved []ResolvedCheck
+	var existing []clustergatev1alpha1.CheckStatus
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("check-%d", i)
+		resolved = append(resolved, ResolvedCheck{Identifier: name, Interval: 60 * time.Second})
+		existing = append(existing, clustergatev1alpha1.CheckStatus{Name: name, LastChecked: timePtr(lastChecked)})
+	}
+
+	due, _, _ := CheckSchedule(resolved, existing, now, 0)
+	if len(due) != len(resolved) {
+		t.Fatalf("without jitter expected all %d checks due, got %d", len(resolved), len(due))
+	}
+
+	jittered, _, requeue := CheckSchedule(resolved, existing, now, 50)
+	if len(jittered) == len(resolved) {
+		t.Fatal("with jitter expected some checks to be delayed")
+	}
+	if requeue <= 0 || requeue > 30*time.Second {
+		t.Errorf("requeue = %s, want within the 30s splay", requeue)
+	}
+
+	// Every check is due once the full splay has elapsed.
+	due, _, _ = CheckSchedule(resolved, existing, now.Add(30*time.Second), 50)
+	if len(due) != len(resolved) {
+		t.Errorf("after the maximum splay expected all checks due, got %d", len(due))
+	}
+
+	// The splay is stable across reconciles.
+	again, _, _ := CheckSchedule(resolved, existing, now, 50)
+	if len(again) != len(jittered) {
+		t.Errorf("splay not stable: %d due, then %d", len(jittered), len(again))
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		retries int32
+		want    time.Duration
+	}{
+		{retries: 1, want: 5 * time.Second},
+		{retries: 2, want: 10 * time.Second},
+		{retries: 3, want: 20 * time.Second},
+		{retries: 4, want: 40 * time.Second},
+		{retries: 5, want: 60 * time.Second},
+		{retries: 100, want: 60 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := retryBackoff(tt.retries, 60*time.Second); got != tt.want {
+			t.Errorf("retryBackoff(%d) = %s, want %s", tt.retries, got, tt.want)
+		}
+	}
+}
+
+func TestCheckScheduleRetry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	resolved := []ResolvedCheck{
+		{Identifier: "erroring", Interval: 5 * time.Minute},
+		{Identifier: "failing", Interval: 5 * time.Minute},
+	}
+	existing := []clustergatev1alpha1.CheckStatus{
+		{Name: "erroring", Status: "Failing", Retries: 2, LastChecked: timePtr(now.Add(-12 * time.Second))},
+		{Name: "failing", Status: "Failing", LastChecked: timePtr(now.Add(-12 * time.Second))},
+	}
+
+	due, carried, requeue := CheckSchedule(resolved, existing, now, 0)
+	if len(due) != 1 || due[0].Identifier != "erroring" {
+		t.Fatalf("expected only the erroring check due after its 10s backoff, got %v", due)
+	}
+	if len(carried) != 1 || carried[0].Name != "failing" {
+		t.Errorf("expected the failing check carried until its interval, got %v", carried)
+	}
+	if requeue != 5*time.Minute-12*time.Second {
+		t.Errorf("requeue = %s, want %s", requeue, 5*time.Minute-12*time.Second)
+	}
+}
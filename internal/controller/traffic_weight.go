@@ -0,0 +1,206 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+const (
+	// defaultTrafficWeightAnnotation is the annotation of the traffic weight
+	// action when none is set.
+	defaultTrafficWeightAnnotation = "external-dns.alpha.kubernetes.io/aws-weight"
+
+	// trafficWeightFinalizer holds a ClusterReadiness with a traffic weight
+	// action until the annotation of every target it manages is reset.
+	trafficWeightFinalizer = "clustergate.io/traffic-weight"
+
+	// weightedByAnnotation names the ClusterReadiness that manages the
+	// traffic weight annotation of a Service or Ingress.
+	weightedByAnnotation = "clustergate.io/weighted-by"
+)
+
+// trafficTargetGVKs maps each traffic target kind to the API version it is
+// managed at.
+var trafficTargetGVKs = map[clustergatev1alpha1.TrafficTargetKind]schema.GroupVersionKind{
+	clustergatev1alpha1.TrafficTargetService: {Version: "v1", Kind: "Service"},
+	clustergatev1alpha1.TrafficTargetIngress: {Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+}
+
+// desiredTrafficWeight returns the annotation cr's traffic weight action
+// manages, with its defaults resolved, or nil when it has none.
+func desiredTrafficWeight(cr *clustergatev1alpha1.ClusterReadiness) *clustergatev1alpha1.TrafficWeightStatus {
+	if cr.Spec.Actions == nil || cr.Spec.Actions.TrafficWeight == nil {
+		return nil
+	}
+	action := cr.Spec.Actions.TrafficWeight
+	return &clustergatev1alpha1.TrafficWeightStatus{
+		Annotation: cmp.Or(action.Annotation, defaultTrafficWeightAnnotation),
+		ResetValue: cmp.Or(action.Healthy, "100"),
+		Targets:    action.Targets,
+	}
+}
+
+// trafficWeightValue returns the value action sets while the
+// ClusterReadiness is in state.
+func trafficWeightValue(action *clustergatev1alpha1.TrafficWeightAction, state clustergatev1alpha1.ClusterHealthState) string {
+	healthy := cmp.Or(action.Healthy, "100")
+	switch state {
+	case clustergatev1alpha1.ClusterHealthy:
+		return healthy
+	case clustergatev1alpha1.ClusterDegraded:
+		return cmp.Or(action.Degraded, healthy)
+	default:
+		return cmp.Or(action.Unhealthy, "0")
+	}
+}
+
+// reconcileTrafficWeight sets the annotation of cr's traffic weight targets
+// to the value for state, and resets it on objects no longer targeted and
+// when the annotation changes or the action is removed. Objects whose
+// annotation another ClusterReadiness manages are left alone. What it
+// manages is recorded in cr's status.
+func (r *ClusterReadinessReconciler) reconcileTrafficWeight(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, state clustergatev1alpha1.ClusterHealthState) error {
+	desired := desiredTrafficWeight(cr)
+	previous := cr.Status.TrafficWeight
+	if desired == nil && previous == nil {
+		return nil
+	}
+
+	// kept reports whether t was managed before and still is targeted with
+	// the same annotation.
+	kept := func(t clustergatev1alpha1.TrafficTarget) bool {
+		return desired != nil && previous != nil && desired.Annotation == previous.Annotation &&
+			slices.Contains(desired.Targets, t) && slices.Contains(previous.Targets, t)
+	}
+
+	// Stale targets are reset first, as resetting releases the object,
+	// which may also be set with a new annotation.
+	var errs []error
+	resetFailed := false
+	if previous != nil {
+		for _, t := range previous.Targets {
+			if kept(t) {
+				continue
+			}
+			if err := r.resetTrafficWeight(ctx, cr, t, previous.Annotation, previous.ResetValue); err != nil {
+				errs = append(errs, err)
+				resetFailed = true
+			}
+		}
+	}
+	var managed []clustergatev1alpha1.TrafficTarget
+	if desired != nil {
+		value := trafficWeightValue(cr.Spec.Actions.TrafficWeight, state)
+		for _, t := range desired.Targets {
+			ok, err := r.setTrafficWeight(ctx, cr, t, desired.Annotation, value)
+			if err != nil {
+				errs = append(errs, err)
+				// Keep it recorded so it is still reset once released.
+				ok = kept(t)
+			}
+			if ok {
+				managed = append(managed, t)
+			}
+		}
+	}
+
+	// Until every stale target is reset the previous annotation stays
+	// recorded, so resetting them is retried.
+	if !resetFailed {
+		cr.Status.TrafficWeight = nil
+		if len(managed) > 0 {
+			desired.Targets = managed
+			cr.Status.TrafficWeight = desired
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// releaseTrafficWeight resets the annotation of every target cr manages.
+func (r *ClusterReadinessReconciler) releaseTrafficWeight(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness) error {
+	previous := cr.Status.TrafficWeight
+	if previous == nil {
+		return nil
+	}
+	var errs []error
+	for _, t := range previous.Targets {
+		errs = append(errs, r.resetTrafficWeight(ctx, cr, t, previous.Annotation, previous.ResetValue))
+	}
+	return errors.Join(errs...)
+}
+
+// setTrafficWeight sets annotation of the object t names to value, and
+// reports whether cr now manages it.
+func (r *ClusterReadinessReconciler) setTrafficWeight(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, t clustergatev1alpha1.TrafficTarget, annotation, value string) (bool, error) {
+	obj, err := r.getTrafficTarget(ctx, t)
+	if err != nil {
+		return false, err
+	}
+	annotations := obj.GetAnnotations()
+	if by := annotations[weightedByAnnotation]; by != "" && by != cr.Name {
+		return false, nil
+	}
+	if annotations[weightedByAnnotation] == cr.Name && annotations[annotation] == value {
+		return true, nil
+	}
+
+	base := obj.DeepCopy()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	old := annotations[annotation]
+	annotations[annotation] = value
+	annotations[weightedByAnnotation] = cr.Name
+	obj.SetAnnotations(annotations)
+	if err := r.Patch(ctx, obj, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})); err != nil {
+		return false, fmt.Errorf("failed to set %s of %s %s/%s: %w", annotation, t.Kind, t.Namespace, t.Name, err)
+	}
+	log.FromContext(ctx).Info("set traffic weight", "kind", t.Kind, "namespace", t.Namespace, "name", t.Name,
+		"annotation", annotation, "from", old, "to", value)
+	return true, nil
+}
+
+// resetTrafficWeight sets annotation of the object t names back to value
+// and releases it, unless the object is gone or not managed by cr.
+func (r *ClusterReadinessReconciler) resetTrafficWeight(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness, t clustergatev1alpha1.TrafficTarget, annotation, value string) error {
+	obj, err := r.getTrafficTarget(ctx, t)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	annotations := obj.GetAnnotations()
+	if annotations[weightedByAnnotation] != cr.Name {
+		return nil
+	}
+
+	base := obj.DeepCopy()
+	annotations[annotation] = value
+	delete(annotations, weightedByAnnotation)
+	obj.SetAnnotations(annotations)
+	if err := r.Patch(ctx, obj, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})); err != nil {
+		return fmt.Errorf("failed to reset %s of %s %s/%s: %w", annotation, t.Kind, t.Namespace, t.Name, err)
+	}
+	log.FromContext(ctx).Info("reset traffic weight", "kind", t.Kind, "namespace", t.Namespace, "name", t.Name,
+		"annotation", annotation, "to", value)
+	return nil
+}
+
+// getTrafficTarget fetches the object t names.
+func (r *ClusterReadinessReconciler) getTrafficTarget(ctx context.Context, t clustergatev1alpha1.TrafficTarget) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(trafficTargetGVKs[t.Kind])
+	if err := r.Get(ctx, types.NamespacedName{Namespace: t.Namespace, Name: t.Name}, obj); err != nil {
+		return nil, fmt.Errorf("failed to get %s %s/%s: %w", t.Kind, t.Namespace, t.Name, err)
+	}
+	return obj, nil
+}
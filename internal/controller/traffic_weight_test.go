@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+func TestTrafficWeightValue(t *testing.T) {
+	defaults := &clustergatev1alpha1.TrafficWeightAction{}
+	custom := &clustergatev1alpha1.TrafficWeightAction{Healthy: "true", Degraded: "degraded", Unhealthy: "false"}
+	tests := []struct {
+		action *clustergatev1alpha1.TrafficWeightAction
+		state  clustergatev1alpha1.ClusterHealthState
+		want   string
+	}{
+		{defaults, clustergatev1alpha1.ClusterHealthy, "100"},
+		{defaults, clustergatev1alpha1.ClusterDegraded, "100"},
+		{defaults, clustergatev1alpha1.ClusterUnhealthy, "0"},
+		{defaults, clustergatev1alpha1.ClusterInitializing, "0"},
+		{custom, clustergatev1alpha1.ClusterHealthy, "true"},
+		{custom, clustergatev1alpha1.ClusterDegraded, "degraded"},
+		{custom, clustergatev1alpha1.ClusterUnhealthy, "false"},
+	}
+	for _, tt := range tests {
+		if got := trafficWeightValue(tt.action, tt.state); got != tt.want {
+			t.Errorf("trafficWeightValue(%+v, %s) = %q, want %q", tt.action, tt.state, got, tt.want)
+		}
+	}
+}
+
+func TestReconcileTrafficWeight(t *testing.T) {
+	const weight = defaultTrafficWeightAnnotation
+	gateway := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "ingress", Name: "gateway",
+		Annotations: map[string]string{weight: "100", "external-dns.alpha.kubernetes.io/set-identifier": "eu-1"}}}
+	web := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "web", Name: "web"}}
+	other := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "ingress", Name: "internal",
+		Annotations: map[string]string{weight: "50", weightedByAnnotation: "staging"}}}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(gateway, web, other).Build()
+	r := &ClusterReadinessReconciler{Client: c}
+	ctx := context.Background()
+	targets := []clustergatev1alpha1.TrafficTarget{
+		{Kind: clustergatev1alpha1.TrafficTargetService, Namespace: "ingress", Name: "gateway"},
+		{Kind: clustergatev1alpha1.TrafficTargetIngress, Namespace: "web", Name: "web"},
+		{Kind: clustergatev1alpha1.TrafficTargetService, Namespace: "ingress", Name: "internal"},
+	}
+	cr := &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Actions: &clustergatev1alpha1.Actions{
+				TrafficWeight: &clustergatev1alpha1.TrafficWeightAction{Targets: targets},
+			},
+		},
+	}
+	annotations := func(obj client.Object) map[string]string {
+		t.Helper()
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			t.Fatal(err)
+		}
+		return obj.GetAnnotations()
+	}
+
+	if err := r.reconcileTrafficWeight(ctx, cr, clustergatev1alpha1.ClusterUnhealthy); err != nil {
+		t.Fatal(err)
+	}
+	for _, obj := range []client.Object{gateway, web} {
+		a := annotations(obj)
+		if a[weight] != "0" || a[weightedByAnnotation] != "prod" {
+			t.Errorf("%s annotations = %v, want weight 0 managed by prod", obj.GetName(), a)
+		}
+	}
+	if a := annotations(other); a[weight] != "50" || a[weightedByAnnotation] != "staging" {
+		t.Errorf("Service managed by another ClusterReadiness was changed: %v", a)
+	}
+	if tw := cr.Status.TrafficWeight; tw == nil || tw.Annotation != weight || tw.ResetValue != "100" || len(tw.Targets) != 2 {
+		t.Fatalf("status.trafficWeight = %+v, want gateway and web managed", tw)
+	}
+
+	if err := r.reconcileTrafficWeight(ctx, cr, clustergatev1alpha1.ClusterHealthy); err != nil {
+		t.Fatal(err)
+	}
+	if a := annotations(gateway); a[weight] != "100" || a["external-dns.alpha.kubernetes.io/set-identifier"] != "eu-1" {
+		t.Errorf("gateway annotations = %v, want weight 100 and the set identifier kept", a)
+	}
+
+	// Switching annotations resets the old one and releases nothing still
+	// targeted.
+	cr.Spec.Actions.TrafficWeight.Annotation = "example.com/healthy"
+	cr.Spec.Actions.TrafficWeight.Healthy = "true"
+	cr.Spec.Actions.TrafficWeight.Unhealthy = "false"
+	cr.Spec.Actions.TrafficWeight.Targets = targets[:1]
+	if err := r.reconcileTrafficWeight(ctx, cr, clustergatev1alpha1.ClusterUnhealthy); err != nil {
+		t.Fatal(err)
+	}
+	if a := annotations(gateway); a[weight] != "100" || a["example.com/healthy"] != "false" || a[weightedByAnnotation] != "prod" {
+		t.Errorf("gateway annotations = %v, want weight reset and example.com/healthy=false managed by prod", a)
+	}
+	if a := annotations(web); a[weight] != "100" || a[weightedByAnnotation] != "" {
+		t.Errorf("web annotations = %v, want weight reset and released", a)
+	}
+	if tw := cr.Status.TrafficWeight; tw == nil || tw.Annotation != "example.com/healthy" || tw.ResetValue != "true" || len(tw.Targets) != 1 {
+		t.Fatalf("status.trafficWeight = %+v", tw)
+	}
+
+	// Releasing, as on deletion, leaves the cluster receiving traffic.
+	if err := r.releaseTrafficWeight(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	if a := annotations(gateway); a["example.com/healthy"] != "true" || a[weightedByAnnotation] != "" {
+		t.Errorf("gateway annotations = %v, want reset and released", a)
+	}
+
+	cr.Spec.Actions = nil
+	if err := r.reconcileTrafficWeight(ctx, cr, clustergatev1alpha1.ClusterUnhealthy); err != nil {
+		t.Fatal(err)
+	}
+	if cr.Status.TrafficWeight != nil {
+		t.Errorf("status.trafficWeight = %+v, want nil once the action is removed", cr.Status.TrafficWeight)
+	}
+}
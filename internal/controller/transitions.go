@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Log keys shared by every transition and execution record so that log-based
+// alerting can rely on them.
+const (
+	logKeyCheck    = "check"
+	logKeyCR       = "cr"
+	logKeyFrom     = "from"
+	logKeyTo       = "to"
+	logKeyReason   = "reason"
+	logKeyDuration = "duration"
+)
+
+// stateUnknown is reported as the previous state the first time a check or CR is evaluated.
+const stateUnknown = "Unknown"
+
+// transitionLogger emits one Info record per check or cluster state change and
+// per-execution detail at V(1), all keyed consistently.
+type transitionLogger struct {
+	logger logr.Logger
+	cr     string
+}
+
+func newTransitionLogger(logger logr.Logger, cr string) transitionLogger {
+	return transitionLogger{logger: logger.WithName("transitions"), cr: cr}
+}
+
+// Check records a single check execution. The transition record is only
+// emitted when the status differs from the previous one.
+func (t transitionLogger) Check(check, from, to, reason string, duration time.Duration) {
+	if from == "" {
+		from = stateUnknown
+	}
+	kv := []interface{}{
+		logKeyCheck, check,
+		logKeyCR, t.cr,
+		logKeyFrom, from,
+		logKeyTo, to,
+		logKeyReason, reason,
+		logKeyDuration, duration,
+	}
+	if from != to {
+		t.logger.Info("check transitioned", kv...)
+	}
+	t.logger.V(1).Info("check executed", kv...)
+}
+
+// Cluster records the overall state of the CR after a reconciliation, logging
+// a transition only when it changed.
+func (t transitionLogger) Cluster(from, to, reason string, duration time.Duration) {
+	if from == "" {
+		from = stateUnknown
+	}
+	if from == to {
+		return
+	}
+	t.logger.Info("cluster readiness transitioned",
+		logKeyCR, t.cr,
+		logKeyFrom, from,
+		logKeyTo, to,
+		logKeyReason, reason,
+		logKeyDuration, duration,
+	)
+}
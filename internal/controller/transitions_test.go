@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+)
+
+func captureLogs(verbosity int) (*[]string, transitionLogger) {
+	var lines []string
+	logger := funcr.New(func(prefix, args string) {
+		lines = append(lines, prefix+" "+args)
+	}, funcr.Options{Verbosity: verbosity})
+	return &lines, newTransitionLogger(logger, "production")
+}
+
+func TestTransitionLogger_Check(t *testing.T) {
+	tests := []struct {
+		name      string
+		from, to  string
+		verbosity int
+		want      []string
+	}{
+		{
+			name: "first evaluation is a transition from Unknown",
+			to:   "Passing",
+			want: []string{`"msg"="check transitioned" "check"="dns" "cr"="production" "from"="Unknown" "to"="Passing"`},
+		},
+		{
+			name: "status change",
+			from: "Passing", to: "Failing",
+			want: []string{`"msg"="check transitioned" "check"="dns" "cr"="production" "from"="Passing" "to"="Failing" "reason"="resolution failed"`},
+		},
+		{
+			name: "unchanged status is silent at Info",
+			from: "Passing", to: "Passing",
+		},
+		{
+			name: "unchanged status is logged at V(1)",
+			from: "Passing", to: "Passing",
+			verbosity: 1,
+			want:      []string{`"level"=1 "msg"="check executed" "check"="dns"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, tl := captureLogs(tt.verbosity)
+			tl.Check("dns", tt.from, tt.to, "resolution failed", 250*time.Millisecond)
+			if len(*lines) != len(tt.want) {
+				t.Fatalf("got %d log lines, want %d: %q", len(*lines), len(tt.want), *lines)
+			}
+			for i, want := range tt.want {
+				if !strings.Contains((*lines)[i], want) {
+					t.Errorf("line %q does not contain %q", (*lines)[i], want)
+				}
+				if !strings.Contains((*lines)[i], `"duration"="250ms"`) {
+					t.Errorf("line %q is missing duration", (*lines)[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTransitionLogger_Cluster(t *testing.T) {
+	lines, tl := captureLogs(0)
+	tl.Cluster("Healthy", "Healthy", "", time.Second)
+	if len(*lines) != 0 {
+		t.Fatalf("expected no log for unchanged state, got %q", *lines)
+	}
+
+	tl.Cluster("Healthy", "Unhealthy", "2/3 critical checks passing", time.Second)
+	if len(*lines) != 1 {
+		t.Fatalf("expected one transition log, got %q", *lines)
+	}
+	want := `"msg"="cluster readiness transitioned" "cr"="production" "from"="Healthy" "to"="Unhealthy" "reason"="2/3 critical checks passing"`
+	if !strings.Contains((*lines)[0], want) {
+		t.Errorf("line %q does not contain %q", (*lines)[0], want)
+	}
+}
@@ -0,0 +1,138 @@
+// Package library ships the operator's default GateProfiles and GateChecks and
+// installs them into the cluster.
+package library
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/bundle"
+)
+
+const (
+	// ManagedByLabel marks the library objects the operator owns. Removing it
+	// from an object stops the operator from updating it.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+
+	// ManagedByValue is the ManagedByLabel value of library objects.
+	ManagedByValue = "clustergate"
+)
+
+//go:embed defaults/*.yaml
+var defaults embed.FS
+
+// Objects returns the library's GateChecks and GateProfiles, GateChecks first,
+// labeled with ManagedByLabel.
+func Objects() ([]client.Object, error) {
+	files, err := fs.Glob(defaults, "defaults/*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	var gateChecks, profiles []client.Object
+	for _, file := range files {
+		data, err := defaults.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		objs, err := bundle.Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path.Base(file), err)
+		}
+		for _, obj := range objs {
+			labels := obj.GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels[ManagedByLabel] = ManagedByValue
+			obj.SetLabels(labels)
+			switch obj.(type) {
+			case *clustergatev1alpha1.GateCheck:
+				gateChecks = append(gateChecks, obj)
+			case *clustergatev1alpha1.GateProfile:
+				profiles = append(profiles, obj)
+			}
+		}
+	}
+	return append(gateChecks, profiles...), nil
+}
+
+// Installer creates the library's GateChecks and GateProfiles at startup and
+// updates those it manages to the shipped definitions. Objects of the same
+// name without ManagedByLabel are left alone.
+type Installer struct {
+	Client client.Client
+}
+
+// Start installs the library once. It implements manager.Runnable.
+func (i *Installer) Start(ctx context.Context) error {
+	if err := i.Install(ctx); err != nil {
+		log.FromContext(ctx).Error(err, "failed to install default profiles")
+	}
+	return nil
+}
+
+// NeedLeaderElection returns true: only the leader writes the library.
+func (i *Installer) NeedLeaderElection() bool {
+	return true
+}
+
+// Install creates or updates every library object.
+func (i *Installer) Install(ctx context.Context) error {
+	objs, err := Objects()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, obj := range objs {
+		if err := i.apply(ctx, obj); err != nil {
+			errs = append(errs, fmt.Errorf("%T %s: %w", obj, obj.GetName(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// apply creates desired, or updates the managed object of its name to it.
+func (i *Installer) apply(ctx context.Context, desired client.Object) error {
+	logger := log.FromContext(ctx).WithValues("name", desired.GetName())
+
+	existing := desired.DeepCopyObject().(client.Object)
+	err := i.Client.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if apierrors.IsNotFound(err) {
+		logger.Info("installing default library object", "kind", fmt.Sprintf("%T", desired))
+		return i.Client.Create(ctx, desired)
+	}
+	if err != nil {
+		return err
+	}
+	if existing.GetLabels()[ManagedByLabel] != ManagedByValue {
+		logger.V(1).Info("leaving unmanaged library object alone")
+		return nil
+	}
+
+	switch want := desired.(type) {
+	case *clustergatev1alpha1.GateCheck:
+		got := existing.(*clustergatev1alpha1.GateCheck)
+		if equality.Semantic.DeepEqual(got.Spec, want.Spec) {
+			return nil
+		}
+		got.Spec = want.Spec
+	case *clustergatev1alpha1.GateProfile:
+		got := existing.(*clustergatev1alpha1.GateProfile)
+		if equality.Semantic.DeepEqual(got.Spec, want.Spec) {
+			return nil
+		}
+		got.Spec = want.Spec
+	}
+	logger.Info("updating default library object", "kind", fmt.Sprintf("%T", desired))
+	return i.Client.Update(ctx, existing)
+}
@@ -0,0 +1,112 @@
+package library
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks/controlplane"
+	"github.com/clustergate/clustergate/internal/checks/dns"
+)
+
+func TestObjects(t *testing.T) {
+	objs, err := Objects()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	builtins := map[string]bool{
+		controlplane.APIServerCheckName:         true,
+		controlplane.EtcdCheckName:              true,
+		controlplane.SchedulerCheckName:         true,
+		controlplane.ControllerManagerCheckName: true,
+		dns.CheckName:                           true,
+	}
+	gateChecks := map[string]bool{}
+	profiles := map[string]bool{}
+	for _, obj := range objs {
+		if obj.GetLabels()[ManagedByLabel] != ManagedByValue {
+			t.Errorf("%s is not labeled as managed", obj.GetName())
+		}
+		switch o := obj.(type) {
+		case *clustergatev1alpha1.GateCheck:
+			if len(profiles) > 0 {
+				t.Errorf("GateCheck %s is listed after a GateProfile", o.Name)
+			}
+			gateChecks[o.Name] = true
+			if o.Spec.Severity == "" || o.Spec.Category == "" {
+				t.Errorf("GateCheck %s must set severity and category", o.Name)
+			}
+		case *clustergatev1alpha1.GateProfile:
+			profiles[o.Name] = true
+			if o.Spec.Version == "" {
+				t.Errorf("GateProfile %s has no version", o.Name)
+			}
+			for _, ref := range o.Spec.Checks {
+				if ref.GateCheckRef != "" && !gateChecks[ref.GateCheckRef] {
+					t.Errorf("GateProfile %s references unknown GateCheck %s", o.Name, ref.GateCheckRef)
+				}
+				if ref.Name != "" && !builtins[ref.Name] {
+					t.Errorf("GateProfile %s references unknown built-in check %s", o.Name, ref.Name)
+				}
+			}
+		}
+	}
+	for _, name := range []string{"clustergate-baseline", "clustergate-cloud", "clustergate-bare-metal", "clustergate-observability"} {
+		if !profiles[name] {
+			t.Errorf("missing GateProfile %s", name)
+		}
+	}
+}
+
+func TestInstaller(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clustergatev1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	drifted := &clustergatev1alpha1.GateProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "clustergate-baseline", Labels: map[string]string{ManagedByLabel: ManagedByValue}},
+		Spec:       clustergatev1alpha1.GateProfileSpec{Checks: []clustergatev1alpha1.ProfileCheckRef{{Name: "dns"}}},
+	}
+	userOwned := &clustergatev1alpha1.GateProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "clustergate-observability"},
+		Spec:       clustergatev1alpha1.GateProfileSpec{Checks: []clustergatev1alpha1.ProfileCheckRef{{Name: "dns"}}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(drifted, userOwned).Build()
+	installer := &Installer{Client: c}
+
+	ctx := context.Background()
+	if err := installer.Install(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// A second install finds everything up to date.
+	if err := installer.Install(ctx); err != nil {
+		t.Fatalf("unexpected error on reinstall: %v", err)
+	}
+
+	var baseline clustergatev1alpha1.GateProfile
+	if err := c.Get(ctx, client.ObjectKey{Name: "clustergate-baseline"}, &baseline); err != nil {
+		t.Fatal(err)
+	}
+	if baseline.Spec.Version == "" || len(baseline.Spec.Checks) < 2 {
+		t.Errorf("managed profile was not updated: %+v", baseline.Spec)
+	}
+
+	var observability clustergatev1alpha1.GateProfile
+	if err := c.Get(ctx, client.ObjectKey{Name: "clustergate-observability"}, &observability); err != nil {
+		t.Fatal(err)
+	}
+	if len(observability.Spec.Checks) != 1 || observability.Labels[ManagedByLabel] != "" {
+		t.Errorf("unmanaged profile was modified: %+v", observability)
+	}
+
+	var gc clustergatev1alpha1.GateCheck
+	if err := c.Get(ctx, client.ObjectKey{Name: "clustergate-nodes-ready"}, &gc); err != nil {
+		t.Errorf("library GateCheck was not created: %v", err)
+	}
+}
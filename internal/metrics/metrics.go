@@ -17,6 +17,18 @@ var (
 		[]string{"check", "cluster_readiness", "severity", "category"},
 	)
 
+	// CheckValue is a gauge that reports the numeric value a check evaluated,
+	// such as the sample a PromQL value condition compares against its threshold.
+	// Labels: check (check name), cluster_readiness (CR name), severity, category.
+	CheckValue = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "clustergate",
+			Name:      "check_value",
+			Help:      "Numeric value evaluated by a readiness check, for checks that produce one.",
+		},
+		[]string{"check", "cluster_readiness", "severity", "category"},
+	)
+
 	// CheckDuration is a histogram that records how long each check takes to run.
 	// Labels: check (check name), severity, category.
 	CheckDuration = prometheus.NewHistogramVec(
@@ -41,13 +53,13 @@ var (
 	)
 
 	// ClusterHealthState is a gauge that reports the cluster health state.
-	// Labels: cluster_readiness (CR name), state (Healthy, Degraded, Unhealthy).
+	// Labels: cluster_readiness (CR name), state (Healthy, Degraded, Unhealthy, Initializing).
 	// The active state has value 1, others have value 0.
 	ClusterHealthState = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "clustergate",
 			Name:      "cluster_health_state",
-			Help:      "Cluster health state: Healthy (all passing), Degraded (warnings failing), Unhealthy (critical failing). Active state=1.",
+			Help:      "Cluster health state: Healthy (all passing), Degraded (warnings failing), Unhealthy (critical failing), Initializing (failing within the initial delay). Info checks never affect it. Active state=1.",
 		},
 		[]string{"cluster_readiness", "state"},
 	)
@@ -62,8 +74,105 @@ var (
 		},
 		[]string{"category", "cluster_readiness"},
 	)
+
+	// NamespaceCheckReady is a gauge that reports whether each check of a
+	// NamespaceReadiness is passing.
+	// Labels: check (check name), namespace, namespace_readiness (CR name), severity, category.
+	NamespaceCheckReady = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "clustergate",
+			Name:      "namespace_check_ready",
+			Help:      "Whether a namespace readiness check is passing (1) or failing (0).",
+		},
+		[]string{"check", "namespace", "namespace_readiness", "severity", "category"},
+	)
+
+	// NamespaceReady is a gauge that reports overall readiness of a NamespaceReadiness.
+	// Labels: namespace, namespace_readiness (CR name).
+	NamespaceReady = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "clustergate",
+			Name:      "namespace_ready",
+			Help:      "Whether a namespace is fully ready (all critical checks passing).",
+		},
+		[]string{"namespace", "namespace_readiness"},
+	)
+
+	// NamespaceHealthState is a gauge that reports the health state of a NamespaceReadiness.
+	// Labels: namespace, namespace_readiness (CR name), state (Healthy, Degraded, Unhealthy).
+	// The active state has value 1, others have value 0.
+	NamespaceHealthState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "clustergate",
+			Name:      "namespace_health_state",
+			Help:      "Namespace health state: Healthy (all passing), Degraded (warnings failing), Unhealthy (critical failing), Initializing (failing within the initial delay). Info checks never affect it. Active state=1.",
+		},
+		[]string{"namespace", "namespace_readiness", "state"},
+	)
+
+	// DynamicExecutorReady reports whether the dynamic executor initialized
+	// every capability; script checks report Unknown while it is 0.
+	DynamicExecutorReady = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "clustergate",
+			Name:      "dynamic_executor_ready",
+			Help:      "Whether the dynamic check executor initialized successfully (1) or is running degraded (0).",
+		},
+	)
+
+	// ReadinessStateEvictions counts /readyz entries dropped because their
+	// ClusterReadiness, gate or NamespaceReadiness CR no longer exists.
+	ReadinessStateEvictions = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "clustergate",
+			Name:      "readiness_state_evictions_total",
+			Help:      "Number of stale readiness state entries evicted for ClusterReadiness CRs, gates or NamespaceReadiness CRs that no longer exist.",
+		},
+	)
+
+	// ScriptJobsCollected counts script check Jobs and pods deleted because the
+	// operator exited before cleaning them up.
+	ScriptJobsCollected = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "clustergate",
+			Name:      "script_jobs_collected_total",
+			Help:      "Number of orphaned script check Jobs and pods deleted by the janitor.",
+		},
+	)
+
+	// NotificationsSent counts state change notifications by outcome.
+	// Labels: cluster_readiness (CR name), notification (target name), result (success or failure).
+	NotificationsSent = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "clustergate",
+			Name:      "notifications_total",
+			Help:      "Number of state change notifications delivered or failed, by target.",
+		},
+		[]string{"cluster_readiness", "notification", "result"},
+	)
 )
 
 func init() {
-	metrics.Registry.MustRegister(CheckReady, CheckDuration, ClusterReady, ClusterHealthState, CategoryReady)
+	metrics.Registry.MustRegister(CheckReady, CheckValue, CheckDuration, ClusterReady, ClusterHealthState, CategoryReady,
+		NamespaceCheckReady, NamespaceReady, NamespaceHealthState, DynamicExecutorReady, ReadinessStateEvictions,
+		ScriptJobsCollected, NotificationsSent)
+}
+
+// DeleteClusterReadiness removes all series labelled with the given ClusterReadiness CR.
+func DeleteClusterReadiness(name string) {
+	labels := prometheus.Labels{"cluster_readiness": name}
+	CheckReady.DeletePartialMatch(labels)
+	CheckValue.DeletePartialMatch(labels)
+	ClusterReady.DeletePartialMatch(labels)
+	ClusterHealthState.DeletePartialMatch(labels)
+	CategoryReady.DeletePartialMatch(labels)
+	NotificationsSent.DeletePartialMatch(labels)
+}
+
+// DeleteNamespaceReadiness removes all series labelled with the given NamespaceReadiness CR.
+func DeleteNamespaceReadiness(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "namespace_readiness": name}
+	NamespaceCheckReady.DeletePartialMatch(labels)
+	NamespaceReady.DeletePartialMatch(labels)
+	NamespaceHealthState.DeletePartialMatch(labels)
 }
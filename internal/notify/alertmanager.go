@@ -0,0 +1,50 @@
+package notify
+
+import (
+	"maps"
+	"strings"
+	"time"
+)
+
+// AlertName is the alertname label of the alerts pushed for failing checks.
+const AlertName = "ClusterGateCheckFailing"
+
+// Alert is an alert in the Alertmanager v2 API.
+type Alert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	StartsAt     time.Time         `json:"startsAt,omitzero"`
+	EndsAt       time.Time         `json:"endsAt,omitzero"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+// CheckAlert returns the alert for check c of ClusterReadiness cr, with the
+// extra labels. The alert resolves at endsAt: a firing alert must be sent
+// again before then, and a resolved one ends now.
+func CheckAlert(cr string, c Check, extra map[string]string, startsAt, endsAt time.Time) Alert {
+	labels := maps.Clone(extra)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels["alertname"] = AlertName
+	labels["cluster_readiness"] = cr
+	labels["check"] = c.Name
+	labels["severity"] = c.Severity
+	if c.Category != "" {
+		labels["category"] = c.Category
+	}
+	return Alert{
+		Labels: labels,
+		Annotations: map[string]string{
+			"summary":     "ClusterReadiness " + cr + ": check " + c.Name + " is " + c.Status,
+			"description": c.Message,
+		},
+		StartsAt: startsAt.UTC(),
+		EndsAt:   endsAt.UTC(),
+	}
+}
+
+// AlertmanagerURL returns the v2 alerts endpoint of the Alertmanager at base.
+func AlertmanagerURL(base string) string {
+	return strings.TrimSuffix(base, "/") + "/api/v2/alerts"
+}
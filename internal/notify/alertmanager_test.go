@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCheckAlert(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 14, 0, 0, time.UTC)
+	extra := map[string]string{"team": "platform", "check": "overridden"}
+	alert := CheckAlert("prod", Check{Name: "etcd", Status: "Failing", Severity: "critical", Category: "control-plane", Message: "2/3 members healthy"},
+		extra, start, start.Add(time.Hour))
+
+	if extra["check"] != "overridden" {
+		t.Error("CheckAlert modified the extra labels")
+	}
+	want := map[string]string{
+		"alertname": AlertName, "cluster_readiness": "prod", "check": "etcd",
+		"severity": "critical", "category": "control-plane", "team": "platform",
+	}
+	if len(alert.Labels) != len(want) {
+		t.Errorf("labels = %v, want %v", alert.Labels, want)
+	}
+	for k, v := range want {
+		if alert.Labels[k] != v {
+			t.Errorf("label %s = %q, want %q", k, alert.Labels[k], v)
+		}
+	}
+
+	payload, err := json.Marshal([]Alert{alert, {Labels: map[string]string{"alertname": "x"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want2 := `[{"labels":{"alertname":"ClusterGateCheckFailing","category":"control-plane","check":"etcd","cluster_readiness":"prod","severity":"critical","team":"platform"},` +
+		`"annotations":{"description":"2/3 members healthy","summary":"ClusterReadiness prod: check etcd is Failing"},` +
+		`"startsAt":"2026-03-02T09:14:00Z","endsAt":"2026-03-02T10:14:00Z"},{"labels":{"alertname":"x"}}]`
+	if string(payload) != want2 {
+		t.Errorf("payload = %s, want %s", payload, want2)
+	}
+
+	if got := AlertmanagerURL("http://alertmanager:9093/"); got != "http://alertmanager:9093/api/v2/alerts" {
+		t.Errorf("AlertmanagerURL() = %s", got)
+	}
+}
@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// DefaultTemplate renders a chat message summarizing a state change and
+// listing its failing checks.
+const DefaultTemplate = `ClusterReadiness {{.ClusterReadiness}} is {{.NewState}} (was {{.OldState}}): {{.Message}}
+{{- range .FailingChecks}}
+• {{.Name}} ({{.Severity}}{{if .Category}}, {{.Category}}{{end}}): {{.Status}}{{if .Message}} - {{.Message}}{{end}}
+{{- end}}`
+
+// Filter returns a copy of e listing only the failing checks of one of
+// severities and one of categories. Empty lists match everything.
+func (e Event) Filter(severities, categories []string) Event {
+	filtered := e
+	filtered.FailingChecks = nil
+	for _, c := range e.FailingChecks {
+		if (len(severities) == 0 || slices.Contains(severities, c.Severity)) &&
+			(len(categories) == 0 || slices.Contains(categories, c.Category)) {
+			filtered.FailingChecks = append(filtered.FailingChecks, c)
+		}
+	}
+	return filtered
+}
+
+// ParseTemplate parses a message template, or DefaultTemplate when text is
+// empty.
+func ParseTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultTemplate
+	}
+	return template.New("message").Option("missingkey=error").Parse(text)
+}
+
+// Render renders e with the message template text.
+func Render(text string, e Event) (string, error) {
+	tmpl, err := ParseTemplate(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, e); err != nil {
+		return "", fmt.Errorf("render message: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// SlackPayload returns the Slack incoming webhook payload posting text.
+func SlackPayload(text string) ([]byte, error) {
+	return json.Marshal(map[string]string{"text": text})
+}
+
+// TeamsPayload returns the Microsoft Teams workflow webhook payload posting
+// text as an Adaptive Card.
+func TeamsPayload(text string) ([]byte, error) {
+	var body []map[string]any
+	for _, line := range strings.Split(text, "\n") {
+		body = append(body, map[string]any{"type": "TextBlock", "text": line, "wrap": true})
+	}
+	return json.Marshal(map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content": map[string]any{
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type":    "AdaptiveCard",
+				"version": "1.4",
+				"body":    body,
+			},
+		}},
+	})
+}
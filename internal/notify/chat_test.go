@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func testEvent() Event {
+	return Event{
+		ClusterReadiness: "prod",
+		OldState:         "Healthy",
+		NewState:         "Unhealthy",
+		Message:          "0/1 critical checks passing",
+		FailingChecks: []Check{
+			{Name: "etcd", Status: "Failing", Severity: "critical", Category: "control-plane", Message: "2/3 members healthy"},
+			{Name: "backups", Status: "Unknown", Severity: "warning"},
+		},
+	}
+}
+
+func TestEvent_Filter(t *testing.T) {
+	tests := []struct {
+		name       string
+		severities []string
+		categories []string
+		want       []string
+	}{
+		{name: "no filters", want: []string{"etcd", "backups"}},
+		{name: "severity", severities: []string{"warning"}, want: []string{"backups"}},
+		{name: "category", categories: []string{"control-plane"}, want: []string{"etcd"}},
+		{name: "both", severities: []string{"warning"}, categories: []string{"control-plane"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range testEvent().Filter(tt.severities, tt.categories).FailingChecks {
+				got = append(got, c.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("checks = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	got, err := Render("", testEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "ClusterReadiness prod is Unhealthy (was Healthy): 0/1 critical checks passing\n" +
+		"• etcd (critical, control-plane): Failing - 2/3 members healthy\n" +
+		"• backups (warning): Unknown"
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	got, err = Render(`{{.ClusterReadiness}}: {{len .FailingChecks}} failing`, testEvent())
+	if err != nil || got != "prod: 2 failing" {
+		t.Errorf("Render(custom) = %q, %v", got, err)
+	}
+	if _, err := Render(`{{.Missing}}`, testEvent()); err == nil {
+		t.Error("expected an error rendering an unknown field")
+	}
+}
+
+func TestTeamsPayload(t *testing.T) {
+	payload, err := TeamsPayload("first\nsecond")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg struct {
+		Type        string `json:"type"`
+		Attachments []struct {
+			ContentType string `json:"contentType"`
+			Content     struct {
+				Type string `json:"type"`
+				Body []struct {
+					Text string `json:"text"`
+				} `json:"body"`
+			} `json:"content"`
+		} `json:"attachments"`
+	}
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Type != "message" || len(msg.Attachments) != 1 || msg.Attachments[0].Content.Type != "AdaptiveCard" ||
+		len(msg.Attachments[0].Content.Body) != 2 || msg.Attachments[0].Content.Body[1].Text != "second" {
+		t.Errorf("payload = %s, want an Adaptive Card with one text block per line", payload)
+	}
+}
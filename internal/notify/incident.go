@@ -0,0 +1,115 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// PagerDutyURL is the PagerDuty Events API v2 endpoint.
+	PagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+
+	// OpsgenieURL is the Opsgenie API base URL.
+	OpsgenieURL = "https://api.opsgenie.com"
+
+	// source identifies ClusterGate as the origin of incidents.
+	source = "clustergate"
+)
+
+// Incident is a check of a ClusterReadiness that started failing, or
+// recovered when Resolved is set.
+type Incident struct {
+	ClusterReadiness string
+	Check            string
+	Severity         string
+	Category         string
+	Message          string
+	Resolved         bool
+	Time             time.Time
+}
+
+// DedupKey identifies the incident of a check, so that repeated failures of
+// the same check update one open incident instead of opening more.
+func (i Incident) DedupKey() string {
+	return source + "/" + i.ClusterReadiness + "/" + i.Check
+}
+
+// summary is the one-line title of the incident.
+func (i Incident) summary() string {
+	return "ClusterReadiness " + i.ClusterReadiness + ": check " + i.Check + " is failing"
+}
+
+// PagerDutyPayload returns the PagerDuty Events API v2 event triggering or
+// resolving i.
+func PagerDutyPayload(routingKey string, i Incident) ([]byte, error) {
+	event := map[string]any{
+		"routing_key":  routingKey,
+		"event_action": "trigger",
+		"dedup_key":    i.DedupKey(),
+	}
+	if i.Resolved {
+		event["event_action"] = "resolve"
+		return json.Marshal(event)
+	}
+	severity := "error"
+	switch i.Severity {
+	case "critical", "warning", "info":
+		severity = i.Severity
+	}
+	event["payload"] = map[string]any{
+		"summary":   truncate(i.summary(), 1024),
+		"source":    i.ClusterReadiness,
+		"severity":  severity,
+		"timestamp": i.Time.UTC().Format(time.RFC3339),
+		"component": i.Check,
+		"group":     i.Category,
+		"class":     source,
+		"custom_details": map[string]string{
+			"message": i.Message,
+		},
+	}
+	return json.Marshal(event)
+}
+
+// OpsgenieRequest returns the URL under base and the payload of the Opsgenie
+// Alert API request creating or closing the alert of i.
+func OpsgenieRequest(base string, i Incident) (string, []byte, error) {
+	base = strings.TrimSuffix(base, "/") + "/v2/alerts"
+	if i.Resolved {
+		payload, err := json.Marshal(map[string]string{"source": source, "note": "check is passing again"})
+		return base + "/" + url.PathEscape(i.DedupKey()) + "/close?identifierType=alias", payload, err
+	}
+	priority := "P3"
+	switch i.Severity {
+	case "critical":
+		priority = "P1"
+	case "info":
+		priority = "P5"
+	}
+	tags := []string{i.ClusterReadiness}
+	if i.Category != "" {
+		tags = append(tags, i.Category)
+	}
+	payload, err := json.Marshal(map[string]any{
+		"message":     truncate(i.summary(), 130),
+		"alias":       i.DedupKey(),
+		"description": truncate(i.Message, 15000),
+		"priority":    priority,
+		"source":      source,
+		"entity":      i.ClusterReadiness,
+		"tags":        tags,
+		"details":     map[string]string{"check": i.Check, "severity": i.Severity},
+	})
+	return base, payload, err
+}
+
+// truncate shortens s to at most n runes.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func testIncident() Incident {
+	return Incident{
+		ClusterReadiness: "prod",
+		Check:            "dynamic:api/eu",
+		Severity:         "critical",
+		Category:         "networking",
+		Message:          "HTTP 503",
+		Time:             time.Date(2026, 3, 2, 9, 14, 0, 0, time.UTC),
+	}
+}
+
+func TestPagerDutyPayload(t *testing.T) {
+	payload, err := PagerDutyPayload("key", testIncident())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var event struct {
+		RoutingKey  string `json:"routing_key"`
+		EventAction string `json:"event_action"`
+		DedupKey    string `json:"dedup_key"`
+		Payload     *struct {
+			Summary  string `json:"summary"`
+			Severity string `json:"severity"`
+			Group    string `json:"group"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.RoutingKey != "key" || event.EventAction != "trigger" || event.DedupKey != "clustergate/prod/dynamic:api/eu" ||
+		event.Payload == nil || event.Payload.Severity != "critical" || event.Payload.Group != "networking" ||
+		event.Payload.Summary != "ClusterReadiness prod: check dynamic:api/eu is failing" {
+		t.Errorf("trigger = %s", payload)
+	}
+
+	resolved := testIncident()
+	resolved.Resolved = true
+	payload, err = PagerDutyPayload("key", resolved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event.Payload = nil
+	if err := json.Unmarshal(payload, &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.EventAction != "resolve" || event.DedupKey != "clustergate/prod/dynamic:api/eu" || event.Payload != nil {
+		t.Errorf("resolve = %s", payload)
+	}
+}
+
+func TestOpsgenieRequest(t *testing.T) {
+	url, payload, err := OpsgenieRequest("https://api.eu.opsgenie.com/", testIncident())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var alert struct {
+		Alias    string   `json:"alias"`
+		Priority string   `json:"priority"`
+		Tags     []string `json:"tags"`
+	}
+	if err := json.Unmarshal(payload, &alert); err != nil {
+		t.Fatal(err)
+	}
+	if url != "https://api.eu.opsgenie.com/v2/alerts" || alert.Alias != "clustergate/prod/dynamic:api/eu" ||
+		alert.Priority != "P1" || len(alert.Tags) != 2 {
+		t.Errorf("create = %s %s", url, payload)
+	}
+
+	resolved := testIncident()
+	resolved.Resolved = true
+	url, _, err = OpsgenieRequest(OpsgenieURL, resolved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://api.opsgenie.com/v2/alerts/clustergate%2Fprod%2Fdynamic:api%2Feu/close?identifierType=alias"; url != want {
+		t.Errorf("close URL = %s, want %s", url, want)
+	}
+}
@@ -0,0 +1,131 @@
+// Package notify delivers ClusterReadiness state changes to external
+// systems over HTTP.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultRetries is how many times a failed delivery is retried when the
+	// target does not say.
+	DefaultRetries = 3
+
+	// DefaultTimeout bounds a delivery attempt when the target does not say.
+	DefaultTimeout = 10 * time.Second
+
+	defaultBackoff = time.Second
+
+	// maxErrorBody bounds how much of a failed response is quoted in errors.
+	maxErrorBody = 512
+)
+
+// Event is a change of a ClusterReadiness's overall state. It is the JSON
+// payload of webhook notifications.
+type Event struct {
+	// ClusterReadiness is the name of the ClusterReadiness that changed.
+	ClusterReadiness string `json:"clusterReadiness"`
+	// OldState and NewState are the overall states before and after.
+	OldState string `json:"oldState"`
+	NewState string `json:"newState"`
+	// Message summarizes the check counts behind NewState.
+	Message string `json:"message"`
+	// FailingChecks are the checks not passing at the change.
+	FailingChecks []Check `json:"failingChecks,omitempty"`
+	// Time is when the change was evaluated.
+	Time time.Time `json:"time"`
+}
+
+// Check is a check that was not passing when an Event occurred.
+type Check struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Severity string `json:"severity"`
+	Category string `json:"category,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// Target is an HTTP endpoint payloads are POSTed to.
+type Target struct {
+	URL string
+	// Header is added to every request, e.g. for authentication.
+	Header http.Header
+	// Retries is how many times a failed delivery is retried.
+	Retries int
+	// Timeout bounds each attempt; zero means DefaultTimeout.
+	Timeout time.Duration
+}
+
+// Sender POSTs payloads to targets, retrying failed deliveries with
+// exponential backoff. The zero value is ready to use.
+type Sender struct {
+	// Client sends the requests; nil means http.DefaultClient.
+	Client *http.Client
+	// Backoff is the delay before the first retry, doubled before each
+	// following one; zero means one second.
+	Backoff time.Duration
+}
+
+// Post sends payload to t as JSON. Connection errors, 429 and 5xx responses
+// are retried up to t.Retries times; other failures are returned at once.
+func (s *Sender) Post(ctx context.Context, t Target, payload []byte) error {
+	backoff := s.Backoff
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+	var err error
+	for attempt := 0; ; attempt++ {
+		var retryable bool
+		retryable, err = s.post(ctx, t, payload)
+		if err == nil || !retryable || attempt >= t.Retries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff << attempt):
+		}
+	}
+}
+
+// post makes a single delivery attempt and reports whether a failure is
+// worth retrying.
+func (s *Sender) post(ctx context.Context, t Target, payload []byte) (retryable bool, err error) {
+	timeout := t.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+	for key, values := range t.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("POST %s: %s: %s", req.URL.Redacted(), resp.Status, bytes.TrimSpace(body))
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSender_Post(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int
+		retries   int
+		wantCalls int32
+		wantErr   string
+	}{
+		{name: "delivered", statuses: []int{http.StatusNoContent}, retries: 3, wantCalls: 1},
+		{name: "server error retried", statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusOK}, retries: 3, wantCalls: 3},
+		{name: "retries exhausted", statuses: []int{http.StatusServiceUnavailable}, retries: 2, wantCalls: 3, wantErr: "503 Service Unavailable: unavailable"},
+		{name: "client error not retried", statuses: []int{http.StatusUnauthorized}, retries: 3, wantCalls: 1, wantErr: "401 Unauthorized"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(calls.Add(1)) - 1
+				if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" || r.Header.Get("Authorization") != "Bearer s3cret" {
+					t.Errorf("request = %s %v, want an authenticated JSON POST", r.Method, r.Header)
+				}
+				status := tt.statuses[min(n, len(tt.statuses)-1)]
+				w.WriteHeader(status)
+				if status == http.StatusServiceUnavailable {
+					_, _ = w.Write([]byte("unavailable\n"))
+				}
+			}))
+			defer srv.Close()
+
+			s := &Sender{Backoff: time.Millisecond}
+			target := Target{URL: srv.URL, Header: http.Header{"Authorization": {"Bearer s3cret"}}, Retries: tt.retries}
+			err := s.Post(context.Background(), target, []byte(`{}`))
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestSender_PostCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	s := &Sender{Backoff: time.Hour}
+	if err := s.Post(ctx, Target{URL: srv.URL, Retries: 3}, []byte(`{}`)); err == nil || !strings.Contains(err.Error(), "deadline exceeded") {
+		t.Errorf("error = %v, want the context's deadline", err)
+	}
+}
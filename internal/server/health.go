@@ -2,14 +2,21 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 )
 
 // ReadinessState holds the latest readiness status, updated by the controller.
 type ReadinessState struct {
 	mu     sync.RWMutex
 	states map[string]*ClusterState // keyed by ClusterReadiness CR name
+	// updated records when each entry was last set, so Prune can spare
+	// entries set after the live CRs were listed.
+	updated map[string]time.Time
 }
 
 // ClusterState represents readiness for a single ClusterReadiness CR.
@@ -28,12 +35,14 @@ type ReadinessSummaryView struct {
 	CriticalTotal   int `json:"criticalTotal"`
 	CriticalPassing int `json:"criticalPassing"`
 	WarningFailing  int `json:"warningFailing"`
+	InfoFailing     int `json:"infoFailing"`
 }
 
 // CategorySummaryView provides per-category check counts for the HTTP response.
 type CategorySummaryView struct {
 	Category string `json:"category"`
 	State    string `json:"state"`
+	Required bool   `json:"required,omitempty"`
 	Total    int    `json:"total"`
 	Passing  int    `json:"passing"`
 	Failing  int    `json:"failing"`
@@ -50,7 +59,8 @@ type CheckState struct {
 // NewReadinessState creates a new ReadinessState store.
 func NewReadinessState() *ReadinessState {
 	return &ReadinessState{
-		states: make(map[string]*ClusterState),
+		states:  make(map[string]*ClusterState),
+		updated: make(map[string]time.Time),
 	}
 }
 
@@ -58,6 +68,7 @@ func NewReadinessState() *ReadinessState {
 func (rs *ReadinessState) Update(name string, state string, checks map[string]*CheckState, summary *ReadinessSummaryView, categorySummaries []CategorySummaryView) {
 	rs.mu.Lock()
 	defer rs.mu.Unlock()
+	rs.updated[name] = time.Now()
 	rs.states[name] = &ClusterState{
 		State:             state,
 		Summary:           summary,
@@ -66,11 +77,62 @@ func (rs *ReadinessState) Update(name string, state string, checks map[string]*C
 	}
 }
 
+// Restore sets the readiness state for a given ClusterReadiness CR unless it
+// already has one, so state restored from a persisted status never replaces a
+// fresher Update. It reports whether the state was set.
+func (rs *ReadinessState) Restore(name string, state string, checks map[string]*CheckState, summary *ReadinessSummaryView, categorySummaries []CategorySummaryView) bool {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if _, ok := rs.states[name]; ok {
+		return false
+	}
+	rs.updated[name] = time.Now()
+	rs.states[name] = &ClusterState{
+		State:             state,
+		Summary:           summary,
+		CategorySummaries: categorySummaries,
+		Checks:            checks,
+	}
+	return true
+}
+
 // Remove deletes the readiness state for a given ClusterReadiness CR.
 func (rs *ReadinessState) Remove(name string) {
 	rs.mu.Lock()
 	defer rs.mu.Unlock()
 	delete(rs.states, name)
+	delete(rs.updated, name)
+}
+
+// RemoveName deletes the entries keyed "<scope>/<name>" for name in every scope.
+func (rs *ReadinessState) RemoveName(name string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	for key := range rs.states {
+		if _, keyName, ok := strings.Cut(key, "/"); ok && keyName == name {
+			delete(rs.states, key)
+			delete(rs.updated, key)
+		}
+	}
+}
+
+// Prune removes entries for ClusterReadiness CRs not present in live and
+// returns the names it evicted. Entries set after listed, when live was
+// listed, are kept: their CR may have been created since.
+func (rs *ReadinessState) Prune(live map[string]bool, listed time.Time) []string {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	var evicted []string
+	for name := range rs.states {
+		if !live[name] && !rs.updated[name].After(listed) {
+			delete(rs.states, name)
+			delete(rs.updated, name)
+			evicted = append(evicted, name)
+		}
+	}
+	sort.Strings(evicted)
+	return evicted
 }
 
 // IsReady returns true if all tracked ClusterReadiness CRs are ready.
@@ -82,13 +144,19 @@ func (rs *ReadinessState) IsReady() bool {
 		return false
 	}
 	for _, state := range rs.states {
-		if state.State == "Unhealthy" {
+		if !stateReady(state.State) {
 			return false
 		}
 	}
 	return true
 }
 
+// stateReady reports whether a health state lets the gate pass: Initializing
+// is not an alarm, but is not ready either.
+func stateReady(state string) bool {
+	return state != "Unhealthy" && state != "Initializing"
+}
+
 // snapshot returns a copy of the current state for serialization.
 func (rs *ReadinessState) snapshot() map[string]*ClusterState {
 	rs.mu.RLock()
@@ -107,53 +175,148 @@ func (rs *ReadinessState) snapshot() map[string]*ClusterState {
 //
 //	category - filter checks by category
 //	severity - filter checks by severity
+//	fields   - comma-separated subset of summary, categories, checks to include
+//	limit    - maximum number of checks per page, across clusters
+//	continue - token from a previous page's "continue" field
+//
+// Responses are gzip-compressed when the client sends Accept-Encoding: gzip.
 func ReadyzHandler(state *ReadinessState) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		snap := state.snapshot()
-		categoryFilter := r.URL.Query().Get("category")
-		severityFilter := r.URL.Query().Get("severity")
+		serveReadyz(w, r, state.snapshot())
+	}
+}
 
-		// Apply filters if present
-		if categoryFilter != "" || severityFilter != "" {
-			snap = filterSnapshot(snap, categoryFilter, severityFilter)
+// NamespaceReadyzHandler returns an HTTP handler for the per-namespace readyz
+// paths, served from the NamespaceReadiness state:
+//
+//	/readyz/namespaces/{namespace}        - every NamespaceReadiness in the namespace
+//	/readyz/namespaces/{namespace}/{name} - a single NamespaceReadiness
+//
+// Entries are keyed by NamespaceReadiness name and support the same query
+// parameters as ReadyzHandler.
+func NamespaceReadyzHandler(state *ReadinessState) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		serveReadyz(w, r, state.scoped(r.PathValue("namespace"), r.PathValue("name")))
+	}
+}
+
+// GateReadyzHandler returns an HTTP handler for /readyz/{gate}, served from the
+// gate state: every ClusterReadiness defining the gate, keyed by name. It
+// supports the same query parameters as ReadyzHandler.
+func GateReadyzHandler(state *ReadinessState) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		serveReadyz(w, r, state.scoped(r.PathValue("gate"), ""))
+	}
+}
+
+// scoped returns the entries keyed "<scope>/<name>" in scope, by name. An
+// empty name matches every name.
+func (rs *ReadinessState) scoped(scope, name string) map[string]*ClusterState {
+	snap := make(map[string]*ClusterState)
+	for key, cs := range rs.snapshot() {
+		keyScope, keyName, ok := strings.Cut(key, "/")
+		if !ok || keyScope != scope || (name != "" && keyName != name) {
+			continue
 		}
+		snap[keyName] = cs
+	}
+	return snap
+}
 
-		healthy := len(snap) > 0
+// serveReadyz writes the readyz response for snap.
+func serveReadyz(w http.ResponseWriter, r *http.Request, snap map[string]*ClusterState) {
+	query := r.URL.Query()
+	fields, err := parseFields(query.Get("fields"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	limit, err := parseLimit(query.Get("limit"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	categoryFilter := query.Get("category")
+	severityFilter := query.Get("severity")
+	if severityFilter != "" && !validSeverities[severityFilter] {
+		http.Error(w, fmt.Sprintf("invalid severity %q: must be critical, warning or info", severityFilter), http.StatusBadRequest)
+		return
+	}
+
+	// Apply filters if present
+	if categoryFilter != "" || severityFilter != "" {
+		snap = filterSnapshot(snap, categoryFilter, severityFilter)
+	}
+
+	healthy := len(snap) > 0
+	for _, cs := range snap {
+		if !stateReady(cs.State) {
+			healthy = false
+			break
+		}
+	}
+
+	resp := struct {
+		State    string                   `json:"state"`
+		Clusters map[string]*ClusterState `json:"clusters,omitempty"`
+		Continue string                   `json:"continue,omitempty"`
+	}{}
+	if !healthy {
+		resp.State = "Unhealthy"
+		// Not ready only because clusters are still initializing.
+		if len(snap) > 0 && !anyState(snap, "Unhealthy") {
+			resp.State = "Initializing"
+		}
+	} else {
+		// Use worst state across clusters
+		resp.State = "Healthy"
 		for _, cs := range snap {
-			if cs.State == "Unhealthy" {
-				healthy = false
-				break
+			if cs.State == "Degraded" {
+				resp.State = "Degraded"
 			}
 		}
+	}
 
-		resp := struct {
-			State    string                   `json:"state"`
-			Clusters map[string]*ClusterState `json:"clusters,omitempty"`
-		}{
-			Clusters: snap,
+	// Shape the payload only after the overall state is computed from the full snapshot.
+	snap = projectSnapshot(snap, fields)
+	if fields[fieldChecks] && (limit > 0 || query.Get("continue") != "") {
+		if limit == 0 {
+			http.Error(w, "continue requires limit", http.StatusBadRequest)
+			return
 		}
-		if !healthy {
-			resp.State = "Unhealthy"
-		} else {
-			// Use worst state across clusters
-			resp.State = "Healthy"
-			for _, cs := range snap {
-				if cs.State == "Degraded" {
-					resp.State = "Degraded"
-				}
-			}
+		snap, resp.Continue, err = paginateChecks(snap, limit, query.Get("continue"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
+	}
+	resp.Clusters = snap
 
-		w.Header().Set("Content-Type", "application/json")
-		if healthy {
-			w.WriteHeader(http.StatusOK)
-		} else {
-			w.WriteHeader(http.StatusServiceUnavailable)
+	w.Header().Set("Content-Type", "application/json")
+	body, closeBody := responseWriter(w, r)
+	if healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(body).Encode(resp)
+	closeBody()
+}
+
+// anyState reports whether any cluster in snap is in state.
+func anyState(snap map[string]*ClusterState, state string) bool {
+	for _, cs := range snap {
+		if cs.State == state {
+			return true
 		}
-		json.NewEncoder(w).Encode(resp)
 	}
+	return false
 }
 
+// validSeverities are the accepted values of the severity query parameter.
+var validSeverities = map[string]bool{"critical": true, "warning": true, "info": true}
+
 // filterSnapshot creates a filtered copy of the snapshot based on category and severity.
 func filterSnapshot(snap map[string]*ClusterState, categoryFilter, severityFilter string) map[string]*ClusterState {
 	filtered := make(map[string]*ClusterState, len(snap))
@@ -177,7 +340,9 @@ func filterSnapshot(snap map[string]*ClusterState, categoryFilter, severityFilte
 				state = "Unhealthy"
 				break
 			}
-			if check.Status == "Failing" && check.Severity == "warning" {
+			if check.Status == "Initializing" {
+				state = "Initializing"
+			} else if check.Status == "Failing" && check.Severity == "warning" && state != "Initializing" {
 				state = "Degraded"
 			}
 		}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestReadinessState_IsReady(t *testing.T) {
@@ -48,6 +49,13 @@ func TestReadinessState_IsReady(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "initializing cluster is not ready",
+			setup: func(rs *ReadinessState) {
+				rs.Update("cluster-1", "Initializing", nil, nil, nil)
+			},
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -86,6 +94,46 @@ func TestReadinessState_Remove(t *testing.T) {
 	}
 }
 
+func TestReadinessState_Restore(t *testing.T) {
+	rs := NewReadinessState()
+	if !rs.Restore("cluster-1", "Healthy", nil, nil, nil) {
+		t.Error("expected an absent entry to be restored")
+	}
+	rs.Update("cluster-2", "Unhealthy", nil, nil, nil)
+
+	// A restored entry never replaces one set by Update.
+	if rs.Restore("cluster-2", "Healthy", nil, nil, nil) {
+		t.Error("expected an existing entry to be kept")
+	}
+	if rs.IsReady() {
+		t.Error("expected not ready while cluster-2 is Unhealthy")
+	}
+}
+
+func TestReadinessState_Prune(t *testing.T) {
+	rs := NewReadinessState()
+	rs.Update("cluster-1", "Healthy", nil, nil, nil)
+	rs.Update("old-name", "Unhealthy", nil, nil, nil)
+	rs.Update("deleted", "Unhealthy", nil, nil, nil)
+
+	listed := time.Now()
+	rs.Update("created-since", "Unhealthy", nil, nil, nil)
+	evicted := rs.Prune(map[string]bool{"cluster-1": true, "not-yet-reconciled": true}, listed)
+
+	if len(evicted) != 2 || evicted[0] != "deleted" || evicted[1] != "old-name" {
+		t.Errorf("evicted = %v, want [deleted old-name]", evicted)
+	}
+	if evicted := rs.Prune(map[string]bool{"cluster-1": true, "created-since": true}, time.Now()); len(evicted) != 0 {
+		t.Errorf("expected nothing to prune on second pass, got %v", evicted)
+	}
+	if evicted := rs.Prune(map[string]bool{"cluster-1": true}, time.Now()); len(evicted) != 1 || evicted[0] != "created-since" {
+		t.Errorf("evicted = %v, want [created-since] once listed after its update", evicted)
+	}
+	if !rs.IsReady() {
+		t.Error("expected ready after pruning stale failing entries")
+	}
+}
+
 func TestReadyzHandler_Ready(t *testing.T) {
 	rs := NewReadinessState()
 	rs.Update("test-cluster", "Healthy", map[string]*CheckState{
@@ -142,6 +190,31 @@ func TestReadyzHandler_NotReady(t *testing.T) {
 	}
 }
 
+func TestReadyzHandler_Initializing(t *testing.T) {
+	rs := NewReadinessState()
+	rs.Update("test-cluster", "Initializing", map[string]*CheckState{
+		"dns": {Status: "Initializing", Message: "failing", Severity: "critical", Category: "networking"},
+	}, nil, nil)
+
+	for _, target := range []string{"/readyz", "/readyz?category=networking"} {
+		rec := httptest.NewRecorder()
+		ReadyzHandler(rs)(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("%s: status = %d, want %d", target, rec.Code, http.StatusServiceUnavailable)
+		}
+		var resp struct {
+			State string `json:"state"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.State != "Initializing" {
+			t.Errorf("%s: expected state=Initializing, got %s", target, resp.State)
+		}
+	}
+}
+
 func TestReadyzHandler_Empty(t *testing.T) {
 	rs := NewReadinessState()
 	handler := ReadyzHandler(rs)
@@ -222,6 +295,103 @@ func TestReadyzHandler_SeverityFilter(t *testing.T) {
 	}
 }
 
+func TestNamespaceReadyzHandler(t *testing.T) {
+	rs := NewReadinessState()
+	rs.Update("shop/checkout", "Healthy", nil, nil, nil)
+	rs.Update("shop/payments", "Unhealthy", nil, nil, nil)
+	rs.Update("blog/web", "Healthy", nil, nil, nil)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/readyz/namespaces/{namespace}", NamespaceReadyzHandler(rs))
+	mux.HandleFunc("/readyz/namespaces/{namespace}/{name}", NamespaceReadyzHandler(rs))
+
+	tests := []struct {
+		path         string
+		wantCode     int
+		wantClusters []string
+	}{
+		{path: "/readyz/namespaces/shop", wantCode: http.StatusServiceUnavailable, wantClusters: []string{"checkout", "payments"}},
+		{path: "/readyz/namespaces/shop/checkout", wantCode: http.StatusOK, wantClusters: []string{"checkout"}},
+		{path: "/readyz/namespaces/blog", wantCode: http.StatusOK, wantClusters: []string{"web"}},
+		{path: "/readyz/namespaces/empty", wantCode: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+
+			var resp struct {
+				Clusters map[string]*ClusterState `json:"clusters"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Clusters) != len(tt.wantClusters) {
+				t.Fatalf("clusters = %v, want %v", resp.Clusters, tt.wantClusters)
+			}
+			for _, name := range tt.wantClusters {
+				if _, ok := resp.Clusters[name]; !ok {
+					t.Errorf("missing entry %q in %v", name, resp.Clusters)
+				}
+			}
+		})
+	}
+}
+
+func TestGateReadyzHandler(t *testing.T) {
+	rs := NewReadinessState()
+	rs.Update("workloads/prod", "Healthy", nil, nil, nil)
+	rs.Update("workloads/staging", "Degraded", nil, nil, nil)
+	rs.Update("ingress-traffic/prod", "Unhealthy", nil, nil, nil)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/readyz/{gate}", GateReadyzHandler(rs))
+
+	tests := []struct {
+		path         string
+		wantCode     int
+		wantClusters []string
+	}{
+		{path: "/readyz/workloads", wantCode: http.StatusOK, wantClusters: []string{"prod", "staging"}},
+		{path: "/readyz/ingress-traffic", wantCode: http.StatusServiceUnavailable, wantClusters: []string{"prod"}},
+		{path: "/readyz/batch", wantCode: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+
+			var resp struct {
+				Clusters map[string]*ClusterState `json:"clusters"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Clusters) != len(tt.wantClusters) {
+				t.Fatalf("clusters = %v, want %v", resp.Clusters, tt.wantClusters)
+			}
+			for _, name := range tt.wantClusters {
+				if _, ok := resp.Clusters[name]; !ok {
+					t.Errorf("missing entry %q in %v", name, resp.Clusters)
+				}
+			}
+		})
+	}
+
+	rs.RemoveName("prod")
+	if snap := rs.snapshot(); len(snap) != 1 || snap["workloads/staging"] == nil {
+		t.Errorf("after RemoveName(prod) state = %v, want only workloads/staging", snap)
+	}
+}
+
 func TestFilterSnapshot(t *testing.T) {
 	snap := map[string]*ClusterState{
 		"cluster-1": {
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Field names accepted by the ?fields= query parameter of /readyz.
+const (
+	fieldSummary    = "summary"
+	fieldCategories = "categories"
+	fieldChecks     = "checks"
+)
+
+// parseFields parses a comma-separated ?fields= value. An empty value selects every field.
+func parseFields(raw string) (map[string]bool, error) {
+	fields := map[string]bool{fieldSummary: true, fieldCategories: true, fieldChecks: true}
+	if raw == "" {
+		return fields, nil
+	}
+	selected := make(map[string]bool)
+	for _, f := range strings.Split(raw, ",") {
+		f = strings.TrimSpace(f)
+		if !fields[f] {
+			return nil, fmt.Errorf("unknown field %q (want %s, %s or %s)", f, fieldSummary, fieldCategories, fieldChecks)
+		}
+		selected[f] = true
+	}
+	return selected, nil
+}
+
+// projectSnapshot returns copies of the cluster states carrying only the selected fields.
+func projectSnapshot(snap map[string]*ClusterState, fields map[string]bool) map[string]*ClusterState {
+	projected := make(map[string]*ClusterState, len(snap))
+	for name, cs := range snap {
+		p := &ClusterState{State: cs.State}
+		if fields[fieldSummary] {
+			p.Summary = cs.Summary
+		}
+		if fields[fieldCategories] {
+			p.CategorySummaries = cs.CategorySummaries
+		}
+		if fields[fieldChecks] {
+			p.Checks = cs.Checks
+		}
+		projected[name] = p
+	}
+	return projected
+}
+
+// paginateChecks keeps at most limit checks, ordered by cluster then check name,
+// starting after the position encoded in token. It returns the continue token
+// for the next page, or "" on the last page. Clusters are always kept so that
+// their state and summaries remain visible on every page.
+func paginateChecks(snap map[string]*ClusterState, limit int, token string) (map[string]*ClusterState, string, error) {
+	var afterCluster, afterCheck string
+	if token != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(token)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid continue token")
+		}
+		var ok bool
+		afterCluster, afterCheck, ok = strings.Cut(string(raw), "\x00")
+		if !ok {
+			return nil, "", fmt.Errorf("invalid continue token")
+		}
+	}
+
+	clusterNames := make([]string, 0, len(snap))
+	for name := range snap {
+		clusterNames = append(clusterNames, name)
+	}
+	sort.Strings(clusterNames)
+
+	paged := make(map[string]*ClusterState, len(snap))
+	remaining := limit
+	var lastCluster, lastCheck, next string
+	for _, clusterName := range clusterNames {
+		cs := snap[clusterName]
+		p := *cs
+		p.Checks = nil
+		paged[clusterName] = &p
+		if len(cs.Checks) == 0 {
+			continue
+		}
+
+		checkNames := make([]string, 0, len(cs.Checks))
+		for name := range cs.Checks {
+			checkNames = append(checkNames, name)
+		}
+		sort.Strings(checkNames)
+
+		page := make(map[string]*CheckState)
+		for _, checkName := range checkNames {
+			if clusterName < afterCluster || (clusterName == afterCluster && checkName <= afterCheck) {
+				continue
+			}
+			if remaining == 0 {
+				if next == "" {
+					next = base64.RawURLEncoding.EncodeToString([]byte(lastCluster + "\x00" + lastCheck))
+				}
+				break
+			}
+			page[checkName] = cs.Checks[checkName]
+			remaining--
+			lastCluster, lastCheck = clusterName, checkName
+		}
+		if len(page) > 0 {
+			p.Checks = page
+		}
+	}
+	return paged, next, nil
+}
+
+// parseLimit parses the ?limit= query parameter; 0 means no pagination.
+func parseLimit(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("limit must be a positive integer")
+	}
+	return n, nil
+}
+
+// responseWriter returns a writer that gzip-compresses the body when the client
+// accepts it. The returned close function must be called after writing.
+func responseWriter(w http.ResponseWriter, r *http.Request) (io.Writer, func() error) {
+	w.Header().Add("Vary", "Accept-Encoding")
+	if !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+		return w, func() error { return nil }
+	}
+	w.Header().Set("Content-Encoding", "gzip")
+	gz := gzip.NewWriter(w)
+	return gz, gz.Close
+}
+
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.TrimSpace(coding) != "gzip" {
+			continue
+		}
+		// Honour an explicit "gzip;q=0" refusal.
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+type readyzResponse struct {
+	State    string                   `json:"state"`
+	Clusters map[string]*ClusterState `json:"clusters"`
+	Continue string                   `json:"continue"`
+}
+
+func TestReadyzHandler_Gzip(t *testing.T) {
+	rs := NewReadinessState()
+	rs.Update("test-cluster", "Healthy", map[string]*CheckState{
+		"dns": {Status: "Passing", Severity: "critical", Category: "networking"},
+	}, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	req.Header.Set("Accept-Encoding", "br, gzip;q=0.8")
+	rec := httptest.NewRecorder()
+	ReadyzHandler(rs)(rec, req)
+
+	if got := rec.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", got)
+	}
+	gz, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatalf("failed to open gzip body: %v", err)
+	}
+	var resp readyzResponse
+	if err := json.NewDecoder(gz).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.State != "Healthy" || resp.Clusters["test-cluster"].Checks["dns"] == nil {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"deflate, gzip", true},
+		{"gzip;q=0", false},
+		{"gzip;q=0.5", true},
+		{"br", false},
+	}
+	for _, tt := range tests {
+		if got := acceptsGzip(tt.header); got != tt.want {
+			t.Errorf("acceptsGzip(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestReadyzHandler_FieldsSummary(t *testing.T) {
+	rs := NewReadinessState()
+	rs.Update("test-cluster", "Unhealthy", map[string]*CheckState{
+		"dns": {Status: "Failing", Severity: "critical", Category: "networking"},
+	}, &ReadinessSummaryView{Total: 1, Failing: 1}, []CategorySummaryView{{Category: "networking"}})
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz?fields=summary", nil)
+	rec := httptest.NewRecorder()
+	ReadyzHandler(rs)(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+	var resp readyzResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	cs := resp.Clusters["test-cluster"]
+	if cs == nil || cs.Summary == nil || cs.Summary.Failing != 1 {
+		t.Fatalf("expected summary in response, got %+v", cs)
+	}
+	if len(cs.Checks) != 0 || len(cs.CategorySummaries) != 0 {
+		t.Errorf("expected checks and categories to be omitted, got %+v", cs)
+	}
+}
+
+func TestReadyzHandler_BadQuery(t *testing.T) {
+	rs := NewReadinessState()
+	rs.Update("test-cluster", "Healthy", nil, nil, nil)
+
+	for _, query := range []string{"fields=bogus", "severity=minor", "limit=0", "limit=abc", "limit=1&continue=%21%21", "continue=abc"} {
+		req := httptest.NewRequest(http.MethodGet, "/readyz?"+query, nil)
+		rec := httptest.NewRecorder()
+		ReadyzHandler(rs)(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", query, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestReadyzHandler_Pagination(t *testing.T) {
+	rs := NewReadinessState()
+	rs.Update("a", "Healthy", map[string]*CheckState{
+		"dns":  {Status: "Passing", Severity: "critical"},
+		"etcd": {Status: "Passing", Severity: "critical"},
+	}, nil, nil)
+	rs.Update("b", "Degraded", map[string]*CheckState{
+		"ingress": {Status: "Failing", Severity: "warning"},
+	}, nil, nil)
+
+	handler := ReadyzHandler(rs)
+	seen := map[string]bool{}
+	token := ""
+	pages := 0
+	for {
+		q := url.Values{"limit": {"2"}}
+		if token != "" {
+			q.Set("continue", token)
+		}
+		req := httptest.NewRequest(http.MethodGet, "/readyz?"+q.Encode(), nil)
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+
+		var resp readyzResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		// Overall state reflects every check, not just the current page.
+		if resp.State != "Degraded" {
+			t.Errorf("page %d: state = %q, want Degraded", pages, resp.State)
+		}
+		if len(resp.Clusters) != 2 {
+			t.Errorf("page %d: expected both clusters, got %d", pages, len(resp.Clusters))
+		}
+		for cluster, cs := range resp.Clusters {
+			for check := range cs.Checks {
+				key := cluster + "/" + check
+				if seen[key] {
+					t.Errorf("check %s returned twice", key)
+				}
+				seen[key] = true
+			}
+		}
+
+		pages++
+		token = resp.Continue
+		if token == "" {
+			break
+		}
+		if pages > 3 {
+			t.Fatal("pagination did not terminate")
+		}
+	}
+
+	if pages != 2 {
+		t.Errorf("pages = %d, want 2", pages)
+	}
+	if len(seen) != 3 {
+		t.Errorf("expected 3 checks across pages, got %v", seen)
+	}
+}
@@ -0,0 +1,6 @@
+// Package version reports the version of the running operator.
+package version
+
+// Version is the operator version, set at build time with
+// -ldflags "-X github.com/clustergate/clustergate/internal/version.Version=v1.2.3".
+var Version = "dev"
@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+	"github.com/clustergate/clustergate/internal/notify"
+)
+
+// +kubebuilder:webhook:path=/validate-clustergate-io-v1alpha1-clusterreadiness,mutating=false,failurePolicy=fail,sideEffects=None,groups=clustergate.io,resources=clusterreadinesses,verbs=create;update,versions=v1alpha1,name=vclusterreadiness-v1alpha1.clustergate.io,admissionReviewVersions=v1
+
+// ClusterReadinessValidator rejects ClusterReadiness CRs referencing
+// GateProfiles, profile versions, GateChecks or built-in checks that do not
+// exist, listing the same inline check twice, setting intervals below
+// MinInterval, with notification templates that do not parse, or with an
+// invalid node taint or traffic weight annotation.
+type ClusterReadinessValidator struct {
+	Client client.Reader
+
+	// MinInterval is the shortest interval accepted; zero accepts any.
+	MinInterval time.Duration
+}
+
+// ValidateCreate validates a new ClusterReadiness.
+func (v *ClusterReadinessValidator) ValidateCreate(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness) (admission.Warnings, error) {
+	return nil, v.validate(ctx, cr)
+}
+
+// ValidateUpdate validates a ClusterReadiness whose spec changed.
+func (v *ClusterReadinessValidator) ValidateUpdate(ctx context.Context, oldCR, cr *clustergatev1alpha1.ClusterReadiness) (admission.Warnings, error) {
+	if equality.Semantic.DeepEqual(oldCR.Spec, cr.Spec) {
+		return nil, nil
+	}
+	return nil, v.validate(ctx, cr)
+}
+
+// ValidateDelete allows every deletion.
+func (v *ClusterReadinessValidator) ValidateDelete(context.Context, *clustergatev1alpha1.ClusterReadiness) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks cr's references, inline check identifiers, intervals,
+// notification templates and node taint.
+func (v *ClusterReadinessValidator) validate(ctx context.Context, cr *clustergatev1alpha1.ClusterReadiness) error {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	errs = append(errs, v.validateInterval(specPath.Child("interval"), cr.Spec.Interval)...)
+
+	for i, ref := range cr.Spec.Profiles {
+		if ref.SnapshotRef != nil {
+			continue
+		}
+		profileErrs, err := v.validateProfileRef(ctx, specPath.Child("profiles").Index(i), ref)
+		if err != nil {
+			return err
+		}
+		errs = append(errs, profileErrs...)
+	}
+
+	seen := map[string]int{}
+	for i, cs := range cr.Spec.Checks {
+		checkPath := specPath.Child("checks").Index(i)
+		id := cs.Identifier()
+		if first, ok := seen[id]; ok {
+			dup := field.Duplicate(checkPath, id)
+			dup.Detail = fmt.Sprintf("already listed as spec.checks[%d]; give each use of a GateCheck its own instance", first)
+			errs = append(errs, dup)
+		} else {
+			seen[id] = i
+		}
+		if cs.Interval != nil {
+			errs = append(errs, v.validateInterval(checkPath.Child("interval"), *cs.Interval)...)
+		}
+
+		switch {
+		case cs.GateCheckRef != "":
+			var gc clustergatev1alpha1.GateCheck
+			if err := v.Client.Get(ctx, types.NamespacedName{Name: cs.GateCheckRef}, &gc); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return err
+				}
+				errs = append(errs, notFound(checkPath.Child("gateCheckRef"), cs.GateCheckRef,
+					"GateCheck %q does not exist; create it first or fix the name", cs.GateCheckRef))
+			}
+		case cs.Name != "":
+			if _, ok := checks.Get(cs.Name); !ok {
+				builtins := checks.List()
+				sort.Strings(builtins)
+				errs = append(errs, field.NotSupported(checkPath.Child("name"), cs.Name, builtins))
+			}
+		}
+	}
+
+	if n := cr.Spec.Notifications; n != nil {
+		for i, p := range n.Providers {
+			if _, err := notify.ParseTemplate(p.Template); err != nil {
+				errs = append(errs, field.Invalid(specPath.Child("notifications", "providers").Index(i).Child("template"),
+					p.Template, err.Error()))
+			}
+		}
+	}
+
+	if a := cr.Spec.Actions; a != nil && a.NodeTaint != nil {
+		errs = append(errs, validateNodeTaint(specPath.Child("actions", "nodeTaint"), a.NodeTaint)...)
+	}
+	if a := cr.Spec.Actions; a != nil && a.TrafficWeight != nil && a.TrafficWeight.Annotation != "" {
+		for _, msg := range validation.IsQualifiedName(a.TrafficWeight.Annotation) {
+			errs = append(errs, field.Invalid(specPath.Child("actions", "trafficWeight", "annotation"), a.TrafficWeight.Annotation, msg))
+		}
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(clustergatev1alpha1.GroupVersion.WithKind("ClusterReadiness").GroupKind(), cr.Name, errs)
+	}
+	return nil
+}
+
+// validateNodeTaint checks that a node taint action's key, value and node
+// selector are valid.
+func validateNodeTaint(path *field.Path, action *clustergatev1alpha1.NodeTaintAction) field.ErrorList {
+	var errs field.ErrorList
+	if action.Key != "" {
+		for _, msg := range validation.IsQualifiedName(action.Key) {
+			errs = append(errs, field.Invalid(path.Child("key"), action.Key, msg))
+		}
+	}
+	if action.Value != "" {
+		for _, msg := range validation.IsValidLabelValue(action.Value) {
+			errs = append(errs, field.Invalid(path.Child("value"), action.Value, msg))
+		}
+	}
+	if action.NodeSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(action.NodeSelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("nodeSelector"), action.NodeSelector, err.Error()))
+		}
+	}
+	return errs
+}
+
+// validateProfileRef checks that ref's GateProfile exists and, when pinned,
+// has recorded the pinned version.
+func (v *ClusterReadinessValidator) validateProfileRef(ctx context.Context, refPath *field.Path, ref clustergatev1alpha1.ProfileRef) (field.ErrorList, error) {
+	var profile clustergatev1alpha1.GateProfile
+	if err := v.Client.Get(ctx, types.NamespacedName{Name: ref.Name}, &profile); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		return field.ErrorList{notFound(refPath.Child("name"), ref.Name,
+			"GateProfile %q does not exist; create it first or fix the name", ref.Name)}, nil
+	}
+	if ref.Version == "" {
+		return nil, nil
+	}
+	if current, _ := profile.Current(); ref.Version == current {
+		return nil, nil
+	}
+	var versions []string
+	for _, pv := range profile.Status.Versions {
+		if pv.Version == ref.Version {
+			return nil, nil
+		}
+		versions = append(versions, pv.Version)
+	}
+	return field.ErrorList{notFound(refPath.Child("version"), ref.Version,
+		"GateProfile %q has no version %q; recorded versions: [%s]", ref.Name, ref.Version, strings.Join(versions, ", "))}, nil
+}
+
+// validateInterval rejects a set interval below the floor.
+func (v *ClusterReadinessValidator) validateInterval(path *field.Path, interval metav1.Duration) field.ErrorList {
+	if interval.Duration == 0 || v.MinInterval == 0 || interval.Duration >= v.MinInterval {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path, interval.Duration.String(),
+		fmt.Sprintf("must be at least %s, the operator's --min-check-interval", v.MinInterval))}
+}
+
+// notFound returns a NotFound error for value at path with an actionable
+// detail.
+func notFound(path *field.Path, value string, format string, args ...any) *field.Error {
+	err := field.NotFound(path, value)
+	err.Detail = fmt.Sprintf(format, args...)
+	return err
+}
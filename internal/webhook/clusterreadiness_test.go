@@ -0,0 +1,148 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+)
+
+func TestClusterReadinessValidator(t *testing.T) {
+	checks.Reset()
+	t.Cleanup(checks.Reset)
+	checks.Register(&stubChecker{name: "dns"})
+
+	s := runtime.NewScheme()
+	if err := clustergatev1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	gc := &clustergatev1alpha1.GateCheck{ObjectMeta: metav1.ObjectMeta{Name: "istiod-ready"}}
+	profile := &clustergatev1alpha1.GateProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+		Spec:       clustergatev1alpha1.GateProfileSpec{Version: "2.0.0"},
+		Status: clustergatev1alpha1.GateProfileStatus{
+			Versions: []clustergatev1alpha1.ProfileVersion{{Version: "2.0.0"}, {Version: "1.0.0"}},
+		},
+	}
+	v := &ClusterReadinessValidator{
+		Client:      fake.NewClientBuilder().WithScheme(s).WithObjects(gc, profile).Build(),
+		MinInterval: 10 * time.Second,
+	}
+	second := metav1.Duration{Duration: time.Second}
+
+	tests := []struct {
+		name    string
+		spec    clustergatev1alpha1.ClusterReadinessSpec
+		wantErr []string
+	}{
+		{
+			name: "valid",
+			spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Interval: metav1.Duration{Duration: time.Minute},
+				Profiles: []clustergatev1alpha1.ProfileRef{{Name: "platform", Version: "1.0.0"}},
+				Checks: []clustergatev1alpha1.CheckSpec{
+					{Name: "dns"},
+					{GateCheckRef: "istiod-ready", Instance: "a"},
+					{GateCheckRef: "istiod-ready", Instance: "b"},
+				},
+			},
+		},
+		{
+			name: "missing references",
+			spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Profiles: []clustergatev1alpha1.ProfileRef{{Name: "nope"}, {Name: "platform", Version: "3.0.0"}},
+				Checks:   []clustergatev1alpha1.CheckSpec{{GateCheckRef: "missing"}, {Name: "dsn"}},
+			},
+			wantErr: []string{
+				`spec.profiles[0].name: Not found: "nope"`,
+				`GateProfile "platform" has no version "3.0.0"; recorded versions: [2.0.0, 1.0.0]`,
+				`GateCheck "missing" does not exist`,
+				`spec.checks[1].name: Unsupported value: "dsn"`,
+			},
+		},
+		{
+			name: "duplicate identifiers",
+			spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Checks: []clustergatev1alpha1.CheckSpec{{GateCheckRef: "istiod-ready"}, {GateCheckRef: "istiod-ready"}},
+			},
+			wantErr: []string{`spec.checks[1]: Duplicate value: "dynamic:istiod-ready"`, "already listed as spec.checks[0]"},
+		},
+		{
+			name: "interval below floor",
+			spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Interval: second,
+				Checks:   []clustergatev1alpha1.CheckSpec{{Name: "dns", Interval: &second}},
+			},
+			wantErr: []string{"spec.interval: Invalid value", "spec.checks[0].interval: Invalid value", "must be at least 10s"},
+		},
+		{
+			name: "unparseable notification template",
+			spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Interval: metav1.Duration{Duration: time.Minute},
+				Notifications: &clustergatev1alpha1.Notifications{
+					Providers: []clustergatev1alpha1.NotificationProvider{
+						{Name: "ok", Type: clustergatev1alpha1.NotificationProviderSlack},
+						{Name: "broken", Type: clustergatev1alpha1.NotificationProviderTeams, Template: "{{.NewState"},
+					},
+				},
+			},
+			wantErr: []string{"spec.notifications.providers[1].template: Invalid value"},
+		},
+		{
+			name: "invalid node taint",
+			spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Interval: metav1.Duration{Duration: time.Minute},
+				Actions: &clustergatev1alpha1.Actions{
+					NodeTaint: &clustergatev1alpha1.NodeTaintAction{
+						Key:   "not a key",
+						Value: "-bad",
+						NodeSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "role", Operator: "Sometimes"},
+						}},
+					},
+				},
+			},
+			wantErr: []string{"spec.actions.nodeTaint.key: Invalid value", "spec.actions.nodeTaint.value: Invalid value", "spec.actions.nodeTaint.nodeSelector: Invalid value"},
+		},
+		{
+			name: "invalid traffic weight annotation",
+			spec: clustergatev1alpha1.ClusterReadinessSpec{
+				Interval: metav1.Duration{Duration: time.Minute},
+				Actions: &clustergatev1alpha1.Actions{
+					TrafficWeight: &clustergatev1alpha1.TrafficWeightAction{
+						Annotation: "external-dns weight",
+						Targets:    []clustergatev1alpha1.TrafficTarget{{Kind: clustergatev1alpha1.TrafficTargetService, Namespace: "ingress", Name: "gateway"}},
+					},
+				},
+			},
+			wantErr: []string{"spec.actions.trafficWeight.annotation: Invalid value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &clustergatev1alpha1.ClusterReadiness{ObjectMeta: metav1.ObjectMeta{Name: "production"}, Spec: tt.spec}
+			_, err := v.ValidateCreate(context.Background(), cr)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected errors %q", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Package webhook rejects invalid ClusterReadiness CRs, GateChecks and
+// GateProfiles at admission, so bad definitions fail at apply time, and gates
+// new workloads in opted-in namespaces on cluster readiness.
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+	"github.com/clustergate/clustergate/internal/controller"
+)
+
+// +kubebuilder:webhook:path=/validate-clustergate-io-v1alpha1-gatecheck,mutating=false,failurePolicy=fail,sideEffects=None,groups=clustergate.io,resources=gatechecks,verbs=create;update,versions=v1alpha1,name=vgatecheck-v1alpha1.clustergate.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-clustergate-io-v1alpha1-gateprofile,mutating=false,failurePolicy=fail,sideEffects=None,groups=clustergate.io,resources=gateprofiles,verbs=create;update,versions=v1alpha1,name=vgateprofile-v1alpha1.clustergate.io,admissionReviewVersions=v1
+
+// SetupWithManager registers the ClusterReadiness, GateCheck and GateProfile
+// validating webhooks and the workload gate with the manager's webhook
+// server. ClusterReadiness intervals below minCheckInterval are rejected.
+func SetupWithManager(mgr ctrl.Manager, minCheckInterval time.Duration) error {
+	if err := ctrl.NewWebhookManagedBy(mgr, &clustergatev1alpha1.ClusterReadiness{}).
+		WithValidator(&ClusterReadinessValidator{Client: mgr.GetClient(), MinInterval: minCheckInterval}).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr, &clustergatev1alpha1.GateCheck{}).
+		WithValidator(&GateCheckValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr, &clustergatev1alpha1.GateProfile{}).
+		WithValidator(&GateProfileValidator{Client: mgr.GetClient()}).
+		Complete(); err != nil {
+		return err
+	}
+	mgr.GetWebhookServer().Register(WorkloadGatePath, &ctrlwebhook.Admission{Handler: &WorkloadGate{Client: mgr.GetClient()}})
+	return nil
+}
+
+// GateCheckValidator rejects GateChecks whose spec is invalid.
+type GateCheckValidator struct{}
+
+// ValidateCreate validates a new GateCheck.
+func (v *GateCheckValidator) ValidateCreate(_ context.Context, gc *clustergatev1alpha1.GateCheck) (admission.Warnings, error) {
+	return nil, validateGateCheck(gc)
+}
+
+// ValidateUpdate validates a GateCheck whose spec changed. Metadata-only
+// updates of GateChecks that predate the webhook are let through.
+func (v *GateCheckValidator) ValidateUpdate(_ context.Context, oldGC, gc *clustergatev1alpha1.GateCheck) (admission.Warnings, error) {
+	if equality.Semantic.DeepEqual(oldGC.Spec, gc.Spec) {
+		return nil, nil
+	}
+	return nil, validateGateCheck(gc)
+}
+
+// ValidateDelete allows every deletion.
+func (v *GateCheckValidator) ValidateDelete(context.Context, *clustergatev1alpha1.GateCheck) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateGateCheck applies the GateCheck reconciler's rules to gc.
+func validateGateCheck(gc *clustergatev1alpha1.GateCheck) error {
+	if err := controller.ValidateGateCheckSpec(gc.Spec); err != nil {
+		return apierrors.NewInvalid(clustergatev1alpha1.GroupVersion.WithKind("GateCheck").GroupKind(), gc.Name,
+			field.ErrorList{field.Invalid(field.NewPath("spec"), field.OmitValueType{}, err.Error())})
+	}
+	return nil
+}
+
+// GateProfileValidator rejects GateProfiles whose checks are invalid, name
+// unknown built-in checks, or reference GateChecks that are invalid with the
+// profile's instance values. References to GateChecks that do not exist yet
+// are warnings, so a profile can be applied with or before its GateChecks.
+type GateProfileValidator struct {
+	Client client.Reader
+}
+
+// ValidateCreate validates a new GateProfile.
+func (v *GateProfileValidator) ValidateCreate(ctx context.Context, gp *clustergatev1alpha1.GateProfile) (admission.Warnings, error) {
+	return v.validate(ctx, gp)
+}
+
+// ValidateUpdate validates a GateProfile whose spec changed.
+func (v *GateProfileValidator) ValidateUpdate(ctx context.Context, oldGP, gp *clustergatev1alpha1.GateProfile) (admission.Warnings, error) {
+	if equality.Semantic.DeepEqual(oldGP.Spec, gp.Spec) {
+		return nil, nil
+	}
+	return v.validate(ctx, gp)
+}
+
+// ValidateDelete allows every deletion.
+func (v *GateProfileValidator) ValidateDelete(context.Context, *clustergatev1alpha1.GateProfile) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate applies the GateProfile reconciler's rules to gp, then dry-runs
+// each referenced check.
+func (v *GateProfileValidator) validate(ctx context.Context, gp *clustergatev1alpha1.GateProfile) (admission.Warnings, error) {
+	checksPath := field.NewPath("spec", "checks")
+	if err := controller.ValidateProfileChecks(gp.Spec.Checks); err != nil {
+		return nil, invalidProfile(gp, field.ErrorList{field.Invalid(checksPath, field.OmitValueType{}, err.Error())})
+	}
+
+	var warnings admission.Warnings
+	var errs field.ErrorList
+	for i, ref := range gp.Spec.Checks {
+		if ref.Name != "" {
+			if _, ok := checks.Get(ref.Name); !ok {
+				builtins := checks.List()
+				sort.Strings(builtins)
+				errs = append(errs, field.NotSupported(checksPath.Index(i).Child("name"), ref.Name, builtins))
+			}
+			continue
+		}
+		refPath := checksPath.Index(i).Child("gateCheckRef")
+		var gc clustergatev1alpha1.GateCheck
+		if err := v.Client.Get(ctx, types.NamespacedName{Name: ref.GateCheckRef}, &gc); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			warnings = append(warnings, fmt.Sprintf("%s: GateCheck %q does not exist yet", refPath, ref.GateCheckRef))
+			continue
+		}
+		spec, err := controller.ApplyParameters(gc.Spec, ref.Values)
+		if err == nil {
+			err = controller.ValidateGateCheckSpec(spec)
+		}
+		if err != nil {
+			errs = append(errs, field.Invalid(refPath, ref.GateCheckRef, err.Error()))
+		}
+	}
+	if len(errs) > 0 {
+		return warnings, invalidProfile(gp, errs)
+	}
+	return warnings, nil
+}
+
+// invalidProfile returns the Invalid error rejecting gp for errs.
+func invalidProfile(gp *clustergatev1alpha1.GateProfile, errs field.ErrorList) error {
+	return apierrors.NewInvalid(clustergatev1alpha1.GroupVersion.WithKind("GateProfile").GroupKind(), gp.Name, errs)
+}
@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+)
+
+type stubChecker struct{ name string }
+
+func (s *stubChecker) Name() string            { return s.name }
+func (s *stubChecker) DefaultSeverity() string { return "critical" }
+func (s *stubChecker) DefaultCategory() string { return "networking" }
+func (s *stubChecker) Run(context.Context, json.RawMessage) (checks.Result, error) {
+	return checks.Result{Ready: true}, nil
+}
+
+func TestGateCheckValidator(t *testing.T) {
+	v := &GateCheckValidator{}
+	ctx := context.Background()
+	valid := &clustergatev1alpha1.GateCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress"},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{URL: "http://ingress.example.com/healthz"},
+		},
+	}
+	if _, err := v.ValidateCreate(ctx, valid); err != nil {
+		t.Errorf("valid GateCheck rejected: %v", err)
+	}
+
+	multiple := valid.DeepCopy()
+	multiple.Spec.PodCheck = &clustergatev1alpha1.PodCheckSpec{Namespace: "ingress"}
+	_, err := v.ValidateCreate(ctx, multiple)
+	if err == nil || !strings.Contains(err.Error(), "MultipleCheckTypes") {
+		t.Errorf("error = %v, want MultipleCheckTypes", err)
+	}
+
+	// Metadata-only updates of an invalid GateCheck are let through.
+	relabeled := multiple.DeepCopy()
+	relabeled.Labels = map[string]string{"team": "platform"}
+	if _, err := v.ValidateUpdate(ctx, multiple, relabeled); err != nil {
+		t.Errorf("metadata-only update rejected: %v", err)
+	}
+	if _, err := v.ValidateUpdate(ctx, valid, multiple); err == nil {
+		t.Error("invalid spec update accepted")
+	}
+}
+
+func TestGateProfileValidator(t *testing.T) {
+	checks.Reset()
+	t.Cleanup(checks.Reset)
+	checks.Register(&stubChecker{name: "dns"})
+
+	required := &clustergatev1alpha1.GateCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "service-health"},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			Parameters: []clustergatev1alpha1.CheckParameter{{Name: "host"}},
+			HTTPCheck:  &clustergatev1alpha1.HTTPCheckSpec{URL: "http://$(params.host)/healthz"},
+		},
+	}
+	s := runtime.NewScheme()
+	if err := clustergatev1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	v := &GateProfileValidator{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(required).Build()}
+
+	tests := []struct {
+		name        string
+		checks      []clustergatev1alpha1.ProfileCheckRef
+		wantErr     string
+		wantWarning string
+	}{
+		{
+			name: "valid",
+			checks: []clustergatev1alpha1.ProfileCheckRef{
+				{Name: "dns"},
+				{GateCheckRef: "service-health", Instance: "api", Values: map[string]string{"host": "api"}},
+			},
+		},
+		{
+			name:    "ambiguous reference",
+			checks:  []clustergatev1alpha1.ProfileCheckRef{{Name: "dns", GateCheckRef: "service-health"}},
+			wantErr: "AmbiguousCheckRef",
+		},
+		{
+			name:    "unknown built-in",
+			checks:  []clustergatev1alpha1.ProfileCheckRef{{Name: "nope"}},
+			wantErr: `spec.checks[0].name: Unsupported value: "nope"`,
+		},
+		{
+			name:    "missing required parameter",
+			checks:  []clustergatev1alpha1.ProfileCheckRef{{GateCheckRef: "service-health"}},
+			wantErr: `parameter "host" is required`,
+		},
+		{
+			name:        "GateCheck not created yet",
+			checks:      []clustergatev1alpha1.ProfileCheckRef{{GateCheckRef: "later"}},
+			wantWarning: `GateCheck "later" does not exist yet`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gp := &clustergatev1alpha1.GateProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+				Spec:       clustergatev1alpha1.GateProfileSpec{Checks: tt.checks},
+			}
+			warnings, err := v.ValidateCreate(context.Background(), gp)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.wantWarning != "" && (len(warnings) != 1 || !strings.Contains(warnings[0], tt.wantWarning)) {
+				t.Errorf("warnings = %q, want %q", warnings, tt.wantWarning)
+			}
+		})
+	}
+}
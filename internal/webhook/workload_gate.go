@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+const (
+	// WorkloadGateAnnotation opts a namespace into the workload gate: it
+	// names the ClusterReadiness new workloads in the namespace wait for.
+	WorkloadGateAnnotation = "clustergate.io/workload-gate"
+
+	// WorkloadGateModeAnnotation sets how the workload gate treats a
+	// namespace's new workloads while its ClusterReadiness is not ready:
+	// "deny" (the default) rejects them, "warn" admits them with a warning.
+	WorkloadGateModeAnnotation = "clustergate.io/workload-gate-mode"
+
+	// WorkloadGatePath is the path the workload gate is served at.
+	WorkloadGatePath = "/validate-workload-gate"
+)
+
+// +kubebuilder:webhook:path=/validate-workload-gate,mutating=false,failurePolicy=ignore,sideEffects=None,groups=apps,resources=deployments;statefulsets,verbs=create,versions=v1,name=vworkloadgate.clustergate.io,admissionReviewVersions=v1,timeoutSeconds=5
+
+// WorkloadGate rejects, or warns on, the creation of Deployments and
+// StatefulSets in namespaces annotated with WorkloadGateAnnotation while the
+// ClusterReadiness it names is Unhealthy, or Initializing within its initial
+// delay, when whether it is healthy is not known yet. Namespaces without the
+// annotation are not gated. The gate fails open: workloads are admitted with
+// a warning when the namespace or ClusterReadiness cannot be read.
+type WorkloadGate struct {
+	Client client.Reader
+}
+
+// Handle admits or rejects the workload of req.
+func (g *WorkloadGate) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create || req.Namespace == "" {
+		return admission.Allowed("")
+	}
+
+	var ns corev1.Namespace
+	if err := g.Client.Get(ctx, types.NamespacedName{Name: req.Namespace}, &ns); err != nil {
+		return admission.Allowed("").WithWarnings(fmt.Sprintf("clustergate: workload gate not applied: failed to get namespace %s: %v", req.Namespace, err))
+	}
+	name := ns.Annotations[WorkloadGateAnnotation]
+	if name == "" {
+		return admission.Allowed("")
+	}
+	warn := false
+	switch mode := ns.Annotations[WorkloadGateModeAnnotation]; mode {
+	case "", "deny":
+	case "warn":
+		warn = true
+	default:
+		return admission.Allowed("").WithWarnings(fmt.Sprintf("clustergate: workload gate not applied: namespace %s has unknown %s %q, want deny or warn", ns.Name, WorkloadGateModeAnnotation, mode))
+	}
+
+	var cr clustergatev1alpha1.ClusterReadiness
+	if err := g.Client.Get(ctx, types.NamespacedName{Name: name}, &cr); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Allowed("").WithWarnings(fmt.Sprintf("clustergate: workload gate not applied: ClusterReadiness %s not found", name))
+		}
+		return admission.Allowed("").WithWarnings(fmt.Sprintf("clustergate: workload gate not applied: failed to get ClusterReadiness %s: %v", name, err))
+	}
+	if cr.Status.State != clustergatev1alpha1.ClusterUnhealthy && cr.Status.State != clustergatev1alpha1.ClusterInitializing {
+		return admission.Allowed("")
+	}
+
+	msg := fmt.Sprintf("ClusterReadiness %s is %s", name, cr.Status.State)
+	if s := cr.Status.Summary; s != nil {
+		msg += fmt.Sprintf(" (%d/%d critical checks passing)", s.CriticalPassing, s.CriticalTotal)
+	}
+	msg += fmt.Sprintf("; namespace %s gates new %s on it", ns.Name, req.Resource.Resource)
+	if warn {
+		return admission.Allowed("").WithWarnings("clustergate: " + msg)
+	}
+	return admission.Denied(msg)
+}
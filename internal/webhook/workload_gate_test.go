@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+)
+
+func TestWorkloadGate(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := clustergatev1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	namespace := func(name string, annotations map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations}}
+	}
+	readiness := func(name string, state clustergatev1alpha1.ClusterHealthState) *clustergatev1alpha1.ClusterReadiness {
+		return &clustergatev1alpha1.ClusterReadiness{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: clustergatev1alpha1.ClusterReadinessStatus{
+				State:   state,
+				Summary: &clustergatev1alpha1.ReadinessSummary{CriticalTotal: 3, CriticalPassing: 1},
+			},
+		}
+	}
+	g := &WorkloadGate{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(
+		namespace("plain", nil),
+		namespace("apps", map[string]string{WorkloadGateAnnotation: "prod"}),
+		namespace("apps-warn", map[string]string{WorkloadGateAnnotation: "prod", WorkloadGateModeAnnotation: "warn"}),
+		namespace("apps-bad-mode", map[string]string{WorkloadGateAnnotation: "prod", WorkloadGateModeAnnotation: "block"}),
+		namespace("staging-apps", map[string]string{WorkloadGateAnnotation: "staging"}),
+		namespace("canary-apps", map[string]string{WorkloadGateAnnotation: "canary", WorkloadGateModeAnnotation: "warn"}),
+		namespace("orphan", map[string]string{WorkloadGateAnnotation: "missing"}),
+		readiness("prod", clustergatev1alpha1.ClusterUnhealthy),
+		readiness("staging", clustergatev1alpha1.ClusterDegraded),
+		readiness("canary", clustergatev1alpha1.ClusterInitializing),
+	).Build()}
+
+	tests := []struct {
+		name      string
+		namespace string
+		operation admissionv1.Operation
+		allowed   bool
+		warning   string
+	}{
+		{name: "namespace not opted in", namespace: "plain", operation: admissionv1.Create, allowed: true},
+		{name: "unhealthy denies", namespace: "apps", operation: admissionv1.Create},
+		{name: "unhealthy warns", namespace: "apps-warn", operation: admissionv1.Create, allowed: true, warning: "ClusterReadiness prod is Unhealthy (1/3 critical checks passing)"},
+		{name: "updates not gated", namespace: "apps", operation: admissionv1.Update, allowed: true},
+		{name: "initializing warns", namespace: "canary-apps", operation: admissionv1.Create, allowed: true, warning: "ClusterReadiness canary is Initializing"},
+		{name: "degraded admits", namespace: "staging-apps", operation: admissionv1.Create, allowed: true},
+		{name: "unknown mode fails open", namespace: "apps-bad-mode", operation: admissionv1.Create, allowed: true, warning: `unknown clustergate.io/workload-gate-mode "block"`},
+		{name: "missing ClusterReadiness fails open", namespace: "orphan", operation: admissionv1.Create, allowed: true, warning: "ClusterReadiness missing not found"},
+		{name: "missing namespace fails open", namespace: "gone", operation: admissionv1.Create, allowed: true, warning: "failed to get namespace gone"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := g.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: tt.operation,
+				Namespace: tt.namespace,
+				Resource:  metav1.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+			}})
+			if resp.Allowed != tt.allowed {
+				t.Fatalf("allowed = %v, want %v (%v)", resp.Allowed, tt.allowed, resp.Result)
+			}
+			warnings := strings.Join(resp.Warnings, "\n")
+			if tt.warning == "" && warnings != "" {
+				t.Errorf("unexpected warnings %q", warnings)
+			}
+			if !strings.Contains(warnings, tt.warning) {
+				t.Errorf("warnings = %q, want %q", warnings, tt.warning)
+			}
+			if !tt.allowed && !strings.Contains(resp.Result.Message, "namespace apps gates new deployments on it") {
+				t.Errorf("message = %q", resp.Result.Message)
+			}
+		})
+	}
+}
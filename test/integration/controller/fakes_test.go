@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/checks"
+)
+
+// fakeChecker is a built-in check whose result is set by the test. It counts
+// executions so that scheduling can be asserted.
+type fakeChecker struct {
+	name     string
+	severity string
+	category string
+
+	mu     sync.Mutex
+	result checks.Result
+	err    error
+	calls  int
+}
+
+// registerFakeChecker registers a passing built-in check. Names must be unique
+// across the suite because the check registry is process-global.
+func registerFakeChecker(name, severity, category string) *fakeChecker {
+	f := &fakeChecker{
+		name:     name,
+		severity: severity,
+		category: category,
+		result:   checks.Result{Ready: true, Message: "ok"},
+	}
+	checks.Register(f)
+	return f
+}
+
+func (f *fakeChecker) Name() string            { return f.name }
+func (f *fakeChecker) DefaultSeverity() string { return f.severity }
+func (f *fakeChecker) DefaultCategory() string { return f.category }
+
+func (f *fakeChecker) Run(_ context.Context, _ json.RawMessage) (checks.Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return f.result, f.err
+}
+
+// Set changes the result returned by subsequent runs.
+func (f *fakeChecker) Set(ready bool, message string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.result = checks.Result{Ready: ready, Message: message}
+}
+
+// Calls returns how many times the check has run.
+func (f *fakeChecker) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+// fakeExecutor stands in for the dynamic executor, returning per-GateCheck
+// results set by the test. GateChecks without a result fail.
+type fakeExecutor struct {
+	mu      sync.Mutex
+	results map[string]checks.Result
+	calls   map[string]int
+}
+
+func newFakeExecutor() *fakeExecutor {
+	return &fakeExecutor{
+		results: make(map[string]checks.Result),
+		calls:   make(map[string]int),
+	}
+}
+
+func (e *fakeExecutor) Execute(_ context.Context, name string, _ clustergatev1alpha1.GateCheckSpec) (checks.Result, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.calls[name]++
+	res, ok := e.results[name]
+	if !ok {
+		return checks.Result{Ready: false, Message: "no fake result"}, nil
+	}
+	return res, nil
+}
+
+// Ready reports the fake executor as fully initialized.
+func (e *fakeExecutor) Ready() error { return nil }
+
+// Set changes the result returned for the named GateCheck.
+func (e *fakeExecutor) Set(name string, ready bool, message string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.results[name] = checks.Result{Ready: ready, Message: message}
+}
+
+// Calls returns how many times the named GateCheck has run.
+func (e *fakeExecutor) Calls(name string) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.calls[name]
+}
+
+// eventually polls cond until it returns nil or the timeout elapses.
+func eventually(t *testing.T, timeout time.Duration, cond func() error) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		err := cond()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met after %s: %v", timeout, err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// create creates obj and deletes it when the test finishes.
+func create(t *testing.T, obj client.Object) {
+	t.Helper()
+	if err := k8sClient.Create(ctx, obj); err != nil {
+		t.Fatalf("failed to create %T %s: %v", obj, obj.GetName(), err)
+	}
+	t.Cleanup(func() { _ = k8sClient.Delete(ctx, obj) })
+}
+
+// waitForState waits until the ClusterReadiness reports the wanted state and returns it.
+func waitForState(t *testing.T, name string, want clustergatev1alpha1.ClusterHealthState) *clustergatev1alpha1.ClusterReadiness {
+	t.Helper()
+	var cr clustergatev1alpha1.ClusterReadiness
+	eventually(t, 15*time.Second, func() error {
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: name}, &cr); err != nil {
+			return err
+		}
+		if cr.Status.State != want {
+			return fmt.Errorf("state = %q, want %q", cr.Status.State, want)
+		}
+		return nil
+	})
+	return &cr
+}
+
+// findCheck returns the status of the named check, or nil.
+func findCheck(cr *clustergatev1alpha1.ClusterReadiness, name string) *clustergatev1alpha1.CheckStatus {
+	for _, cat := range cr.Status.Categories {
+		for i := range cat.Checks {
+			if cat.Checks[i].Name == name {
+				return &cat.Checks[i]
+			}
+		}
+	}
+	return nil
+}
+
+// keyFor returns the object key for a Kubernetes object.
+func keyFor(obj client.Object) client.ObjectKey {
+	return client.ObjectKeyFromObject(obj)
+}
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/metrics"
+	"github.com/clustergate/clustergate/internal/server"
+)
+
+func clusterReadiness(name string, interval time.Duration, checks ...clustergatev1alpha1.CheckSpec) *clustergatev1alpha1.ClusterReadiness {
+	return &clustergatev1alpha1.ClusterReadiness{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: clustergatev1alpha1.ClusterReadinessSpec{
+			Interval: metav1.Duration{Duration: interval},
+			Checks:   checks,
+		},
+	}
+}
+
+func gateCheck(name string, severity clustergatev1alpha1.Severity) *clustergatev1alpha1.GateCheck {
+	return &clustergatev1alpha1.GateCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: clustergatev1alpha1.GateCheckSpec{
+			Severity: severity,
+			Category: "apps",
+			HTTPCheck: &clustergatev1alpha1.HTTPCheckSpec{
+				URL: "http://example.invalid/healthz",
+			},
+		},
+	}
+}
+
+// readyzState returns the overall state /readyz reports for the named CR.
+func readyzState(name string) (string, int) {
+	rec := httptest.NewRecorder()
+	server.ReadyzHandler(readinessState)(rec, httptest.NewRequest(http.MethodGet, "/readyz?fields=summary", nil))
+	var resp struct {
+		Clusters map[string]*server.ClusterState `json:"clusters"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		return "", rec.Code
+	}
+	if cs := resp.Clusters[name]; cs != nil {
+		return cs.State, rec.Code
+	}
+	return "", rec.Code
+}
+
+func TestReconciler_HealthyWithBuiltinAndDynamicChecks(t *testing.T) {
+	registerFakeChecker("healthy-builtin", "critical", "networking")
+	create(t, gateCheck("healthy-dynamic", clustergatev1alpha1.SeverityCritical))
+	executor.Set("healthy-dynamic", true, "dynamic ok")
+
+	create(t, clusterReadiness("healthy", time.Minute,
+		clustergatev1alpha1.CheckSpec{Name: "healthy-builtin"},
+		clustergatev1alpha1.CheckSpec{GateCheckRef: "healthy-dynamic"},
+	))
+
+	cr := waitForState(t, "healthy", clustergatev1alpha1.ClusterHealthy)
+	if cr.Status.Summary == nil || cr.Status.Summary.Total != 2 || cr.Status.Summary.Passing != 2 {
+		t.Errorf("summary = %+v, want 2/2 passing", cr.Status.Summary)
+	}
+	if cs := findCheck(cr, "healthy-dynamic"); cs == nil || cs.Message != "dynamic ok" {
+		t.Errorf("dynamic check status = %+v", cs)
+	}
+	if got := testutil.ToFloat64(metrics.ClusterReady.WithLabelValues("healthy")); got != 1 {
+		t.Errorf("cluster_ready = %v, want 1", got)
+	}
+	if state, _ := readyzState("healthy"); state != "Healthy" {
+		t.Errorf("readyz state = %q, want Healthy", state)
+	}
+}
+
+func TestReconciler_SeverityDeterminesState(t *testing.T) {
+	critical := registerFakeChecker("severity-critical", "critical", "control-plane")
+	warning := registerFakeChecker("severity-warning", "warning", "observability")
+	warning.Set(false, "logging degraded")
+
+	create(t, clusterReadiness("severity", time.Second,
+		clustergatev1alpha1.CheckSpec{Name: "severity-critical"},
+		clustergatev1alpha1.CheckSpec{Name: "severity-warning"},
+	))
+	waitForState(t, "severity", clustergatev1alpha1.ClusterDegraded)
+
+	critical.Set(false, "apiserver down")
+	waitForState(t, "severity", clustergatev1alpha1.ClusterUnhealthy)
+	if got := testutil.ToFloat64(metrics.ClusterReady.WithLabelValues("severity")); got != 0 {
+		t.Errorf("cluster_ready = %v, want 0", got)
+	}
+
+	critical.Set(true, "ok")
+	warning.Set(true, "ok")
+	waitForState(t, "severity", clustergatev1alpha1.ClusterHealthy)
+}
+
+func TestReconciler_CarriesResultsOfChecksNotDue(t *testing.T) {
+	slow := registerFakeChecker("carry-slow", "critical", "networking")
+	fast := registerFakeChecker("carry-fast", "critical", "networking")
+
+	create(t, clusterReadiness("carry", time.Hour,
+		clustergatev1alpha1.CheckSpec{Name: "carry-slow"},
+		clustergatev1alpha1.CheckSpec{Name: "carry-fast", Interval: &metav1.Duration{Duration: time.Second}},
+	))
+
+	eventually(t, 15*time.Second, func() error {
+		if fast.Calls() < 3 {
+			return fmt.Errorf("fast check ran %d times, want at least 3", fast.Calls())
+		}
+		return nil
+	})
+	if calls := slow.Calls(); calls != 1 {
+		t.Errorf("slow check ran %d times, want 1", calls)
+	}
+
+	cr := waitForState(t, "carry", clustergatev1alpha1.ClusterHealthy)
+	if cs := findCheck(cr, "carry-slow"); cs == nil || cs.Status != "Passing" {
+		t.Errorf("carried check status = %+v, want Passing", cs)
+	}
+}
+
+func TestReconciler_MissingGateCheckFails(t *testing.T) {
+	create(t, clusterReadiness("missing-gatecheck", time.Minute,
+		clustergatev1alpha1.CheckSpec{GateCheckRef: "does-not-exist"},
+	))
+
+	cr := waitForState(t, "missing-gatecheck", clustergatev1alpha1.ClusterUnhealthy)
+	if cs := findCheck(cr, "does-not-exist"); cs == nil || cs.Status != "Failing" {
+		t.Errorf("check status = %+v, want Failing", cs)
+	}
+	if calls := executor.Calls("does-not-exist"); calls != 0 {
+		t.Errorf("executor ran %d times for a missing GateCheck", calls)
+	}
+}
+
+func TestReconciler_ProfileResolutionCondition(t *testing.T) {
+	cr := clusterReadiness("missing-profile", time.Minute)
+	cr.Spec.Profiles = []clustergatev1alpha1.ProfileRef{{Name: "does-not-exist"}}
+	create(t, cr)
+
+	eventually(t, 15*time.Second, func() error {
+		if err := k8sClient.Get(ctx, keyFor(cr), cr); err != nil {
+			return err
+		}
+		cond := meta.FindStatusCondition(cr.Status.Conditions, "ProfilesResolved")
+		if cond == nil || cond.Status != metav1.ConditionFalse {
+			return fmt.Errorf("ProfilesResolved condition = %+v, want False", cond)
+		}
+		return nil
+	})
+}
+
+func TestReconciler_DeleteRemovesReadyzState(t *testing.T) {
+	registerFakeChecker("delete-builtin", "critical", "networking")
+	cr := clusterReadiness("delete", time.Minute, clustergatev1alpha1.CheckSpec{Name: "delete-builtin"})
+	if err := k8sClient.Create(ctx, cr); err != nil {
+		t.Fatalf("failed to create ClusterReadiness: %v", err)
+	}
+	waitForState(t, "delete", clustergatev1alpha1.ClusterHealthy)
+	eventually(t, 15*time.Second, func() error {
+		if state, _ := readyzState("delete"); state != "Healthy" {
+			return fmt.Errorf("readyz state = %q, want Healthy", state)
+		}
+		return nil
+	})
+
+	if err := k8sClient.Delete(ctx, cr); err != nil {
+		t.Fatalf("failed to delete ClusterReadiness: %v", err)
+	}
+	eventually(t, 15*time.Second, func() error {
+		if state, _ := readyzState("delete"); state != "" {
+			return fmt.Errorf("readyz still reports %q", state)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,127 @@
+// Package controller runs the real ClusterReadinessReconciler against envtest
+// with fake built-in and dynamic checkers, so that reconciler behaviour
+// (scheduling, carried results, conditions, metrics, /readyz state) can be
+// verified end-to-end.
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	clustergatev1alpha1 "github.com/clustergate/clustergate/api/v1alpha1"
+	"github.com/clustergate/clustergate/internal/controller"
+	"github.com/clustergate/clustergate/internal/server"
+)
+
+var (
+	testEnv        *envtest.Environment
+	k8sClient      client.Client
+	scheme         = k8sruntime.NewScheme()
+	ctx            context.Context
+	cancel         context.CancelFunc
+	readinessState *server.ReadinessState
+	executor       *fakeExecutor
+)
+
+func TestMain(m *testing.M) {
+	logf.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(clustergatev1alpha1.AddToScheme(scheme))
+
+	ctx, cancel = context.WithCancel(context.Background())
+
+	// KUBEBUILDER_ASSETS is set by the Makefile test targets via setup-envtest.
+	// If not set, auto-detect from the project's bin/k8s/ directory.
+	root := filepath.Join("..", "..", "..")
+	binDir := os.Getenv("KUBEBUILDER_ASSETS")
+	if binDir == "" {
+		entries, _ := os.ReadDir(filepath.Join(root, "bin", "k8s"))
+		// Pick the last entry (sorted alphabetically = highest version).
+		for _, e := range entries {
+			if e.IsDir() {
+				binDir = filepath.Join(root, "bin", "k8s", e.Name())
+			}
+		}
+	}
+	if binDir == "" {
+		panic("KUBEBUILDER_ASSETS not set and no binaries found in bin/k8s/. Run: setup-envtest use 1.33.0 --bin-dir bin")
+	}
+
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join(root, "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+		BinaryAssetsDirectory: binDir,
+	}
+
+	cfg, err := testEnv.Start()
+	if err != nil {
+		panic("failed to start envtest: " + err.Error())
+	}
+
+	// Tests read through an uncached client so that assertions see the API server state.
+	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		panic("failed to create client: " + err.Error())
+	}
+
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsserver.Options{BindAddress: "0"},
+		HealthProbeBindAddress: "0",
+	})
+	if err != nil {
+		panic("failed to create manager: " + err.Error())
+	}
+
+	readinessState = server.NewReadinessState()
+	executor = newFakeExecutor()
+	if err := (&controller.ClusterReadinessReconciler{
+		Client:          mgr.GetClient(),
+		ReadinessState:  readinessState,
+		DynamicExecutor: executor,
+	}).SetupWithManager(mgr); err != nil {
+		panic("failed to set up ClusterReadiness controller: " + err.Error())
+	}
+
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			panic("failed to start manager: " + err.Error())
+		}
+	}()
+	if !mgr.GetCache().WaitForCacheSync(ctx) {
+		panic("manager cache did not sync")
+	}
+
+	exitCode := m.Run()
+
+	cancel()
+	// Let the manager shut down its controllers before the API server goes away.
+	time.Sleep(500 * time.Millisecond)
+	if err := testEnv.Stop(); err != nil {
+		// On Windows, envtest cannot send Unix signals to stop processes.
+		// This is a known limitation — the processes will be cleaned up on exit.
+		if runtime.GOOS == "windows" {
+			fmt.Fprintf(os.Stderr, "warning: envtest stop: %v\n", err)
+		} else {
+			panic("failed to stop envtest: " + err.Error())
+		}
+	}
+
+	os.Exit(exitCode)
+}